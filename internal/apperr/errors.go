@@ -15,3 +15,6 @@ var ErrRequestFailed = errors.New("request failed")
 
 // ErrPAPBlocked is returned when a service's PAP level exceeds the user-defined limit.
 var ErrPAPBlocked = errors.New("PAP limit exceeded")
+
+// ErrMissingAPIKey is returned by keyed services when no API key is configured.
+var ErrMissingAPIKey = errors.New("missing API key")
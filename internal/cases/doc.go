@@ -0,0 +1,5 @@
+// Package cases keeps investigation workspaces: a named case records every
+// service result run while it is active, and its observables — domains, IP
+// addresses, URLs, hashes, and more — are collected across all results for
+// review and export as JSON, Markdown, or a STIX 2.1 bundle.
+package cases
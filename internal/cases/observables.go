@@ -0,0 +1,182 @@
+package cases
+
+import (
+	"encoding/json"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tbckr/trident/internal/services"
+)
+
+// Observable types, in the order they are reported.
+const (
+	TypeDomain = "domain"
+	TypeIPv4   = "ipv4"
+	TypeIPv6   = "ipv6"
+	TypeCIDR   = "cidr"
+	TypeASN    = "asn"
+	TypeURL    = "url"
+	TypeEmail  = "email"
+	TypeHash   = "hash"
+)
+
+// Types lists the observable types in report order.
+var Types = []string{TypeDomain, TypeIPv4, TypeIPv6, TypeCIDR, TypeASN, TypeURL, TypeEmail, TypeHash}
+
+// asnRegexp matches autonomous system numbers in their "AS13335" form.
+var asnRegexp = regexp.MustCompile(`^(?i)AS[0-9]{1,10}$`)
+
+// Observable is one indicator seen in a case, deduplicated across services.
+type Observable struct {
+	Type      string    `json:"type"`
+	Value     string    `json:"value"`
+	Services  []string  `json:"services"`   // services whose results contained it, sorted
+	FirstSeen time.Time `json:"first_seen"` // time of the first entry containing it
+	LastSeen  time.Time `json:"last_seen"`  // time of the latest entry containing it
+}
+
+// Classify reports the observable type of s and its normalised value:
+// lowercased domains, e-mail addresses, and hashes, canonical IP addresses
+// and prefixes, and upper-case ASNs. ok is false for anything else.
+func Classify(s string) (typ, value string, ok bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || len(s) > 2048 || strings.ContainsAny(s, " \t\n") {
+		return "", "", false
+	}
+	if addr, err := netip.ParseAddr(s); err == nil {
+		if addr.Is4() || addr.Is4In6() {
+			return TypeIPv4, addr.Unmap().String(), true
+		}
+		return TypeIPv6, addr.String(), true
+	}
+	if p, err := netip.ParsePrefix(s); err == nil {
+		return TypeCIDR, p.Masked().String(), true
+	}
+	if asnRegexp.MatchString(s) {
+		return TypeASN, strings.ToUpper(s), true
+	}
+	if isHash(s) {
+		return TypeHash, strings.ToLower(s), true
+	}
+	lower := strings.ToLower(s)
+	if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") {
+		if u, err := url.Parse(s); err == nil && u.Host != "" {
+			return TypeURL, s, true
+		}
+		return "", "", false
+	}
+	if strings.Contains(s, "@") {
+		if addr, err := mail.ParseAddress(s); err == nil && addr.Address == s {
+			_, domain, _ := strings.Cut(s, "@")
+			if services.IsDomain(domain) {
+				return TypeEmail, lower, true
+			}
+		}
+		return "", "", false
+	}
+	if domain := strings.TrimSuffix(lower, "."); services.IsDomain(domain) {
+		return TypeDomain, domain, true
+	}
+	return "", "", false
+}
+
+// isHash reports whether s is an MD5, SHA-1, or SHA-256 hex digest.
+func isHash(s string) bool {
+	switch len(s) {
+	case 32, 40, 64:
+	default:
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxFields bounds the number of whitespace-separated fields a string value
+// may have to be searched for observables; longer values are prose.
+const maxFields = 8
+
+// Observables collects the observables in the inputs and results of entries
+// — whole string values, or the fields of short multi-field values —
+// deduplicated by type and value and sorted by type, then value.
+func Observables(entries []Entry) []Observable {
+	seen := map[[2]string]*Observable{}
+	var add func(s string, e Entry)
+	add = func(s string, e Entry) {
+		typ, value, ok := Classify(s)
+		if !ok {
+			// Record values such as "10 mx.example.com." hold observables
+			// among other fields.
+			if fields := strings.Fields(s); len(fields) > 1 && len(fields) <= maxFields {
+				for _, f := range fields {
+					add(f, e)
+				}
+			}
+			return
+		}
+		key := [2]string{typ, value}
+		o, exists := seen[key]
+		if !exists {
+			o = &Observable{Type: typ, Value: value, FirstSeen: e.Time, LastSeen: e.Time}
+			seen[key] = o
+		}
+		if !slices.Contains(o.Services, e.Service) {
+			o.Services = append(o.Services, e.Service)
+		}
+		if e.Time.Before(o.FirstSeen) {
+			o.FirstSeen = e.Time
+		}
+		if e.Time.After(o.LastSeen) {
+			o.LastSeen = e.Time
+		}
+	}
+	for _, e := range entries {
+		add(e.Input, e)
+		var tree any
+		if err := json.Unmarshal(e.Result, &tree); err != nil {
+			continue
+		}
+		walkStrings(tree, func(s string) { add(s, e) })
+	}
+
+	list := make([]Observable, 0, len(seen))
+	for _, o := range seen {
+		sort.Strings(o.Services)
+		list = append(list, *o)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		ti, tj := slices.Index(Types, list[i].Type), slices.Index(Types, list[j].Type)
+		if ti != tj {
+			return ti < tj
+		}
+		return list[i].Value < list[j].Value
+	})
+	return list
+}
+
+// walkStrings calls fn for every string value and object key in a decoded
+// JSON tree.
+func walkStrings(v any, fn func(string)) {
+	switch t := v.(type) {
+	case string:
+		fn(t)
+	case map[string]any:
+		for k, child := range t {
+			fn(k)
+			walkStrings(child, fn)
+		}
+	case []any:
+		for _, child := range t {
+			walkStrings(child, fn)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package cases_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tbckr/trident/internal/cases"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		in, typ, value string
+	}{
+		{"Example.COM.", cases.TypeDomain, "example.com"},
+		{"93.184.216.34", cases.TypeIPv4, "93.184.216.34"},
+		{"2606:2800:220:1::248", cases.TypeIPv6, "2606:2800:220:1::248"},
+		{"10.0.0.7/8", cases.TypeCIDR, "10.0.0.0/8"},
+		{"as13335", cases.TypeASN, "AS13335"},
+		{"https://login.example.com/path?q=1", cases.TypeURL, "https://login.example.com/path?q=1"},
+		{"Admin@Example.com", cases.TypeEmail, "admin@example.com"},
+		{"D41D8CD98F00B204E9800998ECF8427E", cases.TypeHash, "d41d8cd98f00b204e9800998ecf8427e"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			typ, value, ok := cases.Classify(tt.in)
+			assert.True(t, ok)
+			assert.Equal(t, tt.typ, typ)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+
+	for _, in := range []string{"", "A", "MX", "true", "GOOGLE, US", "v=spf1 -all", "ftp://example.com", "not@valid", "12345"} {
+		_, _, ok := cases.Classify(in)
+		assert.False(t, ok, in)
+	}
+}
+
+func TestObservables(t *testing.T) {
+	t1 := t0.Add(time.Hour)
+	entries := []cases.Entry{
+		{Time: t0, Service: "dns", Input: "example.com", Result: json.RawMessage(`{"a":["93.184.216.34"],"mx":["10 mail.example.com."]}`)},
+		{Time: t1, Service: "cymru", Input: "93.184.216.34", Result: json.RawMessage(`{"asn":"AS15133","description":"EDGECAST, US"}`)},
+		{Time: t1, Service: "crtsh", Input: "example.com", Result: json.RawMessage(`{"subdomains":["mail.example.com","www.example.com"]}`)},
+	}
+
+	got := cases.Observables(entries)
+	assert.Equal(t, []cases.Observable{
+		{Type: cases.TypeDomain, Value: "example.com", Services: []string{"crtsh", "dns"}, FirstSeen: t0, LastSeen: t1},
+		{Type: cases.TypeDomain, Value: "mail.example.com", Services: []string{"crtsh", "dns"}, FirstSeen: t0, LastSeen: t1},
+		{Type: cases.TypeDomain, Value: "www.example.com", Services: []string{"crtsh"}, FirstSeen: t1, LastSeen: t1},
+		{Type: cases.TypeIPv4, Value: "93.184.216.34", Services: []string{"cymru", "dns"}, FirstSeen: t0, LastSeen: t1},
+		{Type: cases.TypeASN, Value: "AS15133", Services: []string{"cymru"}, FirstSeen: t1, LastSeen: t1},
+	}, got)
+}
@@ -0,0 +1,153 @@
+package cases
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Report is a case with the observables found in its entries.
+type Report struct {
+	Case        Case         `json:"case"`
+	Entries     []Entry      `json:"entries,omitempty"`
+	Observables []Observable `json:"observables"`
+}
+
+// NewReport builds the report of c from its entries. The entries are kept
+// only when withEntries is set.
+func NewReport(c Case, entries []Entry, withEntries bool) *Report {
+	r := &Report{Case: c, Observables: Observables(entries)}
+	if withEntries {
+		r.Entries = entries
+	}
+	return r
+}
+
+// IsEmpty reports whether the case has no observables.
+func (r *Report) IsEmpty() bool {
+	return len(r.Observables) == 0
+}
+
+// WriteText renders one "type value" line per observable.
+func (r *Report) WriteText(w io.Writer) error {
+	for _, o := range r.Observables {
+		if _, err := fmt.Fprintf(w, "%s %s\n", o.Type, o.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the observables as a Type/Value/Services/First Seen
+// table below a line naming the case.
+func (r *Report) WriteTable(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "case %s: %d observable(s)\n", r.Case.Name, len(r.Observables)); err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(r.Observables))
+	for _, o := range r.Observables {
+		rows = append(rows, []string{o.Type, o.Value, strings.Join(o.Services, ", "), o.FirstSeen.Local().Format(time.DateTime)})
+	}
+	table := output.NewWrappingTable(w, 20, 13)
+	table.Header([]string{"Type", "Value", "Services", "First Seen"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
+
+// WriteMarkdown renders the case as a Markdown report: a summary, one
+// observable table per type, and the command log.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Case %s\n\n", output.EscapeMarkdown(r.Case.Name))
+	if r.Case.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", output.EscapeMarkdown(r.Case.Description))
+	}
+	fmt.Fprintf(&b, "- Created: %s\n", r.Case.Created.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Commands recorded: %d\n", len(r.Entries))
+	fmt.Fprintf(&b, "- Observables: %d\n", len(r.Observables))
+
+	for _, typ := range Types {
+		var rows []Observable
+		for _, o := range r.Observables {
+			if o.Type == typ {
+				rows = append(rows, o)
+			}
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n| Value | Services | First Seen |\n|-------|----------|------------|\n", typeTitles[typ], len(rows))
+		for _, o := range rows {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", output.EscapeMarkdown(o.Value), output.EscapeMarkdown(strings.Join(o.Services, ", ")), o.FirstSeen.Format(time.RFC3339))
+		}
+	}
+
+	if len(r.Entries) > 0 {
+		b.WriteString("\n## Command Log\n\n| Time | Command | Input | PAP |\n|------|---------|-------|-----|\n")
+		for _, e := range r.Entries {
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s |\n", e.Time.Format(time.RFC3339),
+				strings.ReplaceAll(e.Command, "`", "'"), output.EscapeMarkdown(e.Input), strings.ToUpper(e.PAP))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// typeTitles are the Markdown section headings per observable type.
+var typeTitles = map[string]string{
+	TypeDomain: "Domains",
+	TypeIPv4:   "IPv4 Addresses",
+	TypeIPv6:   "IPv6 Addresses",
+	TypeCIDR:   "Networks",
+	TypeASN:    "Autonomous Systems",
+	TypeURL:    "URLs",
+	TypeEmail:  "E-mail Addresses",
+	TypeHash:   "File Hashes",
+}
+
+// Listing is the result of "trident case list".
+type Listing struct {
+	Active string    `json:"active,omitempty"`
+	Cases  []Summary `json:"cases"`
+}
+
+// IsEmpty reports whether no case exists.
+func (l *Listing) IsEmpty() bool {
+	return len(l.Cases) == 0
+}
+
+// WriteText renders one case name per line.
+func (l *Listing) WriteText(w io.Writer) error {
+	for _, c := range l.Cases {
+		if _, err := fmt.Fprintln(w, c.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the cases as a table, marking the active one with "*".
+func (l *Listing) WriteTable(w io.Writer) error {
+	rows := make([][]string, 0, len(l.Cases))
+	for _, c := range l.Cases {
+		active, updated := "", "-"
+		if c.Name == l.Active {
+			active = "*"
+		}
+		if !c.Updated.IsZero() {
+			updated = c.Updated.Local().Format(time.DateTime)
+		}
+		rows = append(rows, []string{active, c.Name, fmt.Sprintf("%d", c.Entries), c.Created.Local().Format(time.DateTime), updated, c.Description})
+	}
+	table := output.NewWrappingTable(w, 20, 19)
+	table.Header([]string{"Active", "Name", "Entries", "Created", "Updated", "Description"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
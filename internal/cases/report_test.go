@@ -0,0 +1,137 @@
+package cases_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/cases"
+)
+
+func TestReport_WriteText(t *testing.T) {
+	c := cases.Case{Name: "acme", Description: "Phishing | wave", Created: t0}
+	entries := []cases.Entry{
+		{
+			Time: t0.Add(time.Minute), Command: "trident dns", Service: "dns", Input: "example.com",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{"a":["93.184.216.34"]}`),
+		},
+		{
+			Time: t0.Add(2 * time.Minute), Command: "trident cymru", Service: "cymru", Input: "93.184.216.34",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{"asn":"AS15133","prefix":"93.184.216.0/24"}`),
+		},
+		{
+			Time: t0.Add(3 * time.Minute), Command: "trident threatminer", Service: "threatminer", Input: "d41d8cd98f00b204e9800998ecf8427e",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{}`),
+		},
+	}
+	report := cases.NewReport(c, entries, false)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	assert.Equal(t, "domain example.com\nipv4 93.184.216.34\ncidr 93.184.216.0/24\nasn AS15133\nhash d41d8cd98f00b204e9800998ecf8427e\n", buf.String())
+}
+
+func TestReport_WriteMarkdown(t *testing.T) {
+	c := cases.Case{Name: "acme", Description: "Phishing | wave", Created: t0}
+	entries := []cases.Entry{
+		{
+			Time: t0.Add(time.Minute), Command: "trident dns", Service: "dns", Input: "example.com",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{"a":["93.184.216.34"]}`),
+		},
+		{
+			Time: t0.Add(2 * time.Minute), Command: "trident cymru", Service: "cymru", Input: "93.184.216.34",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{"asn":"AS15133","prefix":"93.184.216.0/24"}`),
+		},
+		{
+			Time: t0.Add(3 * time.Minute), Command: "trident threatminer", Service: "threatminer", Input: "d41d8cd98f00b204e9800998ecf8427e",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{}`),
+		},
+	}
+	report := cases.NewReport(c, entries, true)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteMarkdown(&buf))
+	md := buf.String()
+	assert.Contains(t, md, "# Case acme\n\nPhishing \\| wave\n")
+	assert.Contains(t, md, "- Commands recorded: 3\n- Observables: 5\n")
+	assert.Contains(t, md, "## Domains (1)\n\n| Value | Services | First Seen |")
+	assert.Contains(t, md, "| example.com | dns | 2025-03-01T12:01:00Z |")
+	assert.Contains(t, md, "## Autonomous Systems (1)")
+	assert.Contains(t, md, "## Command Log")
+	assert.Contains(t, md, "| 2025-03-01T12:02:00Z | `trident cymru` | 93.184.216.34 | AMBER |")
+	assert.NotContains(t, md, "## URLs")
+}
+
+func TestReport_STIX(t *testing.T) {
+	c := cases.Case{Name: "acme", Description: "Phishing | wave", Created: t0}
+	entries := []cases.Entry{
+		{
+			Time: t0.Add(time.Minute), Command: "trident dns", Service: "dns", Input: "example.com",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{"a":["93.184.216.34"]}`),
+		},
+		{
+			Time: t0.Add(2 * time.Minute), Command: "trident cymru", Service: "cymru", Input: "93.184.216.34",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{"asn":"AS15133","prefix":"93.184.216.0/24"}`),
+		},
+		{
+			Time: t0.Add(3 * time.Minute), Command: "trident threatminer", Service: "threatminer", Input: "d41d8cd98f00b204e9800998ecf8427e",
+			PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{}`),
+		},
+	}
+	report := cases.NewReport(c, entries, false)
+
+	b := report.STIX()
+	assert.Equal(t, "bundle", b.Type)
+	require.Len(t, b.Objects, 6)
+
+	idRe := regexp.MustCompile(`^[a-z0-9-]+--[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	grouping := b.Objects[0]
+	assert.Equal(t, "grouping", grouping["type"])
+	assert.Equal(t, "acme", grouping["name"])
+	assert.Equal(t, "2025-03-01T12:00:00.000Z", grouping["created"])
+	assert.Equal(t, "2025-03-01T12:03:00.000Z", grouping["modified"])
+	refs, _ := grouping["object_refs"].([]string)
+	assert.Len(t, refs, 5)
+
+	types := map[string]bool{}
+	for _, obj := range b.Objects {
+		assert.Regexp(t, idRe, obj["id"])
+		assert.Equal(t, "2.1", obj["spec_version"])
+		types[obj["type"].(string)] = true
+	}
+	assert.Equal(t, map[string]bool{
+		"grouping": true, "domain-name": true, "ipv4-addr": true, "autonomous-system": true, "file": true,
+	}, types)
+
+	// Observable identifiers follow the STIX UUIDv5 scheme.
+	assert.Contains(t, b.Objects, map[string]any{
+		"type": "domain-name", "spec_version": "2.1",
+		"id": "domain-name--bedb4899-d24b-5401-bc86-8f6b4cc18ec7", "value": "example.com",
+	})
+
+	// Identifiers are deterministic.
+	again := cases.NewReport(c, entries, false).STIX()
+	assert.Equal(t, b, again)
+
+	raw, err := json.Marshal(b)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"hashes":{"MD5":"d41d8cd98f00b204e9800998ecf8427e"}`)
+	assert.Contains(t, string(raw), `"number":15133`)
+}
+
+func TestReport_STIX_Empty(t *testing.T) {
+	b := cases.NewReport(cases.Case{Name: "empty", Created: t0}, nil, false).STIX()
+	assert.Empty(t, b.Objects)
+}
+
+func TestListing_WriteText(t *testing.T) {
+	l := &cases.Listing{Active: "b", Cases: []cases.Summary{{Case: cases.Case{Name: "a"}}, {Case: cases.Case{Name: "b"}}}}
+	var buf bytes.Buffer
+	require.NoError(t, l.WriteText(&buf))
+	assert.Equal(t, "a\nb\n", buf.String())
+}
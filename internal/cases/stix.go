@@ -0,0 +1,137 @@
+package cases
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // UUIDv5 is defined over SHA-1
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// stixNamespace is the UUIDv5 namespace STIX 2.1 defines for deterministic
+// cyber-observable identifiers.
+var stixNamespace = [16]byte{0x00, 0xab, 0xed, 0xb4, 0xaa, 0x42, 0x46, 0x6c, 0x9c, 0x01, 0xfe, 0xd2, 0x33, 0x15, 0xa9, 0xb7}
+
+// stixTime is the STIX timestamp layout, UTC with millisecond precision.
+const stixTime = "2006-01-02T15:04:05.000Z"
+
+// Bundle is a STIX 2.1 bundle.
+type Bundle struct {
+	Type    string           `json:"type"`
+	ID      string           `json:"id"`
+	Objects []map[string]any `json:"objects"`
+}
+
+// STIX returns the observables of the report as a STIX 2.1 bundle: one
+// cyber-observable object per observable and a grouping named after the case
+// that references them all. Identifiers are deterministic, so exporting the
+// same case twice yields the same objects.
+func (r *Report) STIX() *Bundle {
+	modified := r.Case.Created
+	for _, o := range r.Observables {
+		if o.LastSeen.After(modified) {
+			modified = o.LastSeen
+		}
+	}
+	b := &Bundle{
+		Type:    "bundle",
+		ID:      "bundle--" + uuid5(stixNamespace, "trident-case:"+r.Case.Name+":"+modified.UTC().Format(stixTime)),
+		Objects: []map[string]any{},
+	}
+	refs := make([]string, 0, len(r.Observables))
+	for _, o := range r.Observables {
+		obj := stixObservable(o)
+		if obj == nil {
+			continue
+		}
+		b.Objects = append(b.Objects, obj)
+		refs = append(refs, obj["id"].(string))
+	}
+	if len(refs) == 0 {
+		return b // a grouping must reference at least one object
+	}
+	grouping := map[string]any{
+		"type":         "grouping",
+		"spec_version": "2.1",
+		"id":           "grouping--" + uuid5(stixNamespace, "trident-case:"+r.Case.Name),
+		"created":      r.Case.Created.UTC().Format(stixTime),
+		"modified":     modified.UTC().Format(stixTime),
+		"name":         r.Case.Name,
+		"context":      "suspicious-activity",
+		"object_refs":  refs,
+	}
+	if r.Case.Description != "" {
+		grouping["description"] = r.Case.Description
+	}
+	b.Objects = append([]map[string]any{grouping}, b.Objects...)
+	return b
+}
+
+// stixObservable maps o to a STIX cyber-observable object whose identifier
+// is derived from its ID-contributing properties, or nil when o has no STIX
+// representation.
+func stixObservable(o Observable) map[string]any {
+	var typ string
+	var props map[string]any
+	switch o.Type {
+	case TypeDomain:
+		typ, props = "domain-name", map[string]any{"value": o.Value}
+	case TypeIPv4:
+		typ, props = "ipv4-addr", map[string]any{"value": o.Value}
+	case TypeIPv6:
+		typ, props = "ipv6-addr", map[string]any{"value": o.Value}
+	case TypeCIDR:
+		typ = "ipv4-addr"
+		if strings.Contains(o.Value, ":") {
+			typ = "ipv6-addr"
+		}
+		props = map[string]any{"value": o.Value}
+	case TypeASN:
+		n, err := strconv.ParseUint(o.Value[2:], 10, 32)
+		if err != nil {
+			return nil
+		}
+		typ, props = "autonomous-system", map[string]any{"number": n}
+	case TypeURL:
+		typ, props = "url", map[string]any{"value": o.Value}
+	case TypeEmail:
+		typ, props = "email-addr", map[string]any{"value": o.Value}
+	case TypeHash:
+		algo := map[int]string{32: "MD5", 40: "SHA-1", 64: "SHA-256"}[len(o.Value)]
+		typ, props = "file", map[string]any{"hashes": map[string]string{algo: o.Value}}
+	default:
+		return nil
+	}
+	obj := map[string]any{
+		"type":         typ,
+		"spec_version": "2.1",
+		"id":           typ + "--" + uuid5(stixNamespace, canonicalJSON(props)),
+	}
+	for k, v := range props {
+		obj[k] = v
+	}
+	return obj
+}
+
+// canonicalJSON serialises v with sorted keys and no HTML escaping, which
+// matches RFC 8785 for the string and integer values used in identifiers.
+func canonicalJSON(v any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// uuid5 returns the RFC 4122 version 5 UUID of name in namespace ns.
+func uuid5(ns [16]byte, name string) string {
+	h := sha1.New() //nolint:gosec // UUIDv5 is defined over SHA-1
+	h.Write(ns[:])
+	h.Write([]byte(name))
+	var u [16]byte
+	copy(u[:], h.Sum(nil))
+	u[6] = u[6]&0x0f | 0x50
+	u[8] = u[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
@@ -0,0 +1,190 @@
+package cases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/tbckr/trident/internal/appdir"
+	"github.com/tbckr/trident/internal/apperr"
+	"github.com/tbckr/trident/internal/jsonl"
+)
+
+// dirName is the config-dir subdirectory holding the cases.
+const dirName = "cases"
+
+// File names inside a case directory.
+const (
+	metaFile    = "case.json"
+	entriesFile = "entries.jsonl"
+)
+
+// nameRegexp limits case names to characters that are safe as a directory
+// name on every platform.
+var nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,63}$`)
+
+// Case describes one investigation.
+type Case struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Created     time.Time `json:"created"`
+}
+
+// Entry is one recorded service result.
+type Entry struct {
+	Time     time.Time       `json:"time"`
+	Command  string          `json:"command"`   // command line, e.g. "trident apex --profile mail"
+	Service  string          `json:"service"`   // service name
+	Input    string          `json:"input"`     // the input this result belongs to
+	PAP      string          `json:"pap"`       // PAP level of the service
+	PAPLimit string          `json:"pap_limit"` // PAP limit in effect for the run
+	Result   json.RawMessage `json:"result"`
+}
+
+// Summary is a case with statistics about its entries.
+type Summary struct {
+	Case
+	Entries int       `json:"entries"`
+	Updated time.Time `json:"updated,omitzero"` // time of the latest entry
+}
+
+// Store keeps each case in <dir>/<name>/: its metadata in case.json and its
+// entries in entries.jsonl, one JSON object per line in recording order.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at dir. The directory is created with the
+// first case.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the directory holding the cases, <config-dir>/cases.
+func DefaultDir() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, dirName), nil
+}
+
+// ValidateName returns an error wrapping apperr.ErrInvalidInput unless name
+// is 1–64 letters, digits, '.', '-', or '_', starting with a letter or digit.
+func ValidateName(name string) error {
+	if !nameRegexp.MatchString(name) {
+		return fmt.Errorf("%w: case name %q (want up to 64 letters, digits, '.', '-', or '_', starting with a letter or digit)",
+			apperr.ErrInvalidInput, name)
+	}
+	return nil
+}
+
+// Create starts a new case. It fails when a case of that name exists.
+func (s *Store) Create(name, description string, at time.Time) (Case, error) {
+	if err := ValidateName(name); err != nil {
+		return Case{}, err
+	}
+	c := Case{Name: name, Description: description, Created: at.UTC()}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return Case{}, fmt.Errorf("encoding case: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return Case{}, fmt.Errorf("creating cases dir: %w", err)
+	}
+	if err := os.Mkdir(filepath.Join(s.dir, name), 0o700); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return Case{}, fmt.Errorf("%w: case %q already exists", apperr.ErrInvalidInput, name)
+		}
+		return Case{}, fmt.Errorf("creating case dir: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, name, metaFile), append(data, '\n'), 0o600); err != nil {
+		return Case{}, fmt.Errorf("writing case: %w", err)
+	}
+	return c, nil
+}
+
+// Get returns the case called name.
+func (s *Store) Get(name string) (Case, error) {
+	if err := ValidateName(name); err != nil {
+		return Case{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, name, metaFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Case{}, fmt.Errorf("%w: case %q does not exist (create it with \"trident case new %s\")",
+				apperr.ErrInvalidInput, name, name)
+		}
+		return Case{}, fmt.Errorf("reading case: %w", err)
+	}
+	var c Case
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Case{}, fmt.Errorf("parsing case %q: %w", name, err)
+	}
+	c.Name = name
+	return c, nil
+}
+
+// List returns every case with its entry count and last update, sorted by
+// name. Directories without case metadata are skipped.
+func (s *Store) List() ([]Summary, error) {
+	dirEntries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading cases dir: %w", err)
+	}
+	var list []Summary
+	for _, de := range dirEntries {
+		if !de.IsDir() || ValidateName(de.Name()) != nil {
+			continue
+		}
+		c, err := s.Get(de.Name())
+		if err != nil {
+			continue
+		}
+		entries, err := s.Entries(c.Name)
+		if err != nil {
+			return nil, err
+		}
+		sum := Summary{Case: c, Entries: len(entries)}
+		if len(entries) > 0 {
+			sum.Updated = entries[len(entries)-1].Time
+		}
+		list = append(list, sum)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Record appends e to the entries of the case called name, which must exist.
+func (s *Store) Record(name string, e Entry) error {
+	if _, err := s.Get(name); err != nil {
+		return err
+	}
+	e.Time = e.Time.UTC()
+	if err := jsonl.Append(filepath.Join(s.dir, name, entriesFile), e); err != nil {
+		return fmt.Errorf("writing case entry: %w", err)
+	}
+	return nil
+}
+
+// Entries returns the entries of the case called name, oldest first. Lines
+// that cannot be decoded are skipped.
+func (s *Store) Entries(name string) ([]Entry, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	entries, err := jsonl.Read(filepath.Join(s.dir, name, entriesFile), func(e Entry) bool { return !e.Time.IsZero() })
+	if err != nil {
+		return nil, fmt.Errorf("reading case entries: %w", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, nil
+}
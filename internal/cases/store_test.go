@@ -0,0 +1,112 @@
+package cases_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/apperr"
+	"github.com/tbckr/trident/internal/cases"
+)
+
+var t0 = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestStore_CreateGet(t *testing.T) {
+	store := cases.NewStore(t.TempDir())
+
+	c, err := store.Create("acme-phish", "Phishing wave against ACME", t0)
+	require.NoError(t, err)
+	assert.Equal(t, cases.Case{Name: "acme-phish", Description: "Phishing wave against ACME", Created: t0}, c)
+
+	got, err := store.Get("acme-phish")
+	require.NoError(t, err)
+	assert.Equal(t, c, got)
+
+	_, err = store.Create("acme-phish", "", t0)
+	require.ErrorIs(t, err, apperr.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "already exists")
+
+	_, err = store.Get("missing")
+	require.ErrorIs(t, err, apperr.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"acme", "IR-2025.03_1", "a"} {
+		assert.NoError(t, cases.ValidateName(name), name)
+	}
+	for _, name := range []string{"", ".", "..", "../etc", "-x", "a/b", "a b", string(make([]byte, 65))} {
+		assert.ErrorIs(t, cases.ValidateName(name), apperr.ErrInvalidInput, name)
+	}
+}
+
+func TestStore_RecordEntries(t *testing.T) {
+	dir := t.TempDir()
+	store := cases.NewStore(dir)
+	_, err := store.Create("acme", "", t0)
+	require.NoError(t, err)
+
+	later := cases.Entry{
+		Time: t0.Add(time.Hour), Command: "trident crtsh", Service: "crtsh", Input: "example.com",
+		PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{"subdomains":["vpn.example.com"]}`),
+	}
+	earlier := cases.Entry{
+		Time: t0, Command: "trident dns", Service: "dns", Input: "example.com",
+		PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{"a":["93.184.216.34"]}`),
+	}
+	require.NoError(t, store.Record("acme", later))
+	require.NoError(t, store.Record("acme", earlier))
+
+	// A corrupt line is skipped.
+	f, err := os.OpenFile(filepath.Join(dir, "acme", "entries.jsonl"), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("{truncated\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	entries, err := store.Entries("acme")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "dns", entries[0].Service)
+	assert.Equal(t, "crtsh", entries[1].Service)
+
+	info, err := os.Stat(filepath.Join(dir, "acme", "entries.jsonl"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.ErrorIs(t, store.Record("missing", earlier), apperr.ErrInvalidInput)
+}
+
+func TestStore_List(t *testing.T) {
+	dir := t.TempDir()
+	store := cases.NewStore(dir)
+
+	list, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	_, err = store.Create("zeta", "", t0)
+	require.NoError(t, err)
+	_, err = store.Create("alpha", "first", t0)
+	require.NoError(t, err)
+	require.NoError(t, store.Record("alpha", cases.Entry{
+		Time: t0.Add(time.Minute), Command: "trident dns", Service: "dns", Input: "example.com",
+		PAP: "amber", PAPLimit: "white", Result: json.RawMessage(`{}`),
+	}))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "stray"), 0o700))
+
+	list, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "alpha", list[0].Name)
+	assert.Equal(t, 1, list[0].Entries)
+	assert.Equal(t, t0.Add(time.Minute), list[0].Updated)
+	assert.Equal(t, "zeta", list[1].Name)
+	assert.Zero(t, list[1].Entries)
+	assert.True(t, list[1].Updated.IsZero())
+}
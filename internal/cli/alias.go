@@ -85,6 +85,9 @@ func newAliasListCmd(d *deps) *cobra.Command {
 		Short:   "List all aliases",
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := d.rejectReport(cmd); err != nil {
+				return err
+			}
 			if len(d.cfg.Aliases) == 0 {
 				return nil
 			}
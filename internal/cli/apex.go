@@ -7,10 +7,15 @@ import (
 	"github.com/tbckr/trident/internal/httpclient"
 	"github.com/tbckr/trident/internal/ratelimit"
 	apexsvc "github.com/tbckr/trident/internal/services/apex"
+	ipinfosvc "github.com/tbckr/trident/internal/services/ipinfo"
 )
 
 func newApexCmd(d *deps) *cobra.Command {
-	return &cobra.Command{
+	var (
+		flagProfile    string
+		flagOfflineASN bool
+	)
+	cmd := &cobra.Command{
 		Use:     "apex [domain...]",
 		Short:   "Aggregate DNS recon for an apex domain",
 		GroupID: "aggregate",
@@ -22,13 +27,28 @@ and consolidates the results into a single output. CNAME chains are followed
 automatically and CDN providers are detected from CNAME targets. Results are
 returned in a deterministic order matching the query list.
 
-Queried record types: A, AAAA, CAA, DNSKEY, HTTPS, MX, NS, SOA, SRV, SSHFP,
-TXT, CNAME (direct + chain).
-SRV services: _sip._tls, _sipfederationtls._tcp, _xmpp-client._tcp,
-_xmpp-server._tcp.
-Derived hostnames: www, mail, autodiscover, _dmarc, _domainkey, _mta-sts,
-_smtp._tls, default._bimi, google._domainkey, selector1._domainkey,
-selector2._domainkey.
+The queries come from a profile (--profile, or apex.profile in config):
+
+  default  apex records (A, AAAA, CAA, DNSKEY, DS, HTTPS, MX, NS, SOA, SSHFP,
+           TXT), www, mail, autodiscover, email security records, and
+           SIP/XMPP SRV services
+  mail     mail routing, client SRV records (_autodiscover._tcp,
+           _submission._tcp, _imaps._tcp, ...), MTA-STS, DANE, and DKIM
+  web      apex and www address, HTTPS, CAA, and TLSA records
+  full     default plus DNSSEC (CDS, CDNSKEY), directory and calendar SRV
+           records (_kerberos._tcp, _ldap._tcp, _caldav._tcp, ...), and
+           remote-access hosts (vpn, owa, sso, remote, portal)
+
+Profiles in <config-dir>/apex-profiles.yaml replace built-in profiles of the
+same name or add new ones. --profile also accepts the path of a YAML file
+holding a single profile (name, description, cname, queries). "trident
+services" shows the active profile.
+
+ASN enrichment of discovered IPs queries Team Cymru. With --offline-asn (or
+apex.offline_asn in config) the local ipinfo databases (see "trident ipinfo")
+are consulted first, so IPs they know never leave the machine; this needs an
+ASN database such as "trident download dbip" installs. IPs the databases do
+not know are still looked up via Team Cymru.
 
 PAP level: AMBER (queries go to Quad9 and Cymru third-party servers).
 
@@ -43,6 +63,10 @@ Bulk stdin input is processed concurrently (see --concurrency).`,
   # JSON output
   trident apex --output json example.com
 
+  # Mail-focused probes, or a custom query plan
+  trident apex --profile mail example.com
+  trident apex --profile ./engagement.yaml example.com
+
   # Bulk input from stdin
   cat domains.txt | trident apex`,
 		Args: cobra.ArbitraryArgs,
@@ -64,8 +88,42 @@ Bulk stdin input is processed concurrently (see --concurrency).`,
 			if err != nil {
 				return err
 			}
+			profile, err := d.loadApexProfile(flagProfile)
+			if err != nil {
+				return err
+			}
 			svc := apexsvc.NewService(client, r, d.logger, patterns)
+			svc.UseProfile(profile)
+			offlineASN := d.cfg.Apex.OfflineASN
+			if cmd.Flags().Changed("offline-asn") {
+				offlineASN = flagOfflineASN
+			}
+			if offlineASN {
+				dir, err := ipinfosvc.DefaultDataDir()
+				if err != nil {
+					return err
+				}
+				svc.EnableOfflineASN(ipinfosvc.NewService(dir, d.logger))
+			}
 			return runAggregateCmd(cmd, d, svc, args)
 		},
 	}
+	cmd.Flags().BoolVar(&flagOfflineASN, "offline-asn", false, "look up ASNs in local ipinfo databases before Team Cymru (default: apex.offline_asn config)")
+	cmd.Flags().StringVar(&flagProfile, "profile", "", `query profile name or YAML file (default: apex.profile config, "default")`)
+	_ = cmd.RegisterFlagCompletionFunc("profile", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		path, err := apexsvc.DefaultProfilePath()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		profiles, err := apexsvc.LoadProfiles(path)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		names := make([]string, len(profiles))
+		for i, p := range profiles {
+			names[i] = p.Name + "\t" + p.Description
+		}
+		return names, cobra.ShellCompDirectiveDefault
+	})
+	return cmd
 }
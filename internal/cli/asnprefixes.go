@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	asnpfxsvc "github.com/tbckr/trident/internal/services/asnprefixes"
+)
+
+func newASNPrefixesCmd(d *deps) *cobra.Command {
+	var files []string
+	var aggregate bool
+	cmd := &cobra.Command{
+		Use:     "asn-prefixes [asn...]",
+		Short:   "List the IPv4 and IPv6 prefixes announced by an ASN",
+		GroupID: "services",
+		Long: `List every IPv4 and IPv6 prefix announced by an autonomous system, with
+first/last seen timestamps. Complements "trident cymru AS…", which only
+returns the ASN description.
+
+By default the RIPEstat announced-prefixes API is queried; it reports what
+the RIPE RIS route collectors saw during the last two weeks.
+
+With --file, local route data is read instead and no network traffic occurs:
+  - MRT TABLE_DUMP_V2 RIB dumps (RIPE RIS bview.*, RouteViews rib.*)
+  - CAIDA RouteViews prefix-to-AS files (*.pfx2as)
+Files may be gzip (.gz) or bzip2 (.bz2) compressed. For MRT dumps, first seen
+is the earliest time any peer received the route and last seen is the dump
+time; pfx2as files carry no timestamps.
+
+Prefixes are deduplicated and their observation windows merged. --aggregate
+additionally folds more-specifics into covering prefixes and merges adjacent
+prefixes into the smallest covering set.
+
+Output: table mode shows Prefix / First Seen / Last Seen. Text mode prints
+one prefix per line for piping into other tools.
+
+PAP level: AMBER (queries the RIPEstat third-party API).
+With --file: RED (offline, no network activity).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Prefixes announced by Google
+  trident asn-prefixes AS15169
+
+  # Smallest covering set, one prefix per line
+  trident asn-prefixes --aggregate --output text AS15169
+
+  # Offline from a RIPE RIS RIB dump
+  trident asn-prefixes --pap-limit red --file bview.20261018.0000.gz AS15169`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(files) > 0 {
+				return runServiceCmd(cmd, d, asnpfxsvc.NewService(nil, d.logger, files, aggregate), args)
+			}
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			httpclient.AttachRateLimit(client, ratelimit.New(asnpfxsvc.DefaultRPS, asnpfxsvc.DefaultBurst))
+			return runServiceCmd(cmd, d, asnpfxsvc.NewService(client, d.logger, nil, aggregate), args)
+		},
+	}
+	cmd.Flags().StringSliceVar(&files, "file", nil, "read MRT RIB dumps or pfx2as files instead of querying RIPEstat (PAP: RED; repeatable)")
+	cmd.Flags().BoolVar(&aggregate, "aggregate", false, "collapse prefixes into the smallest covering set")
+	return cmd
+}
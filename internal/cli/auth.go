@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/tbckr/trident/internal/credentials"
+	"github.com/tbckr/trident/internal/output"
+	securitytrailssvc "github.com/tbckr/trident/internal/services/securitytrails"
+	shodansvc "github.com/tbckr/trident/internal/services/shodan"
+	virustotalsvc "github.com/tbckr/trident/internal/services/virustotal"
+)
+
+// keyedServices lists the services that need an API key, alphabetically.
+var keyedServices = []string{
+	securitytrailssvc.Name,
+	shodansvc.Name,
+	virustotalsvc.Name,
+}
+
+type authEntry struct {
+	Service string `json:"service"`
+	Source  string `json:"source"`
+	Key     string `json:"key"`
+	EnvVar  string `json:"env_var"`
+}
+
+func newAuthCmd(d *deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "auth",
+		Short:   "Manage API keys for the optional keyed services",
+		GroupID: "utility",
+		Long: `Manage API keys for the optional keyed services (` + strings.Join(keyedServices, ", ") + `).
+
+Keys are stored in credentials.yaml next to the config file with 0600
+permissions. A TRIDENT_<SERVICE>_API_KEY environment variable takes precedence
+over the stored key. No keyless command needs a key, and a broken credentials
+file never fails one.`,
+	}
+	cmd.AddCommand(
+		newAuthSetCmd(d),
+		newAuthListCmd(d),
+		newAuthRemoveCmd(d),
+	)
+	return cmd
+}
+
+// completeKeyedServices completes the first argument with keyed service names.
+func completeKeyedServices(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return keyedServices, cobra.ShellCompDirectiveNoFileComp
+}
+
+// validateKeyedService rejects names that are not keyed services.
+func validateKeyedService(name string) error {
+	if !slices.Contains(keyedServices, name) {
+		return fmt.Errorf("unknown keyed service %q: must be one of %s", name, strings.Join(keyedServices, ", "))
+	}
+	return nil
+}
+
+func newAuthSetCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <service>",
+		Short: "Store the API key for a keyed service",
+		Long: `Store the API key for a keyed service.
+
+On a terminal the key is prompted for without echo. Otherwise the first line of
+stdin is read, so keys never need to appear in shell history.`,
+		Example: `  # Prompt for the key
+  trident auth set virustotal
+
+  # Read the key from a password manager
+  pass show osint/shodan | trident auth set shodan`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeKeyedServices,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := args[0]
+			if err := validateKeyedService(service); err != nil {
+				return err
+			}
+			key, err := readAPIKey(cmd, service)
+			if err != nil {
+				return err
+			}
+			store, err := d.credentials()
+			if err != nil {
+				return err
+			}
+			if err := store.Set(service, key); err != nil {
+				return err
+			}
+			d.logger.Info("API key stored", "service", service, "path", store.Path())
+			return nil
+		},
+	}
+}
+
+// readAPIKey prompts for the key without echo on a terminal, or reads the
+// first line of stdin otherwise.
+func readAPIKey(cmd *cobra.Command, service string) (string, error) {
+	r := cmd.InOrStdin()
+	if f, ok := r.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "API key for %s: ", service)
+		raw, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", fmt.Errorf("reading API key: %w", err)
+		}
+		return strings.TrimSpace(string(raw)), nil
+	}
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return "", fmt.Errorf("reading API key: %w", err)
+		}
+		return "", fmt.Errorf("no API key on stdin")
+	}
+	return strings.TrimSpace(sc.Text()), nil
+}
+
+func newAuthListCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List keyed services and where their keys come from",
+		Long: `List every keyed service with the source of its API key (env, file, or
+none), the masked key, and the environment variable that overrides it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := d.rejectReport(cmd); err != nil {
+				return err
+			}
+			store, err := d.credentials()
+			if err != nil {
+				return err
+			}
+			entries := make([]authEntry, 0, len(keyedServices))
+			for _, name := range keyedServices {
+				key, origin := store.Get(name)
+				source := string(origin)
+				if origin == credentials.OriginNone {
+					source = "none"
+				}
+				entries = append(entries, authEntry{
+					Service: name,
+					Source:  source,
+					Key:     credentials.Mask(key),
+					EnvVar:  credentials.EnvVar(name),
+				})
+			}
+
+			w := cmd.OutOrStdout()
+			switch output.Format(d.cfg.Output) {
+			case output.FormatJSON:
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				return enc.Encode(entries)
+			case output.FormatText:
+				for _, e := range entries {
+					if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", e.Service, e.Source, e.Key); err != nil {
+						return err
+					}
+				}
+				return nil
+			default: // table
+				table := output.NewWrappingTable(w, 20, 30)
+				table.Header([]string{"Service", "Source", "Key", "Env Var"})
+				rows := make([][]string, 0, len(entries))
+				for _, e := range entries {
+					rows = append(rows, []string{e.Service, e.Source, e.Key, e.EnvVar})
+				}
+				if err := table.Bulk(rows); err != nil {
+					return err
+				}
+				return table.Render()
+			}
+		},
+	}
+}
+
+func newAuthRemoveCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:               "remove <service>",
+		Aliases:           []string{"rm"},
+		Short:             "Remove the stored API key for a keyed service",
+		Long:              `Remove the stored API key for a keyed service. A key supplied through the environment is not affected.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeKeyedServices,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := args[0]
+			if err := validateKeyedService(service); err != nil {
+				return err
+			}
+			store, err := d.credentials()
+			if err != nil {
+				return err
+			}
+			removed, err := store.Remove(service)
+			if err != nil {
+				return err
+			}
+			if !removed {
+				d.logger.Info("no stored API key", "service", service)
+			}
+			return nil
+		},
+	}
+}
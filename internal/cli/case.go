@@ -0,0 +1,340 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/tbckr/trident/internal/cases"
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// Export formats of "trident case export".
+const (
+	exportJSON     = "json"
+	exportMarkdown = "md"
+	exportSTIX     = "stix"
+)
+
+// exportFormats are the -o values of "trident case export"; "markdown" is
+// accepted as a synonym of "md" so the global spelling works too.
+var exportFormats = []string{exportJSON, exportMarkdown, string(output.FormatMarkdown), exportSTIX}
+
+func newCaseCmd(d *deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "case",
+		Short:   "Record results into an investigation case and export its observables",
+		GroupID: "utility",
+		Long: `Keep the results of an investigation together in a local case.
+
+While a case is active (the case key in config, set by "trident case new" or
+"trident case use", or --case for a single run), every service command records
+its command line, inputs, PAP level, and results into the case under
+<config-dir>/cases/<name>/. "trident case show" lists the observables found so
+far — domains, IP addresses, networks, ASNs, URLs, e-mail addresses, and
+hashes — deduplicated across services, and "trident case export" writes the
+case as JSON, Markdown, or a STIX 2.1 bundle.`,
+	}
+	cmd.AddCommand(
+		newCaseNewCmd(d),
+		newCaseUseCmd(d),
+		newCaseListCmd(d),
+		newCaseShowCmd(d),
+		newCaseExportCmd(d),
+	)
+	return cmd
+}
+
+func newCaseNewCmd(d *deps) *cobra.Command {
+	var flagDescription string
+	cmd := &cobra.Command{
+		Use:   "new <name>",
+		Short: "Create a case and make it the active one",
+		Args:  cobra.ExactArgs(1),
+		Example: `  trident case new acme-phish --description "Phishing wave against ACME"
+  trident apex acme.example   # recorded into acme-phish`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			store, err := d.caseStore()
+			if err != nil {
+				return err
+			}
+			if _, err := store.Create(args[0], flagDescription, time.Now()); err != nil {
+				return err
+			}
+			if err := setActiveCase(d, args[0]); err != nil {
+				return err
+			}
+			d.logger.Info("case created and active", "case", args[0])
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&flagDescription, "description", "", "short description of the case")
+	return cmd
+}
+
+func newCaseUseCmd(d *deps) *cobra.Command {
+	var flagClear bool
+	cmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Make a case the active one, or stop recording with --clear",
+		Args:  cobra.MaximumNArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return caseNames(d), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			if flagClear {
+				if len(args) > 0 {
+					return fmt.Errorf("%w: --clear takes no case name", services.ErrInvalidInput)
+				}
+				return setActiveCase(d, "")
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("%w: specify a case name or --clear", services.ErrInvalidInput)
+			}
+			store, err := d.caseStore()
+			if err != nil {
+				return err
+			}
+			if _, err := store.Get(args[0]); err != nil {
+				return err
+			}
+			return setActiveCase(d, args[0])
+		},
+	}
+	cmd.Flags().BoolVar(&flagClear, "clear", false, "deactivate the active case")
+	return cmd
+}
+
+func newCaseListCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List cases, marking the active one",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store, err := d.caseStore()
+			if err != nil {
+				return err
+			}
+			list, err := store.List()
+			if err != nil {
+				return err
+			}
+			listing := &cases.Listing{Active: d.cfg.Case, Cases: list}
+			if listing.IsEmpty() {
+				d.logger.Info("no cases; create one with \"trident case new <name>\"")
+				return nil
+			}
+			return writeResult(cmd.OutOrStdout(), d, listing)
+		},
+	}
+}
+
+func newCaseShowCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [name]",
+		Short: "Show the observables discovered in a case",
+		Long: `Show the observables discovered so far in a case (the active one by default),
+deduplicated across services, with the services that reported each one and when
+it was first seen. -o text prints one "type value" pair per line.`,
+		Args: cobra.MaximumNArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return caseNames(d), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := loadCaseReport(d, args, false)
+			if err != nil {
+				return err
+			}
+			if report.IsEmpty() {
+				d.logger.Info("no observables recorded", "case", report.Case.Name)
+				return nil
+			}
+			return writeResult(cmd.OutOrStdout(), d, report)
+		},
+	}
+}
+
+func newCaseExportCmd(d *deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [name]",
+		Short: "Export a case as JSON, Markdown, or a STIX 2.1 bundle",
+		Long: `Export a case (the active one by default) in the format given with -o:
+
+  json  (default) the case, every recorded entry with its result, and the observables
+  md    a Markdown report: summary, one observable table per type, command log
+  stix  a STIX 2.1 bundle with one cyber-observable object per observable and a
+        grouping named after the case
+
+-o markdown is the same as -o md. The configured default output format does
+not apply to export; without -o a case is exported as JSON.
+
+Markdown follows the defang rules of text output and JSON those of JSON output.
+STIX bundles are never defanged so they stay importable.`,
+		Example: `  trident case export -o md > acme-phish.md
+  trident case export acme-phish -o stix > acme-phish.stix.json`,
+		Annotations: map[string]string{formatsAnnotation: strings.Join(exportFormats, ",")},
+		Args:        cobra.MaximumNArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return caseNames(d), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := loadCaseReport(d, args, true)
+			if err != nil {
+				return err
+			}
+			w := cmd.OutOrStdout()
+			switch exportFormat(cmd, d) {
+			case exportMarkdown:
+				if output.ResolveDefang(d.papLevel, output.FormatText, d.cfg.Defang, d.cfg.NoDefang) {
+					w = &output.DefangWriter{Inner: w}
+				}
+				return report.WriteMarkdown(w)
+			case exportSTIX:
+				return output.Write(w, output.FormatJSON, report.STIX())
+			default:
+				if output.ResolveDefang(d.papLevel, output.FormatJSON, d.cfg.Defang, d.cfg.NoDefang) {
+					w = &output.DefangWriter{Inner: w}
+				}
+				return output.Write(w, output.FormatJSON, report)
+			}
+		},
+	}
+	return cmd
+}
+
+// exportFormat returns the export format selected with -o, which buildDeps
+// has already checked against exportFormats. Without -o it is JSON.
+func exportFormat(cmd *cobra.Command, d *deps) string {
+	if !cmd.Flags().Changed("output") {
+		return exportJSON
+	}
+	if d.cfg.Output == string(output.FormatMarkdown) {
+		return exportMarkdown
+	}
+	return d.cfg.Output
+}
+
+// loadCaseReport loads the case named in args, or the active case, and builds
+// its report.
+func loadCaseReport(d *deps, args []string, withEntries bool) (*cases.Report, error) {
+	name := d.cfg.Case
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%w: no active case; name one or run \"trident case use <name>\"", services.ErrInvalidInput)
+	}
+	store, err := d.caseStore()
+	if err != nil {
+		return nil, err
+	}
+	c, err := store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := store.Entries(name)
+	if err != nil {
+		return nil, err
+	}
+	return cases.NewReport(c, entries, withEntries), nil
+}
+
+// caseNames returns the existing case names for shell completion.
+func caseNames(d *deps) []string {
+	store, err := d.caseStore()
+	if err != nil {
+		return nil
+	}
+	list, _ := store.List()
+	names := make([]string, 0, len(list))
+	for _, c := range list {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// setActiveCase persists name as the case key in the config file, removing
+// the key when name is empty.
+func setActiveCase(d *deps, name string) error {
+	if name == "" {
+		return writeConfigKey(d.cfg.ConfigFile, "case", nil)
+	}
+	return writeConfigKey(d.cfg.ConfigFile, "case", name)
+}
+
+// caseRecorder records service results into the active case.
+type caseRecorder struct {
+	store    *cases.Store
+	name     string
+	command  string
+	papLimit string
+}
+
+// caseRecorder returns the recorder for the active case, or nil when no case
+// is active. It fails when the active case does not exist.
+func (d *deps) caseRecorder(cmd *cobra.Command) (*caseRecorder, error) {
+	if d.cfg.Case == "" {
+		return nil, nil
+	}
+	store, err := d.caseStore()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := store.Get(d.cfg.Case); err != nil {
+		return nil, err
+	}
+	return &caseRecorder{store: store, name: d.cfg.Case, command: commandLine(cmd), papLimit: d.papLevel.String()}, nil
+}
+
+// record appends result for input to the case. A nil recorder (no active
+// case) is a no-op.
+func (r *caseRecorder) record(svc services.Service, input string, result services.Result) error {
+	if r == nil {
+		return nil
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("recording to case %s: %w", r.name, err)
+	}
+	e := cases.Entry{
+		Time:     time.Now(),
+		Command:  r.command,
+		Service:  svc.Name(),
+		Input:    input,
+		PAP:      svc.PAP().String(),
+		PAPLimit: r.papLimit,
+		Result:   raw,
+	}
+	if err := r.store.Record(r.name, e); err != nil {
+		return fmt.Errorf("recording to case %s: %w", r.name, err)
+	}
+	return nil
+}
+
+// commandLine returns the command path with the command's own flags that
+// were set, e.g. "trident apex --profile mail". Global flags are left out so
+// proxy credentials never reach the case file.
+func commandLine(cmd *cobra.Command) string {
+	parts := []string{cmd.CommandPath()}
+	cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			parts = append(parts, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaseExport_Formats(t *testing.T) {
+	isolateConfig(t)
+	_, _, err := execute(t, "case", "new", "op-1")
+	require.NoError(t, err)
+	_, _, err = execute(t, "identify", "--case", "op-1", "--domain", "example.com", "--cname", "foo.cloudfront.net")
+	require.NoError(t, err)
+
+	t.Run("json without -o", func(t *testing.T) {
+		out, _, err := execute(t, "case", "export", "op-1")
+		require.NoError(t, err)
+		var report struct {
+			Case struct{ Name string } `json:"case"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		assert.Equal(t, "op-1", report.Case.Name)
+	})
+
+	for _, format := range []string{"md", "markdown"} {
+		t.Run(format, func(t *testing.T) {
+			out, _, err := execute(t, "case", "export", "op-1", "-o", format)
+			require.NoError(t, err)
+			assert.Contains(t, out, "# ")
+			assert.False(t, json.Valid([]byte(out)), "Markdown, not JSON")
+		})
+	}
+
+	t.Run("stix", func(t *testing.T) {
+		out, _, err := execute(t, "case", "export", "op-1", "-o", "stix")
+		require.NoError(t, err)
+		var bundle struct{ Type string }
+		require.NoError(t, json.Unmarshal([]byte(out), &bundle))
+		assert.Equal(t, "bundle", bundle.Type)
+	})
+
+	t.Run("result format rejected", func(t *testing.T) {
+		_, _, err := execute(t, "case", "export", "op-1", "-o", "table")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid output format "table"`)
+	})
+}
+
+func TestOutputFormat_STIXOnlyForCaseExport(t *testing.T) {
+	isolateConfig(t)
+	_, _, err := execute(t, "identify", "-o", "stix", "--cname", "foo.cloudfront.net")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid output format "stix"`)
+}
@@ -13,6 +13,8 @@ import (
 	providers "github.com/tbckr/trident/internal/detect"
 	"github.com/tbckr/trident/internal/httpclient"
 	"github.com/tbckr/trident/internal/output"
+	dnsblsvc "github.com/tbckr/trident/internal/services/dnsbl"
+	"github.com/tbckr/trident/internal/snapshot"
 )
 
 func newConfigCmd(d *deps) *cobra.Command {
@@ -84,10 +86,28 @@ func effectiveValue(d *deps, key string) string {
 		return fmt.Sprintf("%v", d.cfg.NoDefang)
 	case "concurrency":
 		return fmt.Sprintf("%d", d.cfg.Concurrency)
+	case "save":
+		return fmt.Sprintf("%v", d.cfg.Save)
+	case "case":
+		return d.cfg.Case
 	case "detect_patterns.url":
 		return d.cfg.DetectPatterns.URL
 	case "detect_patterns.file":
 		return providers.ResolvePatternFile(d.cfg.DetectPatterns.File)
+	case "reverseip.shared_threshold":
+		return fmt.Sprintf("%d", d.cfg.ReverseIP.SharedThreshold)
+	case "dnsbl.url":
+		return d.cfg.DNSBL.URL
+	case "dnsbl.file":
+		return dnsblsvc.ResolveZonesFile(d.cfg.DNSBL.File)
+	case "apex.profile":
+		return d.cfg.Apex.Profile
+	case "snapshots.dir":
+		if d.cfg.Snapshots.Dir != "" {
+			return d.cfg.Snapshots.Dir
+		}
+		dir, _ := snapshot.DefaultDir()
+		return dir
 	default:
 		return ""
 	}
@@ -120,9 +140,18 @@ If not explicitly configured, trident searches in order:
   2. <config-dir>/detect-downloaded.yaml  (downloaded via 'trident download detect')
   3. built-in embedded patterns          (displayed as "<embedded>")
 
+dnsbl.file: shows the resolved DNSBL zone catalog in the same way:
+
+  1. <config-dir>/dnsbl.yaml             (user-maintained override)
+  2. <config-dir>/dnsbl-downloaded.yaml  (downloaded via 'trident download dnsbl')
+  3. built-in embedded zones             (displayed as "<embedded>")
+
 Use 'trident config path' to find <config-dir> on this system.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := d.rejectReport(cmd); err != nil {
+				return err
+			}
 			w := cmd.OutOrStdout()
 			rows := buildConfigRows(d)
 			switch output.Format(d.cfg.Output) {
@@ -204,36 +233,45 @@ func newConfigSetCmd(d *deps) *cobra.Command {
 				return err
 			}
 
-			// Read only what is already explicitly in the file — never from d.cfg
-			// (which is fully populated with defaults from flags/env vars/code).
-			// This ensures a fresh-file set writes only the one requested key.
-			raw := map[string]any{}
-			data, err := os.ReadFile(d.cfg.ConfigFile)
-			if err != nil && !os.IsNotExist(err) {
-				return fmt.Errorf("reading config file: %w", err)
-			}
-			if len(data) > 0 {
-				if err := yaml.Unmarshal(data, &raw); err != nil {
-					return fmt.Errorf("parsing config file: %w", err)
-				}
-			}
-
-			// Set ONLY the single specified key; leave every other key untouched.
-			raw[key] = typedValue
-
-			out, err := yaml.Marshal(raw)
-			if err != nil {
-				return fmt.Errorf("marshaling config: %w", err)
-			}
-			if err := os.WriteFile(d.cfg.ConfigFile, out, 0o600); err != nil {
-				return fmt.Errorf("writing config file: %w", err)
-			}
-			return nil
+			return writeConfigKey(d.cfg.ConfigFile, key, typedValue)
 		},
 	}
 	return cmd
 }
 
+// writeConfigKey sets key to value in the config file at path, or removes the
+// key when value is nil. It reads only what is already explicitly in the file —
+// never d.cfg, which is fully populated with defaults from flags/env vars/code —
+// so a fresh-file set writes only the one requested key and every other key is
+// left untouched.
+func writeConfigKey(path, key string, value any) error {
+	raw := map[string]any{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("parsing config file: %w", err)
+		}
+	}
+
+	if value == nil {
+		delete(raw, key)
+	} else {
+		raw[key] = value
+	}
+
+	out, err := yaml.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("marshaling config: %w", err)
+	}
+	if err := os.WriteFile(path, out, 0o600); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
+	}
+	return nil
+}
+
 func newConfigEditCmd(d *deps) *cobra.Command {
 	return &cobra.Command{
 		Use:   "edit",
@@ -7,7 +7,11 @@ import (
 )
 
 func newCymruCmd(d *deps) *cobra.Command {
-	return &cobra.Command{
+	var (
+		bulkThreshold int
+		peers         bool
+	)
+	cmd := &cobra.Command{
 		Use:     "cymru [ip|ASN...]",
 		Short:   "Look up ASN information for an IP address or ASN (e.g. AS15169)",
 		GroupID: "services",
@@ -17,6 +21,18 @@ For IP addresses, resolves the originating ASN via Team Cymru's DNS-based
 service (origin.asn.cymru.com). Supports both IPv4 and IPv6.
 For ASN identifiers (e.g. AS15169), retrieves the AS name and description.
 
+IP lookups return every origin ASN announcing a covering prefix, the
+allocation date, and — with --peers — the upstream peer ASNs of the prefix
+(peer.asn.cymru.com, IPv4 only). A prefix announced by more than one origin
+ASN (MOAS) is flagged as a potential hijack indicator.
+
+Each IP costs two DNS queries. When bulk input contains at least
+--bulk-threshold IP addresses, all of them are resolved in a single session
+with Team Cymru's bulk whois interface (whois.cymru.com, TCP port 43)
+instead; ASN inputs and any IPs the session cannot answer fall back to DNS.
+A socks5:// proxy tunnels the whois session. Set --bulk-threshold 0 to
+always use DNS. Bulk mode does not provide peers, so --peers always uses DNS.
+
 PAP level: AMBER (queries Team Cymru's third-party DNS service).
 
 Multiple inputs can be supplied as arguments or piped via stdin (one per line).
@@ -33,6 +49,12 @@ Bulk stdin input is processed concurrently (see --concurrency).`,
   # Bulk input from stdin
   echo -e "8.8.8.8\n1.1.1.1" | trident cymru
 
+  # Include upstream peer ASNs
+  trident cymru --peers 8.8.8.8
+
+  # Large IP list via one bulk whois session
+  trident cymru < ips.txt
+
   # JSON output
   trident cymru --output json 8.8.8.8`,
 		Args: cobra.ArbitraryArgs,
@@ -45,7 +67,21 @@ Bulk stdin input is processed concurrently (see --concurrency).`,
 				return err
 			}
 			svc := cymrusvc.NewService(r, d.logger)
+			if peers {
+				svc.EnablePeers()
+			}
+			if bulkThreshold > 0 {
+				dialer, err := d.newDialer()
+				if err != nil {
+					return err
+				}
+				svc.EnableBulk(dialer, bulkThreshold)
+			}
 			return runServiceCmd(cmd, d, svc, args)
 		},
 	}
+	cmd.Flags().IntVar(&bulkThreshold, "bulk-threshold", cymrusvc.DefaultBulkThreshold,
+		"minimum number of IP inputs for the bulk whois backend (0 disables)")
+	cmd.Flags().BoolVar(&peers, "peers", false, "also look up upstream peer ASNs for IPv4 inputs")
+	return cmd
 }
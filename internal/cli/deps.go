@@ -1,22 +1,34 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/imroc/req/v3"
 	"github.com/spf13/cobra"
+	"golang.org/x/net/proxy"
 
+	"github.com/tbckr/trident/internal/cases"
 	"github.com/tbckr/trident/internal/config"
+	"github.com/tbckr/trident/internal/credentials"
 	providers "github.com/tbckr/trident/internal/detect"
 	"github.com/tbckr/trident/internal/httpclient"
 	"github.com/tbckr/trident/internal/output"
 	"github.com/tbckr/trident/internal/pap"
 	"github.com/tbckr/trident/internal/resolver"
+	"github.com/tbckr/trident/internal/services"
+	apexsvc "github.com/tbckr/trident/internal/services/apex"
+	dnsblsvc "github.com/tbckr/trident/internal/services/dnsbl"
+	filterchecksvc "github.com/tbckr/trident/internal/services/filtercheck"
+	"github.com/tbckr/trident/internal/sink"
+	"github.com/tbckr/trident/internal/snapshot"
 )
 
 // deps holds fully-resolved runtime dependencies for a subcommand.
@@ -25,6 +37,23 @@ type deps struct {
 	cfg      *config.Config
 	doDefang bool
 	papLevel pap.Level
+	// creds is loaded on first use by credentials() so a broken credentials
+	// file never fails a keyless command.
+	creds *credentials.Store
+}
+
+// formatsAnnotation is the command annotation listing, comma-separated, the
+// -o values a command accepts instead of the result formats. Only an explicit
+// -o is checked against it; a configured default output is not.
+const formatsAnnotation = "trident/output-formats"
+
+// commandFormats returns the -o values accepted by cmd from its
+// formatsAnnotation, or nil when it takes the result formats.
+func commandFormats(cmd *cobra.Command) []string {
+	if v, ok := cmd.Annotations[formatsAnnotation]; ok {
+		return strings.Split(v, ",")
+	}
+	return nil
 }
 
 // buildDeps resolves config, logger, output format, PAP level, and defang flag.
@@ -49,10 +78,13 @@ func buildDeps(cmd *cobra.Command, stderr io.Writer) (*deps, error) {
 	logger := slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: level}))
 
 	format := output.Format(cfg.Output)
-	switch format {
-	case output.FormatTable, output.FormatJSON, output.FormatText:
-	default:
-		return nil, fmt.Errorf("invalid output format %q: must be \"table\", \"json\", or \"text\"", cfg.Output)
+	if allowed := commandFormats(cmd); allowed != nil && cmd.Flags().Changed("output") {
+		if !slices.Contains(allowed, cfg.Output) {
+			return nil, fmt.Errorf("invalid output format %q for %s: must be one of %s",
+				cfg.Output, cmd.CommandPath(), strings.Join(allowed, ", "))
+		}
+	} else if !slices.Contains(output.Formats, format) {
+		return nil, fmt.Errorf("invalid output format %q: must be \"table\", \"json\", \"text\", \"markdown\", or \"html\"", cfg.Output)
 	}
 
 	papLevel, err := pap.Parse(cfg.PAPLimit)
@@ -111,6 +143,16 @@ func (d *deps) newResolver() (*net.Resolver, error) {
 	return r, nil
 }
 
+// newDialer creates a TCP dialer for raw protocols (e.g. WHOIS) configured
+// with the proxy from the resolved config.
+func (d *deps) newDialer() (proxy.ContextDialer, error) {
+	dialer, err := resolver.NewDialer(d.cfg.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("creating dialer: %w", err)
+	}
+	return dialer, nil
+}
+
 // loadPatterns loads the provider detection patterns, prepending any
 // user-supplied override file from config.
 func (d *deps) loadPatterns() (providers.Patterns, error) {
@@ -128,15 +170,216 @@ func (d *deps) loadPatterns() (providers.Patterns, error) {
 	return patterns, nil
 }
 
+// loadDNSBLZones loads the DNSBL zone catalog. explicit (from --zones-file)
+// takes precedence over dnsbl.file from config.
+func (d *deps) loadDNSBLZones(explicit string) ([]dnsblsvc.Zone, error) {
+	paths, err := dnsblsvc.DefaultZonePaths()
+	if err != nil {
+		return nil, fmt.Errorf("resolving DNSBL zone paths: %w", err)
+	}
+	for _, f := range []string{d.cfg.DNSBL.File, explicit} {
+		if f != "" {
+			paths = append([]string{f}, paths...)
+		}
+	}
+	zones, err := dnsblsvc.LoadZones(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("loading DNSBL zones: %w", err)
+	}
+	return zones, nil
+}
+
+// loadFilterResolvers loads the filtercheck resolver catalog, preferring
+// explicit (from --resolvers-file) over <config-dir>/filtercheck.yaml.
+func (d *deps) loadFilterResolvers(explicit string) ([]filterchecksvc.Resolver, error) {
+	path, err := filterchecksvc.DefaultResolverPath()
+	if err != nil {
+		return nil, fmt.Errorf("resolving filtercheck resolver path: %w", err)
+	}
+	paths := []string{path}
+	if explicit != "" {
+		paths = append([]string{explicit}, paths...)
+	}
+	resolvers, err := filterchecksvc.LoadResolvers(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("loading filtercheck resolvers: %w", err)
+	}
+	return resolvers, nil
+}
+
+// loadApexProfile loads the apex query profile named by nameOrFile (from
+// --profile), falling back to apex.profile from config. Named profiles are
+// looked up in the embedded set merged with <config-dir>/apex-profiles.yaml.
+func (d *deps) loadApexProfile(nameOrFile string) (apexsvc.Profile, error) {
+	if nameOrFile == "" {
+		nameOrFile = d.cfg.Apex.Profile
+	}
+	path, err := apexsvc.DefaultProfilePath()
+	if err != nil {
+		return apexsvc.Profile{}, fmt.Errorf("resolving apex profile path: %w", err)
+	}
+	p, err := apexsvc.LoadProfile(nameOrFile, path)
+	if err != nil {
+		return apexsvc.Profile{}, fmt.Errorf("loading apex profile: %w", err)
+	}
+	return p, nil
+}
+
+// snapshotStore returns the snapshot store in snapshots.dir from config, or
+// <config-dir>/snapshots when unset.
+func (d *deps) snapshotStore() (*snapshot.Store, error) {
+	dir := d.cfg.Snapshots.Dir
+	if dir == "" {
+		var err error
+		if dir, err = snapshot.DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot.NewStore(dir), nil
+}
+
+// caseStore returns the case store in <config-dir>/cases.
+func (d *deps) caseStore() (*cases.Store, error) {
+	dir, err := cases.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return cases.NewStore(dir), nil
+}
+
+// sinks builds the output sinks selected with --sink, resolving names
+// against the sinks block of the config. Returns nil when none is selected.
+func (d *deps) sinks() ([]sink.Sink, error) {
+	if len(d.cfg.Sink) == 0 {
+		return nil, nil
+	}
+	configured := make([]sink.Spec, len(d.cfg.Sinks))
+	for i, c := range d.cfg.Sinks {
+		configured[i] = sink.Spec{
+			Name:     c.Name,
+			Type:     c.Type,
+			URL:      c.URL,
+			Path:     c.Path,
+			Format:   output.Format(c.Format),
+			MaxSize:  int64(c.MaxSizeMB) << 20,
+			MaxFiles: c.MaxFiles,
+		}
+	}
+	specs := make([]sink.Spec, 0, len(d.cfg.Sink))
+	for _, value := range d.cfg.Sink {
+		spec, err := sink.Resolve(value, configured)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return d.newSinks(specs...)
+}
+
+// newSinks builds the sinks described by specs with the configured HTTP
+// client and the defang rules of this run.
+func (d *deps) newSinks(specs ...sink.Spec) ([]sink.Sink, error) {
+	client, err := d.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	defang := func(f output.Format) bool {
+		return output.ResolveDefang(d.papLevel, f, d.cfg.Defang, d.cfg.NoDefang)
+	}
+	sinks := make([]sink.Sink, 0, len(specs))
+	for _, spec := range specs {
+		s, err := sink.New(spec, client, defang)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+// notifySinks sends m to every sink. Delivery failures are logged so a
+// broken sink never hides the result on stdout.
+func notifySinks(ctx context.Context, d *deps, sinks []sink.Sink, m sink.Message) {
+	for _, s := range sinks {
+		if err := s.Send(ctx, m); err != nil {
+			d.logger.Error("sink delivery failed", "sink", s.Name(), "service", m.Service, "input", m.Input, "error", err)
+		}
+	}
+}
+
+// credentials returns the API-key store next to the config file, loading it
+// on first use.
+func (d *deps) credentials() (*credentials.Store, error) {
+	if d.creds != nil {
+		return d.creds, nil
+	}
+	path := credentials.PathFor(d.cfg.ConfigFile)
+	store, err := credentials.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if warn := credentials.WarnInsecurePermissions(path); warn != "" {
+		d.logger.Warn(warn)
+	}
+	d.creds = store
+	return store, nil
+}
+
+// apiKey returns the API key for a keyed service, or an error wrapping
+// services.ErrMissingAPIKey that tells the user how to configure one.
+func (d *deps) apiKey(service string) (string, error) {
+	store, err := d.credentials()
+	if err != nil {
+		return "", err
+	}
+	key, _ := store.Get(service)
+	if key == "" {
+		return "", fmt.Errorf("%w for %s: run \"trident auth set %s\" or set %s",
+			services.ErrMissingAPIKey, service, service, credentials.EnvVar(service))
+	}
+	return key, nil
+}
+
 // writeResult formats and writes a service result to stdout.
-// When d.doDefang is true the writer is wrapped with DefangWriter.
+// When d.doDefang is true the writer is wrapped with DefangWriter. Report
+// formats defang cell by cell instead, so results other than a *output.Report
+// are wrapped in one.
 func writeResult(stdout io.Writer, d *deps, result any) error {
+	format := output.Format(d.cfg.Output)
 	w := stdout
-	if d.doDefang {
+	switch {
+	case format.IsReport():
+		if _, ok := result.(*output.Report); !ok {
+			result = d.newReport("trident", output.ReportEntry{Result: result})
+		}
+	case d.doDefang:
 		w = &output.DefangWriter{Inner: stdout}
 	}
-	if err := output.Write(w, output.Format(d.cfg.Output), result); err != nil {
+	if err := output.Write(w, format, result); err != nil {
 		return fmt.Errorf("writing output: %w", err)
 	}
 	return nil
 }
+
+// rejectReport fails when -o selects a report format. The utility commands
+// listing local state (services, auth list, alias list, config show) render
+// tables, JSON, or text only.
+func (d *deps) rejectReport(cmd *cobra.Command) error {
+	if output.Format(d.cfg.Output).IsReport() {
+		return fmt.Errorf("%w: %s writes table, json, or text, not %s",
+			services.ErrInvalidInput, cmd.CommandPath(), d.cfg.Output)
+	}
+	return nil
+}
+
+// newReport returns a report of entries carrying the PAP level and defang
+// setting of this run.
+func (d *deps) newReport(title string, entries ...output.ReportEntry) *output.Report {
+	return &output.Report{
+		Title:     title,
+		PAP:       d.papLevel.String(),
+		Generated: time.Now(),
+		Defang:    d.doDefang,
+		Entries:   entries,
+	}
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+	apexsvc "github.com/tbckr/trident/internal/services/apex"
+	crtshsvc "github.com/tbckr/trident/internal/services/crtsh"
+	"github.com/tbckr/trident/internal/sink"
+	"github.com/tbckr/trident/internal/snapshot"
+)
+
+// snapshotResults maps a service name to a constructor for its result type.
+// Results of these services are diffed by their own records; all others are
+// flattened from JSON.
+var snapshotResults = map[string]func() any{
+	apexsvc.Name:  func() any { return &apexsvc.Result{} },
+	crtshsvc.Name: func() any { return &crtshsvc.Result{} },
+}
+
+func newDiffCmd(d *deps) *cobra.Command {
+	var flagSince string
+	cmd := &cobra.Command{
+		Use:     "diff <service> <input>",
+		Short:   "Show what changed between saved snapshots of a result",
+		GroupID: "utility",
+		Long: `Show the records added, removed, or changed between two saved snapshots of
+a service result.
+
+Snapshots are recorded by running any service with --save (or save: true in
+config); each run appends the result with a timestamp to
+<config-dir>/snapshots/<service>/<input>.jsonl (snapshots.dir in config
+overrides the directory).
+
+diff compares the latest snapshot with the one before it. With --since it
+compares the latest snapshot with the last one taken at or before the given
+date, or with the earliest snapshot when all are newer. --since accepts a date
+(2025-01-31), an RFC 3339 timestamp, or a duration such as 7d or 36h.
+
+apex results are compared per host and record type, crtsh results per
+subdomain. Results of other services are compared field by field. With
+--sink a non-empty diff is also sent to the selected sinks, so a scheduled
+"trident diff" can alert a channel.
+
+PAP level: RED (reads local snapshot files only).`,
+		Example: `  # Weekly attack-surface check
+  trident apex --save example.com
+  trident crtsh --save example.com
+  trident diff apex example.com
+
+  # Changes over the last 30 days
+  trident diff crtsh example.com --since 30d
+
+  # Changes since a given date, as JSON
+  trident diff apex example.com --since 2025-01-31 --output json`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			store, err := d.snapshotStore()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var names []string
+			switch len(args) {
+			case 0:
+				names, _ = store.Services()
+			case 1:
+				names, _ = store.Inputs(args[0])
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, input := args[0], output.StripANSI(args[1])
+			var since time.Time
+			if flagSince != "" {
+				var err error
+				if since, err = snapshot.ParseSince(flagSince, time.Now()); err != nil {
+					return err
+				}
+			}
+			store, err := d.snapshotStore()
+			if err != nil {
+				return err
+			}
+			sinks, err := d.sinks()
+			if err != nil {
+				return err
+			}
+			snaps, err := store.List(service, input)
+			if err != nil {
+				return err
+			}
+			from, to, err := snapshot.Select(snaps, since)
+			if err != nil {
+				if errors.Is(err, snapshot.ErrTooFewSnapshots) {
+					return fmt.Errorf("%w: %d snapshot(s) of %s %q saved; %v (run with --save)",
+						services.ErrInvalidInput, len(snaps), service, input, err)
+				}
+				return err
+			}
+			older, err := snapshotRecords(service, from)
+			if err != nil {
+				return err
+			}
+			newer, err := snapshotRecords(service, to)
+			if err != nil {
+				return err
+			}
+			diff := &snapshot.Diff{
+				Service: service,
+				Input:   input,
+				From:    from.Time,
+				To:      to.Time,
+				Changes: snapshot.Compare(older, newer),
+			}
+			if diff.IsEmpty() {
+				d.logger.Info("no changes", "service", service, "input", input,
+					"from", from.Time.Format(time.RFC3339), "to", to.Time.Format(time.RFC3339))
+				return nil
+			}
+			if err := writeResult(cmd.OutOrStdout(), d, diff); err != nil {
+				return err
+			}
+			notifySinks(cmd.Context(), d, sinks, sink.Message{
+				Time: time.Now().UTC(), Kind: sink.KindDiff, Service: service, Input: input, Data: diff,
+			})
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&flagSince, "since", "", "compare against the last snapshot at or before this date or duration (e.g. 2025-01-31, 7d)")
+	return cmd
+}
+
+// snapshotRecords decodes the result of snap into records, using the
+// service's result type when it is registered in snapshotResults.
+func snapshotRecords(service string, snap snapshot.Snapshot) ([]snapshot.Record, error) {
+	var v any
+	if newResult, ok := snapshotResults[service]; ok {
+		v = newResult()
+	}
+	recs, err := snapshot.Records(snap.Result, v)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot of %s: %w", snap.Time.Format(time.RFC3339), err)
+	}
+	return recs, nil
+}
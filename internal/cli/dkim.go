@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/doh"
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	dkimsvc "github.com/tbckr/trident/internal/services/dkim"
+)
+
+func newDKIMCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "dkim [domain...]",
+		Short:   "Discover DKIM selectors and report key size and weak-key warnings",
+		GroupID: "services",
+		Long: `Discover published DKIM selectors for a domain by probing
+<selector>._domainkey.<domain> TXT records via Quad9 DoH.
+
+The probed selectors are an embedded list of common and provider-specific
+selectors, extended by dkim-selectors.txt in the trident config directory
+(one selector per line, '#' starts a comment).
+
+For every selector that publishes a key, the record's k= key type, p= key
+size, and t= flags are reported. Warnings are raised for RSA keys below 2048
+bits (weak below 1024), revoked keys (empty p=), testing mode (t=y), and
+unparsable keys. Selectors are mapped to providers (e.g. k1 → Mailchimp,
+s1/s2 → SendGrid) using the dkim section of the detect patterns.
+
+Output: table mode shows one row per found selector. Text mode prints the
+found selector names, one per line.
+
+PAP level: AMBER (queries go to Quad9 third-party servers).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Discover DKIM selectors
+  trident dkim example.com
+
+  # Full key records as JSON
+  trident dkim --output json example.com
+
+  # Multiple domains from stdin
+  cat domains.txt | trident dkim`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			client.EnableForceHTTP2()
+			httpclient.AttachRateLimit(client, ratelimit.New(doh.DefaultRPS, doh.DefaultBurst))
+			patterns, err := d.loadPatterns()
+			if err != nil {
+				return err
+			}
+			path, err := dkimsvc.DefaultSelectorPath()
+			if err != nil {
+				return err
+			}
+			selectors, err := dkimsvc.LoadSelectors(path)
+			if err != nil {
+				return err
+			}
+			svc := dkimsvc.NewService(client, d.logger, patterns, selectors)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+}
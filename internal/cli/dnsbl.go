@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	dnsblsvc "github.com/tbckr/trident/internal/services/dnsbl"
+)
+
+func newDNSBLCmd(d *deps) *cobra.Command {
+	var flagZonesFile string
+	var flagZones []string
+	cmd := &cobra.Command{
+		Use:     "dnsbl [ip|domain...]",
+		Short:   "Check IP addresses and domains against DNS blocklists",
+		GroupID: "services",
+		Long: `Check IP addresses and domains against DNS-based blocklists (DNSBLs) such as
+Spamhaus ZEN and DBL, SURBL, URIBL, Barracuda, and SpamCop.
+
+IP addresses are queried as <reversed-ip>.<zone> (octets reversed for IPv4,
+nibbles reversed for IPv6; IPv4-only zones are skipped for IPv6 inputs).
+Domains are queried as <domain>.<zone>. Every applicable zone reports one of:
+
+  listed      the zone returned a 127.0.0.x code; its meaning is decoded
+  not listed  NXDOMAIN
+  refused     the operator refused the query, typically because it came
+              through a public or high-volume resolver
+  error       the lookup failed or the resolver rewrote NXDOMAIN
+
+The zones and the meaning of their return codes come from a YAML catalog.
+trident uses the first file found:
+  1. --zones-file
+  2. dnsbl.file in config.yaml
+  3. <config-dir>/dnsbl.yaml            (user-maintained override)
+  4. <config-dir>/dnsbl-downloaded.yaml (see "trident download dnsbl")
+  5. the built-in catalog
+
+Most operators do not answer queries relayed by public resolvers such as
+8.8.8.8 or 1.1.1.1; use a local resolver for reliable results.
+
+Output: table mode shows Zone / Name / Status / Codes / Details.
+Text mode prints "<zone> <status> [details]" per zone.
+
+PAP level: AMBER (queries the blocklist operators' DNS servers).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Check a mail server IP
+  trident dnsbl 192.0.2.1
+
+  # Check a domain against the domain blocklists
+  trident dnsbl example.com
+
+  # Only selected zones
+  trident dnsbl --zone zen.spamhaus.org --zone bl.spamcop.net 192.0.2.1
+
+  # Custom zone catalog
+  trident dnsbl --zones-file ./zones.yaml 192.0.2.1
+
+  # Bulk input from stdin
+  cat ips.txt | trident dnsbl --output json`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zones, err := d.loadDNSBLZones(flagZonesFile)
+			if err != nil {
+				return err
+			}
+			if zones, err = selectDNSBLZones(zones, flagZones); err != nil {
+				return err
+			}
+			r, err := d.newResolver()
+			if err != nil {
+				return err
+			}
+			return runServiceCmd(cmd, d, dnsblsvc.NewService(r, d.logger, zones), args)
+		},
+	}
+	cmd.Flags().StringVar(&flagZonesFile, "zones-file", "", "custom DNSBL zone catalog (overrides dnsbl.yaml search)")
+	cmd.Flags().StringSliceVar(&flagZones, "zone", nil, "query only these zones (repeatable; default: all)")
+	_ = cmd.RegisterFlagCompletionFunc("zone", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		zones, err := d.loadDNSBLZones(flagZonesFile)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names := make([]string, len(zones))
+		for i, z := range zones {
+			names[i] = z.Zone + "\t" + z.Name
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+// selectDNSBLZones keeps the zones named in want, in catalog order. An empty
+// want keeps every zone.
+func selectDNSBLZones(zones []dnsblsvc.Zone, want []string) ([]dnsblsvc.Zone, error) {
+	if len(want) == 0 {
+		return zones, nil
+	}
+	var selected []dnsblsvc.Zone
+	for _, name := range want {
+		if !slices.ContainsFunc(zones, func(z dnsblsvc.Zone) bool { return z.Zone == name }) {
+			return nil, fmt.Errorf("unknown DNSBL zone %q: not in the zone catalog", name)
+		}
+	}
+	for _, z := range zones {
+		if slices.Contains(want, z.Zone) {
+			selected = append(selected, z)
+		}
+	}
+	return selected, nil
+}
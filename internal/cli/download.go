@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -13,6 +15,10 @@ import (
 	providers "github.com/tbckr/trident/internal/detect"
 	"github.com/tbckr/trident/internal/pap"
 	"github.com/tbckr/trident/internal/services"
+	dnsblsvc "github.com/tbckr/trident/internal/services/dnsbl"
+	ipinfosvc "github.com/tbckr/trident/internal/services/ipinfo"
+	rdapsvc "github.com/tbckr/trident/internal/services/rdap"
+	urlchecksvc "github.com/tbckr/trident/internal/services/urlcheck"
 )
 
 func newDownloadCmd(d *deps) *cobra.Command {
@@ -26,6 +32,11 @@ func newDownloadCmd(d *deps) *cobra.Command {
 		},
 	}
 	cmd.AddCommand(newDownloadDetectCmd(d))
+	cmd.AddCommand(newDownloadRDAPBootstrapCmd(d))
+	cmd.AddCommand(newDownloadIPToASNCmd(d))
+	cmd.AddCommand(newDownloadDBIPCmd(d))
+	cmd.AddCommand(newDownloadURLCheckCmd(d))
+	cmd.AddCommand(newDownloadDNSBLCmd(d))
 	return cmd
 }
 
@@ -135,3 +146,336 @@ PAP level: AMBER (makes an outbound HTTPS request).`,
 	cmd.Flags().StringVar(&flagDest, "dest", "", "destination file path (default: <config-dir>/detect-downloaded.yaml)")
 	return cmd
 }
+
+func newDownloadDNSBLCmd(d *deps) *cobra.Command {
+	var flagURL, flagDest string
+	cmd := &cobra.Command{
+		Use:   "dnsbl",
+		Short: "Download the latest DNSBL zone catalog from GitHub",
+		Long: `Download the latest DNSBL zone catalog (zones and return-code meanings).
+
+The catalog is saved to <config-dir>/dnsbl-downloaded.yaml by default and is
+used by the dnsbl command as an override over the embedded catalog. A
+user-maintained <config-dir>/dnsbl.yaml still takes precedence.
+
+URL resolution precedence (highest to lowest):
+  1. --url flag
+  2. dnsbl.url in config.yaml
+  3. Built-in default (trident GitHub repository)
+
+Configure a persistent URL via:
+  trident config set dnsbl.url https://example.com/zones.yaml
+
+PAP level: AMBER (makes an outbound HTTPS request).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !pap.Allows(d.papLevel, pap.AMBER) {
+				return fmt.Errorf("%w: %q requires PAP %s but limit is %s",
+					services.ErrPAPBlocked, "download dnsbl", pap.AMBER, d.papLevel)
+			}
+
+			// Resolve download URL: flag > config/default (via viper).
+			downloadURL := d.cfg.DNSBL.URL // always set; viper default = config.DefaultDNSBLZonesURL
+			if flagURL != "" {
+				downloadURL = flagURL
+			}
+
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.R().SetContext(cmd.Context()).Get(downloadURL)
+			if err != nil {
+				return fmt.Errorf("downloading DNSBL zones: %w", err)
+			}
+			if resp.Response == nil {
+				return fmt.Errorf("downloading DNSBL zones: transport error (no response)")
+			}
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("downloading DNSBL zones: unexpected status %d", resp.StatusCode)
+			}
+
+			catalog, err := dnsblsvc.ParseZones(resp.Bytes())
+			if err != nil {
+				return fmt.Errorf("validating downloaded DNSBL zones: %w", err)
+			}
+
+			// Resolve destination path: --dest flag > default.
+			var path string
+			if flagDest != "" {
+				path = flagDest
+			} else {
+				dir, err := appdir.ConfigDir()
+				if err != nil {
+					return fmt.Errorf("getting config dir: %w", err)
+				}
+				path = filepath.Join(dir, "dnsbl-downloaded.yaml")
+			}
+
+			destDir := filepath.Dir(path)
+			if err := os.MkdirAll(destDir, 0o700); err != nil {
+				return fmt.Errorf("creating destination dir: %w", err)
+			}
+
+			verb := "saved to"
+			if _, err := os.Stat(path); err == nil {
+				verb = "updated at"
+			}
+
+			tmp, err := os.CreateTemp(destDir, "dnsbl-downloaded-*.yaml")
+			if err != nil {
+				return fmt.Errorf("creating temp file: %w", err)
+			}
+			tmpName := tmp.Name()
+			defer func() { _ = os.Remove(tmpName) }()
+
+			if _, err := tmp.Write(resp.Bytes()); err != nil {
+				_ = tmp.Close()
+				return fmt.Errorf("writing DNSBL zones: %w", err)
+			}
+			if err := tmp.Close(); err != nil {
+				return fmt.Errorf("closing temp file: %w", err)
+			}
+			if err := os.Rename(tmpName, path); err != nil {
+				return fmt.Errorf("installing DNSBL zones: %w", err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "DNSBL zones (%d) %s %s\n", len(catalog.Zones), verb, path)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&flagURL, "url", "", "URL to download the zone catalog from (overrides config and default)")
+	cmd.Flags().StringVar(&flagDest, "dest", "", "destination file path (default: <config-dir>/dnsbl-downloaded.yaml)")
+	return cmd
+}
+
+func newDownloadRDAPBootstrapCmd(d *deps) *cobra.Command {
+	var flagURL, flagDest string
+	cmd := &cobra.Command{
+		Use:   "rdap-bootstrap",
+		Short: "Download the IANA RDAP bootstrap registries",
+		Long: `Download the IANA RDAP bootstrap registries (dns, ipv4, ipv6, asn).
+
+The registries are saved to <config-dir>/rdap-bootstrap/ by default and are
+used by the rdap command to locate the authoritative RDAP server. Each file
+is validated before it replaces the cached copy.
+
+PAP level: AMBER (makes outbound HTTPS requests).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !pap.Allows(d.papLevel, pap.AMBER) {
+				return fmt.Errorf("%w: %q requires PAP %s but limit is %s",
+					services.ErrPAPBlocked, "download rdap-bootstrap", pap.AMBER, d.papLevel)
+			}
+
+			baseURL := rdapsvc.BootstrapBaseURL
+			if flagURL != "" {
+				baseURL = flagURL
+			}
+			dir := flagDest
+			if dir == "" {
+				var err error
+				if dir, err = rdapsvc.DefaultBootstrapDir(); err != nil {
+					return err
+				}
+			}
+
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			for _, reg := range rdapsvc.Registries {
+				data, err := rdapsvc.FetchBootstrap(cmd.Context(), client, baseURL, reg)
+				if err != nil {
+					return err
+				}
+				if err := rdapsvc.SaveBootstrap(dir, reg, data); err != nil {
+					return err
+				}
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "RDAP bootstrap registries saved to %s\n", dir)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&flagURL, "url", "", "base URL to download the registries from (default: IANA)")
+	cmd.Flags().StringVar(&flagDest, "dest", "", "destination directory (default: <config-dir>/rdap-bootstrap)")
+	return cmd
+}
+
+func newDownloadIPToASNCmd(d *deps) *cobra.Command {
+	var flagURL, flagDest string
+	cmd := &cobra.Command{
+		Use:   "iptoasn",
+		Short: "Download the iptoasn.com IP-to-ASN database",
+		Long: `Download the iptoasn.com combined IPv4/IPv6 IP-to-ASN dump (PDDL licensed).
+
+The database is saved to <config-dir>/ipinfo/ip2asn-combined.tsv by default
+and is used by the ipinfo command (and apex ASN enrichment) for offline
+lookups. The file is decompressed and validated before it replaces the
+existing copy.
+
+PAP level: AMBER (makes an outbound HTTPS request).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDownloadIPInfo(cmd, d, "download iptoasn", flagURL, flagDest, []string{"iptoasn"})
+		},
+	}
+	cmd.Flags().StringVar(&flagURL, "url", "", "base URL to download the dump from (default: https://iptoasn.com/data)")
+	cmd.Flags().StringVar(&flagDest, "dest", "", "destination directory (default: <config-dir>/ipinfo)")
+	return cmd
+}
+
+func newDownloadDBIPCmd(d *deps) *cobra.Command {
+	var flagURL, flagDest string
+	var flagEditions []string
+	cmd := &cobra.Command{
+		Use:   "dbip",
+		Short: "Download the DB-IP lite ASN and City databases",
+		Long: `Download the monthly DB-IP lite MMDB databases (CC BY 4.0 licensed).
+
+The asn edition provides ASN and organisation, the city edition provides
+country, city and coordinates. They are saved to <config-dir>/ipinfo/ by
+default and are used by the ipinfo command (and apex ASN enrichment) for
+offline lookups. If the current month's release is not yet published the
+previous month is used. Each file is decompressed and validated before it
+replaces the existing copy.
+
+Attribution: IP Geolocation by DB-IP (https://db-ip.com).
+
+PAP level: AMBER (makes outbound HTTPS requests).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			names := make([]string, 0, len(flagEditions))
+			for _, e := range flagEditions {
+				if e != "asn" && e != "city" {
+					return fmt.Errorf("invalid --edition %q: must be asn or city", e)
+				}
+				names = append(names, "dbip-"+e)
+			}
+			return runDownloadIPInfo(cmd, d, "download dbip", flagURL, flagDest, names)
+		},
+	}
+	cmd.Flags().StringSliceVar(&flagEditions, "edition", []string{"asn", "city"}, "editions to download: asn, city")
+	cmd.Flags().StringVar(&flagURL, "url", "", "base URL to download the databases from (default: https://download.db-ip.com/free)")
+	cmd.Flags().StringVar(&flagDest, "dest", "", "destination directory (default: <config-dir>/ipinfo)")
+	return cmd
+}
+
+// runDownloadIPInfo downloads the named ipinfo datasets into dest (or the
+// default data directory) and reports each installed file.
+func runDownloadIPInfo(cmd *cobra.Command, d *deps, name, baseURL, dest string, datasets []string) error {
+	if !pap.Allows(d.papLevel, pap.AMBER) {
+		return fmt.Errorf("%w: %q requires PAP %s but limit is %s",
+			services.ErrPAPBlocked, name, pap.AMBER, d.papLevel)
+	}
+	dir := dest
+	if dir == "" {
+		var err error
+		if dir, err = ipinfosvc.DefaultDataDir(); err != nil {
+			return err
+		}
+	}
+
+	client, err := d.newHTTPClient()
+	if err != nil {
+		return err
+	}
+	for _, n := range datasets {
+		ds, ok := ipinfosvc.LookupDataset(n)
+		if !ok {
+			return fmt.Errorf("unknown dataset %q", n)
+		}
+		path, err := ipinfosvc.DownloadDataset(cmd.Context(), client, ds, baseURL, dir)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s saved to %s (%s)\n", ds.Name, path, ds.License); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newDownloadURLCheckCmd(d *deps) *cobra.Command {
+	var flagURL, flagDest string
+	var flagFeeds []string
+	cmd := &cobra.Command{
+		Use:   "urlcheck",
+		Short: "Download the URL reputation and phishing feeds",
+		Long: `Download the URL reputation and phishing feeds used by the urlcheck command:
+  urlhaus    URLhaus online URLs (abuse.ch, CC0)
+  phishtank  PhishTank verified online phishes (CSV)
+  openphish  OpenPhish community feed
+  threatfox  ThreatFox recent IOCs (abuse.ch, CC0)
+
+The feeds are saved to <config-dir>/urlcheck/ by default. Each file is
+validated before it replaces the existing copy. A feed that fails to
+download is reported and the others are still installed.
+
+PhishTank may require a registered application key in the download URL;
+pass it with --feed phishtank --url <url>. Any PhishTank-format CSV dump can
+be installed the same way.
+
+PAP level: AMBER (makes outbound HTTPS requests).`,
+		Example: `  # Download every feed
+  trident download urlcheck
+
+  # Refresh only the abuse.ch feeds
+  trident download urlcheck --feed urlhaus,threatfox
+
+  # PhishTank dump with an application key
+  trident download urlcheck --feed phishtank --url http://data.phishtank.com/data/<key>/online-valid.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !pap.Allows(d.papLevel, pap.AMBER) {
+				return fmt.Errorf("%w: %q requires PAP %s but limit is %s",
+					services.ErrPAPBlocked, "download urlcheck", pap.AMBER, d.papLevel)
+			}
+			feeds := make([]urlchecksvc.Feed, 0, len(flagFeeds))
+			for _, name := range flagFeeds {
+				f, ok := urlchecksvc.LookupFeed(name)
+				if !ok {
+					return fmt.Errorf("invalid --feed %q: must be one of %s", name, strings.Join(urlchecksvc.FeedNames(), ", "))
+				}
+				feeds = append(feeds, f)
+			}
+			if flagURL != "" && len(feeds) != 1 {
+				return fmt.Errorf("--url requires exactly one --feed")
+			}
+			dir := flagDest
+			if dir == "" {
+				var err error
+				if dir, err = urlchecksvc.DefaultDataDir(); err != nil {
+					return err
+				}
+			}
+
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			var errs []error
+			for _, f := range feeds {
+				path, n, err := urlchecksvc.DownloadFeed(cmd.Context(), client, f, flagURL, dir)
+				if err != nil {
+					d.logger.Warn("feed download failed", "feed", f.Name, "error", err)
+					errs = append(errs, err)
+					continue
+				}
+				if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s saved to %s (%d entries; %s)\n", f.Name, path, n, f.License); err != nil {
+					return err
+				}
+			}
+			return errors.Join(errs...)
+		},
+	}
+	cmd.Flags().StringSliceVar(&flagFeeds, "feed", urlchecksvc.FeedNames(), "feeds to download: "+strings.Join(urlchecksvc.FeedNames(), ", "))
+	cmd.Flags().StringVar(&flagURL, "url", "", "download URL for a single --feed (mirror or PhishTank URL with application key)")
+	cmd.Flags().StringVar(&flagDest, "dest", "", "destination directory (default: <config-dir>/urlcheck)")
+	_ = cmd.RegisterFlagCompletionFunc("feed", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return urlchecksvc.FeedNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	filterchecksvc "github.com/tbckr/trident/internal/services/filtercheck"
+)
+
+func newFilterCheckCmd(d *deps) *cobra.Command {
+	var flagResolversFile string
+	var flagResolvers []string
+	cmd := &cobra.Command{
+		Use:     "filtercheck [domain...]",
+		Short:   "Compare how filtering DNS resolvers answer a domain against an unfiltered baseline",
+		GroupID: "services",
+		Long: `Query several filtering DNS-over-HTTPS resolvers — Quad9, Cloudflare 1.1.1.2
+and 1.1.1.3, AdGuard, CleanBrowsing, and OpenDNS FamilyShield — alongside an
+unfiltered baseline, and report a verdict per resolver:
+
+  blocked   the answer matches the resolver's block signature
+  resolved  the resolver returned NOERROR
+  nxdomain  the name does not exist
+  error     the query failed or returned another response code
+
+Block signatures are provider-specific: Extended DNS Error codes (RFC 8914,
+e.g. 17 Filtered), sinkhole addresses returned instead of the real A record
+(0.0.0.0, OpenDNS block pages), or NXDOMAIN without a SOA record. The
+baseline is never classified as blocked.
+
+The resolver catalog is YAML. trident uses the first file found:
+  1. --resolvers-file
+  2. <config-dir>/filtercheck.yaml
+  3. the built-in catalog
+
+PAP level: AMBER (queries go to third-party resolvers, never to the target).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Compare all resolvers
+  trident filtercheck malicious.example.com
+
+  # Only selected resolvers
+  trident filtercheck --resolver quad9 --resolver cloudflare-security example.com
+
+  # Custom resolver catalog
+  trident filtercheck --resolvers-file ./resolvers.yaml example.com
+
+  # Bulk input from stdin
+  cat domains.txt | trident filtercheck --output json`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolvers, err := d.loadFilterResolvers(flagResolversFile)
+			if err != nil {
+				return err
+			}
+			if resolvers, err = selectFilterResolvers(resolvers, flagResolvers); err != nil {
+				return err
+			}
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			client.EnableForceHTTP2()
+			httpclient.AttachRateLimit(client, ratelimit.New(filterchecksvc.DefaultRPS, filterchecksvc.DefaultBurst))
+			return runServiceCmd(cmd, d, filterchecksvc.NewService(client, d.logger, resolvers), args)
+		},
+	}
+	cmd.Flags().StringVar(&flagResolversFile, "resolvers-file", "", "custom resolver catalog (overrides filtercheck.yaml search)")
+	cmd.Flags().StringSliceVar(&flagResolvers, "resolver", nil, "query only these resolvers (repeatable; default: all)")
+	_ = cmd.RegisterFlagCompletionFunc("resolver", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		resolvers, err := d.loadFilterResolvers(flagResolversFile)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names := make([]string, len(resolvers))
+		for i, r := range resolvers {
+			names[i] = r.Name + "\t" + r.Provider
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+// selectFilterResolvers keeps the resolvers named in want, in catalog order.
+// An empty want keeps every resolver.
+func selectFilterResolvers(resolvers []filterchecksvc.Resolver, want []string) ([]filterchecksvc.Resolver, error) {
+	if len(want) == 0 {
+		return resolvers, nil
+	}
+	for _, name := range want {
+		if !slices.ContainsFunc(resolvers, func(r filterchecksvc.Resolver) bool { return r.Name == name }) {
+			return nil, fmt.Errorf("unknown filtercheck resolver %q: not in the resolver catalog", name)
+		}
+	}
+	var selected []filterchecksvc.Resolver
+	for _, r := range resolvers {
+		if slices.Contains(want, r.Name) {
+			selected = append(selected, r)
+		}
+	}
+	return selected, nil
+}
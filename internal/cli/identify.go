@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -29,6 +30,10 @@ Unlike the detect command, identify does not make any DNS queries — it operate
 entirely on record values you already have. This makes it suitable for offline
 use and PAP RED environments.
 
+--domain is the input the result is reported under: with --save the snapshot
+is stored for it, and while a case is active the result is recorded into the
+case with it, as for every other service command.
+
 PAP level: RED (no network calls — pure pattern matching).`,
 		Example: `  trident identify --cname abc.cloudfront.net
   trident identify --domain example.com --ns ns1.cloudflare.com
@@ -47,16 +52,8 @@ PAP level: RED (no network calls — pure pattern matching).`,
 			if len(cnames)+len(mxHosts)+len(nsHosts)+len(txtRecords) == 0 {
 				return fmt.Errorf("no records provided: specify at least one --cname, --mx, --ns, or --txt value")
 			}
-			result, err := svc.Run(cnames, mxHosts, nsHosts, txtRecords)
-			if err != nil {
-				return err
-			}
-			result.Input = domain
-			if result.IsEmpty() {
-				d.logger.Info("no providers detected")
-				return nil
-			}
-			return writeResult(cmd.OutOrStdout(), d, result)
+			run := &identifyRun{Service: svc, cnames: cnames, mxHosts: mxHosts, nsHosts: nsHosts, txtRecords: txtRecords}
+			return runInputs(cmd, d, run, []string{domain})
 		},
 	}
 	cmd.Flags().StringVar(&domain, "domain", "", "optional label for output (e.g. the domain these records belong to)")
@@ -66,3 +63,27 @@ PAP level: RED (no network calls — pure pattern matching).`,
 	cmd.Flags().StringArrayVar(&txtRecords, "txt", nil, "TXT record value (repeatable)")
 	return cmd
 }
+
+// identifyRun adapts the identify service, which matches a fixed set of record
+// values instead of looking up an input, to services.Service. This gives
+// identify the output, sink, snapshot, case, and watch handling of the other
+// service commands. The input is only the --domain label of the result.
+type identifyRun struct {
+	*identifysvc.Service
+	cnames, mxHosts, nsHosts, txtRecords []string
+}
+
+// Run matches the record values and labels the result with input.
+func (r *identifyRun) Run(_ context.Context, input string) (services.Result, error) {
+	result, err := r.Service.Run(r.cnames, r.mxHosts, r.nsHosts, r.txtRecords)
+	if err != nil {
+		return nil, err
+	}
+	result.Input = input
+	return result, nil
+}
+
+// AggregateResults returns the only result; identify always has one input.
+func (r *identifyRun) AggregateResults(results []services.Result) services.Result {
+	return results[0]
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/cases"
+	"github.com/tbckr/trident/internal/snapshot"
+)
+
+func TestIdentify_SavesSnapshot(t *testing.T) {
+	dir := isolateConfig(t)
+	stdout, _, err := execute(t, "identify", "--save", "-o", "json", "--domain", "example.com", "--cname", "foo.cloudfront.net")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "CloudFront")
+
+	snaps, err := snapshot.NewStore(filepath.Join(dir, "snapshots")).List("identify", "example.com")
+	require.NoError(t, err)
+	require.Len(t, snaps, 1)
+	assert.JSONEq(t, stdout, string(snaps[0].Result))
+}
+
+func TestIdentify_RecordsIntoCase(t *testing.T) {
+	dir := isolateConfig(t)
+	_, _, err := execute(t, "case", "new", "op-1")
+	require.NoError(t, err)
+	_, _, err = execute(t, "identify", "--case", "op-1", "--domain", "example.com", "--mx", "aspmx.l.google.com")
+	require.NoError(t, err)
+
+	entries, err := cases.NewStore(filepath.Join(dir, "cases")).Entries("op-1")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, "identify", e.Service)
+	assert.Equal(t, "example.com", e.Input)
+	assert.Equal(t, "red", e.PAP)
+	var result struct {
+		Detections []struct{ Provider string } `json:"detections"`
+	}
+	require.NoError(t, json.Unmarshal(e.Result, &result))
+	require.NotEmpty(t, result.Detections)
+	assert.Contains(t, result.Detections[0].Provider, "Google")
+}
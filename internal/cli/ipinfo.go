@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	ipinfosvc "github.com/tbckr/trident/internal/services/ipinfo"
+)
+
+func newIPInfoCmd(d *deps) *cobra.Command {
+	var flagDataDir string
+	cmd := &cobra.Command{
+		Use:     "ipinfo [ip...]",
+		Short:   "Look up ASN, organisation, and geolocation from local databases",
+		GroupID: "services",
+		Long: `Look up the ASN, organisation, network, country, city, and coordinates of
+IP addresses from local databases, with zero network traffic.
+
+Databases are read from <config-dir>/ipinfo/ (see --data-dir):
+  *.mmdb  MaxMind DB files: GeoLite2 ASN/City/Country, DB-IP lite,
+          IPinfo lite, or any database using the same field layout
+  *.tsv   iptoasn.com dumps (ip2asn-v4.tsv, ip2asn-combined.tsv, ...)
+
+Every database containing the address contributes: MMDB files are consulted
+in file-name order before TSV dumps, and the first database supplying a
+field wins. The Sources field lists the databases that matched.
+
+Freely redistributable databases can be fetched with
+"trident download dbip" and "trident download iptoasn". GeoLite2 and IPinfo
+databases require an account; copy them into the directory manually.
+
+PAP level: RED (offline lookup, no network traffic).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Offline ASN and geolocation lookup
+  trident ipinfo 8.8.8.8
+
+  # Use a different database directory
+  trident ipinfo --data-dir ./geo 2001:4860:4860::8888
+
+  # Bulk input from stdin
+  cat ips.txt | trident ipinfo --output json`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := flagDataDir
+			if dir == "" {
+				var err error
+				if dir, err = ipinfosvc.DefaultDataDir(); err != nil {
+					return err
+				}
+			}
+			svc := ipinfosvc.NewService(dir, d.logger)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+	cmd.Flags().StringVar(&flagDataDir, "data-dir", "", "directory holding *.mmdb and *.tsv databases (default: <config-dir>/ipinfo)")
+	return cmd
+}
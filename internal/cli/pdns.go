@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	pdnssvc "github.com/tbckr/trident/internal/services/pdns"
+	tmsvc "github.com/tbckr/trident/internal/services/threatminer"
+)
+
+func newPDNSCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "pdns [domain|ip...]",
+		Short:   "Merge passive DNS history from Mnemonic, ThreatMiner, and COF servers",
+		GroupID: "services",
+		Long: `Look up passive DNS history for a domain or IP address across every
+available keyless source and merge the results.
+
+Sources:
+  - mnemonic     Mnemonic's public PDNS API (no key needed for low volume)
+  - threatminer  ThreatMiner domain/host passive DNS (A/AAAA only)
+  - COF servers  any Common Output Format endpoint (e.g. CIRCL) listed under
+                 pdns.cof in the config file, with optional credentials
+
+Every source has its own rate limiter and is queried concurrently. Identical
+(name, type, data) tuples are merged across sources: first/last seen span all
+observations, counts are summed, and the contributing sources are listed.
+A failing source is skipped; an error is reported only when all fail.
+
+Output: table mode shows Name / Type / Data / First Seen / Last Seen / Count /
+Sources, most recently seen first. Text mode prints "<name> <type> <data>".
+
+PAP level: AMBER (queries third-party passive DNS APIs).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Resolution history of a domain
+  trident pdns example.com
+
+  # Names seen on an IP
+  trident pdns 93.184.216.34
+
+  # JSON with per-record sources
+  trident pdns --output json example.com`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources, err := newPDNSSources(d, true)
+			if err != nil {
+				return err
+			}
+			svc := pdnssvc.NewService(d.logger, sources)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+}
+
+// newPDNSSources builds the passive DNS sources, each with its own HTTP client
+// and rate limiter. ThreatMiner is included only when withThreatMiner is set
+// so callers that already query it directly do not query it twice.
+func newPDNSSources(d *deps, withThreatMiner bool) ([]pdnssvc.Source, error) {
+	mnClient, err := d.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	httpclient.AttachRateLimit(mnClient, ratelimit.New(pdnssvc.MnemonicRPS, pdnssvc.MnemonicBurst))
+	sources := []pdnssvc.Source{pdnssvc.NewMnemonicSource(mnClient)}
+
+	if withThreatMiner {
+		tmClient, err := d.newHTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		httpclient.AttachRateLimit(tmClient, ratelimit.New(tmsvc.DefaultRPS, tmsvc.DefaultBurst))
+		sources = append(sources, pdnssvc.NewThreatMinerSource(tmClient, d.logger))
+	}
+
+	for i, ep := range d.cfg.PDNS.COF {
+		if ep.URL == "" {
+			return nil, fmt.Errorf("pdns.cof[%d]: url is required", i)
+		}
+		name := ep.Name
+		if name == "" {
+			name = fmt.Sprintf("cof%d", i+1)
+		}
+		client, err := d.newHTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		httpclient.AttachRateLimit(client, ratelimit.New(pdnssvc.COFRPS, pdnssvc.COFBurst))
+		sources = append(sources, pdnssvc.NewCOFSource(client, name, ep.URL, ep.Username, ep.Password))
+	}
+	return sources, nil
+}
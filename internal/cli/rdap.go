@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	rdapsvc "github.com/tbckr/trident/internal/services/rdap"
+)
+
+func newRDAPCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rdap [domain|ip|asn...]",
+		Short:   "Look up domain, IP, and ASN registration data via RDAP",
+		GroupID: "services",
+		Long: `Look up registration data for domains, IP addresses, and ASNs via RDAP.
+
+The authoritative RDAP server is located through IANA's bootstrap registries
+(dns, ipv4, ipv6, asn), which are cached in <config-dir>/rdap-bootstrap/.
+Missing registries are downloaded on first use; refresh them with
+"trident download rdap-bootstrap".
+
+Domain lookups query the registry and then follow its link to the
+registrar's RDAP server to fill in registrant and abuse details.
+
+Normalized fields: registrar, registrant organization, country, network,
+creation/update/expiry dates, status, nameservers, and abuse contacts.
+
+Output: table mode shows one Field/Value table per input. Text mode prints
+"name / registrar / registrant org / created / expires" per input.
+
+PAP level: AMBER (queries go to registry and registrar RDAP servers).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Domain registration data
+  trident rdap example.com
+
+  # IP network and ASN owners
+  trident rdap 8.8.8.8 AS15169
+
+  # Full normalized record as JSON
+  trident rdap --output json example.com`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			httpclient.AttachRateLimit(client, ratelimit.New(rdapsvc.DefaultRPS, rdapsvc.DefaultBurst))
+			dir, err := rdapsvc.DefaultBootstrapDir()
+			if err != nil {
+				return err
+			}
+			svc := rdapsvc.NewService(client, d.logger, dir)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	reverseipsvc "github.com/tbckr/trident/internal/services/reverseip"
+	tmsvc "github.com/tbckr/trident/internal/services/threatminer"
+)
+
+func newReverseIPCmd(d *deps) *cobra.Command {
+	var threshold int
+	cmd := &cobra.Command{
+		Use:     "reverseip [ip|cidr...]",
+		Short:   "Discover domains co-hosted on an IP address or CIDR range",
+		GroupID: "services",
+		Long: `Discover the domains that resolve (or resolved) to an IP address or a small
+CIDR range (up to 256 addresses, e.g. an IPv4 /24) by merging every available
+passive-DNS source.
+
+Sources: ThreatMiner host passive DNS, Mnemonic's public PDNS API, and any
+COF passive DNS servers listed under pdns.cof in the config file (see
+"trident pdns --help"). Each source has its own rate limiter.
+
+Domains are deduplicated across sources and addresses; first/last seen span
+all observations. IPs with more distinct domains than the shared-hosting
+threshold are treated as shared hosting: their domains are dropped and the
+IP is listed separately with its domain count. Configure the default with:
+
+  trident config set reverseip.shared_threshold 100
+
+Output: table mode shows Domain / IPs / First Seen / Last Seen / Sources,
+followed by any filtered shared-hosting IPs. Text mode prints one domain per
+line.
+
+PAP level: AMBER (queries third-party passive-DNS APIs).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Domains co-hosted on an IP
+  trident reverseip 93.184.216.34
+
+  # Sweep a /28 and keep even busy shared-hosting IPs
+  trident reverseip --shared-threshold 0 192.0.2.0/28
+
+  # Domains only, one per line
+  trident reverseip --output text 93.184.216.34`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("shared-threshold") {
+				threshold = d.cfg.ReverseIP.SharedThreshold
+			}
+			tmClient, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			httpclient.AttachRateLimit(tmClient, ratelimit.New(tmsvc.DefaultRPS, tmsvc.DefaultBurst))
+			sources := []reverseipsvc.Source{
+				reverseipsvc.NewThreatMinerSource(tmsvc.NewService(tmClient, d.logger)),
+			}
+			pdnsSources, err := newPDNSSources(d, false)
+			if err != nil {
+				return err
+			}
+			for _, src := range pdnsSources {
+				sources = append(sources, reverseipsvc.NewPDNSSource(src))
+			}
+			svc := reverseipsvc.NewService(d.logger, threshold, sources)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+	cmd.Flags().IntVar(&threshold, "shared-threshold", 0, "treat IPs with more domains than this as shared hosting and drop their domains; 0 disables (default: reverseip.shared_threshold, 100)")
+	return cmd
+}
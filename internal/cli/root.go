@@ -4,16 +4,21 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
 	"github.com/tbckr/trident/internal/config"
 	"github.com/tbckr/trident/internal/input"
+	"github.com/tbckr/trident/internal/output"
 	"github.com/tbckr/trident/internal/pap"
 	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/sink"
+	"github.com/tbckr/trident/internal/snapshot"
 	"github.com/tbckr/trident/internal/version"
 	"github.com/tbckr/trident/internal/worker"
 )
@@ -32,9 +37,10 @@ func newRootCmd(aliases map[string]string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "trident",
 		Short: "trident — keyless OSINT reconnaissance tool",
-		Long: `trident is a fast, keyless OSINT CLI for DNS, ASN, certificate transparency, threat intelligence, PGP, Quad9, provider detection, and aggregate DNS recon.
+		Long: `trident is a fast, keyless OSINT CLI for DNS, ASN, BGP prefixes, certificate transparency, threat intelligence, passive DNS, reverse IP, URL reputation, DNS blocklists, PGP, Quad9, filtering-resolver comparison, SPF, DKIM, typosquatting, RDAP, WHOIS, offline IP geolocation, provider detection, and aggregate DNS recon.
 
-No API keys required for any service (dns, cymru, crtsh, threatminer, pgp, quad9, detect, identify, apex).
+No API keys required for any service (dns, cymru, asn-prefixes, crtsh, threatminer, pdns, reverseip, urlcheck, dnsbl, pgp, quad9, filtercheck, spf, dkim, typo, rdap, whois, ipinfo, detect, identify, apex).
+Optional keyed services (virustotal, shodan, securitytrails) are enabled with "trident auth set <service>".
 PAP levels (least to most active intrusion): red < amber < green < white.`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
@@ -49,8 +55,11 @@ PAP levels (least to most active intrusion): red < amber < green < white.`,
 	}
 
 	config.RegisterFlags(cmd.PersistentFlags())
-	_ = cmd.RegisterFlagCompletionFunc("output", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{"table", "json", "text"}, cobra.ShellCompDirectiveNoFileComp
+	_ = cmd.RegisterFlagCompletionFunc("output", func(c *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if formats := commandFormats(c); formats != nil {
+			return formats, cobra.ShellCompDirectiveNoFileComp
+		}
+		return []string{"table", "json", "text", "markdown", "html"}, cobra.ShellCompDirectiveNoFileComp
 	})
 	_ = cmd.RegisterFlagCompletionFunc("pap-limit", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"red", "amber", "green", "white"}, cobra.ShellCompDirectiveNoFileComp
@@ -60,6 +69,7 @@ PAP levels (least to most active intrusion): red < amber < green < white.`,
 
 	cmd.AddGroup(&cobra.Group{ID: "services", Title: "Services:"})
 	cmd.AddGroup(&cobra.Group{ID: "aggregate", Title: "Aggregate Commands:"})
+	cmd.AddGroup(&cobra.Group{ID: "keyed", Title: "Keyed Services (API key required):"})
 
 	if len(aliases) > 0 {
 		cmd.AddGroup(&cobra.Group{ID: "aliases", Title: "Aliases:"})
@@ -86,15 +96,34 @@ PAP levels (least to most active intrusion): red < amber < green < white.`,
 		newThreatMinerCmd(&d),
 		newPGPCmd(&d),
 		newQuad9Cmd(&d),
+		newFilterCheckCmd(&d),
+		newSPFCmd(&d),
+		newDKIMCmd(&d),
+		newTypoCmd(&d),
+		newRDAPCmd(&d),
+		newWhoisCmd(&d),
+		newIPInfoCmd(&d),
+		newASNPrefixesCmd(&d),
+		newPDNSCmd(&d),
+		newReverseIPCmd(&d),
+		newURLCheckCmd(&d),
+		newDNSBLCmd(&d),
 		newDetectCmd(&d),
 		newIdentifyCmd(&d),
 		newApexCmd(&d),
+		newVirusTotalCmd(&d),
+		newShodanCmd(&d),
+		newSecurityTrailsCmd(&d),
 		newCompletionCmd(),
 		newVersionCmd(&d),
 		newConfigCmd(&d),
 		newAliasCmd(&d),
 		newServicesCmd(&d),
+		newDiffCmd(&d),
+		newWatchCmd(&d),
+		newCaseCmd(&d),
 		newDownloadCmd(&d),
+		newAuthCmd(&d),
 	)
 
 	cmd.SetHelpCommandGroupID("utility")
@@ -171,46 +200,156 @@ func resolveInputs(cmd *cobra.Command, args []string) ([]string, error) {
 }
 
 // runCmdBody is the shared execution body for all OSINT subcommands after PAP enforcement.
-// It handles input resolution, single-result and bulk paths.
+// It resolves the inputs from args or stdin and runs them with runInputs.
 func runCmdBody(cmd *cobra.Command, d *deps, svc services.Service, args []string) error {
 	inputs, err := resolveInputs(cmd, args)
 	if err != nil {
 		return err
 	}
+	return runInputs(cmd, d, svc, inputs)
+}
 
+// runInputs handles the single-result and bulk paths for inputs. Results are
+// written to stdout first and then sent to the sinks selected with --sink;
+// afterwards every successful result, empty ones included, is saved as a
+// snapshot with --save and recorded into the active case. Under
+// "trident watch" the inputs are handed to the watch loop instead.
+func runInputs(cmd *cobra.Command, d *deps, svc services.Service, inputs []string) error {
+	sinks, err := d.sinks()
+	if err != nil {
+		return err
+	}
+	p, err := d.newPersister(cmd)
+	if err != nil {
+		return err
+	}
+	if w := watchFrom(cmd.Context()); w != nil {
+		return w.run(cmd, d, svc, inputs, sinks, p)
+	}
 	if len(inputs) == 1 {
-		result, err := svc.Run(cmd.Context(), inputs[0])
-		if err != nil {
-			return err
+		return runSingle(cmd, d, svc, inputs[0], sinks, p)
+	}
+	return runBulk(cmd, d, svc, inputs, sinks, p)
+}
+
+// runSingle looks up one input and writes its result.
+func runSingle(cmd *cobra.Command, d *deps, svc services.Service, input string, sinks []sink.Sink, p *persister) error {
+	result, err := svc.Run(cmd.Context(), input)
+	if err != nil {
+		return err
+	}
+	if result.IsEmpty() {
+		d.logger.Info("no results found", "service", svc.Name(), "input", input)
+	} else {
+		var out any = result
+		if output.Format(d.cfg.Output).IsReport() {
+			out = d.newReport("trident "+svc.Name(), output.ReportEntry{Input: input, Result: result})
 		}
-		if result.IsEmpty() {
-			d.logger.Info("no results found", "service", svc.Name(), "input", inputs[0])
-			return nil
+		write := func() error { return writeResult(cmd.OutOrStdout(), d, out) }
+		if err := emitResult(cmd.Context(), d, sinks, write, resultMessage(svc.Name(), input, result)); err != nil {
+			return err
 		}
-		return writeResult(cmd.OutOrStdout(), d, result)
 	}
+	p.persist(svc, input, result)
+	return nil
+}
 
-	// Bulk path
-	workerResults := worker.Run(cmd.Context(), svc, inputs, d.cfg.Concurrency)
+// runBulk looks up inputs concurrently and writes the non-empty results
+// aggregated into one output. Reports keep one part per input instead.
+func runBulk(cmd *cobra.Command, d *deps, svc services.Service, inputs []string, sinks []sink.Sink, p *persister) error {
+	var done []worker.Result
 	var valid []services.Result
-	for _, r := range workerResults {
+	var entries []output.ReportEntry
+	var messages []sink.Message
+	for _, r := range worker.Run(cmd.Context(), svc, inputs, d.cfg.Concurrency) {
 		if r.Err != nil {
 			d.logger.Error("lookup failed", "service", svc.Name(), "input", r.Input, "error", r.Err)
 			continue
 		}
+		done = append(done, r)
 		if r.Output.IsEmpty() {
 			d.logger.Info("no results found", "service", svc.Name(), "input", r.Input)
 			continue
 		}
 		valid = append(valid, r.Output)
+		entries = append(entries, output.ReportEntry{Input: r.Input, Result: r.Output})
+		messages = append(messages, resultMessage(svc.Name(), r.Input, r.Output))
+	}
+	if len(valid) > 0 {
+		var out any
+		switch {
+		case output.Format(d.cfg.Output).IsReport():
+			out = d.newReport("trident "+svc.Name(), entries...)
+		case len(valid) == 1:
+			out = valid[0]
+		default:
+			out = svc.AggregateResults(valid)
+		}
+		write := func() error { return writeResult(cmd.OutOrStdout(), d, out) }
+		if err := emitResult(cmd.Context(), d, sinks, write, messages...); err != nil {
+			return err
+		}
+	}
+	for _, r := range done {
+		p.persist(svc, r.Input, r.Output)
+	}
+	return nil
+}
+
+// emitResult writes output to stdout with write and only then sends messages
+// to the sinks, so sinks never see what stdout did not.
+func emitResult(ctx context.Context, d *deps, sinks []sink.Sink, write func() error, messages ...sink.Message) error {
+	if err := write(); err != nil {
+		return err
+	}
+	for _, m := range messages {
+		notifySinks(ctx, d, sinks, m)
+	}
+	return nil
+}
+
+// resultMessage wraps a service result for delivery to sinks.
+func resultMessage(service, input string, result services.Result) sink.Message {
+	return sink.Message{Time: time.Now().UTC(), Kind: sink.KindResult, Service: service, Input: input, Data: result}
+}
+
+// persister keeps results beyond stdout: as snapshots with --save and in the
+// active case.
+type persister struct {
+	logger *slog.Logger
+	store  *snapshot.Store // nil without --save
+	rec    *caseRecorder   // nil without an active case
+}
+
+// newPersister returns the persister for this run.
+func (d *deps) newPersister(cmd *cobra.Command) (*persister, error) {
+	p := &persister{logger: d.logger}
+	if d.cfg.Save {
+		store, err := d.snapshotStore()
+		if err != nil {
+			return nil, err
+		}
+		p.store = store
+	}
+	rec, err := d.caseRecorder(cmd)
+	if err != nil {
+		return nil, err
+	}
+	p.rec = rec
+	return p, nil
+}
+
+// persist saves result for input as a snapshot, timestamped now, and records
+// it into the active case. Failures are logged rather than returned so they
+// never hide a result that was already written.
+func (p *persister) persist(svc services.Service, input string, result services.Result) {
+	if p.store != nil {
+		if err := p.store.Save(svc.Name(), input, result, time.Now()); err != nil {
+			p.logger.Error("saving snapshot failed", "service", svc.Name(), "input", input, "error", err)
+		}
 	}
-	switch len(valid) {
-	case 0:
-		return nil
-	case 1:
-		return writeResult(cmd.OutOrStdout(), d, valid[0])
-	default:
-		return writeResult(cmd.OutOrStdout(), d, svc.AggregateResults(valid))
+	if err := p.rec.record(svc, input, result); err != nil {
+		p.logger.Error("recording to case failed", "service", svc.Name(), "input", input, "error", err)
 	}
 }
 
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	securitytrailssvc "github.com/tbckr/trident/internal/services/securitytrails"
+)
+
+func newSecurityTrailsCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "securitytrails [domain...]",
+		Short:   "List subdomains known to SecurityTrails (API key)",
+		GroupID: "keyed",
+		Long: `List the subdomains SecurityTrails has recorded for a domain.
+
+Requires an API key: run "trident auth set securitytrails" or set
+TRIDENT_SECURITYTRAILS_API_KEY.
+
+PAP level: AMBER (queries the SecurityTrails third-party API).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Subdomains of a domain
+  trident securitytrails example.com
+
+  # Text output (one subdomain per line, ideal for piping)
+  trident securitytrails --output text example.com`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := d.apiKey(securitytrailssvc.Name)
+			if err != nil {
+				return err
+			}
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			httpclient.AttachRateLimit(client, ratelimit.New(securitytrailssvc.DefaultRPS, securitytrailssvc.DefaultBurst))
+			svc := securitytrailssvc.NewService(client, d.logger, key)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+}
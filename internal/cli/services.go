@@ -4,32 +4,52 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/tbckr/trident/internal/output"
 	"github.com/tbckr/trident/internal/pap"
 	apexsvc "github.com/tbckr/trident/internal/services/apex"
+	asnpfxsvc "github.com/tbckr/trident/internal/services/asnprefixes"
 	crtshsvc "github.com/tbckr/trident/internal/services/crtsh"
 	cymrusvc "github.com/tbckr/trident/internal/services/cymru"
 	detectsvc "github.com/tbckr/trident/internal/services/detect"
+	dkimsvc "github.com/tbckr/trident/internal/services/dkim"
 	dnssvc "github.com/tbckr/trident/internal/services/dns"
+	dnsblsvc "github.com/tbckr/trident/internal/services/dnsbl"
+	filterchecksvc "github.com/tbckr/trident/internal/services/filtercheck"
 	identifysvc "github.com/tbckr/trident/internal/services/identify"
+	ipinfosvc "github.com/tbckr/trident/internal/services/ipinfo"
+	pdnssvc "github.com/tbckr/trident/internal/services/pdns"
 	pgpsvc "github.com/tbckr/trident/internal/services/pgp"
 	quad9svc "github.com/tbckr/trident/internal/services/quad9"
+	rdapsvc "github.com/tbckr/trident/internal/services/rdap"
+	reverseipsvc "github.com/tbckr/trident/internal/services/reverseip"
+	securitytrailssvc "github.com/tbckr/trident/internal/services/securitytrails"
+	shodansvc "github.com/tbckr/trident/internal/services/shodan"
+	spfsvc "github.com/tbckr/trident/internal/services/spf"
 	threatsvc "github.com/tbckr/trident/internal/services/threatminer"
+	typosvc "github.com/tbckr/trident/internal/services/typo"
+	urlchecksvc "github.com/tbckr/trident/internal/services/urlcheck"
+	virustotalsvc "github.com/tbckr/trident/internal/services/virustotal"
+	whoissvc "github.com/tbckr/trident/internal/services/whois"
 )
 
 type serviceEntry struct {
-	Name   string `json:"name"`
-	Group  string `json:"group"`
-	MinPAP string `json:"min_pap"`
-	PAP    string `json:"max_pap"`
+	Name    string `json:"name"`
+	Group   string `json:"group"`
+	MinPAP  string `json:"min_pap"`
+	PAP     string `json:"max_pap"`
+	Profile string `json:"profile,omitempty"` // active query profile, for commands that have one
 }
 
 // allServices returns a fixed-order list of every service and aggregate command.
-// Services are ordered alphabetically within each group; "services" precedes "aggregate".
-func allServices() []serviceEntry {
+// Services are ordered alphabetically within each group; "services" precedes
+// "aggregate", which precedes "keyed". Keyed services are listed only when
+// hasKey reports a configured API key. profiles maps a command name to its
+// active query profile.
+func allServices(hasKey func(service string) bool, profiles map[string]string) []serviceEntry {
 	type meta struct {
 		name   string
 		minPAP pap.Level
@@ -37,26 +57,48 @@ func allServices() []serviceEntry {
 		group  string
 	}
 	metas := []meta{
-		// services group — alphabetical; MinPAP == PAP for all regular services
+		// services group — alphabetical; MinPAP == PAP for all regular services except
+		// asn-prefixes, typo, and urlcheck, whose --file / --generate-only / offline
+		// feed modes run at RED
+		{asnpfxsvc.Name, asnpfxsvc.MinPAP, asnpfxsvc.PAP, "services"},
 		{cymrusvc.Name, cymrusvc.PAP, cymrusvc.PAP, "services"},
 		{crtshsvc.Name, crtshsvc.PAP, crtshsvc.PAP, "services"},
 		{detectsvc.Name, detectsvc.PAP, detectsvc.PAP, "services"},
+		{dkimsvc.Name, dkimsvc.PAP, dkimsvc.PAP, "services"},
 		{dnssvc.Name, dnssvc.PAP, dnssvc.PAP, "services"},
+		{dnsblsvc.Name, dnsblsvc.PAP, dnsblsvc.PAP, "services"},
+		{filterchecksvc.Name, filterchecksvc.PAP, filterchecksvc.PAP, "services"},
 		{identifysvc.Name, identifysvc.PAP, identifysvc.PAP, "services"},
+		{ipinfosvc.Name, ipinfosvc.PAP, ipinfosvc.PAP, "services"},
+		{pdnssvc.Name, pdnssvc.PAP, pdnssvc.PAP, "services"},
 		{pgpsvc.Name, pgpsvc.PAP, pgpsvc.PAP, "services"},
 		{quad9svc.Name, quad9svc.PAP, quad9svc.PAP, "services"},
+		{rdapsvc.Name, rdapsvc.PAP, rdapsvc.PAP, "services"},
+		{reverseipsvc.Name, reverseipsvc.PAP, reverseipsvc.PAP, "services"},
+		{spfsvc.Name, spfsvc.PAP, spfsvc.PAP, "services"},
 		{threatsvc.Name, threatsvc.PAP, threatsvc.PAP, "services"},
+		{typosvc.Name, typosvc.MinPAP, typosvc.PAP, "services"},
+		{urlchecksvc.Name, urlchecksvc.MinPAP, urlchecksvc.PAP, "services"},
+		{whoissvc.Name, whoissvc.PAP, whoissvc.PAP, "services"},
 		// aggregate group — alphabetical
 		{apexsvc.Name, apexsvc.MinPAP, apexsvc.PAP, "aggregate"},
+		// keyed group — alphabetical
+		{securitytrailssvc.Name, securitytrailssvc.PAP, securitytrailssvc.PAP, "keyed"},
+		{shodansvc.Name, shodansvc.PAP, shodansvc.PAP, "keyed"},
+		{virustotalsvc.Name, virustotalsvc.PAP, virustotalsvc.PAP, "keyed"},
 	}
-	entries := make([]serviceEntry, len(metas))
-	for i, m := range metas {
-		entries[i] = serviceEntry{
-			Name:   m.name,
-			Group:  m.group,
-			MinPAP: m.minPAP.String(),
-			PAP:    m.pap.String(),
+	entries := make([]serviceEntry, 0, len(metas))
+	for _, m := range metas {
+		if m.group == "keyed" && !hasKey(m.name) {
+			continue
 		}
+		entries = append(entries, serviceEntry{
+			Name:    m.name,
+			Group:   m.group,
+			MinPAP:  m.minPAP.String(),
+			PAP:     m.pap.String(),
+			Profile: profiles[m.name],
+		})
 	}
 	return entries
 }
@@ -71,10 +113,28 @@ func newServicesCmd(d *deps) *cobra.Command {
 When all services have identical PAP values a single PAP column is shown.
 For aggregate commands whose sub-services span different PAP levels, two columns
 (MIN PAP and MAX PAP) appear instead. If --pap-limit falls between these values
-the command runs at reduced scope and reports which sub-services were skipped.`,
+the command runs at reduced scope and reports which sub-services were skipped.
+
+Keyed services (group "keyed") are listed only once an API key is configured
+with "trident auth set <service>" or a TRIDENT_<SERVICE>_API_KEY variable.
+
+The PROFILE column shows the query profile apex uses when run without
+--profile (apex.profile in config), followed by the file it was loaded from
+when that is not the built-in set.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			entries := allServices()
+			if err := d.rejectReport(cmd); err != nil {
+				return err
+			}
+			// A broken credentials file must not break a keyless command; keyed
+			// services are then simply hidden.
+			hasKey := func(string) bool { return false }
+			if store, err := d.credentials(); err != nil {
+				d.logger.Warn("ignoring credentials", "error", err)
+			} else {
+				hasKey = store.Has
+			}
+			entries := allServices(hasKey, map[string]string{apexsvc.Name: activeApexProfile(d)})
 			w := cmd.OutOrStdout()
 			switch output.Format(d.cfg.Output) {
 			case output.FormatJSON:
@@ -90,14 +150,23 @@ the command runs at reduced scope and reports which sub-services were skipped.`,
 	}
 }
 
-func writeServicesTable(w io.Writer, entries []serviceEntry) error {
-	splitCols := false
-	for _, e := range entries {
-		if e.MinPAP != e.PAP {
-			splitCols = true
-			break
-		}
+// activeApexProfile describes the apex profile selected by config. A profile
+// that fails to load is reported by its configured value; running apex
+// surfaces the error.
+func activeApexProfile(d *deps) string {
+	p, err := d.loadApexProfile("")
+	if err != nil {
+		d.logger.Warn("cannot load apex profile", "error", err)
+		return d.cfg.Apex.Profile
+	}
+	if p.Source != "<embedded>" {
+		return p.Name + " (" + p.Source + ")"
 	}
+	return p.Name
+}
+
+func writeServicesTable(w io.Writer, entries []serviceEntry) error {
+	splitCols, profileCol := servicesColumns(entries)
 
 	var headers []string
 	rows := make([][]string, len(entries))
@@ -112,6 +181,12 @@ func writeServicesTable(w io.Writer, entries []serviceEntry) error {
 			rows[i] = []string{e.Group, e.Name, e.MinPAP}
 		}
 	}
+	if profileCol {
+		headers = append(headers, "Profile")
+		for i, e := range entries {
+			rows[i] = append(rows[i], e.Profile)
+		}
+	}
 
 	overhead := 37
 	if !splitCols {
@@ -126,23 +201,32 @@ func writeServicesTable(w io.Writer, entries []serviceEntry) error {
 }
 
 func writeServicesText(w io.Writer, entries []serviceEntry) error {
-	splitCols := false
-	for _, e := range entries {
-		if e.MinPAP != e.PAP {
-			splitCols = true
-			break
-		}
-	}
+	splitCols, profileCol := servicesColumns(entries)
 	for _, e := range entries {
-		var err error
+		fields := []string{e.Group, e.Name, e.MinPAP}
 		if splitCols {
-			_, err = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.Group, e.Name, e.MinPAP, e.PAP)
-		} else {
-			_, err = fmt.Fprintf(w, "%s\t%s\t%s\n", e.Group, e.Name, e.MinPAP)
+			fields = append(fields, e.PAP)
 		}
-		if err != nil {
+		if profileCol {
+			fields = append(fields, e.Profile)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// servicesColumns reports whether entries need separate MIN/MAX PAP columns
+// and whether any entry has a profile to show.
+func servicesColumns(entries []serviceEntry) (splitCols, profileCol bool) {
+	for _, e := range entries {
+		if e.MinPAP != e.PAP {
+			splitCols = true
+		}
+		if e.Profile != "" {
+			profileCol = true
+		}
+	}
+	return splitCols, profileCol
+}
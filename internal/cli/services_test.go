@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUtilityCommands_RejectReportFormats(t *testing.T) {
+	isolateConfig(t)
+	for _, args := range [][]string{
+		{"services"},
+		{"auth", "list"},
+		{"alias", "list"},
+		{"config", "show"},
+	} {
+		for _, format := range []string{"markdown", "html"} {
+			stdout, _, err := execute(t, append(args, "-o", format)...)
+			require.Error(t, err, "%v -o %s", args, format)
+			assert.Contains(t, err.Error(), "writes table, json, or text, not "+format)
+			assert.Empty(t, stdout)
+		}
+	}
+}
+
+func TestServices_Text(t *testing.T) {
+	isolateConfig(t)
+	stdout, _, err := execute(t, "services", "-o", "text")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "identify")
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	shodansvc "github.com/tbckr/trident/internal/services/shodan"
+)
+
+func newShodanCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "shodan [ip...]",
+		Short:   "Look up open ports, services, and vulnerabilities in Shodan (API key)",
+		GroupID: "keyed",
+		Long: `Look up an IP address in Shodan: organisation, ASN, location, hostnames, open
+ports with the product and version seen on each, and known vulnerabilities.
+
+Requires an API key: run "trident auth set shodan" or set TRIDENT_SHODAN_API_KEY.
+
+PAP level: AMBER (queries Shodan's existing scan data; the target is not contacted).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Host lookup
+  trident shodan 192.0.2.1
+
+  # Text output (one "<ip> <port>/<transport> <product> <version>" per service)
+  trident shodan --output text 192.0.2.1
+
+  # JSON output
+  trident shodan --output json 192.0.2.1`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := d.apiKey(shodansvc.Name)
+			if err != nil {
+				return err
+			}
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			httpclient.AttachRateLimit(client, ratelimit.New(shodansvc.DefaultRPS, shodansvc.DefaultBurst))
+			svc := shodansvc.NewService(client, d.logger, key)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/doh"
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	spfsvc "github.com/tbckr/trident/internal/services/spf"
+)
+
+func newSPFCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "spf [domain...]",
+		Short:   "Resolve an SPF include tree and flatten it to authorized networks",
+		GroupID: "services",
+		Long: `Resolve a domain's SPF record via Quad9 DoH and recursively expand its
+include:, redirect=, a, mx, ptr, and exists terms into a tree.
+
+Every DNS-querying term is counted against the RFC 7208 limit of 10 lookups,
+and lookups returning NXDOMAIN or no answers are counted against the limit of
+2 void lookups. Include and redirect loops are detected and reported. Include
+nodes are annotated with the email provider matched by the detect patterns.
+
+All pass-qualified mechanisms are flattened into the final, deduplicated set
+of authorized IPv4 and IPv6 networks. ptr and exists terms depend on the
+connecting client and cannot be flattened; macros are not expanded.
+
+Output: table mode shows the tree, a lookup summary, and the flattened
+networks. Text mode prints one authorized CIDR per line. JSON contains the
+full tree.
+
+PAP level: AMBER (queries go to Quad9 third-party servers).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Resolve and flatten an SPF record
+  trident spf example.com
+
+  # Authorized networks only, one per line
+  trident spf --output text example.com
+
+  # Full tree as JSON
+  trident spf --output json example.com`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			client.EnableForceHTTP2()
+			httpclient.AttachRateLimit(client, ratelimit.New(doh.DefaultRPS, doh.DefaultBurst))
+			patterns, err := d.loadPatterns()
+			if err != nil {
+				return err
+			}
+			svc := spfsvc.NewService(client, d.logger, patterns)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+}
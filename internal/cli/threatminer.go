@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/tbckr/trident/internal/httpclient"
@@ -9,16 +11,25 @@ import (
 )
 
 func newThreatMinerCmd(d *deps) *cobra.Command {
-	return &cobra.Command{
-		Use:     "threatminer [domain|ip|hash...]",
-		Short:   "Query ThreatMiner for passive DNS, subdomains, or file hash metadata",
+	var sections []string
+	cmd := &cobra.Command{
+		Use:     "threatminer [domain|ip|hash|ssl:<sha1>|email...]",
+		Short:   "Query ThreatMiner for passive DNS, WHOIS, samples, reports, and more",
 		GroupID: "services",
 		Long: `Query the ThreatMiner API for threat intelligence data.
 
-Automatically detects the input type and queries the appropriate endpoint:
-  - Domain: passive DNS, subdomains, related samples (domain.php)
-  - IP address: passive DNS, URI/domain associations (host.php)
-  - File hash (MD5/SHA1/SHA256): metadata, AV detections (sample.php)
+Automatically detects the input type and queries the appropriate endpoint.
+Sections marked * are queried by default; select others with --sections
+(comma-separated or repeated, or "all"):
+  - Domain (domain.php): whois, pdns*, uris, samples, subdomains*, reports
+  - IP address (host.php): whois, pdns*, uris, samples, ssl, reports
+  - File hash, MD5/SHA1/SHA256 (sample.php): metadata*, http, hosts, mutants,
+    registry, av, reports
+  - SSL certificate, "ssl:<sha1>" (ssl.php): hosts*, reports
+  - Email address (email.php): domains*
+
+Sections that do not apply to an input type are ignored for that input. Each
+section is one API request; ThreatMiner allows roughly one request per second.
 
 A 404-status response from ThreatMiner is treated as "no data found" (not an
 error). Results vary by input type.
@@ -36,6 +47,15 @@ Bulk stdin input is processed concurrently (see --concurrency).`,
   # File hash lookup (SHA256)
   trident threatminer e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
 
+  # Sandbox behaviour and AV verdicts for a sample
+  trident threatminer --sections http,hosts,av e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
+
+  # Everything ThreatMiner knows about an IP
+  trident threatminer --sections all 1.2.3.4
+
+  # Hosts that served an SSL certificate, domains registered with an email
+  trident threatminer ssl:42a8d5b3a867a59a79f44ffadd61460780fe58f2 admin@example.com
+
   # Bulk input from stdin
   echo -e "example.com\n1.2.3.4" | trident threatminer
 
@@ -46,13 +66,23 @@ Bulk stdin input is processed concurrently (see --concurrency).`,
 			return nil, cobra.ShellCompDirectiveNoFileComp
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			selected, err := tmsvc.ParseSections(sections)
+			if err != nil {
+				return err
+			}
 			client, err := d.newHTTPClient()
 			if err != nil {
 				return err
 			}
 			httpclient.AttachRateLimit(client, ratelimit.New(tmsvc.DefaultRPS, tmsvc.DefaultBurst))
 			svc := tmsvc.NewService(client, d.logger)
+			svc.EnableSections(selected)
 			return runServiceCmd(cmd, d, svc, args)
 		},
 	}
+	cmd.Flags().StringSliceVar(&sections, "sections", nil, "sections to query instead of the defaults ("+strings.Join(tmsvc.SectionNames(), ", ")+", or all)")
+	_ = cmd.RegisterFlagCompletionFunc("sections", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return append(tmsvc.SectionNames(), "all"), cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
 }
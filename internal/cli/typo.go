@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/doh"
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	typosvc "github.com/tbckr/trident/internal/services/typo"
+)
+
+func newTypoCmd(d *deps) *cobra.Command {
+	var generateOnly bool
+	cmd := &cobra.Command{
+		Use:     "typo [domain...]",
+		Short:   "Generate typosquat permutations and check which are registered or malicious",
+		GroupID: "services",
+		Long: `Generate lookalike permutations of a domain's registrable part and check
+each one via Quad9 DoH.
+
+Permutation techniques: omission, transposition, homoglyph (ASCII lookalikes
+such as l → 1 and m → rn), bitsquat, TLD swap, hyphenation, vowel swap, and
+IDN homographs (Cyrillic/Greek lookalikes, queried as punycode).
+
+Each permutation is queried for A records. A genuine NXDOMAIN marks it as
+unregistered; Quad9's blocked verdict (NXDOMAIN without authority) marks it as
+malicious. Registered permutations are additionally queried for MX and NS to
+report whether they can receive mail.
+
+Output: table mode lists registered or malicious permutations with a summary.
+Text mode prints those domains one per line. JSON contains every permutation.
+With --generate-only, no queries are made and all permutations are listed.
+
+PAP level: AMBER (queries go to Quad9 third-party servers).
+With --generate-only: RED (offline, no network activity).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Find registered lookalikes of a domain
+  trident typo example.com
+
+  # Generate permutations offline
+  trident typo --generate-only --pap-limit red example.com
+
+  # Registered lookalikes, one per line
+  trident typo --output text example.com`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if generateOnly {
+				return runServiceCmd(cmd, d, typosvc.NewService(nil, d.logger, true), args)
+			}
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			client.EnableForceHTTP2()
+			httpclient.AttachRateLimit(client, ratelimit.New(doh.DefaultRPS, doh.DefaultBurst))
+			return runServiceCmd(cmd, d, typosvc.NewService(client, d.logger, false), args)
+		},
+	}
+	cmd.Flags().BoolVar(&generateOnly, "generate-only", false, "only generate permutations without DNS checks (PAP: RED)")
+	return cmd
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	urlchecksvc "github.com/tbckr/trident/internal/services/urlcheck"
+)
+
+func newURLCheckCmd(d *deps) *cobra.Command {
+	var flagOnline bool
+	var flagDataDir string
+	cmd := &cobra.Command{
+		Use:     "urlcheck [url|domain|ip...]",
+		Short:   "Check URLs and domains against URLhaus, PhishTank, OpenPhish, and ThreatFox",
+		GroupID: "services",
+		Long: `Check URLs, domains, and IP addresses against public URL reputation and
+phishing feeds: URLhaus, PhishTank-format dumps, the OpenPhish community
+feed, and ThreatFox.
+
+By default the feeds are read from <config-dir>/urlcheck/ (see --data-dir),
+where "trident download urlcheck" stores them; lookups then generate no
+network traffic. Only the feeds present are consulted. With --online the
+live URLhaus, PhishTank, and ThreatFox APIs are queried instead, and the
+OpenPhish feed is fetched for the run.
+
+A URL matches identical listed URLs (scheme and host are case-insensitive,
+default ports and fragments are ignored) and listed domains or ip:port
+indicators of its host. A bare domain or IP matches every entry on that host.
+
+Output: table mode shows Feed / Indicator / Threat Type / Tags / First Seen.
+Text mode prints "<feed> <threat type> <indicator>" per match.
+
+PAP level: RED (offline lookup against downloaded feeds).
+With --online: AMBER (queries the abuse.ch, PhishTank, and OpenPhish third-party APIs).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Fetch the feeds once, then check offline
+  trident download urlcheck
+  trident urlcheck --pap-limit red https://evil.example.com/payload.exe
+
+  # Every listed URL on a host
+  trident urlcheck evil.example.com
+
+  # Query the live APIs instead of local feeds
+  trident urlcheck --online https://login.example.net/secure/
+
+  # Bulk input from stdin
+  cat urls.txt | trident urlcheck --output json`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flagOnline {
+				client, err := d.newHTTPClient()
+				if err != nil {
+					return err
+				}
+				httpclient.AttachRateLimit(client, ratelimit.New(urlchecksvc.DefaultRPS, urlchecksvc.DefaultBurst))
+				return runServiceCmd(cmd, d, urlchecksvc.NewService(client, d.logger, ""), args)
+			}
+			dir := flagDataDir
+			if dir == "" {
+				var err error
+				if dir, err = urlchecksvc.DefaultDataDir(); err != nil {
+					return err
+				}
+			}
+			return runServiceCmd(cmd, d, urlchecksvc.NewService(nil, d.logger, dir), args)
+		},
+	}
+	cmd.Flags().BoolVar(&flagOnline, "online", false, "query the live APIs instead of downloaded feeds (PAP: AMBER)")
+	cmd.Flags().StringVar(&flagDataDir, "data-dir", "", "directory holding downloaded feeds (default: <config-dir>/urlcheck)")
+	cmd.MarkFlagsMutuallyExclusive("online", "data-dir")
+	return cmd
+}
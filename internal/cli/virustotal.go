@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/httpclient"
+	"github.com/tbckr/trident/internal/ratelimit"
+	virustotalsvc "github.com/tbckr/trident/internal/services/virustotal"
+)
+
+func newVirusTotalCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "virustotal [domain|ip|hash...]",
+		Short:   "Look up VirusTotal reports for domains, IPs, and file hashes (API key)",
+		GroupID: "keyed",
+		Long: `Look up the VirusTotal v3 report for a domain, IP address, or MD5/SHA1/SHA256
+file hash: detection counts of the last analysis, reputation, tags, categories,
+and type-specific details (registrar, ASN and network, file type and threat label).
+
+Requires an API key: run "trident auth set virustotal" or set
+TRIDENT_VIRUSTOTAL_API_KEY. Requests are limited to the public API quota of
+four per minute.
+
+PAP level: AMBER (queries the VirusTotal third-party API).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Domain report
+  trident virustotal example.com
+
+  # File hash report
+  trident virustotal 44d88612fea8a8f36de82e1278abb02f
+
+  # Text output (detections per input)
+  trident virustotal --output text 192.0.2.1`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := d.apiKey(virustotalsvc.Name)
+			if err != nil {
+				return err
+			}
+			client, err := d.newHTTPClient()
+			if err != nil {
+				return err
+			}
+			httpclient.AttachRateLimit(client, ratelimit.New(virustotalsvc.DefaultRPS, virustotalsvc.DefaultBurst))
+			svc := virustotalsvc.NewService(client, d.logger, key)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+}
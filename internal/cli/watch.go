@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/sink"
+	"github.com/tbckr/trident/internal/watch"
+)
+
+// watchKey is the context key under which the watch command passes its
+// options to the service command it runs.
+type watchKey struct{}
+
+// watchGroups are the command groups watch accepts.
+var watchGroups = []string{"services", "aggregate", "keyed"}
+
+func newWatchCmd(d *deps) *cobra.Command {
+	var (
+		flagEvery   time.Duration
+		flagMaxRuns int
+		flagWebhook string
+	)
+	cmd := &cobra.Command{
+		Use:     "watch <command> [input...]",
+		Short:   "Re-run a service on an interval and report changes",
+		GroupID: "utility",
+		Long: `Re-run a service command on an interval and emit only what changed.
+
+watch runs <command> for the inputs every --every, compares each result with
+the previous one of the same input, and writes one event per added, removed,
+or changed record (a new subdomain, an A record pointing elsewhere, a quad9
+verdict flipping). The first run records the baseline and emits nothing; a
+failed lookup is logged and keeps the previous baseline.
+
+Events are written as NDJSON, one object per line
+({time, service, input, run, kind, key, old, new}), or as plain lines with
+-o text. --sink sends each event to the selected sinks, and --webhook URL is
+shorthand for --sink webhook:URL; delivery failures are logged and do not stop
+the watch. With --save every result is also stored as a snapshot for "trident
+diff", and while a case is active every result is recorded into it.
+
+The service keeps its PAP level, rate limiter, and --concurrency across runs.
+Flags of the watched command go after "--"; global flags go before it.
+watch runs until interrupted (Ctrl-C) or for --max-runs runs.
+
+PAP level: that of the watched command.`,
+		Example: `  # New subdomains in certificate transparency logs, checked hourly
+  trident watch crtsh example.com --every 1h
+
+  # Apex DNS changes with the mail profile, posted to a webhook
+  trident watch --every 15m --webhook https://hooks.example.com/trident -- apex --profile mail example.com
+
+  # Quad9 verdict flips for a list of domains
+  cat domains.txt | trident watch quad9 --every 30m
+
+  # Two runs, one minute apart, as plain text
+  trident watch dns example.com --every 1m --max-runs 2 -o text`,
+		Args: cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var names []string
+			for _, c := range cmd.Root().Commands() {
+				if slices.Contains(watchGroups, c.GroupID) {
+					names = append(names, c.Name()+"\t"+c.Short)
+				}
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flagEvery < watch.MinInterval {
+				return fmt.Errorf("%w: --every %s is shorter than the minimum of %s",
+					services.ErrInvalidInput, flagEvery, watch.MinInterval)
+			}
+			if flagMaxRuns < 0 {
+				return fmt.Errorf("%w: --max-runs must not be negative, got %d", services.ErrInvalidInput, flagMaxRuns)
+			}
+			sub, subArgs, err := cmd.Root().Find(args)
+			if err != nil || sub == cmd.Root() {
+				return fmt.Errorf("%w: unknown command %q", services.ErrInvalidInput, args[0])
+			}
+			if !slices.Contains(watchGroups, sub.GroupID) {
+				return fmt.Errorf("%w: %q is not a service command", services.ErrInvalidInput, sub.Name())
+			}
+			if err := sub.ParseFlags(subArgs); err != nil {
+				return err
+			}
+			if err := sub.ValidateArgs(sub.Flags().Args()); err != nil {
+				return err
+			}
+
+			w := &watchRun{opts: watch.Options{Every: flagEvery, MaxRuns: flagMaxRuns}}
+			if flagWebhook != "" {
+				if w.sinks, err = d.newSinks(sink.Spec{Name: "webhook", Type: sink.TypeWebhook, URL: flagWebhook}); err != nil {
+					return err
+				}
+			}
+			sub.SetContext(context.WithValue(cmd.Context(), watchKey{}, w))
+			return sub.RunE(sub, sub.Flags().Args())
+		},
+	}
+	cmd.Flags().DurationVar(&flagEvery, "every", 15*time.Minute, "interval between runs (minimum 10s)")
+	cmd.Flags().IntVar(&flagMaxRuns, "max-runs", 0, "stop after this many runs (0 = until interrupted)")
+	cmd.Flags().StringVar(&flagWebhook, "webhook", "", "also send each change event to this webhook URL (same as --sink webhook:URL)")
+	return cmd
+}
+
+// watchRun carries the watch options into runCmdBody of the watched command.
+type watchRun struct {
+	opts  watch.Options
+	sinks []sink.Sink // from --webhook, added to those selected with --sink
+}
+
+// watchFrom returns the watch options installed by the watch command, or nil.
+func watchFrom(ctx context.Context) *watchRun {
+	w, _ := ctx.Value(watchKey{}).(*watchRun)
+	return w
+}
+
+// run loops svc over inputs until the context is done or the run limit is
+// reached, writing change events to stdout and the sinks.
+func (w *watchRun) run(cmd *cobra.Command, d *deps, svc services.Service, inputs []string, sinks []sink.Sink, p *persister) error {
+	if output.Format(d.cfg.Output).IsReport() {
+		return fmt.Errorf("%w: watch writes NDJSON or text, not %s", services.ErrInvalidInput, d.cfg.Output)
+	}
+	ctx := cmd.Context()
+	sinks = append(sinks, w.sinks...)
+	out := cmd.OutOrStdout()
+	if d.doDefang {
+		out = &output.DefangWriter{Inner: out}
+	}
+	enc := json.NewEncoder(out)
+	text := output.Format(d.cfg.Output) == output.FormatText
+
+	opts := w.opts
+	opts.Concurrency = d.cfg.Concurrency
+	opts.OnResult = func(input string, result services.Result) {
+		p.persist(svc, input, result)
+	}
+
+	d.logger.Info("watching", "service", svc.Name(), "inputs", len(inputs), "every", opts.Every, "max_runs", opts.MaxRuns)
+	return watch.Run(ctx, svc, inputs, opts, func(e watch.Event) error {
+		write := func() error {
+			var err error
+			if text {
+				err = e.WriteText(out)
+			} else {
+				err = enc.Encode(e)
+			}
+			if err != nil {
+				return fmt.Errorf("writing event: %w", err)
+			}
+			return nil
+		}
+		return emitResult(ctx, d, sinks, write, sink.Message{Time: e.Time, Kind: sink.KindChange, Service: e.Service, Input: e.Input, Data: e})
+	}, d.logger)
+}
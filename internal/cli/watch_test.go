@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch_Identify(t *testing.T) {
+	isolateConfig(t)
+	stdout, stderr, err := execute(t, "watch", "--every", "10s", "--max-runs", "1", "--",
+		"identify", "--domain", "example.com", "--cname", "foo.cloudfront.net")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "watching")
+	assert.Empty(t, stdout, "the first run is the baseline and emits no events")
+}
+
+func TestWatch_RejectsUtilityCommand(t *testing.T) {
+	isolateConfig(t)
+	_, _, err := execute(t, "watch", "--every", "10s", "--max-runs", "1", "--", "services")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a service command")
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	whoissvc "github.com/tbckr/trident/internal/services/whois"
+)
+
+func newWhoisCmd(d *deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "whois [domain|ip|asn...]",
+		Short:   "Look up domain, IP, and ASN registration data via port-43 WHOIS",
+		GroupID: "services",
+		Long: `Look up registration data for domains, IP addresses, and ASNs via the
+legacy WHOIS protocol (TCP port 43). Useful for ccTLDs without RDAP.
+
+Queries start at whois.iana.org and follow referrals to the registry and
+then the registrar WHOIS server. Per-TLD servers can be pinned in
+config.yaml, which skips the IANA lookup for matching domains (the longest
+matching suffix wins):
+
+  whois:
+    servers:
+      - suffix: de
+        server: whois.denic.de
+      - suffix: co.uk
+        server: whois.nic.uk
+
+Responses are parsed into the same normalized fields as the rdap command:
+registrar, registrant organization, country, network, creation/update/expiry
+dates, status, nameservers, and abuse contacts.
+
+Output: table mode shows one Field/Value table per input, including the
+referral chain. Text mode prints the raw response of the last server that
+answered, preceded by a "% <input> (<server>)" line.
+
+A socks5:// proxy (--proxy or ALL_PROXY) tunnels all WHOIS connections;
+HTTP/HTTPS proxies cannot carry WHOIS and are ignored.
+
+PAP level: AMBER (queries go to IANA, registry, and registrar WHOIS servers).
+
+Multiple inputs can be supplied as arguments or piped via stdin (one per line).
+Bulk stdin input is processed concurrently (see --concurrency).`,
+		Example: `  # Domain registration data
+  trident whois example.de
+
+  # Raw WHOIS response
+  trident whois --output text example.com
+
+  # IP network and ASN owners
+  trident whois 192.0.2.1 AS3333`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dialer, err := d.newDialer()
+			if err != nil {
+				return err
+			}
+			overrides := make(map[string]string, len(d.cfg.Whois.Servers))
+			for _, s := range d.cfg.Whois.Servers {
+				overrides[s.Suffix] = s.Server
+			}
+			svc := whoissvc.NewService(dialer, d.logger, overrides)
+			return runServiceCmd(cmd, d, svc, args)
+		},
+	}
+}
@@ -19,6 +19,14 @@ import (
 // custom URL is configured.
 const DefaultPatternsURL = "https://raw.githubusercontent.com/tbckr/trident/refs/heads/main/internal/detect/patterns.yaml"
 
+// DefaultDNSBLZonesURL is the built-in URL used by `download dnsbl` when no
+// custom URL is configured.
+const DefaultDNSBLZonesURL = "https://raw.githubusercontent.com/tbckr/trident/refs/heads/main/internal/services/dnsbl/zones.yaml"
+
+// DefaultSharedThreshold is the default reverseip.shared_threshold: IPs with
+// more distinct domains are treated as shared hosting.
+const DefaultSharedThreshold = 100
+
 // ErrUnknownKey is returned when a config key is not recognised.
 var ErrUnknownKey = errors.New("unknown config key")
 
@@ -40,16 +48,24 @@ type configKeyMeta struct {
 // configKeys is the single source of truth for valid config keys.
 // Keys use the viper/mapstructure naming convention (underscores, not hyphens).
 var configKeys = map[string]configKeyMeta{
-	"verbose":              {typ: keyTypeBool},
-	"output":               {typ: keyTypeString, allowed: []string{"table", "json", "text"}},
-	"proxy":                {typ: keyTypeString},
-	"user_agent":           {typ: keyTypeString},
-	"pap_limit":            {typ: keyTypeString, allowed: []string{"red", "amber", "green", "white"}},
-	"defang":               {typ: keyTypeBool},
-	"no_defang":            {typ: keyTypeBool},
-	"concurrency":          {typ: keyTypeInt},
-	"detect_patterns.url":  {typ: keyTypeString},
-	"detect_patterns.file": {typ: keyTypeString},
+	"verbose":                    {typ: keyTypeBool},
+	"output":                     {typ: keyTypeString, allowed: []string{"table", "json", "text", "markdown", "html"}},
+	"proxy":                      {typ: keyTypeString},
+	"user_agent":                 {typ: keyTypeString},
+	"pap_limit":                  {typ: keyTypeString, allowed: []string{"red", "amber", "green", "white"}},
+	"defang":                     {typ: keyTypeBool},
+	"no_defang":                  {typ: keyTypeBool},
+	"concurrency":                {typ: keyTypeInt},
+	"save":                       {typ: keyTypeBool},
+	"case":                       {typ: keyTypeString},
+	"detect_patterns.url":        {typ: keyTypeString},
+	"detect_patterns.file":       {typ: keyTypeString},
+	"reverseip.shared_threshold": {typ: keyTypeInt},
+	"dnsbl.url":                  {typ: keyTypeString},
+	"dnsbl.file":                 {typ: keyTypeString},
+	"apex.profile":               {typ: keyTypeString},
+	"apex.offline_asn":           {typ: keyTypeBool},
+	"snapshots.dir":              {typ: keyTypeString},
 }
 
 // ValidKeys returns every recognised config key in sorted order.
@@ -128,19 +144,91 @@ type DetectPatternsConfig struct {
 	File string `mapstructure:"file"` // custom patterns file; empty = use DefaultPatternPaths
 }
 
+// DNSBLConfig holds configuration for the dnsbl zone catalog.
+type DNSBLConfig struct {
+	URL  string `mapstructure:"url"`  // custom download URL; empty = built-in default
+	File string `mapstructure:"file"` // custom zones file; empty = use DefaultZonePaths
+}
+
+// ApexConfig holds configuration for the apex aggregate command.
+type ApexConfig struct {
+	Profile    string `mapstructure:"profile"`     // query profile name or file; empty = "default"
+	OfflineASN bool   `mapstructure:"offline_asn"` // ASN lookups from local ipinfo databases first
+}
+
+// SnapshotsConfig holds configuration for the snapshot store used by --save
+// and "trident diff".
+type SnapshotsConfig struct {
+	Dir string `mapstructure:"dir"` // snapshot directory; empty = <config-dir>/snapshots
+}
+
+// SinkConfig describes one named output sink selected with --sink.
+type SinkConfig struct {
+	Name      string `mapstructure:"name"`        // referenced by --sink
+	Type      string `mapstructure:"type"`        // webhook | slack | mattermost | teams | file
+	URL       string `mapstructure:"url"`         // HTTP sinks
+	Path      string `mapstructure:"path"`        // file sinks
+	Format    string `mapstructure:"format"`      // file sinks: json (default) or text
+	MaxSizeMB int    `mapstructure:"max_size_mb"` // file sinks: rotate beyond this size; 0 = 10
+	MaxFiles  int    `mapstructure:"max_files"`   // file sinks: rotated files kept; 0 = 5
+}
+
+// WhoisServer maps a TLD or domain suffix to the WHOIS server that is queried
+// directly instead of following IANA referrals.
+type WhoisServer struct {
+	Suffix string `mapstructure:"suffix"` // e.g. "de" or "co.uk"
+	Server string `mapstructure:"server"` // host or host:port
+}
+
+// WhoisConfig holds configuration for the whois service.
+// A list is used instead of a map because viper treats dots in map keys
+// (e.g. "co.uk") as nesting.
+type WhoisConfig struct {
+	Servers []WhoisServer `mapstructure:"servers"`
+}
+
+// ReverseIPConfig holds configuration for the reverseip service.
+type ReverseIPConfig struct {
+	SharedThreshold int `mapstructure:"shared_threshold"` // domains per IP above which the IP counts as shared hosting
+}
+
+// COFEndpoint is a passive DNS server speaking the Common Output Format,
+// such as CIRCL's, queried by the pdns service.
+type COFEndpoint struct {
+	Name     string `mapstructure:"name"`     // source name shown in results
+	URL      string `mapstructure:"url"`      // query endpoint; the domain or IP is appended as a path segment
+	Username string `mapstructure:"username"` // optional basic-auth credentials
+	Password string `mapstructure:"password"`
+}
+
+// PDNSConfig holds configuration for the pdns service.
+type PDNSConfig struct {
+	COF []COFEndpoint `mapstructure:"cof"`
+}
+
 // Config holds the runtime settings resolved from flags, env vars, and config file.
 type Config struct {
 	ConfigFile     string               // set after Unmarshal — no mapstructure tag
 	Verbose        bool                 `mapstructure:"verbose"`
-	Output         string               `mapstructure:"output"`          // table | json | text
+	Output         string               `mapstructure:"output"`          // table | json | text | markdown | html
 	Proxy          string               `mapstructure:"proxy"`           // http://, https://, socks5://
 	UserAgent      string               `mapstructure:"user_agent"`      // override or empty (→ rotation)
 	PAPLimit       string               `mapstructure:"pap_limit"`       // "white" (default)
 	Defang         bool                 `mapstructure:"defang"`          // force defang
 	NoDefang       bool                 `mapstructure:"no_defang"`       // suppress defang
 	Concurrency    int                  `mapstructure:"concurrency"`     // default 10
+	Save           bool                 `mapstructure:"save"`            // store each result as a snapshot
+	Case           string               `mapstructure:"case"`            // active case recording every result
 	Aliases        map[string]string    `mapstructure:"alias"`           // file-only; no flag/env binding
 	DetectPatterns DetectPatternsConfig `mapstructure:"detect_patterns"` // detect patterns configuration
+	Whois          WhoisConfig          `mapstructure:"whois"`           // file-only; per-TLD server overrides
+	ReverseIP      ReverseIPConfig      `mapstructure:"reverseip"`       // reverseip shared-hosting filter
+	PDNS           PDNSConfig           `mapstructure:"pdns"`            // file-only; extra COF passive DNS endpoints
+	DNSBL          DNSBLConfig          `mapstructure:"dnsbl"`           // dnsbl zone catalog configuration
+	Apex           ApexConfig           `mapstructure:"apex"`            // apex query profile selection
+	Snapshots      SnapshotsConfig      `mapstructure:"snapshots"`       // snapshot store location
+	Sinks          []SinkConfig         `mapstructure:"sinks"`           // file-only; named output sinks
+	Sink           []string             `mapstructure:"sink"`            // sinks selected with --sink
 }
 
 // RegisterFlags defines all persistent CLI flags on the given FlagSet.
@@ -148,13 +236,16 @@ type Config struct {
 func RegisterFlags(flags *pflag.FlagSet) {
 	flags.String("config", "", "config file (default: $XDG_CONFIG_HOME/trident/config.yaml)")
 	flags.BoolP("verbose", "v", false, "enable verbose (debug) logging")
-	flags.StringP("output", "o", "table", "output format: table, json, or text")
+	flags.StringP("output", "o", "table", "output format: table, json, text, markdown, or html")
 	flags.String("proxy", "", "proxy URL (http://, https://, or socks5://)")
 	flags.String("user-agent", "", "HTTP User-Agent header (default: trident/<version>)")
 	flags.String("pap-limit", "white", "PAP limit: white, green, amber, or red")
 	flags.Bool("defang", false, "defang text/plain output (dots → [.], http → hxxp)")
 	flags.Bool("no-defang", false, "disable defanging even if enabled in config")
 	flags.IntP("concurrency", "c", 10, "parallel workers for bulk stdin input")
+	flags.Bool("save", false, "save each result as a snapshot for \"trident diff\"")
+	flags.String("case", "", "record results into this case instead of the active one")
+	flags.StringArray("sink", nil, "also send results to a configured sink or <type>:<url|path> (repeatable)")
 	flags.String("patterns-file", "", "custom detect patterns file (overrides detect.yaml search)")
 }
 
@@ -172,6 +263,9 @@ func Load(flags *pflag.FlagSet) (*Config, error) {
 	v.SetDefault("pap_limit", "white")
 	v.SetDefault("concurrency", 10)
 	v.SetDefault("detect_patterns.url", DefaultPatternsURL)
+	v.SetDefault("reverseip.shared_threshold", DefaultSharedThreshold)
+	v.SetDefault("dnsbl.url", DefaultDNSBLZonesURL)
+	v.SetDefault("apex.profile", "default")
 
 	// Env vars: TRIDENT_VERBOSE, TRIDENT_OUTPUT, TRIDENT_USER_AGENT, etc.
 	v.SetEnvPrefix("TRIDENT")
@@ -186,6 +280,9 @@ func Load(flags *pflag.FlagSet) (*Config, error) {
 	_ = v.BindPFlag("defang", flags.Lookup("defang"))
 	_ = v.BindPFlag("no_defang", flags.Lookup("no-defang"))
 	_ = v.BindPFlag("concurrency", flags.Lookup("concurrency"))
+	_ = v.BindPFlag("save", flags.Lookup("save"))
+	_ = v.BindPFlag("case", flags.Lookup("case"))
+	_ = v.BindPFlag("sink", flags.Lookup("sink"))
 	_ = v.BindPFlag("detect_patterns.file", flags.Lookup("patterns-file"))
 
 	// Config file resolution.
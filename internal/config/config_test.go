@@ -160,6 +160,8 @@ func TestParseValue(t *testing.T) {
 		{key: "output", value: "json", want: "json"},
 		{key: "output", value: "table", want: "table"},
 		{key: "output", value: "text", want: "text"},
+		{key: "output", value: "markdown", want: "markdown"},
+		{key: "output", value: "html", want: "html"},
 		{key: "output", value: "xml", wantErr: true},
 		// enum string — pap_limit (hyphenated key)
 		{key: "pap-limit", value: "amber", want: "amber"},
@@ -278,3 +280,132 @@ func TestWarnInsecurePermissions_NonExistent(t *testing.T) {
 	warn := config.WarnInsecurePermissions("/nonexistent/path/config.yaml")
 	assert.Empty(t, warn)
 }
+
+func TestLoad_WhoisServers(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	yamlContent := "whois:\n  servers:\n    - suffix: de\n      server: whois.denic.de\n    - suffix: co.uk\n      server: whois.nic.uk:43\n"
+	require.NoError(t, os.WriteFile(cfgFile, []byte(yamlContent), 0o600))
+
+	cfg, err := config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, []config.WhoisServer{
+		{Suffix: "de", Server: "whois.denic.de"},
+		{Suffix: "co.uk", Server: "whois.nic.uk:43"},
+	}, cfg.Whois.Servers)
+}
+
+func TestLoad_PDNSCOFEndpoints(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	yamlContent := "pdns:\n  cof:\n    - name: circl\n      url: https://www.circl.lu/pdns/query\n      username: analyst\n      password: s3cret\n"
+	require.NoError(t, os.WriteFile(cfgFile, []byte(yamlContent), 0o600))
+
+	cfg, err := config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, []config.COFEndpoint{
+		{Name: "circl", URL: "https://www.circl.lu/pdns/query", Username: "analyst", Password: "s3cret"},
+	}, cfg.PDNS.COF)
+}
+
+func TestLoad_ReverseIPSharedThreshold(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(""), 0o600))
+
+	cfg, err := config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultSharedThreshold, cfg.ReverseIP.SharedThreshold)
+
+	require.NoError(t, os.WriteFile(cfgFile, []byte("reverseip:\n  shared_threshold: 25\n"), 0o600))
+	cfg, err = config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, 25, cfg.ReverseIP.SharedThreshold)
+}
+
+func TestLoad_DNSBL(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(""), 0o600))
+
+	cfg, err := config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultDNSBLZonesURL, cfg.DNSBL.URL)
+	assert.Empty(t, cfg.DNSBL.File)
+
+	require.NoError(t, os.WriteFile(cfgFile, []byte("dnsbl:\n  url: https://example.com/zones.yaml\n  file: /tmp/zones.yaml\n"), 0o600))
+	cfg, err = config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/zones.yaml", cfg.DNSBL.URL)
+	assert.Equal(t, "/tmp/zones.yaml", cfg.DNSBL.File)
+}
+
+func TestLoad_ApexProfile(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(""), 0o600))
+
+	cfg, err := config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, "default", cfg.Apex.Profile)
+
+	require.NoError(t, os.WriteFile(cfgFile, []byte("apex:\n  profile: mail\n"), 0o600))
+	cfg, err = config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, "mail", cfg.Apex.Profile)
+}
+
+func TestLoad_Snapshots(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(""), 0o600))
+
+	cfg, err := config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.False(t, cfg.Save)
+	assert.Empty(t, cfg.Snapshots.Dir)
+
+	require.NoError(t, os.WriteFile(cfgFile, []byte("snapshots:\n  dir: /srv/trident\n"), 0o600))
+	cfg, err = config.Load(newTestFlags(t, cfgFile, "--save"))
+	require.NoError(t, err)
+	assert.True(t, cfg.Save)
+	assert.Equal(t, "/srv/trident", cfg.Snapshots.Dir)
+}
+
+func TestLoad_Case(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("case: acme\n"), 0o600))
+
+	cfg, err := config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, "acme", cfg.Case)
+
+	cfg, err = config.Load(newTestFlags(t, cfgFile, "--case", "other"))
+	require.NoError(t, err)
+	assert.Equal(t, "other", cfg.Case)
+}
+
+func TestLoad_Sinks(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	yamlContent := "sinks:\n  - name: soc\n    type: slack\n    url: https://hooks.slack.com/services/T/B/X\n  - name: log\n    type: file\n    path: /var/log/trident.ndjson\n    max_size_mb: 50\n    max_files: 3\n"
+	require.NoError(t, os.WriteFile(cfgFile, []byte(yamlContent), 0o600))
+
+	cfg, err := config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, []config.SinkConfig{
+		{Name: "soc", Type: "slack", URL: "https://hooks.slack.com/services/T/B/X"},
+		{Name: "log", Type: "file", Path: "/var/log/trident.ndjson", MaxSizeMB: 50, MaxFiles: 3},
+	}, cfg.Sinks)
+	assert.Empty(t, cfg.Sink)
+
+	cfg, err = config.Load(newTestFlags(t, cfgFile, "--sink", "soc", "--sink=file:/tmp/out.ndjson"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"soc", "file:/tmp/out.ndjson"}, cfg.Sink)
+
+	require.NoError(t, os.WriteFile(cfgFile, []byte(yamlContent+"sink: [soc]\n"), 0o600))
+	cfg, err = config.Load(newTestFlags(t, cfgFile))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"soc"}, cfg.Sink)
+}
@@ -0,0 +1,172 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the credentials file in the config directory.
+const FileName = "credentials.yaml"
+
+// Origin reports where an API key came from.
+type Origin string
+
+const (
+	// OriginNone means no key is configured.
+	OriginNone Origin = ""
+	// OriginEnv means the key came from TRIDENT_<SERVICE>_API_KEY.
+	OriginEnv Origin = "env"
+	// OriginFile means the key came from the credentials file.
+	OriginFile Origin = "file"
+)
+
+// Store holds API keys keyed by service name.
+type Store struct {
+	path string
+	keys map[string]string
+}
+
+// PathFor returns the credentials file path next to configFile.
+func PathFor(configFile string) string {
+	return filepath.Join(filepath.Dir(configFile), FileName)
+}
+
+// EnvVar returns the environment variable consulted for service, e.g.
+// TRIDENT_VIRUSTOTAL_API_KEY.
+func EnvVar(service string) string {
+	return "TRIDENT_" + strings.ToUpper(strings.ReplaceAll(service, "-", "_")) + "_API_KEY"
+}
+
+// Load reads the credentials file at path. A missing file yields an empty
+// store that can still be written with Set.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path, keys: map[string]string{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("reading credentials: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &s.keys); err != nil {
+		return nil, fmt.Errorf("parsing credentials %s: %w", path, err)
+	}
+	if s.keys == nil {
+		s.keys = map[string]string{}
+	}
+	return s, nil
+}
+
+// Path returns the credentials file path.
+func (s *Store) Path() string { return s.path }
+
+// Get returns the API key for service and where it came from. The
+// environment variable wins over the file.
+func (s *Store) Get(service string) (string, Origin) {
+	if v := strings.TrimSpace(os.Getenv(EnvVar(service))); v != "" {
+		return v, OriginEnv
+	}
+	if v := s.keys[service]; v != "" {
+		return v, OriginFile
+	}
+	return "", OriginNone
+}
+
+// Has reports whether an API key is configured for service.
+func (s *Store) Has(service string) bool {
+	_, origin := s.Get(service)
+	return origin != OriginNone
+}
+
+// Set stores key for service and rewrites the credentials file with 0600
+// permissions.
+func (s *Store) Set(service, key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return fmt.Errorf("empty API key for %s", service)
+	}
+	s.keys[service] = key
+	return s.save()
+}
+
+// Remove deletes the stored key for service and reports whether one existed.
+// Keys supplied through the environment are not affected.
+func (s *Store) Remove(service string) (bool, error) {
+	if _, ok := s.keys[service]; !ok {
+		return false, nil
+	}
+	delete(s.keys, service)
+	return true, s.save()
+}
+
+// Services returns the names of services with a key in the file, sorted.
+func (s *Store) Services() []string {
+	names := make([]string, 0, len(s.keys))
+	for name := range s.keys {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// save writes the keys atomically: a 0600 temp file in the same directory is
+// renamed over the credentials file.
+func (s *Store) save() error {
+	data, err := yaml.Marshal(s.keys)
+	if err != nil {
+		return fmt.Errorf("encoding credentials: %w", err)
+	}
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating config dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, FileName+".*")
+	if err != nil {
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	return nil
+}
+
+// Mask shortens key for display, keeping the first and last four characters
+// of keys long enough to stay unrecognisable.
+func Mask(key string) string {
+	if len(key) <= 12 {
+		return strings.Repeat("*", len(key))
+	}
+	return key[:4] + strings.Repeat("*", 8) + key[len(key)-4:]
+}
+
+// WarnInsecurePermissions returns a warning when the credentials file at path
+// is readable or writable by group or others, or "" when it is not (or does
+// not exist).
+func WarnInsecurePermissions(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return fmt.Sprintf("credentials file %s has permissions %04o, want 0600; run: chmod 0600 %s", path, perm, path)
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package credentials_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/credentials"
+)
+
+func TestPathFor(t *testing.T) {
+	got := credentials.PathFor(filepath.Join("cfg", "trident", "config.yaml"))
+	assert.Equal(t, filepath.Join("cfg", "trident", "credentials.yaml"), got)
+}
+
+func TestEnvVar(t *testing.T) {
+	assert.Equal(t, "TRIDENT_VIRUSTOTAL_API_KEY", credentials.EnvVar("virustotal"))
+	assert.Equal(t, "TRIDENT_SECURITY_TRAILS_API_KEY", credentials.EnvVar("security-trails"))
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	s, err := credentials.Load(filepath.Join(t.TempDir(), "credentials.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, s.Services())
+	assert.False(t, s.Has("shodan"))
+}
+
+func TestSetGetRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "credentials.yaml")
+	s, err := credentials.Load(path)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Set("shodan", "  abc123  "))
+	key, origin := s.Get("shodan")
+	assert.Equal(t, "abc123", key)
+	assert.Equal(t, credentials.OriginFile, origin)
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	reloaded, err := credentials.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shodan"}, reloaded.Services())
+
+	removed, err := reloaded.Remove("shodan")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = reloaded.Remove("shodan")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	assert.Error(t, s.Set("shodan", " "), "empty keys are rejected")
+}
+
+func TestGet_EnvWins(t *testing.T) {
+	s, err := credentials.Load(filepath.Join(t.TempDir(), "credentials.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, s.Set("virustotal", "from-file"))
+
+	t.Setenv("TRIDENT_VIRUSTOTAL_API_KEY", "from-env")
+	key, origin := s.Get("virustotal")
+	assert.Equal(t, "from-env", key)
+	assert.Equal(t, credentials.OriginEnv, origin)
+}
+
+func TestLoad_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- not\n- a map\n"), 0o600))
+	_, err := credentials.Load(path)
+	assert.Error(t, err)
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "******", credentials.Mask("abcdef"))
+	assert.Equal(t, "abcd********wxyz", credentials.Mask("abcdefghijklmnopqrstuvwxyz"))
+}
+
+func TestWarnInsecurePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX permissions only")
+	}
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+	assert.Empty(t, credentials.WarnInsecurePermissions(path), "missing file")
+
+	require.NoError(t, os.WriteFile(path, []byte("a: b\n"), 0o600))
+	assert.Empty(t, credentials.WarnInsecurePermissions(path))
+
+	require.NoError(t, os.Chmod(path, 0o644))
+	assert.Contains(t, credentials.WarnInsecurePermissions(path), "0644")
+}
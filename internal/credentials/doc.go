@@ -0,0 +1,6 @@
+// Package credentials stores API keys for the optional keyed services.
+//
+// Keys live in credentials.yaml next to the config file with 0600
+// permissions, or are supplied through TRIDENT_<SERVICE>_API_KEY environment
+// variables, which take precedence. Keyless commands never consult the store.
+package credentials
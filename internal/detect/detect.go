@@ -18,7 +18,7 @@ type Detection struct {
 	Type     ServiceType
 	Provider string
 	Evidence string // e.g. CNAME target, MX exchange, NS server
-	Source   string // DNS record type: "cname", "mx", "ns", "txt"
+	Source   string // DNS record type: "cname", "mx", "ns", "txt", "dkim"
 }
 
 // Detector holds loaded patterns and provides detection methods.
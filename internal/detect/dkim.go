@@ -0,0 +1,30 @@
+package detect
+
+import "strings"
+
+// DKIMSelector matches DKIM selector names against known provider selectors
+// and returns one Detection per unique (provider, selector) pair.
+// Matching is exact and case-insensitive.
+func (d *Detector) DKIMSelector(selectors []string) []Detection {
+	var detections []Detection
+	seen := map[string]bool{}
+	for _, sel := range selectors {
+		for _, p := range d.patterns.DKIM {
+			if !strings.EqualFold(sel, p.Selector) {
+				continue
+			}
+			key := p.Provider + ":" + sel
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			detections = append(detections, Detection{
+				Type:     TypeEmail,
+				Provider: p.Provider,
+				Evidence: sel,
+				Source:   "dkim",
+			})
+		}
+	}
+	return detections
+}
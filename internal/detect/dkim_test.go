@@ -0,0 +1,68 @@
+package detect_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/detect"
+)
+
+// allDKIMPatterns is the full set of DKIM selector patterns used across DKIM tests.
+var allDKIMPatterns = []detect.DKIMPattern{
+	{Selector: "google", Provider: "Google Workspace"},
+	{Selector: "selector1", Provider: "Microsoft 365"},
+	{Selector: "selector2", Provider: "Microsoft 365"},
+	{Selector: "k1", Provider: "Mailchimp"},
+	{Selector: "s1", Provider: "SendGrid"},
+	{Selector: "s2", Provider: "SendGrid"},
+}
+
+func newDKIMDetector() *detect.Detector {
+	return detect.NewDetector(detect.Patterns{DKIM: allDKIMPatterns})
+}
+
+func TestDKIMSelector_KnownSelectors(t *testing.T) {
+	tests := []struct {
+		selector string
+		provider string
+	}{
+		{"google", "Google Workspace"},
+		{"selector1", "Microsoft 365"},
+		{"k1", "Mailchimp"},
+		{"s1", "SendGrid"},
+		{"S2", "SendGrid"},
+	}
+	d := newDKIMDetector()
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			detections := d.DKIMSelector([]string{tt.selector})
+			require.Len(t, detections, 1)
+			assert.Equal(t, detect.TypeEmail, detections[0].Type)
+			assert.Equal(t, tt.provider, detections[0].Provider)
+			assert.Equal(t, tt.selector, detections[0].Evidence)
+			assert.Equal(t, "dkim", detections[0].Source)
+		})
+	}
+}
+
+func TestDKIMSelector_UnknownSelector(t *testing.T) {
+	assert.Empty(t, newDKIMDetector().DKIMSelector([]string{"default", "s10"}))
+}
+
+func TestDKIMSelector_Deduplicates(t *testing.T) {
+	detections := newDKIMDetector().DKIMSelector([]string{"s1", "s1", "s2"})
+	assert.Len(t, detections, 2)
+}
+
+func TestLoadPatterns_EmbeddedDKIM(t *testing.T) {
+	p, err := detect.LoadPatterns()
+	require.NoError(t, err)
+	d := detect.NewDetector(p)
+	for selector, provider := range map[string]string{"k1": "Mailchimp", "s1": "SendGrid", "s2": "SendGrid"} {
+		detections := d.DKIMSelector([]string{selector})
+		require.Len(t, detections, 1, selector)
+		assert.Equal(t, provider, detections[0].Provider)
+	}
+}
@@ -41,12 +41,19 @@ type TXTPattern struct {
 	Type      ServiceType `yaml:"type"`
 }
 
-// Patterns holds all detection patterns for CDN, email, DNS, and TXT records.
+// DKIMPattern maps a DKIM selector to the email provider that conventionally uses it.
+type DKIMPattern struct {
+	Selector string `yaml:"selector"`
+	Provider string `yaml:"provider"`
+}
+
+// Patterns holds all detection patterns for CDN, email, DNS, TXT records, and DKIM selectors.
 type Patterns struct {
 	CDN   []CDNPattern   `yaml:"cdn"`
 	Email []EmailPattern `yaml:"email"`
 	DNS   []DNSPattern   `yaml:"dns"`
 	TXT   []TXTPattern   `yaml:"txt"`
+	DKIM  []DKIMPattern  `yaml:"dkim"`
 }
 
 // LoadPatterns tries each path in order; the first file that exists is used.
@@ -1,2 +1,3 @@
-// Package doh provides a DNS-over-HTTPS client for the Quad9 resolver.
+// Package doh provides an RFC 8484 DNS-over-HTTPS client, defaulting to the
+// Quad9 resolver.
 package doh
@@ -3,8 +3,11 @@ package doh
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
 
 	"codeberg.org/miekg/dns"
@@ -23,19 +26,144 @@ const (
 	DefaultBurst = 10
 )
 
+// ednsUDPSize is the EDNS0 UDP payload size advertised in queries. Sending an
+// OPT record lets resolvers return Extended DNS Errors.
+const ednsUDPSize = 1232
+
 // Response holds the parsed DNS wire-format response.
 type Response struct {
 	Status       uint16
 	HasAuthority bool
+	Flags        Flags
 	Answer       []Answer
+	Authority    []Answer // authority section (SOA, NS, ...)
+	Additional   []Answer // additional section, without the OPT record
+	EDNS         *EDNS    // nil when the response carries no OPT record
+	EDE          []EDE    // RFC 8914 Extended DNS Errors
+}
+
+// Flags holds the DNS header flags of a response.
+type Flags struct {
+	Authoritative      bool `json:"aa"`
+	Truncated          bool `json:"tc"`
+	RecursionAvailable bool `json:"ra"`
+	AuthenticatedData  bool `json:"ad"` // DNSSEC-validated by the resolver
+	CheckingDisabled   bool `json:"cd"`
+}
+
+// EDNS holds the OPT pseudo-record of a response.
+type EDNS struct {
+	UDPSize  uint16 `json:"udp_size"`
+	DNSSECOK bool   `json:"do"`
+}
+
+// EDE is an RFC 8914 Extended DNS Error returned in the OPT record.
+type EDE struct {
+	Code uint16 `json:"code"`
+	Text string `json:"text,omitempty"` // optional EXTRA-TEXT
+}
+
+// Name returns the RFC 8914 name of the info code.
+func (e EDE) Name() string { return EDECodeName(e.Code) }
+
+// String renders the error as "EDE 17 (Filtered)", followed by ": <text>"
+// when EXTRA-TEXT is present.
+func (e EDE) String() string {
+	s := fmt.Sprintf("EDE %d (%s)", e.Code, e.Name())
+	if e.Text != "" {
+		s += ": " + e.Text
+	}
+	return s
 }
 
-// Answer holds a single DNS resource record from a DoH response.
+// MarshalJSON adds the info-code name to the JSON form.
+func (e EDE) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code uint16 `json:"code"`
+		Name string `json:"name"`
+		Text string `json:"text,omitempty"`
+	}{e.Code, e.Name(), e.Text})
+}
+
+// blockingEDECodes are the RFC 8914 info codes a resolver sets when it
+// refuses to answer by policy: Blocked, Censored, Filtered, and Prohibited.
+var blockingEDECodes = []uint16{15, 16, 17, 18}
+
+// BlockingEDE returns the first EDE in r that signals a policy block, if any.
+func (r *Response) BlockingEDE() (EDE, bool) {
+	for _, e := range r.EDE {
+		if slices.Contains(blockingEDECodes, e.Code) {
+			return e, true
+		}
+	}
+	return EDE{}, false
+}
+
+// rcodeNames are the presentation names of the common response codes.
+var rcodeNames = map[uint16]string{
+	dns.RcodeSuccess:        "NOERROR",
+	dns.RcodeFormatError:    "FORMERR",
+	dns.RcodeServerFailure:  "SERVFAIL",
+	dns.RcodeNameError:      "NXDOMAIN",
+	dns.RcodeNotImplemented: "NOTIMP",
+	dns.RcodeRefused:        "REFUSED",
+}
+
+// RcodeName returns the presentation name of a response code, e.g. "NXDOMAIN".
+func RcodeName(rcode uint16) string {
+	if name, ok := rcodeNames[rcode]; ok {
+		return name
+	}
+	return fmt.Sprintf("RCODE%d", rcode)
+}
+
+// edeNames are the RFC 8914 Extended DNS Error info-code names.
+var edeNames = map[uint16]string{
+	0:  "Other Error",
+	1:  "Unsupported DNSKEY Algorithm",
+	2:  "Unsupported DS Digest Type",
+	3:  "Stale Answer",
+	4:  "Forged Answer",
+	5:  "DNSSEC Indeterminate",
+	6:  "DNSSEC Bogus",
+	7:  "Signature Expired",
+	8:  "Signature Not Yet Valid",
+	9:  "DNSKEY Missing",
+	10: "RRSIGs Missing",
+	11: "No Zone Key Bit Set",
+	12: "NSEC Missing",
+	13: "Cached Error",
+	14: "Not Ready",
+	15: "Blocked",
+	16: "Censored",
+	17: "Filtered",
+	18: "Prohibited",
+	19: "Stale NXDOMAIN Answer",
+	20: "Not Authoritative",
+	21: "Not Supported",
+	22: "No Reachable Authority",
+	23: "Network Error",
+	24: "Invalid Data",
+}
+
+// EDECodeName returns the RFC 8914 name of an EDE info code, or "Unknown".
+func EDECodeName(code uint16) string {
+	if name, ok := edeNames[code]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// Answer holds a single DNS resource record from a DoH response. Data is the
+// presentation form of the record data; RData holds the same data as one of
+// the typed structs in this package (e.g. *MX, *SVCB) for record types with
+// more than one field, and is nil otherwise.
 type Answer struct {
-	Name string
-	Type uint16
-	TTL  int
-	Data string
+	Name  string
+	Type  uint16
+	TTL   int
+	Data  string
+	RData any
 }
 
 // buildDNSQuery encodes a DNS query for the given domain and record type into wire format.
@@ -44,6 +172,7 @@ func buildDNSQuery(domain string, recordType uint16) ([]byte, error) {
 	if m == nil {
 		return nil, fmt.Errorf("unknown DNS record type: %d", recordType)
 	}
+	m.UDPSize = ednsUDPSize
 	if err := m.Pack(); err != nil {
 		return nil, err
 	}
@@ -60,60 +189,141 @@ func parseDNSResponse(data []byte) (*Response, error) {
 	resp := &Response{
 		Status:       m.Rcode,
 		HasAuthority: len(m.Ns) > 0,
+		Flags: Flags{
+			Authoritative:      m.Authoritative,
+			Truncated:          m.Truncated,
+			RecursionAvailable: m.RecursionAvailable,
+			AuthenticatedData:  m.AuthenticatedData,
+			CheckingDisabled:   m.CheckingDisabled,
+		},
+		Answer:     toAnswers(m.Answer),
+		Authority:  toAnswers(m.Ns),
+		Additional: toAnswers(m.Extra),
 	}
-	for _, rr := range m.Answer {
-		ans := Answer{
-			Name: rr.Header().Name,
-			TTL:  int(rr.Header().TTL),
-		}
-		switch v := rr.(type) {
-		case *dns.A:
-			ans.Type = dns.TypeA
-			ans.Data = v.Addr.String()
-		case *dns.AAAA:
-			ans.Type = dns.TypeAAAA
-			ans.Data = v.Addr.String()
-		case *dns.NS:
-			ans.Type = dns.TypeNS
-			ans.Data = v.Ns
-		case *dns.MX:
-			ans.Type = dns.TypeMX
-			ans.Data = fmt.Sprintf("%d %s", v.Preference, v.Mx)
-		case *dns.CNAME:
-			ans.Type = dns.TypeCNAME
-			ans.Data = v.Target
-		case *dns.SOA:
-			ans.Type = dns.TypeSOA
-			ans.Data = fmt.Sprintf("%s %s %d %d %d %d %d", v.Ns, v.Mbox, v.Serial, v.Refresh, v.Retry, v.Expire, v.Minttl)
-		case *dns.SRV:
-			ans.Type = dns.TypeSRV
-			ans.Data = fmt.Sprintf("%d %d %d %s", v.Priority, v.Weight, v.Port, v.Target)
-		case *dns.TXT:
-			ans.Type = dns.TypeTXT
-			ans.Data = strings.Join(v.Txt, "")
-		case *dns.CAA:
-			ans.Type = dns.TypeCAA
-			ans.Data = fmt.Sprintf("%d %s %q", v.Flag, v.Tag, v.Value)
-		case *dns.DNSKEY:
-			ans.Type = dns.TypeDNSKEY
-			ans.Data = fmt.Sprintf("%d %d %d %s", v.Flags, v.Protocol, v.Algorithm, v.PublicKey)
-		case *dns.HTTPS:
-			ans.Type = dns.TypeHTTPS
-			ans.Data = fmt.Sprintf("%d %s", v.Priority, v.Target)
-		case *dns.SSHFP:
-			ans.Type = dns.TypeSSHFP
-			ans.Data = fmt.Sprintf("%d %d %s", v.Algorithm, v.Type, v.FingerPrint)
-		default:
-			continue
+	if m.UDPSize > 0 {
+		resp.EDNS = &EDNS{UDPSize: m.UDPSize, DNSSECOK: m.Security}
+	}
+	for _, rr := range m.Pseudo {
+		if e, ok := rr.(*dns.EDE); ok {
+			resp.EDE = append(resp.EDE, EDE{Code: e.InfoCode, Text: e.ExtraText})
 		}
-		resp.Answer = append(resp.Answer, ans)
 	}
 	return resp, nil
 }
 
-// MakeDoHRequest performs a DNS-over-HTTPS query using RFC 8484 wire format.
-// It encodes the DNS query as base64url and sends it as the "dns" query parameter.
+// toAnswers converts the supported records of a message section; other
+// record types are skipped.
+func toAnswers(rrs []dns.RR) []Answer {
+	var answers []Answer
+	for _, rr := range rrs {
+		if ans, ok := toAnswer(rr); ok {
+			answers = append(answers, ans)
+		}
+	}
+	return answers
+}
+
+// toAnswer converts a single resource record.
+func toAnswer(rr dns.RR) (Answer, bool) {
+	ans := Answer{
+		Name: rr.Header().Name,
+		TTL:  int(rr.Header().TTL),
+	}
+	switch v := rr.(type) {
+	case *dns.A:
+		ans.Type = dns.TypeA
+		ans.Data = v.Addr.String()
+	case *dns.AAAA:
+		ans.Type = dns.TypeAAAA
+		ans.Data = v.Addr.String()
+	case *dns.NS:
+		ans.Type = dns.TypeNS
+		ans.Data = v.Ns
+	case *dns.MX:
+		ans.Type = dns.TypeMX
+		ans.Data = fmt.Sprintf("%d %s", v.Preference, v.Mx)
+		ans.RData = &MX{Preference: v.Preference, Exchange: v.Mx}
+	case *dns.CNAME:
+		ans.Type = dns.TypeCNAME
+		ans.Data = v.Target
+	case *dns.SOA:
+		ans.Type = dns.TypeSOA
+		ans.Data = fmt.Sprintf("%s %s %d %d %d %d %d", v.Ns, v.Mbox, v.Serial, v.Refresh, v.Retry, v.Expire, v.Minttl)
+		ans.RData = &SOA{MName: v.Ns, RName: v.Mbox, Serial: v.Serial, Refresh: v.Refresh, Retry: v.Retry, Expire: v.Expire, Minimum: v.Minttl}
+	case *dns.SRV:
+		ans.Type = dns.TypeSRV
+		ans.Data = fmt.Sprintf("%d %d %d %s", v.Priority, v.Weight, v.Port, v.Target)
+		ans.RData = &SRV{Priority: v.Priority, Weight: v.Weight, Port: v.Port, Target: v.Target}
+	case *dns.TXT:
+		ans.Type = dns.TypeTXT
+		ans.Data = strings.Join(v.Txt, "")
+	case *dns.CAA:
+		ans.Type = dns.TypeCAA
+		ans.Data = fmt.Sprintf("%d %s %q", v.Flag, v.Tag, v.Value)
+		ans.RData = &CAA{Flag: v.Flag, Tag: v.Tag, Value: v.Value}
+	case *dns.DNSKEY:
+		ans.Type = dns.TypeDNSKEY
+		ans.Data = fmt.Sprintf("%d %d %d %s", v.Flags, v.Protocol, v.Algorithm, v.PublicKey)
+		ans.RData = &DNSKEY{Flags: v.Flags, Protocol: v.Protocol, Algorithm: v.Algorithm, PublicKey: v.PublicKey}
+	case *dns.CDNSKEY:
+		ans.Type = dns.TypeCDNSKEY
+		ans.Data = fmt.Sprintf("%d %d %d %s", v.Flags, v.Protocol, v.Algorithm, v.PublicKey)
+		ans.RData = &DNSKEY{Flags: v.Flags, Protocol: v.Protocol, Algorithm: v.Algorithm, PublicKey: v.PublicKey}
+	case *dns.DS:
+		ans.Type = dns.TypeDS
+		ans.Data = fmt.Sprintf("%d %d %d %s", v.KeyTag, v.Algorithm, v.DigestType, v.Digest)
+		ans.RData = &DS{KeyTag: v.KeyTag, Algorithm: v.Algorithm, DigestType: v.DigestType, Digest: v.Digest}
+	case *dns.CDS:
+		ans.Type = dns.TypeCDS
+		ans.Data = fmt.Sprintf("%d %d %d %s", v.KeyTag, v.Algorithm, v.DigestType, v.Digest)
+		ans.RData = &DS{KeyTag: v.KeyTag, Algorithm: v.Algorithm, DigestType: v.DigestType, Digest: v.Digest}
+	case *dns.SSHFP:
+		ans.Type = dns.TypeSSHFP
+		ans.Data = fmt.Sprintf("%d %d %s", v.Algorithm, v.Type, v.FingerPrint)
+		ans.RData = &SSHFP{Algorithm: v.Algorithm, Type: v.Type, Fingerprint: v.FingerPrint}
+	case *dns.TLSA:
+		ans.Type = dns.TypeTLSA
+		ans.Data = fmt.Sprintf("%d %d %d %s", v.Usage, v.Selector, v.MatchingType, v.Certificate)
+		ans.RData = &TLSA{Usage: v.Usage, Selector: v.Selector, MatchingType: v.MatchingType, Certificate: v.Certificate}
+	case *dns.NAPTR:
+		ans.Type = dns.TypeNAPTR
+		ans.Data = fmt.Sprintf("%d %d %q %q %q %s", v.Order, v.Preference, v.Flags, v.Service, v.Regexp, v.Replacement)
+		ans.RData = &NAPTR{Order: v.Order, Preference: v.Preference, Flags: v.Flags, Service: v.Service, Regexp: v.Regexp, Replacement: v.Replacement}
+	case *dns.SVCB:
+		svcb := newSVCB(v.SVCB)
+		ans.Type = dns.TypeSVCB
+		ans.Data = svcb.String()
+		ans.RData = svcb
+	case *dns.HTTPS:
+		svcb := newSVCB(v.SVCB.SVCB)
+		ans.Type = dns.TypeHTTPS
+		ans.Data = svcb.String()
+		ans.RData = svcb
+	default:
+		return Answer{}, false
+	}
+	return ans, true
+}
+
+// MakeDoHRequest performs a DNS-over-HTTPS query against Quad9 using RFC 8484
+// wire format. It encodes the DNS query as base64url and sends it as the "dns"
+// query parameter.
 func MakeDoHRequest(ctx context.Context, client *req.Client, domain string, recordType uint16) (*Response, error) {
+	return makeRequest(ctx, client, dohURL, "quad9", domain, recordType)
+}
+
+// MakeDoHRequestURL performs an RFC 8484 DNS-over-HTTPS query against the
+// given endpoint, e.g. "https://security.cloudflare-dns.com/dns-query".
+func MakeDoHRequestURL(ctx context.Context, client *req.Client, endpoint, domain string, recordType uint16) (*Response, error) {
+	label := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		label = u.Host
+	}
+	return makeRequest(ctx, client, endpoint, label, domain, recordType)
+}
+
+// makeRequest sends the query to endpoint; label names the resolver in errors.
+func makeRequest(ctx context.Context, client *req.Client, endpoint, label, domain string, recordType uint16) (*Response, error) {
 	query, err := buildDNSQuery(domain, recordType)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to build DNS query for %q type %d: %v", apperr.ErrRequestFailed, domain, recordType, err)
@@ -124,19 +334,19 @@ func MakeDoHRequest(ctx context.Context, client *req.Client, domain string, reco
 		SetContext(ctx).
 		SetHeader("Accept", "application/dns-message").
 		SetQueryParam("dns", encoded).
-		Get(dohURL)
+		Get(endpoint)
 	if err != nil {
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			return nil, err
 		}
-		return nil, fmt.Errorf("%w: quad9 request error for %q type %d: %v", apperr.ErrRequestFailed, domain, recordType, err)
+		return nil, fmt.Errorf("%w: %s request error for %q type %d: %v", apperr.ErrRequestFailed, label, domain, recordType, err)
 	}
 	if !httpResp.IsSuccessState() {
 		body := httpResp.String()
 		if len(body) > 200 {
 			body = body[:200] + "..."
 		}
-		return nil, fmt.Errorf("%w: quad9 returned HTTP %d for %q type %d: %q", apperr.ErrRequestFailed, httpResp.StatusCode, domain, recordType, body)
+		return nil, fmt.Errorf("%w: %s returned HTTP %d for %q type %d: %q", apperr.ErrRequestFailed, label, httpResp.StatusCode, domain, recordType, body)
 	}
 	return parseDNSResponse(httpResp.Bytes())
 }
@@ -1,11 +1,13 @@
 package doh
 
 import (
+	"encoding/binary"
 	"net/netip"
 	"testing"
 
 	"codeberg.org/miekg/dns"
 	"codeberg.org/miekg/dns/rdata"
+	"codeberg.org/miekg/dns/svcb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -90,3 +92,135 @@ func TestParseDNSResponse_EmptyResponse(t *testing.T) {
 	assert.False(t, resp.HasAuthority)
 	assert.Empty(t, resp.Answer)
 }
+
+func TestParseDNSResponse_EDE(t *testing.T) {
+	m := new(dns.Msg)
+	m.Rcode = dns.RcodeNameError
+	m.Response = true
+	m.Pseudo = []dns.RR{&dns.EDE{InfoCode: 17}}
+	require.NoError(t, m.Pack())
+
+	// The library packs EDE without its extra text, so append it by hand: the
+	// EDE option is the last thing in the message, and both the OPT RDLENGTH
+	// and the option length must grow by the text length.
+	const text = "blocked by policy"
+	wire := m.Data
+	n := len(wire)
+	binary.BigEndian.PutUint16(wire[n-8:], binary.BigEndian.Uint16(wire[n-8:])+uint16(len(text)))
+	binary.BigEndian.PutUint16(wire[n-4:], binary.BigEndian.Uint16(wire[n-4:])+uint16(len(text)))
+	wire = append(wire, text...)
+
+	resp, err := parseDNSResponse(wire)
+	require.NoError(t, err)
+	assert.Equal(t, []EDE{{Code: 17, Text: "blocked by policy"}}, resp.EDE)
+}
+
+func TestBuildDNSQuery_AdvertisesEDNS(t *testing.T) {
+	data, err := buildDNSQuery("example.com", dns.TypeA)
+	require.NoError(t, err)
+	m := new(dns.Msg)
+	m.Data = data
+	require.NoError(t, m.Unpack())
+	assert.Equal(t, uint16(ednsUDPSize), m.UDPSize)
+}
+
+func TestParseDNSResponse_Metadata(t *testing.T) {
+	soaRR := &dns.SOA{Hdr: dns.Header{Name: "example.", Class: dns.ClassINET, TTL: 60}, SOA: rdata.SOA{Ns: "ns1.example.", Mbox: "hostmaster.example."}}
+	nsRR := &dns.NS{Hdr: dns.Header{Name: "example.", Class: dns.ClassINET, TTL: 60}, NS: rdata.NS{Ns: "ns1.example."}}
+	m := new(dns.Msg)
+	m.Response = true
+	m.AuthenticatedData = true
+	m.Truncated = true
+	m.RecursionAvailable = true
+	m.Ns = []dns.RR{soaRR}
+	m.Extra = []dns.RR{nsRR}
+	m.UDPSize = 1232
+	m.Security = true
+	require.NoError(t, m.Pack())
+
+	resp, err := parseDNSResponse(m.Data)
+	require.NoError(t, err)
+	assert.Equal(t, Flags{Truncated: true, RecursionAvailable: true, AuthenticatedData: true}, resp.Flags)
+	require.Len(t, resp.Authority, 1)
+	assert.Equal(t, uint16(dns.TypeSOA), resp.Authority[0].Type)
+	require.Len(t, resp.Additional, 1)
+	assert.Equal(t, "ns1.example.", resp.Additional[0].Data)
+	require.NotNil(t, resp.EDNS)
+	assert.Equal(t, EDNS{UDPSize: 1232, DNSSECOK: true}, *resp.EDNS)
+}
+
+func TestParseDNSResponse_NoOPT(t *testing.T) {
+	resp, err := parseDNSResponse(buildWire(t, dns.RcodeSuccess, nil, nil))
+	require.NoError(t, err)
+	assert.Nil(t, resp.EDNS)
+	assert.Empty(t, resp.EDE)
+}
+
+func TestEDE(t *testing.T) {
+	e := EDE{Code: 17, Text: "policy"}
+	assert.Equal(t, "Filtered", e.Name())
+	assert.Equal(t, "EDE 17 (Filtered): policy", e.String())
+	assert.Equal(t, "EDE 99 (Unknown)", EDE{Code: 99}.String())
+
+	resp := &Response{EDE: []EDE{{Code: 3}, e}}
+	got, ok := resp.BlockingEDE()
+	require.True(t, ok)
+	assert.Equal(t, e, got)
+	_, ok = (&Response{EDE: []EDE{{Code: 3}}}).BlockingEDE()
+	assert.False(t, ok)
+}
+
+func TestRcodeName(t *testing.T) {
+	assert.Equal(t, "NXDOMAIN", RcodeName(dns.RcodeNameError))
+	assert.Equal(t, "RCODE11", RcodeName(11))
+}
+
+func TestParseDNSResponse_TypedRData(t *testing.T) {
+	h := dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}
+	rrs := []dns.RR{
+		&dns.MX{Hdr: h, MX: rdata.MX{Preference: 10, Mx: "mail.example.com."}},
+		&dns.SRV{Hdr: h, SRV: rdata.SRV{Priority: 10, Weight: 5, Port: 5061, Target: "sip.example.com."}},
+		&dns.CAA{Hdr: h, CAA: rdata.CAA{Flag: 0, Tag: "issue", Value: "letsencrypt.org"}},
+		&dns.CDS{DS: dns.DS{Hdr: h, DS: rdata.DS{KeyTag: 370, Algorithm: 13, DigestType: 2, Digest: "be74"}}},
+		&dns.CDNSKEY{DNSKEY: dns.DNSKEY{Hdr: h, DNSKEY: rdata.DNSKEY{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "AQID"}}},
+		&dns.TLSA{Hdr: h, TLSA: rdata.TLSA{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "abcd"}},
+		&dns.NAPTR{Hdr: h, NAPTR: rdata.NAPTR{Order: 100, Preference: 10, Flags: "S", Service: "SIP+D2T", Replacement: "_sip._tcp.example.com."}},
+		&dns.SVCB{Hdr: h, SVCB: rdata.SVCB{Priority: 1, Target: "svc.example.com.", Value: []svcb.Pair{
+			&svcb.ALPN{Alpn: []string{"h3"}},
+			&svcb.NODEFAULTALPN{},
+			&svcb.PORT{Port: 8443},
+			&svcb.LOCAL{KeyCode: 65500, Data: []byte("x")},
+			&svcb.LOCAL{KeyCode: 65501, Data: []byte("a b")},
+		}}},
+	}
+	resp, err := parseDNSResponse(buildWire(t, dns.RcodeSuccess, rrs, nil))
+	require.NoError(t, err)
+	require.Len(t, resp.Answer, len(rrs), "every record type is converted")
+
+	want := []struct {
+		data  string
+		rdata any
+	}{
+		{"10 mail.example.com.", &MX{Preference: 10, Exchange: "mail.example.com."}},
+		{"10 5 5061 sip.example.com.", &SRV{Priority: 10, Weight: 5, Port: 5061, Target: "sip.example.com."}},
+		{`0 issue "letsencrypt.org"`, &CAA{Tag: "issue", Value: "letsencrypt.org"}},
+		{"370 13 2 be74", &DS{KeyTag: 370, Algorithm: 13, DigestType: 2, Digest: "be74"}},
+		{"257 3 13 AQID", &DNSKEY{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "AQID"}},
+		{"3 1 1 abcd", &TLSA{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "abcd"}},
+		{`100 10 "S" "SIP+D2T" "" _sip._tcp.example.com.`, &NAPTR{Order: 100, Preference: 10, Flags: "S", Service: "SIP+D2T", Replacement: "_sip._tcp.example.com."}},
+		{`1 svc.example.com. alpn=h3 no-default-alpn port=8443 key65500=x key65501="a b"`, &SVCB{Priority: 1, Target: "svc.example.com.", ALPN: []string{"h3"}, NoDefaultALPN: true, Port: 8443, Params: map[string]string{"key65500": "x", "key65501": "a b"}}},
+	}
+	for i, w := range want {
+		assert.Equal(t, w.data, resp.Answer[i].Data)
+		assert.Equal(t, w.rdata, resp.Answer[i].RData)
+	}
+}
+
+func TestParseDNSResponse_NoRDataForSingleField(t *testing.T) {
+	aRR := &dns.A{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}}
+	aRR.Addr = netip.MustParseAddr("192.0.2.1")
+	resp, err := parseDNSResponse(buildWire(t, dns.RcodeSuccess, []dns.RR{aRR}, nil))
+	require.NoError(t, err)
+	require.Len(t, resp.Answer, 1)
+	assert.Nil(t, resp.Answer[0].RData)
+}
@@ -0,0 +1,210 @@
+package doh
+
+import (
+	"encoding/base64"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeberg.org/miekg/dns/rdata"
+	"codeberg.org/miekg/dns/svcb"
+)
+
+// Typed record data, set in Answer.RData for record types whose presentation
+// form packs several fields into one string. JSON consumers read these
+// instead of re-parsing Answer.Data.
+
+// MX holds the data of an MX record.
+type MX struct {
+	Preference uint16 `json:"preference"`
+	Exchange   string `json:"exchange"`
+}
+
+// SRV holds the data of an SRV record.
+type SRV struct {
+	Priority uint16 `json:"priority"`
+	Weight   uint16 `json:"weight"`
+	Port     uint16 `json:"port"`
+	Target   string `json:"target"`
+}
+
+// CAA holds the data of a CAA record.
+type CAA struct {
+	Flag  uint8  `json:"flag"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// SOA holds the data of a SOA record.
+type SOA struct {
+	MName   string `json:"mname"`
+	RName   string `json:"rname"`
+	Serial  uint32 `json:"serial"`
+	Refresh uint32 `json:"refresh"`
+	Retry   uint32 `json:"retry"`
+	Expire  uint32 `json:"expire"`
+	Minimum uint32 `json:"minimum"`
+}
+
+// DNSKEY holds the data of a DNSKEY or CDNSKEY record.
+type DNSKEY struct {
+	Flags     uint16 `json:"flags"`
+	Protocol  uint8  `json:"protocol"`
+	Algorithm uint8  `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+}
+
+// DS holds the data of a DS or CDS record.
+type DS struct {
+	KeyTag     uint16 `json:"key_tag"`
+	Algorithm  uint8  `json:"algorithm"`
+	DigestType uint8  `json:"digest_type"`
+	Digest     string `json:"digest"`
+}
+
+// SSHFP holds the data of an SSHFP record.
+type SSHFP struct {
+	Algorithm   uint8  `json:"algorithm"`
+	Type        uint8  `json:"type"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// TLSA holds the data of a TLSA record.
+type TLSA struct {
+	Usage        uint8  `json:"usage"`
+	Selector     uint8  `json:"selector"`
+	MatchingType uint8  `json:"matching_type"`
+	Certificate  string `json:"certificate"`
+}
+
+// NAPTR holds the data of a NAPTR record.
+type NAPTR struct {
+	Order       uint16 `json:"order"`
+	Preference  uint16 `json:"preference"`
+	Flags       string `json:"flags"`
+	Service     string `json:"service"`
+	Regexp      string `json:"regexp"`
+	Replacement string `json:"replacement"`
+}
+
+// SVCB holds the data of an SVCB or HTTPS record (RFC 9460). Priority 0 marks
+// AliasMode; service parameters other than the well-known ones are kept in
+// Params, keyed by their presentation name (e.g. "key65500").
+type SVCB struct {
+	Priority      uint16            `json:"priority"`
+	Target        string            `json:"target"`
+	Mandatory     []string          `json:"mandatory,omitempty"`
+	ALPN          []string          `json:"alpn,omitempty"`
+	NoDefaultALPN bool              `json:"no_default_alpn,omitempty"`
+	Port          uint16            `json:"port,omitempty"`
+	IPv4Hint      []string          `json:"ipv4hint,omitempty"`
+	ECH           string            `json:"ech,omitempty"`
+	IPv6Hint      []string          `json:"ipv6hint,omitempty"`
+	Params        map[string]string `json:"params,omitempty"`
+}
+
+// newSVCB builds an SVCB from the typed record data, reading the SvcParams
+// from its key/value pairs.
+func newSVCB(r rdata.SVCB) *SVCB {
+	s := &SVCB{Priority: r.Priority, Target: r.Target}
+	for _, pair := range r.Value {
+		switch v := pair.(type) {
+		case nil:
+			continue
+		case *svcb.MANDATORY:
+			for _, k := range v.Key {
+				s.Mandatory = append(s.Mandatory, svcb.KeyToString(k))
+			}
+		case *svcb.ALPN:
+			s.ALPN = slices.Clone(v.Alpn)
+		case *svcb.NODEFAULTALPN:
+			s.NoDefaultALPN = true
+		case *svcb.PORT:
+			s.Port = v.Port
+		case *svcb.IPV4HINT:
+			s.IPv4Hint = addrStrings(v.Hint)
+		case *svcb.ECHCONFIG:
+			s.ECH = base64.StdEncoding.EncodeToString(v.ECH)
+		case *svcb.IPV6HINT:
+			s.IPv6Hint = addrStrings(v.Hint)
+		default:
+			if s.Params == nil {
+				s.Params = make(map[string]string)
+			}
+			s.Params[svcb.KeyToString(svcb.PairToKey(pair))] = paramValue(pair)
+		}
+	}
+	return s
+}
+
+// paramValue returns the value of a SvcParam without a dedicated SVCB field.
+// Opaque values are kept as-is; others use the library's value form.
+func paramValue(pair svcb.Pair) string {
+	switch v := pair.(type) {
+	case *svcb.LOCAL:
+		return string(v.Data)
+	case *svcb.DOHPATH:
+		return v.Template
+	default:
+		return pair.String()
+	}
+}
+
+// addrStrings formats addrs as strings.
+func addrStrings(addrs []netip.Addr) []string {
+	out := make([]string, len(addrs))
+	for i, a := range addrs {
+		out[i] = a.String()
+	}
+	return out
+}
+
+// String renders the record in presentation form, e.g.
+// "1 . alpn=h2,h3 ipv4hint=192.0.2.1".
+func (s *SVCB) String() string {
+	parts := []string{strconv.Itoa(int(s.Priority)), s.Target}
+	if len(s.Mandatory) > 0 {
+		parts = append(parts, "mandatory="+strings.Join(s.Mandatory, ","))
+	}
+	if len(s.ALPN) > 0 {
+		parts = append(parts, "alpn="+strings.Join(s.ALPN, ","))
+	}
+	if s.NoDefaultALPN {
+		parts = append(parts, "no-default-alpn")
+	}
+	if s.Port != 0 {
+		parts = append(parts, "port="+strconv.Itoa(int(s.Port)))
+	}
+	if len(s.IPv4Hint) > 0 {
+		parts = append(parts, "ipv4hint="+strings.Join(s.IPv4Hint, ","))
+	}
+	if s.ECH != "" {
+		parts = append(parts, "ech="+s.ECH)
+	}
+	if len(s.IPv6Hint) > 0 {
+		parts = append(parts, "ipv6hint="+strings.Join(s.IPv6Hint, ","))
+	}
+	keys := make([]string, 0, len(s.Params))
+	for k := range s.Params {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		if v := s.Params[k]; v != "" {
+			parts = append(parts, k+"="+presentValue(v))
+		} else {
+			parts = append(parts, k)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// presentValue quotes a SvcParam value that contains whitespace, quotes, or
+// non-printable characters, as in zone-file presentation form.
+func presentValue(v string) string {
+	if strings.IndexFunc(v, func(r rune) bool { return r <= ' ' || r == '"' || r == '\\' || r > '~' }) < 0 {
+		return v
+	}
+	return strconv.Quote(v)
+}
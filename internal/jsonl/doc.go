@@ -0,0 +1,3 @@
+// Package jsonl appends JSON values to and reads them back from JSON Lines
+// files, one value per line. It backs the snapshot and case stores.
+package jsonl
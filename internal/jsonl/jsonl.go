@@ -0,0 +1,60 @@
+package jsonl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// maxLineSize bounds a single line; large crt.sh results can run to several
+// megabytes.
+const maxLineSize = 64 << 20
+
+// Append encodes v as one line at the end of the file at path, creating the
+// file with 0600 permissions when it does not exist.
+func Append(path string, v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding line: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read decodes every line of the file at path in file order. Lines that cannot
+// be decoded, or that valid rejects, are skipped so one truncated or
+// hand-edited line does not lose the rest. A missing file yields no values.
+func Read[T any](path string, valid func(T) bool) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var values []T
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for sc.Scan() {
+		var v T
+		if err := json.Unmarshal(sc.Bytes(), &v); err != nil || !valid(v) {
+			continue
+		}
+		values = append(values, v)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
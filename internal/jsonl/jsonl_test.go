@@ -0,0 +1,52 @@
+package jsonl_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/jsonl"
+)
+
+type line struct {
+	N int `json:"n"`
+}
+
+func positive(l line) bool { return l.N > 0 }
+
+func TestAppendRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lines.jsonl")
+	require.NoError(t, jsonl.Append(path, line{N: 1}))
+	require.NoError(t, jsonl.Append(path, line{N: 2}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	got, err := jsonl.Read(path, positive)
+	require.NoError(t, err)
+	assert.Equal(t, []line{{N: 1}, {N: 2}}, got)
+}
+
+func TestRead_SkipsCorruptAndInvalidLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lines.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{\"n\":1}\n{\"n\":\n{\"n\":0}\n{\"n\":3}\n"), 0o600))
+
+	got, err := jsonl.Read(path, positive)
+	require.NoError(t, err)
+	assert.Equal(t, []line{{N: 1}, {N: 3}}, got)
+}
+
+func TestRead_MissingFile(t *testing.T) {
+	got, err := jsonl.Read(filepath.Join(t.TempDir(), "missing.jsonl"), positive)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestAppend_UnencodableValue(t *testing.T) {
+	err := jsonl.Append(filepath.Join(t.TempDir(), "lines.jsonl"), func() {})
+	require.Error(t, err)
+}
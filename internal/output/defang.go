@@ -74,6 +74,8 @@ func DefangURL(s string) string {
 //     including JSON.
 //   - PAP=AMBER or PAP=RED without --no-defang: returns true for text/plain
 //     formats; JSON stays raw because downstream consumers want unmodified data.
+//   - Report formats (markdown, html) without --no-defang: always returns true;
+//     reports go to people who may click what they read.
 //   - Default (PAP=WHITE, no flags): returns false.
 //
 // noDefang and explicitDefang are mutually exclusive; callers must validate
@@ -83,7 +85,19 @@ func ResolveDefang(papLevel pap.Level, format Format, explicitDefang, noDefang b
 		return false
 	}
 	isPAPTriggered := papLevel == pap.AMBER || papLevel == pap.RED
-	return explicitDefang || (isPAPTriggered && format != FormatJSON)
+	return explicitDefang || format.IsReport() || (isPAPTriggered && format != FormatJSON)
+}
+
+// DefangText applies the DefangWriter transforms to s: http/https schemes
+// become hxxp/hxxps and every dot becomes [.].
+func DefangText(s string) string {
+	// Defang scheme prefixes
+	s = defangSchemeRe.ReplaceAllStringFunc(s, func(match string) string {
+		lower := strings.ToLower(match)
+		return strings.Replace(lower, "http", "hxxp", 1)
+	})
+	// Defang dots
+	return defangDotRe.ReplaceAllString(s, "[.]")
 }
 
 // DefangWriter wraps an io.Writer and applies defanging transforms on every Write call.
@@ -94,15 +108,7 @@ type DefangWriter struct {
 
 // Write implements io.Writer; it defangs p before forwarding to the inner writer.
 func (d *DefangWriter) Write(p []byte) (n int, err error) {
-	s := string(p)
-	// Defang scheme prefixes
-	s = defangSchemeRe.ReplaceAllStringFunc(s, func(match string) string {
-		lower := strings.ToLower(match)
-		return strings.Replace(lower, "http", "hxxp", 1)
-	})
-	// Defang dots
-	s = defangDotRe.ReplaceAllString(s, "[.]")
-	written, err := d.Inner.Write([]byte(s))
+	written, err := d.Inner.Write([]byte(DefangText(string(p))))
 	// Return the original length so callers don't think a short write occurred due to expansion.
 	if err != nil {
 		return written, err
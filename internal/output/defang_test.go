@@ -80,6 +80,10 @@ func TestDefangWriter(t *testing.T) {
 	assert.Contains(t, out, "1[.]2[.]3[.]4")
 }
 
+func TestDefangText(t *testing.T) {
+	assert.Equal(t, "hxxps://www[.]example[.]com/a and 10[.]0[.]0[.]1", output.DefangText("HTTPS://www.example.com/a and 10.0.0.1"))
+}
+
 func TestDefangWriter_ReturnsOriginalLength(t *testing.T) {
 	var buf bytes.Buffer
 	w := &output.DefangWriter{Inner: &buf}
@@ -155,6 +159,22 @@ func TestResolveDefang(t *testing.T) {
 			noDefang:       false,
 			want:           false,
 		},
+		{
+			name:           "PAP=white, markdown defangs by default",
+			papLevel:       pap.WHITE,
+			format:         output.FormatMarkdown,
+			explicitDefang: false,
+			noDefang:       false,
+			want:           true,
+		},
+		{
+			name:           "no-defang suppresses html default",
+			papLevel:       pap.WHITE,
+			format:         output.FormatHTML,
+			explicitDefang: false,
+			noDefang:       true,
+			want:           false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,2 +1,3 @@
-// Package output provides formatters for rendering service results as text tables or JSON.
+// Package output provides formatters for rendering service results as text tables, JSON, plain
+// text, or Markdown and HTML reports.
 package output
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 )
 
 // Format is the output format requested by the user.
@@ -14,8 +15,19 @@ const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
 	FormatText  Format = "text"
+
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
 )
 
+// Formats lists every output format in documentation order.
+var Formats = []Format{FormatTable, FormatJSON, FormatText, FormatMarkdown, FormatHTML}
+
+// IsReport reports whether f is a report format (markdown or html).
+func (f Format) IsReport() bool {
+	return f == FormatMarkdown || f == FormatHTML
+}
+
 // TableFormattable results know how to render themselves as an ASCII table.
 type TableFormattable interface {
 	WriteTable(w io.Writer) error
@@ -29,7 +41,8 @@ type TextFormattable interface {
 
 // Write dispatches a service result to the appropriate formatter.
 // JSON uses json.Encoder with indentation. Table requires the result to implement TableFormattable.
-// Text requires the result to implement TextFormattable.
+// Text requires the result to implement TextFormattable. Markdown and HTML render a *Report, or
+// wrap any other result in an untitled one.
 func Write(w io.Writer, format Format, result any) error {
 	switch format {
 	case FormatJSON:
@@ -48,6 +61,15 @@ func Write(w io.Writer, format Format, result any) error {
 			return fmt.Errorf("result type %T does not support text output", result)
 		}
 		return pf.WriteText(w)
+	case FormatMarkdown, FormatHTML:
+		r, ok := result.(*Report)
+		if !ok {
+			r = &Report{Generated: time.Now(), Entries: []ReportEntry{{Result: result}}}
+		}
+		if format == FormatHTML {
+			return r.WriteHTML(w)
+		}
+		return r.WriteMarkdown(w)
 	default:
 		return fmt.Errorf("unsupported output format: %q", format)
 	}
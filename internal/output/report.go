@@ -0,0 +1,292 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// Section is one titled part of a report: summary facts, a table, and a
+// preformatted block, each optional.
+type Section struct {
+	Title   string
+	Facts   []Fact     // label/value lines shown before the table
+	Headers []string   // table header; no table when Rows is empty
+	Rows    [][]string // table rows
+	Text    string     // preformatted text shown after the table
+}
+
+// Fact is one labelled summary value.
+type Fact struct {
+	Label string
+	Value string
+}
+
+// ReportFormattable results know how to describe themselves as report
+// sections for the markdown and html formats. Results without sections are
+// rendered as their table (or text) output in a preformatted block.
+type ReportFormattable interface {
+	ReportSections() []Section
+}
+
+// ReportEntry is the result for one input of a report.
+type ReportEntry struct {
+	Input  string
+	Result any
+}
+
+// Report is a titled document with one part per input, rendered by the
+// markdown and html formats.
+type Report struct {
+	Title     string
+	PAP       string // PAP level shown in the banner ("red" … "white"); empty = no banner
+	Generated time.Time
+	Defang    bool // defang indicators in every value
+	Entries   []ReportEntry
+}
+
+// papBanners are the FIRST PAP definitions shown in the report banner.
+var papBanners = map[string]string{
+	"red":   "Non-detectable actions only. Do not use this information on the network; only passive checks on logs that are not detectable from the outside.",
+	"amber": "Passive cross-checks only, such as third-party services or a monitoring honeypot. No direct interaction with the target.",
+	"green": "Active actions allowed, such as pinging the target or blocking traffic to and from it.",
+	"white": "No restrictions on using this information.",
+}
+
+// reportPart is an entry prepared for rendering: its sections with defanging
+// applied.
+type reportPart struct {
+	Input    string
+	Sections []Section
+}
+
+// parts renders every entry into sections, defanging values when asked.
+func (r *Report) parts() ([]reportPart, error) {
+	parts := make([]reportPart, 0, len(r.Entries))
+	for _, e := range r.Entries {
+		sections, err := reportSections(e.Result)
+		if err != nil {
+			return nil, err
+		}
+		input := e.Input
+		if r.Defang {
+			input = DefangText(input)
+			for i := range sections {
+				sections[i] = defangSection(sections[i])
+			}
+		}
+		parts = append(parts, reportPart{Input: input, Sections: sections})
+	}
+	return parts, nil
+}
+
+// reportSections returns the sections of result, falling back to its table,
+// text, or JSON output as a single preformatted section.
+func reportSections(result any) ([]Section, error) {
+	if rf, ok := result.(ReportFormattable); ok {
+		return rf.ReportSections(), nil
+	}
+	var buf bytes.Buffer
+	var err error
+	switch v := result.(type) {
+	case TableFormattable:
+		err = v.WriteTable(&buf)
+	case TextFormattable:
+		err = v.WriteText(&buf)
+	default:
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []Section{{Title: "Results", Text: strings.TrimRight(buf.String(), "\n")}}, nil
+}
+
+// defangSection returns s with every fact value, cell, and text defanged.
+func defangSection(s Section) Section {
+	out := Section{Title: s.Title, Headers: s.Headers, Text: DefangText(s.Text)}
+	for _, f := range s.Facts {
+		out.Facts = append(out.Facts, Fact{Label: f.Label, Value: DefangText(f.Value)})
+	}
+	for _, row := range s.Rows {
+		cells := make([]string, len(row))
+		for i, c := range row {
+			cells[i] = DefangText(c)
+		}
+		out.Rows = append(out.Rows, cells)
+	}
+	return out
+}
+
+// title returns the report title, defaulting to "trident report".
+func (r *Report) title() string {
+	if r.Title != "" {
+		return r.Title
+	}
+	return "trident report"
+}
+
+// WriteMarkdown renders the report as Markdown: the title, the PAP banner as
+// a block quote, the generation time, then per input a heading and one
+// sub-heading per section.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	parts, err := r.parts()
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", EscapeMarkdown(r.title()))
+	if banner, ok := papBanners[r.PAP]; ok {
+		fmt.Fprintf(&b, "> **PAP:%s** — %s\n\n", strings.ToUpper(r.PAP), banner)
+	}
+	fmt.Fprintf(&b, "_Generated %s_\n", r.Generated.UTC().Format(time.RFC3339))
+	for _, p := range parts {
+		if p.Input != "" {
+			fmt.Fprintf(&b, "\n## %s\n", EscapeMarkdown(p.Input))
+		}
+		for _, s := range p.Sections {
+			fmt.Fprintf(&b, "\n### %s\n", EscapeMarkdown(s.Title))
+			if len(s.Facts) > 0 {
+				b.WriteString("\n")
+				for _, f := range s.Facts {
+					fmt.Fprintf(&b, "- **%s:** %s\n", EscapeMarkdown(f.Label), EscapeMarkdown(f.Value))
+				}
+			}
+			if len(s.Rows) > 0 {
+				b.WriteString("\n|")
+				for _, h := range s.Headers {
+					fmt.Fprintf(&b, " %s |", EscapeMarkdown(h))
+				}
+				b.WriteString("\n|")
+				for range s.Headers {
+					b.WriteString(" --- |")
+				}
+				b.WriteString("\n")
+				for _, row := range s.Rows {
+					b.WriteString("|")
+					for _, c := range row {
+						fmt.Fprintf(&b, " %s |", EscapeMarkdown(c))
+					}
+					b.WriteString("\n")
+				}
+			}
+			if s.Text != "" {
+				fence := "```"
+				for strings.Contains(s.Text, fence) {
+					fence += "`"
+				}
+				fmt.Fprintf(&b, "\n%s\n%s\n%s\n", fence, s.Text, fence)
+			}
+		}
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// EscapeMarkdown escapes the characters that would break a Markdown table
+// cell or start inline markup, and folds newlines into spaces.
+func EscapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\", "|", "\\|", "*", "\\*", "_", "\\_", "`", "\\`",
+	"<", "&lt;", ">", "&gt;", "[", "\\[", "]", "\\]", "\r", "", "\n", " ",
+)
+
+// WriteHTML renders the report as a single self-contained HTML document with
+// inline styles and no external assets.
+func (r *Report) WriteHTML(w io.Writer) error {
+	parts, err := r.parts()
+	if err != nil {
+		return err
+	}
+	data := struct {
+		Title     string
+		PAP       string
+		Banner    string
+		Generated string
+		Parts     []reportPart
+	}{
+		Title:     r.title(),
+		PAP:       r.PAP,
+		Banner:    papBanners[r.PAP],
+		Generated: r.Generated.UTC().Format(time.RFC3339),
+		Parts:     parts,
+	}
+	return htmlReport.Execute(w, data)
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"upper": strings.ToUpper,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body{font-family:-apple-system,"Segoe UI",Roboto,Helvetica,Arial,sans-serif;color:#1f2328;margin:2rem auto;max-width:72rem;padding:0 1rem;line-height:1.5}
+h1{margin-bottom:.25rem}
+h2{border-bottom:1px solid #d0d7de;padding-bottom:.25rem;margin-top:2rem}
+.meta{color:#59636e;margin-top:0}
+.pap{border-radius:6px;padding:.75rem 1rem;margin:1rem 0;border:1px solid #d0d7de}
+.pap-red{background:#ff2b2b;color:#fff;border-color:#ff2b2b}
+.pap-amber{background:#ffc000;color:#000;border-color:#ffc000}
+.pap-green{background:#33ff00;color:#000;border-color:#33ff00}
+.pap-white{background:#fff;color:#000}
+dl{display:grid;grid-template-columns:max-content auto;gap:.25rem 1rem}
+dt{font-weight:600}
+dd{margin:0;word-break:break-word}
+table{border-collapse:collapse;width:100%;margin:.5rem 0 1rem}
+th,td{border:1px solid #d0d7de;padding:.35rem .6rem;text-align:left;vertical-align:top;word-break:break-word}
+th{background:#f6f8fa}
+tr:nth-child(even) td{background:#fafbfc}
+pre{background:#f6f8fa;border:1px solid #d0d7de;border-radius:6px;padding:.75rem;overflow-x:auto;font-size:.85rem}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Banner}}
+<div class="pap pap-{{.PAP}}"><strong>PAP:{{upper .PAP}}</strong> — {{.Banner}}</div>
+{{- end}}
+<p class="meta">Generated {{.Generated}}</p>
+{{- range .Parts}}
+<section>
+{{- if .Input}}
+<h2>{{.Input}}</h2>
+{{- end}}
+{{- range .Sections}}
+<h3>{{.Title}}</h3>
+{{- if .Facts}}
+<dl>
+{{- range .Facts}}
+<dt>{{.Label}}</dt><dd>{{.Value}}</dd>
+{{- end}}
+</dl>
+{{- end}}
+{{- if .Rows}}
+<table>
+<thead><tr>{{range .Headers}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Text}}
+<pre>{{.Text}}</pre>
+{{- end}}
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
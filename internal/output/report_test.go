@@ -0,0 +1,99 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+type sectionedResult struct{}
+
+func (sectionedResult) ReportSections() []output.Section {
+	return []output.Section{
+		{Title: "Summary", Facts: []output.Fact{{Label: "Mail", Value: "mx.example.com"}}},
+		{Title: "A records", Headers: []string{"Host", "Value"}, Rows: [][]string{{"example.com", "93.184.216.34"}}},
+		{Title: "Raw", Text: "https://example.com/<x>"},
+	}
+}
+
+func TestReport_WriteMarkdown(t *testing.T) {
+	report := &output.Report{
+		Title:     "trident dns",
+		PAP:       "amber",
+		Generated: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Defang:    true,
+		Entries:   []output.ReportEntry{{Input: "example.com", Result: sectionedResult{}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteMarkdown(&buf))
+	assert.Equal(t, "# trident dns\n\n"+
+		"> **PAP:AMBER** — Passive cross-checks only, such as third-party services or a monitoring honeypot. No direct interaction with the target.\n\n"+
+		"_Generated 2025-03-01T12:00:00Z_\n"+
+		"\n## example\\[.\\]com\n"+
+		"\n### Summary\n\n- **Mail:** mx\\[.\\]example\\[.\\]com\n"+
+		"\n### A records\n\n| Host | Value |\n| --- | --- |\n| example\\[.\\]com | 93\\[.\\]184\\[.\\]216\\[.\\]34 |\n"+
+		"\n### Raw\n\n```\nhxxps://example[.]com/<x>\n```\n", buf.String())
+}
+
+func TestReport_WriteHTML(t *testing.T) {
+	report := &output.Report{
+		Title:     "trident dns",
+		PAP:       "amber",
+		Generated: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Defang:    false,
+		Entries:   []output.ReportEntry{{Input: "example.com", Result: sectionedResult{}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteHTML(&buf))
+	html := buf.String()
+	assert.Contains(t, html, "<!DOCTYPE html>")
+	assert.Contains(t, html, "<title>trident dns</title>")
+	assert.Contains(t, html, `<div class="pap pap-amber"><strong>PAP:AMBER</strong>`)
+	assert.Contains(t, html, "<p class=\"meta\">Generated 2025-03-01T12:00:00Z</p>")
+	assert.Contains(t, html, "<h2>example.com</h2>")
+	assert.Contains(t, html, "<dt>Mail</dt><dd>mx.example.com</dd>")
+	assert.Contains(t, html, "<tr><td>example.com</td><td>93.184.216.34</td></tr>")
+	assert.Contains(t, html, "<pre>https://example.com/&lt;x&gt;</pre>", "text is escaped")
+	assert.NotContains(t, html, "<link", "no external assets")
+	assert.NotContains(t, html, "<script")
+}
+
+func TestReport_WriteHTML_Defang(t *testing.T) {
+	report := &output.Report{
+		Title:     "trident dns",
+		PAP:       "amber",
+		Generated: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Defang:    true,
+		Entries:   []output.ReportEntry{{Input: "example.com", Result: sectionedResult{}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteHTML(&buf))
+	html := buf.String()
+	assert.Contains(t, html, "<h2>example[.]com</h2>")
+	assert.Contains(t, html, "<td>93[.]184[.]216[.]34</td>")
+	assert.Contains(t, html, "line-height:1.5", "styles are not defanged")
+}
+
+func TestWrite_Report_Fallback(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, output.Write(&buf, output.FormatMarkdown, &fakeResult{Name: "hello"}))
+	assert.Contains(t, buf.String(), "# trident report\n")
+	assert.Contains(t, buf.String(), "### Results\n\n```\ntext:hello\n```\n")
+	assert.NotContains(t, buf.String(), "PAP:")
+
+	buf.Reset()
+	require.NoError(t, output.Write(&buf, output.FormatHTML, struct{ X int }{X: 1}))
+	assert.Contains(t, buf.String(), "<pre>{\n  &#34;X&#34;: 1\n}</pre>")
+}
+
+func TestFormat_IsReport(t *testing.T) {
+	assert.True(t, output.FormatMarkdown.IsReport())
+	assert.True(t, output.FormatHTML.IsReport())
+	assert.False(t, output.FormatTable.IsReport())
+	assert.False(t, output.FormatJSON.IsReport())
+}
@@ -24,42 +24,66 @@ import (
 // When proxyURL is a socks5:// URL, DNS queries are tunnelled through the
 // SOCKS5 proxy using DNS-over-TCP, preventing DNS leaks to the local ISP.
 func NewResolver(proxyURL string) (*net.Resolver, error) {
-	if proxyURL == "" {
-		// Honour ALL_PROXY / all_proxy env var. Only SOCKS5 can proxy raw TCP
-		// DNS traffic; HTTP/HTTPS proxies are intentionally ignored.
-		allProxy := os.Getenv("ALL_PROXY")
-		if allProxy == "" {
-			allProxy = os.Getenv("all_proxy")
-		}
-		if host, ok := strings.CutPrefix(allProxy, "socks5://"); ok {
-			dialer, err := proxy.SOCKS5("tcp", host, nil, proxy.Direct)
-			if err != nil {
-				return nil, fmt.Errorf("creating SOCKS5 dialer for DNS from ALL_PROXY: %w", err)
-			}
-			if cd, ok := dialer.(proxy.ContextDialer); ok {
-				return newSocks5Resolver(cd), nil
-			}
-		}
+	cd, err := socks5Dialer(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("creating SOCKS5 dialer for DNS: %w", err)
+	}
+	if cd == nil {
 		return &net.Resolver{}, nil
 	}
+	return newSocks5Resolver(cd), nil
+}
+
+// NewDialer returns a proxy.ContextDialer for raw TCP protocols (e.g. WHOIS)
+// that honours the same proxy rules as NewResolver: socks5:// URLs (from
+// proxyURL or ALL_PROXY) tunnel connections through the SOCKS5 proxy, while
+// HTTP/HTTPS proxies are ignored and connections are dialled directly.
+func NewDialer(proxyURL string) (proxy.ContextDialer, error) {
+	cd, err := socks5Dialer(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("creating SOCKS5 dialer: %w", err)
+	}
+	if cd == nil {
+		return &net.Dialer{}, nil
+	}
+	return cd, nil
+}
+
+// socks5Dialer returns a SOCKS5 ContextDialer for proxyURL, or for ALL_PROXY /
+// all_proxy when proxyURL is empty. It returns (nil, nil) when the effective
+// proxy is not a socks5:// URL.
+func socks5Dialer(proxyURL string) (proxy.ContextDialer, error) {
+	fromEnv := proxyURL == ""
+	if fromEnv {
+		proxyURL = os.Getenv("ALL_PROXY")
+		if proxyURL == "" {
+			proxyURL = os.Getenv("all_proxy")
+		}
+	}
 
 	host, ok := strings.CutPrefix(proxyURL, "socks5://")
 	if !ok {
-		return &net.Resolver{}, nil
+		return nil, nil
 	}
 
 	dialer, err := proxy.SOCKS5("tcp", host, nil, proxy.Direct)
 	if err != nil {
-		return nil, fmt.Errorf("creating SOCKS5 dialer for DNS: %w", err)
+		if fromEnv {
+			return nil, fmt.Errorf("from ALL_PROXY: %w", err)
+		}
+		return nil, err
 	}
 
 	// proxy.SOCKS5 returns a ContextDialer — type-assert to get DialContext.
 	ctxDialer, ok := dialer.(proxy.ContextDialer)
 	if !ok {
+		if fromEnv {
+			// Historical behaviour: an unusable ALL_PROXY falls back to direct.
+			return nil, nil
+		}
 		return nil, fmt.Errorf("SOCKS5 dialer does not implement ContextDialer")
 	}
-
-	return newSocks5Resolver(ctxDialer), nil
+	return ctxDialer, nil
 }
 
 func newSocks5Resolver(cd proxy.ContextDialer) *net.Resolver {
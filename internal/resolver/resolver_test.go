@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,3 +48,27 @@ func TestNewResolver_AllProxy_Http(t *testing.T) {
 	assert.NotNil(t, r)
 	assert.Nil(t, r.Dial, "HTTP ALL_PROXY should fall back to standard resolver (nil Dial)")
 }
+
+func TestNewDialer_Direct(t *testing.T) {
+	for _, u := range []string{"", "http://proxy.example.com:8080"} {
+		d, err := NewDialer(u)
+		require.NoError(t, err, "proxy=%s", u)
+		_, ok := d.(*net.Dialer)
+		assert.True(t, ok, "proxy=%q should dial directly", u)
+	}
+}
+
+func TestNewDialer_Socks5Proxy(t *testing.T) {
+	d, err := NewDialer("socks5://127.0.0.1:1080")
+	require.NoError(t, err)
+	_, ok := d.(*net.Dialer)
+	assert.False(t, ok, "socks5 proxy should not dial directly")
+}
+
+func TestNewDialer_AllProxy_Socks5(t *testing.T) {
+	t.Setenv("ALL_PROXY", "socks5://127.0.0.1:1080")
+	d, err := NewDialer("")
+	require.NoError(t, err)
+	_, ok := d.(*net.Dialer)
+	assert.False(t, ok, "ALL_PROXY socks5 should not dial directly")
+}
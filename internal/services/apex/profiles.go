@@ -0,0 +1,273 @@
+package apex
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"codeberg.org/miekg/dns"
+	"gopkg.in/yaml.v3"
+
+	"github.com/tbckr/trident/internal/appdir"
+	"github.com/tbckr/trident/internal/services"
+)
+
+//go:embed profiles.yaml
+var embeddedProfiles []byte
+
+// DefaultProfile is the name of the profile used when none is selected.
+const DefaultProfile = "default"
+
+// queryTypes maps the record type names accepted in profiles to their codes.
+var queryTypes = map[string]uint16{
+	"A":       dns.TypeA,
+	"AAAA":    dns.TypeAAAA,
+	"CAA":     dns.TypeCAA,
+	"CDNSKEY": dns.TypeCDNSKEY,
+	"CDS":     dns.TypeCDS,
+	"CNAME":   dns.TypeCNAME,
+	"DNSKEY":  dns.TypeDNSKEY,
+	"DS":      dns.TypeDS,
+	"HTTPS":   dns.TypeHTTPS,
+	"MX":      dns.TypeMX,
+	"NAPTR":   dns.TypeNAPTR,
+	"NS":      dns.TypeNS,
+	"SOA":     dns.TypeSOA,
+	"SRV":     dns.TypeSRV,
+	"SSHFP":   dns.TypeSSHFP,
+	"SVCB":    dns.TypeSVCB,
+	"TLSA":    dns.TypeTLSA,
+	"TXT":     dns.TypeTXT,
+}
+
+// hostPrefixRegexp matches a relative host prefix such as "www" or
+// "_sip._tls"; underscores are allowed because service labels use them.
+var hostPrefixRegexp = regexp.MustCompile(`^([a-zA-Z0-9_]([a-zA-Z0-9_\-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9_]([a-zA-Z0-9_\-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// ProfileQuery lists the record types queried for one host. Host is "@" for
+// the apex domain or a prefix prepended to it.
+type ProfileQuery struct {
+	Host  string   `yaml:"host"`
+	Types []string `yaml:"types"`
+}
+
+// Profile is an apex query plan: the direct queries and the hosts whose
+// CNAME chains are followed.
+type Profile struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	CNAME       []string       `yaml:"cname"`
+	Queries     []ProfileQuery `yaml:"queries"`
+
+	// Source is the file the profile was loaded from, or "<embedded>".
+	Source string `yaml:"-"`
+}
+
+// ProfileCatalog is the top-level structure of a profiles file.
+type ProfileCatalog struct {
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// ParseProfiles decodes and validates a profiles file.
+func ParseProfiles(data []byte) (ProfileCatalog, error) {
+	var c ProfileCatalog
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return ProfileCatalog{}, err
+	}
+	if len(c.Profiles) == 0 {
+		return ProfileCatalog{}, errors.New("no profiles defined")
+	}
+	seen := make(map[string]bool, len(c.Profiles))
+	for i := range c.Profiles {
+		p := &c.Profiles[i]
+		if err := p.validate(); err != nil {
+			return ProfileCatalog{}, fmt.Errorf("profile %d (%s): %w", i+1, p.Name, err)
+		}
+		if seen[p.Name] {
+			return ProfileCatalog{}, fmt.Errorf("duplicate profile name %q", p.Name)
+		}
+		seen[p.Name] = true
+	}
+	return c, nil
+}
+
+// ParseProfile decodes and validates a single-profile document, as passed to
+// "trident apex --profile custom.yaml". A missing name defaults to "custom".
+func ParseProfile(data []byte) (Profile, error) {
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return Profile{}, err
+	}
+	if p.Name == "" {
+		p.Name = "custom"
+	}
+	if err := p.validate(); err != nil {
+		return Profile{}, err
+	}
+	return p, nil
+}
+
+// validate checks the profile name, hosts, and record types.
+func (p *Profile) validate() error {
+	if p.Name == "" || strings.ContainsAny(p.Name, " ,/") {
+		return fmt.Errorf("invalid profile name %q", p.Name)
+	}
+	if len(p.Queries) == 0 && len(p.CNAME) == 0 {
+		return errors.New("profile defines no queries")
+	}
+	for _, q := range p.Queries {
+		if !validHost(q.Host) {
+			return fmt.Errorf("invalid host %q", q.Host)
+		}
+		if len(q.Types) == 0 {
+			return fmt.Errorf("host %q lists no record types", q.Host)
+		}
+		for _, t := range q.Types {
+			if _, ok := queryTypes[strings.ToUpper(t)]; !ok {
+				return fmt.Errorf("host %q: unsupported record type %q", q.Host, t)
+			}
+		}
+	}
+	for _, h := range p.CNAME {
+		if !validHost(h) {
+			return fmt.Errorf("invalid cname host %q", h)
+		}
+	}
+	return nil
+}
+
+func validHost(h string) bool {
+	return h == "@" || hostPrefixRegexp.MatchString(h)
+}
+
+// plan expands the profile for domain into its direct queries and the hosts
+// whose CNAME chains are followed, both in profile order.
+func (p *Profile) plan(domain string) ([]directQuery, []string) {
+	var queries []directQuery
+	for _, q := range p.Queries {
+		host := qualify(q.Host, domain)
+		for _, t := range q.Types {
+			name := strings.ToUpper(t)
+			queries = append(queries, directQuery{host: host, typeCode: queryTypes[name], typeName: name})
+		}
+	}
+	cnameHosts := make([]string, 0, len(p.CNAME))
+	for _, h := range p.CNAME {
+		cnameHosts = append(cnameHosts, qualify(h, domain))
+	}
+	return queries, cnameHosts
+}
+
+func qualify(host, domain string) string {
+	if host == "@" {
+		return domain
+	}
+	return host + "." + domain
+}
+
+// LoadProfiles returns the embedded profiles merged with each profiles file
+// in paths that exists: a profile whose name matches an earlier one replaces
+// it, any other is appended. Corrupt files are skipped.
+func LoadProfiles(paths ...string) ([]Profile, error) {
+	c, err := ParseProfiles(embeddedProfiles)
+	if err != nil {
+		return nil, fmt.Errorf("parsing embedded profiles: %w", err)
+	}
+	profiles := c.Profiles
+	for i := range profiles {
+		profiles[i].Source = "<embedded>"
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading profiles file %q: %w", path, err)
+		}
+		c, err := ParseProfiles(data)
+		if err != nil {
+			continue // corrupt file → keep the profiles loaded so far
+		}
+		for _, p := range c.Profiles {
+			p.Source = path
+			if i := slices.IndexFunc(profiles, func(q Profile) bool { return q.Name == p.Name }); i >= 0 {
+				profiles[i] = p
+			} else {
+				profiles = append(profiles, p)
+			}
+		}
+	}
+	return profiles, nil
+}
+
+// LoadProfile returns the profile selected by nameOrFile. A value ending in
+// .yaml or .yml, or containing a path separator, is read as a single-profile
+// file; anything else names a profile from LoadProfiles(paths...).
+func LoadProfile(nameOrFile string, paths ...string) (Profile, error) {
+	if nameOrFile == "" {
+		nameOrFile = DefaultProfile
+	}
+	if IsProfileFile(nameOrFile) {
+		data, err := os.ReadFile(nameOrFile)
+		if err != nil {
+			return Profile{}, fmt.Errorf("reading profile file: %w", err)
+		}
+		p, err := ParseProfile(data)
+		if err != nil {
+			return Profile{}, fmt.Errorf("%w: profile file %q: %v", services.ErrInvalidInput, nameOrFile, err)
+		}
+		p.Source = nameOrFile
+		return p, nil
+	}
+	profiles, err := LoadProfiles(paths...)
+	if err != nil {
+		return Profile{}, err
+	}
+	for _, p := range profiles {
+		if p.Name == nameOrFile {
+			return p, nil
+		}
+	}
+	return Profile{}, fmt.Errorf("%w: unknown apex profile %q (available: %s)",
+		services.ErrInvalidInput, nameOrFile, strings.Join(ProfileNames(profiles), ", "))
+}
+
+// ProfileNames returns the names of profiles in order.
+func ProfileNames(profiles []Profile) []string {
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// IsProfileFile reports whether a --profile value refers to a file rather
+// than a profile name.
+func IsProfileFile(nameOrFile string) bool {
+	ext := strings.ToLower(filepath.Ext(nameOrFile))
+	return ext == ".yaml" || ext == ".yml" || strings.ContainsRune(nameOrFile, '/') || strings.ContainsRune(nameOrFile, filepath.Separator)
+}
+
+// DefaultProfilePath returns the user-maintained profiles file,
+// <config-dir>/apex-profiles.yaml.
+func DefaultProfilePath() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, "apex-profiles.yaml"), nil
+}
+
+// embeddedDefaultProfile returns the built-in default profile.
+func embeddedDefaultProfile() Profile {
+	// The embedded file is validated by the package tests, so the lookup
+	// cannot fail at runtime.
+	p, _ := LoadProfile(DefaultProfile)
+	return p
+}
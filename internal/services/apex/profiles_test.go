@@ -0,0 +1,183 @@
+package apex_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"codeberg.org/miekg/dns"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/apex"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func TestLoadProfiles_Embedded(t *testing.T) {
+	profiles, err := apex.LoadProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "mail", "web", "full"}, apex.ProfileNames(profiles))
+	for _, p := range profiles {
+		assert.Equal(t, "<embedded>", p.Source)
+		assert.NotEmpty(t, p.Description, p.Name)
+	}
+}
+
+func TestLoadProfiles_OverrideMergesByName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apex-profiles.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`profiles:
+  - name: web
+    description: just the apex
+    queries:
+      - {host: "@", types: [A]}
+  - name: ad
+    description: Active Directory
+    queries:
+      - {host: _ldap._tcp.dc._msdcs, types: [SRV]}
+`), 0o600))
+
+	profiles, err := apex.LoadProfiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "mail", "web", "full", "ad"}, apex.ProfileNames(profiles))
+	assert.Equal(t, "just the apex", profiles[2].Description)
+	assert.Equal(t, path, profiles[2].Source)
+	assert.Equal(t, "<embedded>", profiles[0].Source)
+}
+
+func TestLoadProfiles_CorruptOverrideSkipped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apex-profiles.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("profiles: [{name: x, queries: [{host: '@', types: [BOGUS]}]}]"), 0o600))
+
+	profiles, err := apex.LoadProfiles(path)
+	require.NoError(t, err)
+	assert.Len(t, profiles, 4)
+}
+
+func TestLoadProfile_ByName(t *testing.T) {
+	p, err := apex.LoadProfile("mail")
+	require.NoError(t, err)
+	assert.Equal(t, "mail", p.Name)
+
+	p, err = apex.LoadProfile("")
+	require.NoError(t, err)
+	assert.Equal(t, apex.DefaultProfile, p.Name)
+}
+
+func TestLoadProfile_Unknown(t *testing.T) {
+	_, err := apex.LoadProfile("nope")
+	require.ErrorIs(t, err, services.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "default, mail, web, full")
+}
+
+func TestLoadProfile_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`queries:
+  - {host: vpn, types: [a, AAAA]}
+`), 0o600))
+
+	p, err := apex.LoadProfile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", p.Name)
+	assert.Equal(t, path, p.Source)
+}
+
+func TestLoadProfile_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`queries:
+  - {host: "bad host", types: [A]}
+`), 0o600))
+
+	_, err := apex.LoadProfile(path)
+	require.ErrorIs(t, err, services.ErrInvalidInput)
+	assert.Contains(t, err.Error(), `invalid host "bad host"`)
+}
+
+func TestParseProfile_Validation(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"unsupported type", `queries: [{host: "@", types: [PTR]}]`, `unsupported record type "PTR"`},
+		{"no types", `queries: [{host: www}]`, "lists no record types"},
+		{"no queries", `name: empty`, "defines no queries"},
+		{"bad cname host", `cname: ["a b"]`, `invalid cname host "a b"`},
+		{"bad name", `{name: "a b", cname: ["@"]}`, "invalid profile name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := apex.ParseProfile([]byte(tt.yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestIsProfileFile(t *testing.T) {
+	assert.True(t, apex.IsProfileFile("custom.yaml"))
+	assert.True(t, apex.IsProfileFile("custom.YML"))
+	assert.True(t, apex.IsProfileFile("./profiles/engagement"))
+	assert.False(t, apex.IsProfileFile("mail"))
+}
+
+func TestApexService_Run_UsesProfile(t *testing.T) {
+	client := newTestClient(t)
+
+	var mu sync.Mutex
+	var queried []string
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		apexWireResponder(t, func(qname string, qtype uint16) []byte {
+			mu.Lock()
+			queried = append(queried, qname+" "+dns.TypeToString[qtype])
+			mu.Unlock()
+			return buildWireResponse(t, 0, nil, nil)
+		}))
+
+	p, err := apex.ParseProfile([]byte(`name: engagement
+cname: [vpn]
+queries:
+  - {host: "@", types: [MX]}
+  - {host: _kerberos._tcp, types: [SRV]}
+`))
+	require.NoError(t, err)
+
+	svc := apex.NewService(client, &testutil.MockResolver{}, testutil.NopLogger(), embeddedPatterns(t))
+	svc.UseProfile(p)
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "engagement", raw.(*apex.Result).Profile)
+
+	sort.Strings(queried)
+	assert.Equal(t, []string{
+		"_kerberos._tcp.example.com SRV",
+		"example.com MX",
+		"vpn.example.com CNAME",
+	}, queried)
+}
+
+func TestApexService_Run_DefaultProfile(t *testing.T) {
+	client := newTestClient(t)
+
+	var mu sync.Mutex
+	hosts := map[string]bool{}
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		apexWireResponder(t, func(qname string, _ uint16) []byte {
+			mu.Lock()
+			hosts[strings.TrimSuffix(qname, ".example.com")] = true
+			mu.Unlock()
+			return buildWireResponse(t, 0, nil, nil)
+		}))
+
+	svc := apex.NewService(client, &testutil.MockResolver{}, testutil.NopLogger(), embeddedPatterns(t))
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, apex.DefaultProfile, raw.(*apex.Result).Profile)
+	assert.True(t, hosts["_dmarc"])
+	assert.True(t, hosts["_xmpp-server._tcp"])
+	assert.False(t, hosts["vpn"], "vpn is only probed by the full profile")
+}
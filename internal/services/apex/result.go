@@ -3,34 +3,132 @@ package apex
 import (
 	"fmt"
 	"io"
+	"slices"
 	"sort"
+	"strings"
 
+	"github.com/tbckr/trident/internal/doh"
 	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/snapshot"
 )
 
-// Record holds a single DNS reconnaissance record for an apex domain.
+// Record holds a single DNS record found for an apex domain.
+// Value is the presentation form shown in table and text output; Data holds
+// the typed record data (e.g. *doh.MX, *doh.SVCB) for JSON consumers and is
+// nil for single-field records.
 type Record struct {
 	Host  string `json:"host"`
 	Type  string `json:"type"`
 	Value string `json:"value"`
+	Data  any    `json:"data,omitempty"`
+}
+
+// CNAMEChain is the CNAME chain followed from Host, first target first.
+type CNAMEChain struct {
+	Host  string   `json:"host"`
+	Chain []string `json:"chain"`
+}
+
+// Detection is a provider identified from the records of an apex domain.
+type Detection struct {
+	Type     string `json:"type"`     // e.g. "CDN", "Email", "DNS", "Verification"
+	Provider string `json:"provider"` // e.g. "Google Workspace"
+	Evidence string `json:"evidence"` // the record value that matched
+	Source   string `json:"source"`   // record type of the evidence: "cname", "mx", "ns", "txt"
+}
+
+// ASN is the origin network of one IP address found in A or AAAA records.
+type ASN struct {
+	IP          string `json:"ip"`
+	ASN         string `json:"asn"`
+	Prefix      string `json:"prefix,omitempty"`
+	Country     string `json:"country,omitempty"`
+	Registry    string `json:"registry,omitempty"`    // RIR; Team Cymru only
+	Description string `json:"description,omitempty"` // AS name or organisation
+	Source      string `json:"source"`                // "cymru" or "ipinfo"
+}
+
+// String renders the ASN as "ASN / Prefix / Country / Registry / Description";
+// the registry is left out when unknown.
+func (a ASN) String() string {
+	parts := []string{a.ASN, a.Prefix, a.Country}
+	if a.Registry != "" {
+		parts = append(parts, a.Registry)
+	}
+	return strings.Join(append(parts, a.Description), " / ")
+}
+
+// Response holds the metadata of one DoH query made for an apex domain.
+// It appears in JSON output only.
+type Response struct {
+	Host      string    `json:"host"`
+	Type      string    `json:"type"`
+	Rcode     string    `json:"rcode"`
+	Flags     doh.Flags `json:"flags"`
+	EDE       []doh.EDE `json:"ede,omitempty"`
+	Authority []Record  `json:"authority,omitempty"`
 }
 
 // Result holds aggregated DNS reconnaissance results for an apex domain.
+// Records holds the answers to the profile's direct queries; CNAME chains,
+// provider detections, and ASN lookups have sections of their own.
 type Result struct {
-	Input   string   `json:"input"`
-	Records []Record `json:"records,omitempty"`
-	Skipped []string `json:"skipped,omitempty"`
+	Input       string       `json:"input"`
+	Profile     string       `json:"profile,omitempty"` // query profile used
+	Records     []Record     `json:"records,omitempty"`
+	CNAMEChains []CNAMEChain `json:"cname_chains,omitempty"`
+	Detections  []Detection  `json:"detections,omitempty"`
+	ASN         []ASN        `json:"asn,omitempty"`
+	Skipped     []string     `json:"skipped,omitempty"`
+	Responses   []Response   `json:"responses,omitempty"`
 }
 
-// IsEmpty reports whether the result contains no records and no skipped sub-services.
+// IsEmpty reports whether the result contains no records, CNAME chains, or
+// skipped sub-services. Detections and ASN entries derive from records.
 func (r *Result) IsEmpty() bool {
-	return len(r.Records) == 0 && len(r.Skipped) == 0
+	return len(r.Records) == 0 && len(r.CNAMEChains) == 0 && len(r.Skipped) == 0
+}
+
+// displayRecords flattens every section into HOST/TYPE/VALUE rows: records,
+// then one CNAME row per chain hop, then detections and ASNs under the
+// "detected" host. Identical ASN rows are shown once.
+func (r *Result) displayRecords() []Record {
+	recs := slices.Clone(r.Records)
+	for _, c := range r.CNAMEChains {
+		for _, target := range c.Chain {
+			recs = append(recs, Record{Host: c.Host, Type: "CNAME", Value: target})
+		}
+	}
+	for _, d := range r.Detections {
+		recs = append(recs, Record{Host: "detected", Type: d.Type, Value: d.Provider + " (" + d.Source + ": " + d.Evidence + ")"})
+	}
+	seen := map[string]bool{}
+	for _, a := range r.ASN {
+		v := a.String()
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		recs = append(recs, Record{Host: "detected", Type: "ASN", Value: v})
+	}
+	return recs
+}
+
+// SnapshotRecords returns the displayed records keyed by "HOST TYPE" so
+// snapshot diffs line up with table output. Query metadata is left out.
+func (r *Result) SnapshotRecords() []snapshot.Record {
+	recs := r.displayRecords()
+	out := make([]snapshot.Record, len(recs))
+	for i, rec := range recs {
+		out[i] = snapshot.Record{Key: rec.Host + " " + rec.Type, Value: rec.Value}
+	}
+	return out
 }
 
 // WriteText renders each record as "HOST TYPE VALUE\n".
 // Any skipped sub-services are listed at the end as "[skipped: <name>]".
 func (r *Result) WriteText(w io.Writer) error {
-	for _, rec := range r.Records {
+	for _, rec := range r.displayRecords() {
 		if _, err := fmt.Fprintf(w, "%s %s %s\n", rec.Host, rec.Type, rec.Value); err != nil {
 			return err
 		}
@@ -73,7 +171,7 @@ func recSortKey(input string, rec Record) string {
 // Skipped sub-services appear at the end with Host="skipped".
 func (r *Result) WriteTable(w io.Writer) error {
 	var rows [][]string
-	for _, rec := range sortRecordsForDisplay(r.Input, r.Records) {
+	for _, rec := range sortRecordsForDisplay(r.Input, r.displayRecords()) {
 		rows = append(rows, []string{rec.Host, rec.Type, rec.Value})
 	}
 	for _, name := range r.Skipped {
@@ -97,3 +195,88 @@ func (r *Result) WriteTable(w io.Writer) error {
 	}
 	return table.Render()
 }
+
+// ReportSections renders a summary of the domain — record and host counts,
+// detected providers per type, origin networks, skipped sub-services —
+// followed by one section per record type, the CNAME chains, the provider
+// detections, and the ASN lookups.
+func (r *Result) ReportSections() []output.Section {
+	summary := output.Section{Title: "Summary", Facts: []output.Fact{{Label: "Domain", Value: r.Input}}}
+	if r.Profile != "" {
+		summary.Facts = append(summary.Facts, output.Fact{Label: "Profile", Value: r.Profile})
+	}
+	hosts := map[string]bool{}
+	for _, rec := range r.Records {
+		hosts[rec.Host] = true
+	}
+	summary.Facts = append(summary.Facts, output.Fact{Label: "Records", Value: fmt.Sprintf("%d across %d host(s)", len(r.Records), len(hosts))})
+
+	var detTypes []string
+	providers := map[string][]string{}
+	for _, d := range r.Detections {
+		if _, ok := providers[d.Type]; !ok {
+			detTypes = append(detTypes, d.Type)
+		}
+		if !slices.Contains(providers[d.Type], d.Provider) {
+			providers[d.Type] = append(providers[d.Type], d.Provider)
+		}
+	}
+	for _, t := range detTypes {
+		summary.Facts = append(summary.Facts, output.Fact{Label: t, Value: strings.Join(providers[t], ", ")})
+	}
+	var networks []string
+	for _, a := range r.ASN {
+		n := a.ASN
+		if a.Description != "" {
+			n += " (" + a.Description + ")"
+		}
+		if !slices.Contains(networks, n) {
+			networks = append(networks, n)
+		}
+	}
+	if len(networks) > 0 {
+		summary.Facts = append(summary.Facts, output.Fact{Label: "Networks", Value: strings.Join(networks, ", ")})
+	}
+	if len(r.Skipped) > 0 {
+		summary.Facts = append(summary.Facts, output.Fact{Label: "Skipped", Value: strings.Join(r.Skipped, ", ")})
+	}
+	sections := []output.Section{summary}
+
+	byType := map[string]*output.Section{}
+	var typeOrder []string
+	for _, rec := range sortRecordsForDisplay(r.Input, r.Records) {
+		s, ok := byType[rec.Type]
+		if !ok {
+			s = &output.Section{Title: rec.Type + " records", Headers: []string{"Host", "Value"}}
+			byType[rec.Type] = s
+			typeOrder = append(typeOrder, rec.Type)
+		}
+		s.Rows = append(s.Rows, []string{rec.Host, rec.Value})
+	}
+	for _, t := range typeOrder {
+		sections = append(sections, *byType[t])
+	}
+
+	if len(r.CNAMEChains) > 0 {
+		s := output.Section{Title: "CNAME chains", Headers: []string{"Host", "Chain"}}
+		for _, c := range r.CNAMEChains {
+			s.Rows = append(s.Rows, []string{c.Host, strings.Join(c.Chain, " → ")})
+		}
+		sections = append(sections, s)
+	}
+	if len(r.Detections) > 0 {
+		s := output.Section{Title: "Detected providers", Headers: []string{"Type", "Provider", "Source", "Evidence"}}
+		for _, d := range r.Detections {
+			s.Rows = append(s.Rows, []string{d.Type, d.Provider, d.Source, d.Evidence})
+		}
+		sections = append(sections, s)
+	}
+	if len(r.ASN) > 0 {
+		s := output.Section{Title: "Networks", Headers: []string{"IP", "ASN", "Prefix", "Country", "Description"}}
+		for _, a := range r.ASN {
+			s.Rows = append(s.Rows, []string{a.IP, a.ASN, a.Prefix, a.Country, a.Description})
+		}
+		sections = append(sections, s)
+	}
+	return sections
+}
@@ -2,13 +2,16 @@ package apex_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tbckr/trident/internal/output"
 	"github.com/tbckr/trident/internal/services/apex"
+	"github.com/tbckr/trident/internal/snapshot"
 )
 
 func TestResult_IsEmpty(t *testing.T) {
@@ -104,14 +107,16 @@ func TestResult_WriteTable_SortOrder(t *testing.T) {
 	r := &apex.Result{
 		Input: "example.com",
 		Records: []apex.Record{
-			{Host: "detected", Type: "CDN", Value: "CloudFront"},
-			{Host: "detected", Type: "Email", Value: "Google Workspace (mx: aspmx.l.google.com.)"},
-			{Host: "detected", Type: "DNS", Value: "Cloudflare DNS (ns: liz.ns.cloudflare.com.)"},
 			{Host: "www.example.com", Type: "A", Value: "9.9.9.9"},
 			{Host: "example.com", Type: "TXT", Value: "v=spf1"},
 			{Host: "autodiscover.example.com", Type: "A", Value: "5.6.7.8"},
 			{Host: "example.com", Type: "A", Value: "1.2.3.4"},
-			{Host: "detected", Type: "ASN", Value: "15169 / 1.2.0.0/16 / US / arin / GOOGLE"},
+		},
+		ASN: []apex.ASN{{IP: "1.2.3.4", ASN: "15169", Prefix: "1.2.0.0/16", Country: "US", Registry: "arin", Description: "GOOGLE"}},
+		Detections: []apex.Detection{
+			{Type: "CDN", Provider: "CloudFront", Evidence: "d1.cloudfront.net.", Source: "cname"},
+			{Type: "Email", Provider: "Google Workspace", Evidence: "aspmx.l.google.com.", Source: "mx"},
+			{Type: "DNS", Provider: "Cloudflare DNS", Evidence: "liz.ns.cloudflare.com.", Source: "ns"},
 		},
 	}
 
@@ -140,3 +145,90 @@ func TestResult_WriteTable_SortOrder(t *testing.T) {
 	assert.Greater(t, strings.Index(out, "GOOGLE"), strings.Index(out, "CloudFront"),
 		"ASN rows should appear after detected rows")
 }
+
+func TestResult_Sections(t *testing.T) {
+	r := &apex.Result{
+		Input:       "example.com",
+		Records:     []apex.Record{{Host: "example.com", Type: "A", Value: "1.2.3.4"}},
+		CNAMEChains: []apex.CNAMEChain{{Host: "www.example.com", Chain: []string{"a.cdn.net.", "b.cdn.net."}}},
+		Detections:  []apex.Detection{{Type: "CDN", Provider: "Example CDN", Evidence: "a.cdn.net.", Source: "cname"}},
+		ASN: []apex.ASN{
+			{IP: "1.2.3.4", ASN: "AS64500", Prefix: "1.2.3.0/24", Country: "US", Registry: "arin", Description: "EXAMPLE", Source: "cymru"},
+			{IP: "1.2.3.5", ASN: "AS64500", Prefix: "1.2.3.0/24", Country: "US", Registry: "arin", Description: "EXAMPLE", Source: "cymru"},
+		},
+	}
+
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	var sections map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &sections))
+	assert.JSONEq(t, `[{"host":"www.example.com","chain":["a.cdn.net.","b.cdn.net."]}]`, string(sections["cname_chains"]))
+	assert.JSONEq(t, `[{"type":"CDN","provider":"Example CDN","evidence":"a.cdn.net.","source":"cname"}]`, string(sections["detections"]))
+	assert.Contains(t, string(sections["asn"]), `"ip":"1.2.3.5"`)
+	assert.NotContains(t, string(sections["records"]), "detected", "records hold DNS answers only")
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteText(&buf))
+	assert.Equal(t, `example.com A 1.2.3.4
+www.example.com CNAME a.cdn.net.
+www.example.com CNAME b.cdn.net.
+detected CDN Example CDN (cname: a.cdn.net.)
+detected ASN AS64500 / 1.2.3.0/24 / US / arin / EXAMPLE
+`, buf.String())
+}
+
+func TestResult_IsEmpty_CNAMEChainOnly(t *testing.T) {
+	r := &apex.Result{CNAMEChains: []apex.CNAMEChain{{Host: "www.example.com", Chain: []string{"a.cdn.net."}}}}
+	assert.False(t, r.IsEmpty())
+}
+
+func TestResult_SnapshotRecords(t *testing.T) {
+	r := &apex.Result{
+		Input:       "example.com",
+		Records:     []apex.Record{{Host: "example.com", Type: "MX", Value: "10 mx.example.com."}},
+		CNAMEChains: []apex.CNAMEChain{{Host: "www.example.com", Chain: []string{"cdn.example.net."}}},
+		ASN:         []apex.ASN{{IP: "192.0.2.1", ASN: "AS64500", Prefix: "192.0.2.0/24", Country: "US", Description: "EXAMPLE", Source: "ipinfo"}},
+		Responses:   []apex.Response{{Host: "example.com", Type: "MX", Rcode: "NOERROR"}},
+	}
+	assert.Equal(t, []snapshot.Record{
+		{Key: "example.com MX", Value: "10 mx.example.com."},
+		{Key: "www.example.com CNAME", Value: "cdn.example.net."},
+		{Key: "detected ASN", Value: "AS64500 / 192.0.2.0/24 / US / EXAMPLE"},
+	}, r.SnapshotRecords())
+}
+
+func TestResult_ReportSections(t *testing.T) {
+	r := &apex.Result{
+		Input:   "example.com",
+		Profile: "default",
+		Records: []apex.Record{
+			{Host: "www.example.com", Type: "A", Value: "192.0.2.1"},
+			{Host: "example.com", Type: "MX", Value: "10 mx.example.com."},
+			{Host: "example.com", Type: "A", Value: "192.0.2.1"},
+		},
+		CNAMEChains: []apex.CNAMEChain{{Host: "cdn.example.com", Chain: []string{"a.cdn.net.", "b.cdn.net."}}},
+		Detections: []apex.Detection{
+			{Type: "Email", Provider: "Google Workspace", Evidence: "aspmx.l.google.com.", Source: "mx"},
+			{Type: "Email", Provider: "Google Workspace", Evidence: "alt1.aspmx.l.google.com.", Source: "mx"},
+		},
+		ASN:     []apex.ASN{{IP: "192.0.2.1", ASN: "AS64500", Prefix: "192.0.2.0/24", Country: "US", Description: "EXAMPLE", Source: "cymru"}},
+		Skipped: []string{"crtsh"},
+	}
+	sections := r.ReportSections()
+
+	titles := make([]string, len(sections))
+	for i, s := range sections {
+		titles[i] = s.Title
+	}
+	assert.Equal(t, []string{"Summary", "A records", "MX records", "CNAME chains", "Detected providers", "Networks"}, titles)
+	assert.Equal(t, []output.Fact{
+		{Label: "Domain", Value: "example.com"},
+		{Label: "Profile", Value: "default"},
+		{Label: "Records", Value: "3 across 2 host(s)"},
+		{Label: "Email", Value: "Google Workspace"},
+		{Label: "Networks", Value: "AS64500 (EXAMPLE)"},
+		{Label: "Skipped", Value: "crtsh"},
+	}, sections[0].Facts)
+	assert.Equal(t, [][]string{{"example.com", "192.0.2.1"}, {"www.example.com", "192.0.2.1"}}, sections[1].Rows)
+	assert.Equal(t, [][]string{{"cdn.example.com", "a.cdn.net. → b.cdn.net."}}, sections[3].Rows)
+}
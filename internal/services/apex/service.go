@@ -18,6 +18,7 @@ import (
 	"github.com/tbckr/trident/internal/pap"
 	"github.com/tbckr/trident/internal/services"
 	cymrusvc "github.com/tbckr/trident/internal/services/cymru"
+	ipinfosvc "github.com/tbckr/trident/internal/services/ipinfo"
 )
 
 // Compile-time interface check.
@@ -30,7 +31,8 @@ const (
 	// Quad9 DoH (the mandatory core sub-service) is AMBER.
 	MinPAP = pap.AMBER
 	// PAP is the highest PAP level among all sub-services.
-	// Currently MinPAP == PAP == AMBER because every sub-service (Quad9 DoH + Cymru ASN) is AMBER.
+	// Currently MinPAP == PAP == AMBER because every sub-service (Quad9 DoH + Cymru ASN) is at most
+	// AMBER; the opt-in offline ipinfo ASN source is RED.
 	// This equality means runAggregateCmd's MinPAP gate is sufficient: if the command runs at all,
 	// all sub-services are permitted and no per-sub-service PAP check inside Run() is needed.
 	// If a future sub-service at a higher PAP level is added, PAP must be raised and a per-sub-service
@@ -44,6 +46,8 @@ type Service struct {
 	resolver services.DNSResolverInterface
 	logger   *slog.Logger
 	detector *detect.Detector
+	ipinfo   *ipinfosvc.Service
+	profile  Profile
 }
 
 // NewService creates a new Service with the given HTTP client, DNS resolver, logger, and patterns.
@@ -53,9 +57,23 @@ func NewService(client *req.Client, resolver services.DNSResolverInterface, logg
 		resolver: resolver,
 		logger:   logger,
 		detector: detect.NewDetector(patterns),
+		profile:  embeddedDefaultProfile(),
 	}
 }
 
+// UseProfile replaces the built-in default query plan with p.
+func (s *Service) UseProfile(p Profile) {
+	s.profile = p
+}
+
+// EnableOfflineASN makes the ASN enrichment step look IPs up in the local
+// ipinfo databases first, so they are not sent to a third party. It only
+// takes effect when a loaded database provides ASN data; IPs the databases
+// do not know are still looked up via Team Cymru.
+func (s *Service) EnableOfflineASN(svc *ipinfosvc.Service) {
+	s.ipinfo = svc
+}
+
 // Name returns the service identifier.
 func (s *Service) Name() string { return Name }
 
@@ -123,68 +141,34 @@ type directQuery struct {
 	typeName string
 }
 
-// Run performs parallel DNS reconnaissance for the given apex domain.
-// Partial results are returned when context is cancelled mid-query.
+// queryResponse records the rcode, flags, EDEs, and authority section of resp.
+func queryResponse(host, typeName string, resp *doh.Response) *Response {
+	r := &Response{Host: host, Type: typeName, Rcode: doh.RcodeName(resp.Status), Flags: resp.Flags}
+	for _, e := range resp.EDE {
+		e.Text = output.StripANSI(e.Text)
+		r.EDE = append(r.EDE, e)
+	}
+	for _, ans := range resp.Authority {
+		r.Authority = append(r.Authority, Record{Host: ans.Name, Type: dns.TypeToString[ans.Type], Value: output.StripANSI(ans.Data), Data: ans.RData})
+	}
+	return r
+}
+
+// Run performs parallel DNS reconnaissance for the given apex domain using the
+// queries of the active profile. Partial results are returned when context is cancelled mid-query.
 func (s *Service) Run(ctx context.Context, domain string) (services.Result, error) {
 	domain = output.StripANSI(domain)
 	if !services.IsDomain(domain) {
 		return nil, fmt.Errorf("%w: must be a valid domain name: %q", services.ErrInvalidInput, domain)
 	}
 
-	result := &Result{Input: domain}
-
-	directQueries := []directQuery{
-		// Apex domain — core types
-		{domain, dns.TypeA, "A"},
-		{domain, dns.TypeAAAA, "AAAA"},
-		{domain, dns.TypeCAA, "CAA"},
-		{domain, dns.TypeDNSKEY, "DNSKEY"},
-		{domain, dns.TypeHTTPS, "HTTPS"},
-		{domain, dns.TypeMX, "MX"},
-		{domain, dns.TypeNS, "NS"},
-		{domain, dns.TypeSOA, "SOA"},
-		{domain, dns.TypeSSHFP, "SSHFP"},
-		{domain, dns.TypeTXT, "TXT"},
-		// SRV services
-		{"_sip._tls." + domain, dns.TypeSRV, "SRV"},
-		{"_sipfederationtls._tcp." + domain, dns.TypeSRV, "SRV"},
-		{"_xmpp-client._tcp." + domain, dns.TypeSRV, "SRV"},
-		{"_xmpp-server._tcp." + domain, dns.TypeSRV, "SRV"},
-		// www subdomain
-		{"www." + domain, dns.TypeA, "A"},
-		{"www." + domain, dns.TypeAAAA, "AAAA"},
-		{"www." + domain, dns.TypeHTTPS, "HTTPS"},
-		// autodiscover subdomain
-		{"autodiscover." + domain, dns.TypeA, "A"},
-		{"autodiscover." + domain, dns.TypeAAAA, "AAAA"},
-		// mail subdomain — outbound mail server; may carry a separate SPF record
-		{"mail." + domain, dns.TypeA, "A"},
-		{"mail." + domain, dns.TypeAAAA, "AAAA"},
-		{"mail." + domain, dns.TypeTXT, "TXT"},
-		// Email security subdomains (TXT then CNAME for each)
-		{"_dmarc." + domain, dns.TypeTXT, "TXT"},
-		{"_dmarc." + domain, dns.TypeCNAME, "CNAME"},
-		{"_domainkey." + domain, dns.TypeTXT, "TXT"},
-		{"_domainkey." + domain, dns.TypeCNAME, "CNAME"},
-		{"_mta-sts." + domain, dns.TypeTXT, "TXT"},
-		{"_mta-sts." + domain, dns.TypeCNAME, "CNAME"},
-		{"_smtp._tls." + domain, dns.TypeTXT, "TXT"},
-		{"_smtp._tls." + domain, dns.TypeCNAME, "CNAME"},
-		// BIMI + DKIM selectors
-		{"default._bimi." + domain, dns.TypeTXT, "TXT"},
-		{"google._domainkey." + domain, dns.TypeTXT, "TXT"},
-		{"selector1._domainkey." + domain, dns.TypeTXT, "TXT"},
-		{"selector2._domainkey." + domain, dns.TypeTXT, "TXT"},
-	}
+	result := &Result{Input: domain, Profile: s.profile.Name}
 
-	cnameHosts := []string{
-		domain,
-		"www." + domain,
-		"autodiscover." + domain,
-	}
+	directQueries, cnameHosts := s.profile.plan(domain)
 
 	results := make([][]Record, len(directQueries))
-	cnameResults := make([][]Record, len(cnameHosts))
+	responses := make([]*Response, len(directQueries))
+	chains := make([]CNAMEChain, len(cnameHosts))
 	var wg sync.WaitGroup
 
 	for i, q := range directQueries {
@@ -201,9 +185,10 @@ func (s *Service) Run(ctx context.Context, domain string) (services.Result, erro
 				if ans.Type != q.typeCode {
 					continue
 				}
-				recs = append(recs, Record{Host: q.host, Type: q.typeName, Value: output.StripANSI(ans.Data)})
+				recs = append(recs, Record{Host: q.host, Type: q.typeName, Value: output.StripANSI(ans.Data), Data: ans.RData})
 			}
 			results[i] = recs
+			responses[i] = queryResponse(q.host, q.typeName, resp)
 		})
 	}
 
@@ -214,14 +199,10 @@ func (s *Service) Run(ctx context.Context, domain string) (services.Result, erro
 				s.logger.Debug("apex: CNAME chain failed", "host", h, "error", err)
 				return
 			}
-			if len(chain) == 0 {
-				return
-			}
-			var recs []Record
-			for _, target := range chain {
-				recs = append(recs, Record{Host: h, Type: "CNAME", Value: output.StripANSI(target)})
+			for j := range chain {
+				chain[j] = output.StripANSI(chain[j])
 			}
-			cnameResults[i] = recs
+			chains[i] = CNAMEChain{Host: h, Chain: chain}
 		})
 	}
 
@@ -232,131 +213,148 @@ func (s *Service) Run(ctx context.Context, domain string) (services.Result, erro
 		result.Records = append(result.Records, recs...)
 	}
 
-	// Flatten CNAME chains in slice order.
-	for _, recs := range cnameResults {
-		result.Records = append(result.Records, recs...)
+	for _, r := range responses {
+		if r != nil {
+			result.Responses = append(result.Responses, *r)
+		}
 	}
 
-	// CDN detection from all CNAME records (chain traversals + direct queries
-	// for email security subdomains such as _dmarc, _mta-sts, _domainkey, _smtp._tls).
-	var allCNAMEs []string
+	// Keep non-empty CNAME chains in slice order.
+	for _, c := range chains {
+		if len(c.Chain) > 0 {
+			result.CNAMEChains = append(result.CNAMEChains, c)
+		}
+	}
+
+	result.Detections = s.detect(result)
+
+	// ASN lookup for all unique IPs discovered via A and AAAA records.
+	ipSet := map[string]bool{}
 	for _, rec := range result.Records {
-		if rec.Type == "CNAME" {
-			allCNAMEs = append(allCNAMEs, rec.Value)
+		if rec.Type == "A" || rec.Type == "AAAA" {
+			ipSet[rec.Value] = true
 		}
 	}
-	if len(allCNAMEs) > 0 {
-		for _, d := range s.detector.CDN(allCNAMEs) {
-			result.Records = append(result.Records, Record{
-				Host:  "detected",
-				Type:  string(d.Type),
-				Value: d.Provider + " (cname: " + d.Evidence + ")",
-			})
+	if len(ipSet) > 0 {
+		ips := make([]string, 0, len(ipSet))
+		for ip := range ipSet {
+			ips = append(ips, ip)
 		}
+		sort.Strings(ips)
+		result.ASN = s.lookupASN(ctx, ips)
 	}
 
-	// Email provider detection from MX records
-	var mxHosts []string
+	return result, nil
+}
+
+// detect runs the provider detectors over the records of result: CDNs from
+// CNAME targets (chains and direct queries for email security subdomains such
+// as _dmarc and _mta-sts), email providers from MX exchanges, DNS hosting from
+// NS servers, and providers and verification tokens from TXT records.
+func (s *Service) detect(result *Result) []Detection {
+	var cnames, mxHosts, nsHosts, txts []string
+	for _, c := range result.CNAMEChains {
+		cnames = append(cnames, c.Chain...)
+	}
 	for _, rec := range result.Records {
-		if rec.Type == "MX" {
+		switch rec.Type {
+		case "CNAME":
+			cnames = append(cnames, rec.Value)
+		case "MX":
 			// MX value format: "10 aspmx.l.google.com." — take last token
 			parts := strings.Fields(rec.Value)
 			if len(parts) >= 2 {
 				mxHosts = append(mxHosts, parts[len(parts)-1])
 			}
-		}
-	}
-	if len(mxHosts) > 0 {
-		for _, d := range s.detector.EmailProvider(mxHosts) {
-			result.Records = append(result.Records, Record{
-				Host:  "detected",
-				Type:  string(d.Type),
-				Value: d.Provider + " (mx: " + d.Evidence + ")",
-			})
+		case "NS":
+			nsHosts = append(nsHosts, rec.Value)
+		case "TXT":
+			txts = append(txts, rec.Value)
 		}
 	}
 
-	// DNS hosting detection from NS records
-	var nsHosts []string
-	for _, rec := range result.Records {
-		if rec.Type == "NS" {
-			nsHosts = append(nsHosts, rec.Value)
-		}
+	var found []detect.Detection
+	if len(cnames) > 0 {
+		found = append(found, s.detector.CDN(cnames)...)
+	}
+	if len(mxHosts) > 0 {
+		found = append(found, s.detector.EmailProvider(mxHosts)...)
 	}
 	if len(nsHosts) > 0 {
-		for _, d := range s.detector.DNSHost(nsHosts) {
-			result.Records = append(result.Records, Record{
-				Host:  "detected",
-				Type:  string(d.Type),
-				Value: d.Provider + " (ns: " + d.Evidence + ")",
-			})
-		}
+		found = append(found, s.detector.DNSHost(nsHosts)...)
 	}
-
-	// TXT record detection from all TXT records (apex + subdomains such as _dmarc, _mta-sts, etc.).
-	var allTXTs []string
-	for _, rec := range result.Records {
-		if rec.Type == "TXT" {
-			allTXTs = append(allTXTs, rec.Value)
-		}
+	if len(txts) > 0 {
+		found = append(found, s.detector.TXTRecord(txts)...)
 	}
-	if len(allTXTs) > 0 {
-		for _, d := range s.detector.TXTRecord(allTXTs) {
-			result.Records = append(result.Records, Record{
-				Host:  "detected",
-				Type:  string(d.Type),
-				Value: d.Provider + " (txt: " + d.Evidence + ")",
-			})
-		}
+	detections := make([]Detection, len(found))
+	for i, d := range found {
+		detections[i] = Detection{Type: string(d.Type), Provider: d.Provider, Evidence: d.Evidence, Source: d.Source}
 	}
+	return detections
+}
 
-	// ASN lookup for all unique IPs discovered via A and AAAA records.
-	ipSet := map[string]bool{}
-	for _, rec := range result.Records {
-		if rec.Type == "A" || rec.Type == "AAAA" {
-			ipSet[rec.Value] = true
-		}
+// lookupASN looks up the origin ASN of each IP, offline first when enabled
+// and a local database provides ASN data, then via Team Cymru for the rest.
+// The entries are returned in the order of ips.
+func (s *Service) lookupASN(ctx context.Context, ips []string) []ASN {
+	if s.ipinfo == nil || !s.ipinfo.HasASN() {
+		return s.cymruASN(ctx, ips)
 	}
-	if len(ipSet) > 0 {
-		ips := make([]string, 0, len(ipSet))
-		for ip := range ipSet {
-			ips = append(ips, ip)
-		}
-		sort.Strings(ips)
-		cymruSvc := cymrusvc.NewService(s.resolver, s.logger)
-		asnResults := make([]*cymrusvc.Result, len(ips))
-		var asnWg sync.WaitGroup
-		for i, ip := range ips {
-			asnWg.Go(func() {
-				raw, err := cymruSvc.Run(ctx, ip)
-				if err != nil {
-					s.logger.Debug("apex: ASN lookup failed", "ip", ip, "error", err)
-					return
-				}
-				if cr, ok := raw.(*cymrusvc.Result); ok && !cr.IsEmpty() {
-					asnResults[i] = cr
-				}
-			})
-		}
-		asnWg.Wait()
-		seenASN := map[string]bool{}
-		for _, cr := range asnResults {
-			if cr == nil {
-				continue
+	asns, missing := s.offlineASN(ctx, ips)
+	if len(missing) == 0 {
+		return asns
+	}
+	asns = append(asns, s.cymruASN(ctx, missing)...)
+	sort.Slice(asns, func(i, j int) bool { return asns[i].IP < asns[j].IP })
+	return asns
+}
+
+// cymruASN looks up the origin ASN of each IP via Team Cymru DNS. IPs whose
+// lookup failed are left out.
+func (s *Service) cymruASN(ctx context.Context, ips []string) []ASN {
+	cymruSvc := cymrusvc.NewService(s.resolver, s.logger)
+	found := make([]*ASN, len(ips))
+	var wg sync.WaitGroup
+	for i, ip := range ips {
+		wg.Go(func() {
+			raw, err := cymruSvc.Run(ctx, ip)
+			if err != nil {
+				s.logger.Debug("apex: ASN lookup failed", "ip", ip, "error", err)
+				return
 			}
-			value := fmt.Sprintf("%s / %s / %s / %s / %s",
-				cr.ASN, cr.Prefix, cr.Country, cr.Registry, cr.Description)
-			if seenASN[value] {
-				continue
+			if cr, ok := raw.(*cymrusvc.Result); ok && !cr.IsEmpty() {
+				found[i] = &ASN{IP: ip, ASN: cr.ASN, Prefix: cr.Prefix, Country: cr.Country,
+					Registry: cr.Registry, Description: cr.Description, Source: cymrusvc.Name}
 			}
-			seenASN[value] = true
-			result.Records = append(result.Records, Record{
-				Host:  "detected",
-				Type:  "ASN",
-				Value: value,
-			})
+		})
+	}
+	wg.Wait()
+	var asns []ASN
+	for _, a := range found {
+		if a != nil {
+			asns = append(asns, *a)
 		}
 	}
+	return asns
+}
 
-	return result, nil
+// offlineASN looks up the ASN of each IP in the local ipinfo databases and
+// returns the IPs no database knows the ASN of as missing.
+func (s *Service) offlineASN(ctx context.Context, ips []string) (asns []ASN, missing []string) {
+	for _, ip := range ips {
+		raw, err := s.ipinfo.Run(ctx, ip)
+		if err != nil {
+			s.logger.Debug("apex: offline ASN lookup failed", "ip", ip, "error", err)
+			missing = append(missing, ip)
+			continue
+		}
+		ir, ok := raw.(*ipinfosvc.Result)
+		if !ok || ir.ASN == "" {
+			missing = append(missing, ip)
+			continue
+		}
+		asns = append(asns, ASN{IP: ip, ASN: ir.ASN, Prefix: ir.Network, Country: ir.Country,
+			Description: ir.Org, Source: ipinfosvc.Name})
+	}
+	return asns, missing
 }
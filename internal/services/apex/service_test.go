@@ -1,25 +1,31 @@
 package apex_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/netip"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"codeberg.org/miekg/dns"
 	"codeberg.org/miekg/dns/rdata"
+	"codeberg.org/miekg/dns/svcb"
 	"github.com/imroc/req/v3"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	providers "github.com/tbckr/trident/internal/detect"
+	"github.com/tbckr/trident/internal/doh"
 	"github.com/tbckr/trident/internal/pap"
 	"github.com/tbckr/trident/internal/services"
 	"github.com/tbckr/trident/internal/services/apex"
+	"github.com/tbckr/trident/internal/services/ipinfo"
 	"github.com/tbckr/trident/internal/testutil"
 )
 
@@ -92,6 +98,102 @@ func TestApexService_MinPAP(t *testing.T) {
 	assert.Equal(t, pap.AMBER, svc.MinPAP())
 }
 
+func TestApexService_Run_ResponseMetadata(t *testing.T) {
+	client := newTestClient(t)
+
+	soaRR := &dns.SOA{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 3600}, SOA: rdata.SOA{Ns: "ns1.example.com.", Mbox: "hostmaster.example.com."}}
+
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		apexWireResponder(t, func(qname string, qtype uint16) []byte {
+			if qname == "example.com" && qtype == dns.TypeDNSKEY {
+				m := new(dns.Msg)
+				m.Response = true
+				m.AuthenticatedData = true
+				m.Rcode = dns.RcodeServerFailure
+				m.Pseudo = []dns.RR{&dns.EDE{InfoCode: 6, ExtraText: "validation failed"}}
+				require.NoError(t, m.Pack())
+				return m.Data
+			}
+			return buildWireResponse(t, dns.RcodeNameError, nil, []dns.RR{soaRR})
+		}))
+
+	svc := apex.NewService(client, &testutil.MockResolver{}, testutil.NopLogger(), embeddedPatterns(t))
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	result := raw.(*apex.Result)
+	assert.True(t, result.IsEmpty(), "response metadata alone does not make a result non-empty")
+	require.NotEmpty(t, result.Responses)
+
+	var dnskey *apex.Response
+	for i := range result.Responses {
+		r := &result.Responses[i]
+		if r.Host == "example.com" && r.Type == "DNSKEY" {
+			dnskey = r
+			continue
+		}
+		assert.Equal(t, "NXDOMAIN", r.Rcode)
+		require.Len(t, r.Authority, 1)
+		assert.Equal(t, "SOA", r.Authority[0].Type)
+	}
+	require.NotNil(t, dnskey)
+	assert.Equal(t, "SERVFAIL", dnskey.Rcode)
+	assert.True(t, dnskey.Flags.AuthenticatedData)
+	require.Len(t, dnskey.EDE, 1)
+	assert.Equal(t, "DNSSEC Bogus", dnskey.EDE[0].Name())
+	assert.Empty(t, dnskey.Authority)
+}
+
+func TestApexService_Run_TypedData(t *testing.T) {
+	client := newTestClient(t)
+
+	mxRR := &dns.MX{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}, MX: rdata.MX{Preference: 10, Mx: "mail.example.com."}}
+	httpsRR := &dns.HTTPS{SVCB: dns.SVCB{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}, SVCB: rdata.SVCB{Priority: 1, Target: ".", Value: []svcb.Pair{
+		&svcb.ALPN{Alpn: []string{"h2", "h3"}},
+		&svcb.IPV4HINT{Hint: []netip.Addr{netip.MustParseAddr("192.0.2.1")}},
+	}}}}
+	dsRR := &dns.DS{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}, DS: rdata.DS{KeyTag: 370, Algorithm: 13, DigestType: 2, Digest: "be74359954660069d5c63d200c39f5603827d7dd02b56f120ee9f3a86764247c"}}
+
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		apexWireResponder(t, func(qname string, qtype uint16) []byte {
+			if qname == "example.com" {
+				switch qtype {
+				case dns.TypeMX:
+					return buildWireResponse(t, 0, []dns.RR{mxRR}, nil)
+				case dns.TypeHTTPS:
+					return buildWireResponse(t, 0, []dns.RR{httpsRR}, nil)
+				case dns.TypeDS:
+					return buildWireResponse(t, 0, []dns.RR{dsRR}, nil)
+				}
+			}
+			return buildWireResponse(t, 0, nil, nil)
+		}))
+
+	svc := apex.NewService(client, &testutil.MockResolver{}, testutil.NopLogger(), embeddedPatterns(t))
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	byType := map[string]apex.Record{}
+	for _, rec := range raw.(*apex.Result).Records {
+		if rec.Host == "example.com" {
+			byType[rec.Type] = rec
+		}
+	}
+	assert.Equal(t, "10 mail.example.com.", byType["MX"].Value)
+	assert.Equal(t, &doh.MX{Preference: 10, Exchange: "mail.example.com."}, byType["MX"].Data)
+	assert.Equal(t, "1 . alpn=h2,h3 ipv4hint=192.0.2.1", byType["HTTPS"].Value)
+
+	out, err := json.Marshal(byType["HTTPS"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"host":"example.com","type":"HTTPS","value":"1 . alpn=h2,h3 ipv4hint=192.0.2.1",
+		"data":{"priority":1,"target":".","alpn":["h2","h3"],"ipv4hint":["192.0.2.1"]}}`, string(out))
+
+	ds, ok := byType["DS"].Data.(*doh.DS)
+	require.True(t, ok, "expected *doh.DS")
+	assert.Equal(t, uint16(370), ds.KeyTag)
+	assert.Equal(t, uint8(13), ds.Algorithm)
+}
+
 func TestApexService_Run_ValidDomain(t *testing.T) {
 	client := newTestClient(t)
 
@@ -166,15 +268,9 @@ func TestApexService_Run_CDNDetection(t *testing.T) {
 	result, ok := raw.(*apex.Result)
 	require.True(t, ok, "expected *apex.Result")
 
-	var cdnRecords []apex.Record
-	for _, rec := range result.Records {
-		if rec.Type == "CDN" {
-			cdnRecords = append(cdnRecords, rec)
-		}
-	}
-	require.Len(t, cdnRecords, 1)
-	assert.Contains(t, cdnRecords[0].Value, "AWS CloudFront")
-	assert.Contains(t, cdnRecords[0].Value, "abc.cloudfront.net.")
+	assert.Equal(t, []apex.CNAMEChain{{Host: "example.com", Chain: []string{"abc.cloudfront.net."}}}, result.CNAMEChains)
+	require.Len(t, result.Detections, 1)
+	assert.Equal(t, apex.Detection{Type: "CDN", Provider: "AWS CloudFront", Evidence: "abc.cloudfront.net.", Source: "cname"}, result.Detections[0])
 }
 
 func TestApexService_Run_EmailProviderDetection(t *testing.T) {
@@ -200,15 +296,8 @@ func TestApexService_Run_EmailProviderDetection(t *testing.T) {
 	result, ok := raw.(*apex.Result)
 	require.True(t, ok, "expected *apex.Result")
 
-	var emailRecords []apex.Record
-	for _, rec := range result.Records {
-		if rec.Host == "detected" && rec.Type == "Email" {
-			emailRecords = append(emailRecords, rec)
-		}
-	}
-	require.Len(t, emailRecords, 1)
-	assert.Equal(t, "Email", emailRecords[0].Type)
-	assert.Contains(t, emailRecords[0].Value, "Google Workspace")
+	require.Len(t, result.Detections, 1)
+	assert.Equal(t, apex.Detection{Type: "Email", Provider: "Google Workspace", Evidence: "aspmx.l.google.com.", Source: "mx"}, result.Detections[0])
 }
 
 func TestApexService_Run_DNSHostDetection(t *testing.T) {
@@ -234,15 +323,10 @@ func TestApexService_Run_DNSHostDetection(t *testing.T) {
 	result, ok := raw.(*apex.Result)
 	require.True(t, ok, "expected *apex.Result")
 
-	var dnsRecords []apex.Record
-	for _, rec := range result.Records {
-		if rec.Host == "detected" && rec.Type == "DNS" {
-			dnsRecords = append(dnsRecords, rec)
-		}
-	}
-	require.Len(t, dnsRecords, 1)
-	assert.Equal(t, "DNS", dnsRecords[0].Type)
-	assert.Contains(t, dnsRecords[0].Value, "Cloudflare DNS")
+	require.Len(t, result.Detections, 1)
+	assert.Equal(t, "DNS", result.Detections[0].Type)
+	assert.Equal(t, "Cloudflare DNS", result.Detections[0].Provider)
+	assert.Equal(t, "ns", result.Detections[0].Source)
 }
 
 func TestApexService_Run_TXTDetection(t *testing.T) {
@@ -268,16 +352,12 @@ func TestApexService_Run_TXTDetection(t *testing.T) {
 	result, ok := raw.(*apex.Result)
 	require.True(t, ok, "expected *apex.Result")
 
-	var verificationRecords []apex.Record
-	for _, rec := range result.Records {
-		if rec.Host == "detected" && rec.Type == "Verification" {
-			verificationRecords = append(verificationRecords, rec)
-		}
-	}
-	require.Len(t, verificationRecords, 1)
-	assert.Equal(t, "Verification", verificationRecords[0].Type)
-	assert.Contains(t, verificationRecords[0].Value, "Google")
-	assert.Contains(t, verificationRecords[0].Value, "google-site-verification=abc123")
+	require.Len(t, result.Detections, 1)
+	d := result.Detections[0]
+	assert.Equal(t, "Verification", d.Type)
+	assert.Contains(t, d.Provider, "Google")
+	assert.Equal(t, "google-site-verification=abc123", d.Evidence)
+	assert.Equal(t, "txt", d.Source)
 }
 
 func TestApexService_Run_InvalidInput(t *testing.T) {
@@ -432,15 +512,8 @@ func TestApexService_Run_SubdomainCNAMEDetection(t *testing.T) {
 	result, ok := raw.(*apex.Result)
 	require.True(t, ok, "expected *apex.Result")
 
-	var cdnRecords []apex.Record
-	for _, rec := range result.Records {
-		if rec.Type == "CDN" {
-			cdnRecords = append(cdnRecords, rec)
-		}
-	}
-	require.Len(t, cdnRecords, 1)
-	assert.Contains(t, cdnRecords[0].Value, "AWS CloudFront")
-	assert.Contains(t, cdnRecords[0].Value, "abc.cloudfront.net.")
+	require.Len(t, result.Detections, 1)
+	assert.Equal(t, apex.Detection{Type: "CDN", Provider: "AWS CloudFront", Evidence: "abc.cloudfront.net.", Source: "cname"}, result.Detections[0])
 }
 
 func TestApexService_Run_SubdomainTXTDetection(t *testing.T) {
@@ -467,15 +540,15 @@ func TestApexService_Run_SubdomainTXTDetection(t *testing.T) {
 	result, ok := raw.(*apex.Result)
 	require.True(t, ok, "expected *apex.Result")
 
-	var emailRecords []apex.Record
-	for _, rec := range result.Records {
-		if rec.Host == "detected" && rec.Type == "Email" {
-			emailRecords = append(emailRecords, rec)
+	var email []apex.Detection
+	for _, d := range result.Detections {
+		if d.Type == "Email" {
+			email = append(email, d)
 		}
 	}
-	require.NotEmpty(t, emailRecords, "expected Email detection from mail subdomain SPF")
-	assert.Contains(t, emailRecords[0].Value, "Google Workspace")
-	assert.Contains(t, emailRecords[0].Value, "include:_spf.google.com")
+	require.NotEmpty(t, email, "expected Email detection from mail subdomain SPF")
+	assert.Equal(t, "Google Workspace", email[0].Provider)
+	assert.Contains(t, email[0].Evidence, "include:_spf.google.com")
 }
 
 func TestApexService_Run_ManagedDMARCDelegation(t *testing.T) {
@@ -514,8 +587,8 @@ func TestApexService_Run_ManagedDMARCDelegation(t *testing.T) {
 	assert.Equal(t, "_dmarc.valimail.com.", dmarcCNAME[0].Value)
 
 	// No CDN detection — valimail.com is not a CDN endpoint.
-	for _, rec := range result.Records {
-		assert.NotEqual(t, "CDN", rec.Type, "unexpected CDN detection for non-CDN DMARC delegation")
+	for _, d := range result.Detections {
+		assert.NotEqual(t, "CDN", d.Type, "unexpected CDN detection for non-CDN DMARC delegation")
 	}
 }
 
@@ -655,16 +728,190 @@ func TestApexService_Run_ASNLookup(t *testing.T) {
 	result, ok := raw.(*apex.Result)
 	require.True(t, ok, "expected *apex.Result")
 
-	var asnRecords []apex.Record
-	for _, rec := range result.Records {
-		if rec.Type == "ASN" {
-			asnRecords = append(asnRecords, rec)
-		}
+	// One structured entry per IP.
+	require.Len(t, result.ASN, 2)
+	assert.Equal(t, apex.ASN{
+		IP: "93.184.216.34", ASN: "AS15133", Prefix: "93.184.216.0/24", Country: "US",
+		Registry: "arin", Description: "EDGECAST, US", Source: "cymru",
+	}, result.ASN[0])
+	assert.Equal(t, "93.184.216.35", result.ASN[1].IP)
+
+	// Two IPs in the same /24 produce identical ASN strings — the text view shows one row.
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, 1, strings.Count(buf.String(), "detected ASN AS15133 / 93.184.216.0/24 / US / arin / EDGECAST, US"),
+		"duplicate ASN values should be deduplicated")
+}
+
+func TestApexService_Run_OfflineASN(t *testing.T) {
+	client := newTestClient(t)
+
+	aRR := &dns.A{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}}
+	aRR.Addr = netip.MustParseAddr("93.184.216.34")
+
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		apexWireResponder(t, func(qname string, qtype uint16) []byte {
+			if qname == "example.com" && qtype == dns.TypeA {
+				return buildWireResponse(t, 0, []dns.RR{aRR}, nil)
+			}
+			return buildWireResponse(t, 0, nil, nil)
+		}))
+
+	dir := t.TempDir()
+	testutil.WriteMMDB(t, filepath.Join(dir, "asn.mmdb"), 6, "GeoLite2-ASN", map[string]any{
+		"93.184.216.0/24": map[string]any{
+			"autonomous_system_number":       15133,
+			"autonomous_system_organization": "EDGECAST",
+		},
+	})
+
+	// Local databases are available, so Team Cymru must not be queried.
+	mockRes := &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, name string) ([]string, error) {
+			t.Errorf("unexpected Cymru lookup for %s", name)
+			return nil, nil
+		},
 	}
-	// Two IPs in the same /24 produce identical ASN strings — expect exactly one deduplicated record.
-	require.Len(t, asnRecords, 1, "duplicate ASN values should be deduplicated")
-	assert.Equal(t, "detected", asnRecords[0].Host)
-	assert.Contains(t, asnRecords[0].Value, "AS15133")
-	assert.Contains(t, asnRecords[0].Value, "93.184.216.0/24")
-	assert.Contains(t, asnRecords[0].Value, "EDGECAST")
+
+	svc := apex.NewService(client, mockRes, testutil.NopLogger(), embeddedPatterns(t))
+	svc.EnableOfflineASN(ipinfo.NewService(dir, testutil.NopLogger()))
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	result, ok := raw.(*apex.Result)
+	require.True(t, ok, "expected *apex.Result")
+	require.Len(t, result.ASN, 1)
+	assert.Equal(t, apex.ASN{
+		IP: "93.184.216.34", ASN: "AS15133", Prefix: "93.184.216.0/24", Description: "EDGECAST", Source: "ipinfo",
+	}, result.ASN[0])
+	assert.Equal(t, "AS15133 / 93.184.216.0/24 /  / EDGECAST", result.ASN[0].String())
+}
+
+func TestApexService_Run_OfflineASN_FallsBackToCymru(t *testing.T) {
+	client := newTestClient(t)
+
+	aRR := &dns.A{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}}
+	aRR.Addr = netip.MustParseAddr("93.184.216.34")
+
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		apexWireResponder(t, func(qname string, qtype uint16) []byte {
+			if qname == "example.com" && qtype == dns.TypeA {
+				return buildWireResponse(t, 0, []dns.RR{aRR}, nil)
+			}
+			return buildWireResponse(t, 0, nil, nil)
+		}))
+
+	mockRes := &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, name string) ([]string, error) {
+			switch name {
+			case "34.216.184.93.origin.asn.cymru.com":
+				return []string{"15133 | 93.184.216.0/24 | US | arin | 2002-07-10"}, nil
+			case "AS15133.asn.cymru.com":
+				return []string{"15133 | US | arin | 2007-03-19 | EDGECAST, US"}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	// An empty data directory leaves the Cymru path in place.
+	svc := apex.NewService(client, mockRes, testutil.NopLogger(), embeddedPatterns(t))
+	svc.EnableOfflineASN(ipinfo.NewService(t.TempDir(), testutil.NopLogger()))
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	result := raw.(*apex.Result)
+	require.Len(t, result.ASN, 1)
+	assert.Equal(t, "cymru", result.ASN[0].Source)
+	assert.Equal(t, "arin", result.ASN[0].Registry)
+}
+
+func TestApexService_Run_OfflineASN_CymruForUnknownIPs(t *testing.T) {
+	client := newTestClient(t)
+
+	known := &dns.A{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}}
+	known.Addr = netip.MustParseAddr("93.184.216.34")
+	unknown := &dns.A{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}}
+	unknown.Addr = netip.MustParseAddr("198.51.100.7")
+
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		apexWireResponder(t, func(qname string, qtype uint16) []byte {
+			if qname == "example.com" && qtype == dns.TypeA {
+				return buildWireResponse(t, 0, []dns.RR{unknown, known}, nil)
+			}
+			return buildWireResponse(t, 0, nil, nil)
+		}))
+
+	dir := t.TempDir()
+	testutil.WriteMMDB(t, filepath.Join(dir, "asn.mmdb"), 6, "GeoLite2-ASN", map[string]any{
+		"93.184.216.0/24": map[string]any{"autonomous_system_number": 15133},
+	})
+
+	var cymruNames []string
+	mockRes := &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, name string) ([]string, error) {
+			cymruNames = append(cymruNames, name)
+			switch name {
+			case "7.100.51.198.origin.asn.cymru.com":
+				return []string{"64500 | 198.51.100.0/24 | ZZ | ripencc | 2010-01-01"}, nil
+			case "AS64500.asn.cymru.com":
+				return []string{"64500 | ZZ | ripencc | 2010-01-01 | EXAMPLE-AS, ZZ"}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	svc := apex.NewService(client, mockRes, testutil.NopLogger(), embeddedPatterns(t))
+	svc.EnableOfflineASN(ipinfo.NewService(dir, testutil.NopLogger()))
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	result := raw.(*apex.Result)
+	require.Len(t, result.ASN, 2)
+	assert.Equal(t, "198.51.100.7", result.ASN[0].IP)
+	assert.Equal(t, "cymru", result.ASN[0].Source)
+	assert.Equal(t, "93.184.216.34", result.ASN[1].IP)
+	assert.Equal(t, "ipinfo", result.ASN[1].Source)
+	assert.NotContains(t, cymruNames, "34.216.184.93.origin.asn.cymru.com", "IPs known offline must not reach Cymru")
+}
+
+func TestApexService_Run_OfflineASN_CityOnlyUsesCymru(t *testing.T) {
+	client := newTestClient(t)
+
+	aRR := &dns.A{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}}
+	aRR.Addr = netip.MustParseAddr("93.184.216.34")
+
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		apexWireResponder(t, func(qname string, qtype uint16) []byte {
+			if qname == "example.com" && qtype == dns.TypeA {
+				return buildWireResponse(t, 0, []dns.RR{aRR}, nil)
+			}
+			return buildWireResponse(t, 0, nil, nil)
+		}))
+
+	dir := t.TempDir()
+	testutil.WriteMMDB(t, filepath.Join(dir, "city.mmdb"), 6, "GeoLite2-City", map[string]any{
+		"93.184.216.0/24": map[string]any{"country": map[string]any{"iso_code": "US"}},
+	})
+
+	mockRes := &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, name string) ([]string, error) {
+			switch name {
+			case "34.216.184.93.origin.asn.cymru.com":
+				return []string{"15133 | 93.184.216.0/24 | US | arin | 2002-07-10"}, nil
+			case "AS15133.asn.cymru.com":
+				return []string{"15133 | US | arin | 2007-03-19 | EDGECAST, US"}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	// A City database carries no ASN data, so the ASN section still comes from Cymru.
+	svc := apex.NewService(client, mockRes, testutil.NopLogger(), embeddedPatterns(t))
+	svc.EnableOfflineASN(ipinfo.NewService(dir, testutil.NopLogger()))
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	result := raw.(*apex.Result)
+	require.Len(t, result.ASN, 1)
+	assert.Equal(t, "cymru", result.ASN[0].Source)
 }
@@ -0,0 +1,4 @@
+// Package asnprefixes lists the IPv4 and IPv6 prefixes announced by an
+// autonomous system, either from the RIPEstat announced-prefixes API or
+// offline from local MRT RIB dumps and CAIDA pfx2as files.
+package asnprefixes
@@ -0,0 +1,185 @@
+package asnprefixes
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"time"
+)
+
+// MRT constants (RFC 6396, RFC 8050).
+const (
+	mrtHeaderLen   = 12
+	mrtTableDumpV2 = 13
+
+	ribIPv4Unicast        = 2
+	ribIPv6Unicast        = 4
+	ribIPv4UnicastAddPath = 8
+	ribIPv6UnicastAddPath = 10
+
+	bgpAttrASPath     = 2
+	bgpAttrExtLenFlag = 0x10
+
+	asPathSet         = 1
+	asPathSequence    = 2
+	asPathConfedSeq   = 3
+	asPathConfedSet   = 4
+	maxMRTRecordBytes = 16 << 20
+)
+
+// isMRT reports whether header looks like an MRT TABLE_DUMP_V2 record.
+func isMRT(header []byte) bool {
+	return len(header) >= mrtHeaderLen && binary.BigEndian.Uint16(header[4:6]) == mrtTableDumpV2
+}
+
+// parseMRT reads a TABLE_DUMP_V2 RIB dump and records the origin ASN(s) of
+// every unicast prefix in idx. The earliest originated time across peers is
+// used as first seen and the dump timestamp as last seen. Records of other
+// MRT types and subtypes are skipped.
+func parseMRT(r io.Reader, idx originIndex) error {
+	header := make([]byte, mrtHeaderLen)
+	var body []byte
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("reading MRT header: %w", err)
+		}
+		ts := time.Unix(int64(binary.BigEndian.Uint32(header[0:4])), 0).UTC()
+		typ := binary.BigEndian.Uint16(header[4:6])
+		subtype := binary.BigEndian.Uint16(header[6:8])
+		length := binary.BigEndian.Uint32(header[8:12])
+		if length > maxMRTRecordBytes {
+			return fmt.Errorf("MRT record too large: %d bytes", length)
+		}
+		if cap(body) < int(length) {
+			body = make([]byte, length)
+		}
+		body = body[:length]
+		if _, err := io.ReadFull(r, body); err != nil {
+			return fmt.Errorf("reading MRT record: %w", err)
+		}
+		if typ != mrtTableDumpV2 {
+			continue
+		}
+		var bits int
+		addPath := false
+		switch subtype {
+		case ribIPv4Unicast:
+			bits = 32
+		case ribIPv6Unicast:
+			bits = 128
+		case ribIPv4UnicastAddPath:
+			bits, addPath = 32, true
+		case ribIPv6UnicastAddPath:
+			bits, addPath = 128, true
+		default:
+			continue
+		}
+		if err := parseRIBRecord(body, bits, addPath, ts, idx); err != nil {
+			return err
+		}
+	}
+}
+
+// parseRIBRecord decodes one RIB_IPV4/IPV6_UNICAST(_ADDPATH) record.
+func parseRIBRecord(b []byte, bits int, addPath bool, ts time.Time, idx originIndex) error {
+	errShort := errors.New("truncated MRT RIB record")
+	if len(b) < 5 {
+		return errShort
+	}
+	plen := int(b[4])
+	if plen > bits {
+		return fmt.Errorf("invalid MRT prefix length %d", plen)
+	}
+	n := (plen + 7) / 8
+	b = b[5:]
+	if len(b) < n+2 {
+		return errShort
+	}
+	raw := make([]byte, bits/8)
+	copy(raw, b[:n])
+	addr, _ := netip.AddrFromSlice(raw)
+	prefix := netip.PrefixFrom(addr, plen).Masked()
+	count := int(binary.BigEndian.Uint16(b[n : n+2]))
+	b = b[n+2:]
+
+	for range count {
+		hdr := 8 // peer index, originated time, attribute length
+		if addPath {
+			hdr += 4
+		}
+		if len(b) < hdr {
+			return errShort
+		}
+		originated := time.Unix(int64(binary.BigEndian.Uint32(b[2:6])), 0).UTC()
+		attrLen := int(binary.BigEndian.Uint16(b[hdr-2 : hdr]))
+		b = b[hdr:]
+		if len(b) < attrLen {
+			return errShort
+		}
+		for _, asn := range originASNs(b[:attrLen]) {
+			idx.add(asn, prefix, span{first: originated, last: ts})
+		}
+		b = b[attrLen:]
+	}
+	return nil
+}
+
+// originASNs extracts the origin AS(es) from BGP path attributes: the last
+// AS of a trailing AS_SEQUENCE or every member of a trailing AS_SET.
+// Confederation segments are ignored. AS numbers are four bytes wide as
+// mandated for TABLE_DUMP_V2.
+func originASNs(attrs []byte) []uint32 {
+	for len(attrs) >= 3 {
+		flags, code := attrs[0], attrs[1]
+		var l, off int
+		if flags&bgpAttrExtLenFlag != 0 {
+			if len(attrs) < 4 {
+				return nil
+			}
+			l, off = int(binary.BigEndian.Uint16(attrs[2:4])), 4
+		} else {
+			l, off = int(attrs[2]), 3
+		}
+		if len(attrs) < off+l {
+			return nil
+		}
+		if code == bgpAttrASPath {
+			return pathOrigins(attrs[off : off+l])
+		}
+		attrs = attrs[off+l:]
+	}
+	return nil
+}
+
+// pathOrigins returns the origin AS(es) of an encoded AS_PATH.
+func pathOrigins(path []byte) []uint32 {
+	var origins []uint32
+	for len(path) >= 2 {
+		segType, count := path[0], int(path[1])
+		path = path[2:]
+		if len(path) < count*4 {
+			return origins
+		}
+		seg := make([]uint32, count)
+		for i := range seg {
+			seg[i] = binary.BigEndian.Uint32(path[i*4:])
+		}
+		path = path[count*4:]
+		switch segType {
+		case asPathSequence:
+			if count > 0 {
+				origins = seg[count-1:]
+			}
+		case asPathSet:
+			origins = seg
+		case asPathConfedSeq, asPathConfedSet:
+			// Confederation-internal hops never carry the origin.
+		}
+	}
+	return origins
+}
@@ -0,0 +1,161 @@
+package asnprefixes
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mrtRoute is one RIB entry for buildMRT: a peer's route with its AS path
+// segments (type, ASNs) and originated time.
+type mrtRoute struct {
+	originated uint32
+	segments   []asSegment
+}
+
+type asSegment struct {
+	typ  byte
+	asns []uint32
+}
+
+// buildMRT encodes a TABLE_DUMP_V2 dump with a peer index table followed by
+// one RIB record per prefix.
+func buildMRT(t *testing.T, ts uint32, routes map[string][]mrtRoute) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	record := func(subtype uint16, body []byte) {
+		hdr := make([]byte, mrtHeaderLen)
+		binary.BigEndian.PutUint32(hdr[0:4], ts)
+		binary.BigEndian.PutUint16(hdr[4:6], mrtTableDumpV2)
+		binary.BigEndian.PutUint16(hdr[6:8], subtype)
+		binary.BigEndian.PutUint32(hdr[8:12], uint32(len(body)))
+		out.Write(hdr)
+		out.Write(body)
+	}
+	record(1, []byte{192, 0, 2, 1, 0, 0, 0, 0}) // PEER_INDEX_TABLE (skipped)
+
+	seq := uint32(0)
+	for cidr, entries := range routes {
+		p := netip.MustParsePrefix(cidr)
+		var body bytes.Buffer
+		_ = binary.Write(&body, binary.BigEndian, seq)
+		seq++
+		body.WriteByte(byte(p.Bits()))
+		body.Write(p.Addr().AsSlice()[:(p.Bits()+7)/8])
+		_ = binary.Write(&body, binary.BigEndian, uint16(len(entries)))
+		for i, e := range entries {
+			var path bytes.Buffer
+			for _, seg := range e.segments {
+				path.WriteByte(seg.typ)
+				path.WriteByte(byte(len(seg.asns)))
+				for _, asn := range seg.asns {
+					_ = binary.Write(&path, binary.BigEndian, asn)
+				}
+			}
+			var attrs bytes.Buffer
+			attrs.Write([]byte{0x40, 1, 1, 0})       // ORIGIN IGP
+			attrs.Write([]byte{0x50, bgpAttrASPath}) // AS_PATH, extended length
+			_ = binary.Write(&attrs, binary.BigEndian, uint16(path.Len()))
+			attrs.Write(path.Bytes())
+
+			_ = binary.Write(&body, binary.BigEndian, uint16(i))
+			_ = binary.Write(&body, binary.BigEndian, e.originated)
+			_ = binary.Write(&body, binary.BigEndian, uint16(attrs.Len()))
+			body.Write(attrs.Bytes())
+		}
+		subtype := uint16(ribIPv4Unicast)
+		if p.Addr().Is6() {
+			subtype = ribIPv6Unicast
+		}
+		record(subtype, body.Bytes())
+	}
+	return out.Bytes()
+}
+
+func TestParseMRT(t *testing.T) {
+	dump := buildMRT(t, 2000, map[string][]mrtRoute{
+		"8.8.8.0/24": {
+			{originated: 1500, segments: []asSegment{{asPathSequence, []uint32{3356, 15169}}}},
+			{originated: 1200, segments: []asSegment{{asPathSequence, []uint32{174, 15169}}}},
+		},
+		"2001:4860::/32": {
+			{originated: 1800, segments: []asSegment{{asPathSequence, []uint32{6939, 15169}}}},
+		},
+		"192.0.2.0/24": {
+			{originated: 1900, segments: []asSegment{
+				{asPathSequence, []uint32{3356}},
+				{asPathSet, []uint32{64500, 64501}},
+			}},
+		},
+		"198.51.100.0/24": {
+			{originated: 1900, segments: []asSegment{
+				{asPathSequence, []uint32{3356, 64510}},
+				{asPathConfedSeq, []uint32{65001}},
+			}},
+		},
+	})
+
+	idx := originIndex{}
+	require.NoError(t, parseMRT(bytes.NewReader(dump), idx))
+
+	google := idx[15169]
+	require.Len(t, google, 2)
+	assert.Equal(t, span{time.Unix(1200, 0).UTC(), time.Unix(2000, 0).UTC()}, google[netip.MustParsePrefix("8.8.8.0/24")])
+	assert.Contains(t, google, netip.MustParsePrefix("2001:4860::/32"))
+
+	assert.Contains(t, idx[64500], netip.MustParsePrefix("192.0.2.0/24"), "AS_SET members are origins")
+	assert.Contains(t, idx[64501], netip.MustParsePrefix("192.0.2.0/24"))
+	assert.Contains(t, idx[64510], netip.MustParsePrefix("198.51.100.0/24"), "confederation segments are ignored")
+	assert.NotContains(t, idx, uint32(3356))
+}
+
+func TestParseMRT_Truncated(t *testing.T) {
+	dump := buildMRT(t, 2000, map[string][]mrtRoute{
+		"8.8.8.0/24": {{originated: 1, segments: []asSegment{{asPathSequence, []uint32{15169}}}}},
+	})
+	err := parseMRT(bytes.NewReader(dump[:len(dump)-3]), originIndex{})
+	require.Error(t, err)
+}
+
+func TestLoadFile_DetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err := zw.Write(buildMRT(t, 2000, map[string][]mrtRoute{
+		"8.8.8.0/24": {{originated: 1, segments: []asSegment{{asPathSequence, []uint32{15169}}}}},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	mrtPath := filepath.Join(dir, "bview.20260101.0000.gz")
+	require.NoError(t, os.WriteFile(mrtPath, gz.Bytes(), 0o600))
+
+	pfxPath := filepath.Join(dir, "routeviews-rv2.pfx2as")
+	require.NoError(t, os.WriteFile(pfxPath, []byte("8.8.4.0\t24\t15169\n1.0.0.0\t24\t13335_15169\n2001:db8::\t32\t64500,64501\n"), 0o600))
+
+	idx := originIndex{}
+	require.NoError(t, loadFile(mrtPath, idx))
+	require.NoError(t, loadFile(pfxPath, idx))
+
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("1.0.0.0/24"),
+		netip.MustParsePrefix("8.8.4.0/24"),
+		netip.MustParsePrefix("8.8.8.0/24"),
+	}, idx[15169].sorted())
+	assert.Contains(t, idx[13335], netip.MustParsePrefix("1.0.0.0/24"))
+	assert.Contains(t, idx[64501], netip.MustParsePrefix("2001:db8::/32"))
+}
+
+func TestParsePfx2as_Invalid(t *testing.T) {
+	for _, line := range []string{"8.8.8.0\t24\n", "bogus\t24\t1\n", "8.8.8.0\t33\t1\n", "8.8.8.0\t24\tAS1\n"} {
+		require.Error(t, parsePfx2as(bytes.NewReader([]byte(line)), originIndex{}), line)
+	}
+}
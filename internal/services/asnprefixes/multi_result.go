@@ -0,0 +1,30 @@
+package asnprefixes
+
+import (
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds asn-prefixes results for multiple inputs.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteTable renders all results in a single combined table grouped by ASN.
+// Columns: ASN / Prefix / First Seen / Last Seen. ASN cells are merged hierarchically.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows [][]string
+	for _, r := range m.Results {
+		for _, row := range r.rows() {
+			rows = append(rows, append([]string{r.ASN}, row...))
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 20, 20)
+	table.Header([]string{"ASN", "Prefix", "First Seen", "Last Seen"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
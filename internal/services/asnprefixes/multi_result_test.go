@@ -0,0 +1,64 @@
+package asnprefixes_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/asnprefixes"
+)
+
+func TestMultiResult_IsEmpty(t *testing.T) {
+	t.Run("empty when no results", func(t *testing.T) {
+		assert.True(t, (&asnprefixes.MultiResult{}).IsEmpty())
+	})
+
+	t.Run("empty when no ASN announces a prefix", func(t *testing.T) {
+		mr := &asnprefixes.MultiResult{}
+		mr.Results = []*asnprefixes.Result{{Input: "AS1", ASN: "AS1"}, {Input: "AS2", ASN: "AS2"}}
+		assert.True(t, mr.IsEmpty())
+	})
+
+	t.Run("not empty when one ASN announces a prefix", func(t *testing.T) {
+		mr := &asnprefixes.MultiResult{}
+		mr.Results = []*asnprefixes.Result{
+			{Input: "AS1", ASN: "AS1"},
+			{Input: "AS13335", ASN: "AS13335", Prefixes: []asnprefixes.Prefix{{Prefix: "1.1.1.0/24"}}},
+		}
+		assert.False(t, mr.IsEmpty())
+	})
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	mr := &asnprefixes.MultiResult{}
+	mr.Results = []*asnprefixes.Result{
+		{
+			Input: "AS15169", ASN: "AS15169",
+			Prefixes: []asnprefixes.Prefix{{Prefix: "8.8.8.0/24", FirstSeen: "2026-10-04T08:00:00Z"}, {Prefix: "2001:4860::/32"}},
+		},
+		{Input: "AS1", ASN: "AS1"},
+		{Input: "AS13335", ASN: "AS13335", Prefixes: []asnprefixes.Prefix{{Prefix: "1.1.1.0/24"}}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "ASN")
+	assert.Contains(t, out, "AS15169")
+	assert.Contains(t, out, "AS13335")
+	assert.Contains(t, out, "1.1.1.0/24")
+	assert.NotContains(t, out, "AS1 ", "ASNs without prefixes have no rows")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	mr := &asnprefixes.MultiResult{}
+	mr.Results = []*asnprefixes.Result{
+		{Input: "AS15169", ASN: "AS15169", Prefixes: []asnprefixes.Prefix{{Prefix: "8.8.8.0/24"}, {Prefix: "2001:4860::/32"}}},
+		{Input: "AS13335", ASN: "AS13335", Prefixes: []asnprefixes.Prefix{{Prefix: "1.1.1.0/24"}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteText(&buf))
+	assert.Equal(t, "8.8.8.0/24\n2001:4860::/32\n1.1.1.0/24\n", buf.String())
+}
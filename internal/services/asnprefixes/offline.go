@@ -0,0 +1,98 @@
+package asnprefixes
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// originIndex maps origin ASNs to the prefixes they announce.
+type originIndex map[uint32]prefixSet
+
+// add records that asn originated p during sp.
+func (idx originIndex) add(asn uint32, p netip.Prefix, sp span) {
+	set := idx[asn]
+	if set == nil {
+		set = prefixSet{}
+		idx[asn] = set
+	}
+	set.add(p, sp)
+}
+
+// loadFile adds the routes of an MRT RIB dump or pfx2as file to idx. Files
+// ending in .gz or .bz2 are decompressed; the format is detected from the
+// content.
+func loadFile(path string, idx originIndex) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gz":
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	case ".bz2":
+		r = bzip2.NewReader(f)
+	}
+
+	br := bufio.NewReaderSize(r, 1<<16)
+	header, err := br.Peek(mrtHeaderLen)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if isMRT(header) {
+		return parseMRT(br, idx)
+	}
+	return parsePfx2as(br, idx)
+}
+
+// parsePfx2as reads a CAIDA RouteViews prefix-to-AS file: one
+// "prefix<TAB>length<TAB>ASN" line per route, where ASN may list several
+// origins joined by "_" (MOAS) or "," (AS set). The format carries no
+// timestamps.
+func parsePfx2as(r io.Reader, idx originIndex) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return fmt.Errorf("pfx2as line %d: want 3 fields, got %d", line, len(fields))
+		}
+		addr, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return fmt.Errorf("pfx2as line %d: %w", line, err)
+		}
+		bits, err := strconv.Atoi(fields[1])
+		if err != nil || bits < 0 || bits > addr.BitLen() {
+			return fmt.Errorf("pfx2as line %d: invalid prefix length %q", line, fields[1])
+		}
+		prefix := netip.PrefixFrom(addr, bits)
+		for as := range strings.FieldsFuncSeq(fields[2], func(r rune) bool { return r == '_' || r == ',' }) {
+			asn, err := strconv.ParseUint(as, 10, 32)
+			if err != nil {
+				return fmt.Errorf("pfx2as line %d: invalid ASN %q", line, as)
+			}
+			idx.add(uint32(asn), prefix, span{})
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,124 @@
+package asnprefixes
+
+import (
+	"net/netip"
+	"slices"
+	"time"
+)
+
+// span is the observation window of a prefix; zero times are unknown.
+type span struct {
+	first, last time.Time
+}
+
+// merge widens s to cover o.
+func (s *span) merge(o span) {
+	if !o.first.IsZero() && (s.first.IsZero() || o.first.Before(s.first)) {
+		s.first = o.first
+	}
+	if !o.last.IsZero() && (s.last.IsZero() || o.last.After(s.last)) {
+		s.last = o.last
+	}
+}
+
+// prefixSet collects the prefixes of one ASN, merging duplicate observations.
+type prefixSet map[netip.Prefix]span
+
+// add records an observation of p.
+func (ps prefixSet) add(p netip.Prefix, sp span) {
+	p = p.Masked()
+	cur := ps[p]
+	cur.merge(sp)
+	ps[p] = cur
+}
+
+// sorted returns the prefixes in IPv4-before-IPv6 address order.
+func (ps prefixSet) sorted() []netip.Prefix {
+	out := make([]netip.Prefix, 0, len(ps))
+	for p := range ps {
+		out = append(out, p)
+	}
+	slices.SortFunc(out, comparePrefix)
+	return out
+}
+
+// comparePrefix orders IPv4 before IPv6, then by address and prefix length.
+func comparePrefix(a, b netip.Prefix) int {
+	if a.Addr().Is4() != b.Addr().Is4() {
+		if a.Addr().Is4() {
+			return -1
+		}
+		return 1
+	}
+	if c := a.Addr().Compare(b.Addr()); c != 0 {
+		return c
+	}
+	return a.Bits() - b.Bits()
+}
+
+// aggregate collapses the set into the smallest list of covering prefixes:
+// more-specifics are folded into a covering announcement and adjacent sibling
+// prefixes are merged into their parent. Observation windows are merged into
+// the surviving prefix.
+func (ps prefixSet) aggregate() prefixSet {
+	cur := make(prefixSet, len(ps))
+	for p, sp := range ps {
+		cur.add(p, sp)
+	}
+	for {
+		changed := false
+		list := cur.sorted()
+
+		// Fold more-specifics into the nearest covering prefix. Sorting by
+		// address then length places every covered prefix after its cover.
+		var covers []netip.Prefix
+		for _, p := range list {
+			for len(covers) > 0 && !covers[len(covers)-1].Contains(p.Addr()) {
+				covers = covers[:len(covers)-1]
+			}
+			if len(covers) > 0 {
+				top := covers[len(covers)-1]
+				sp := cur[top]
+				sp.merge(cur[p])
+				cur[top] = sp
+				delete(cur, p)
+				changed = true
+				continue
+			}
+			covers = append(covers, p)
+		}
+
+		// Merge sibling pairs into their parent.
+		for p, sp := range cur {
+			if p.Bits() == 0 {
+				continue
+			}
+			parent, _ := p.Addr().Prefix(p.Bits() - 1)
+			sib := sibling(p, parent)
+			ssp, ok := cur[sib]
+			if !ok {
+				continue
+			}
+			delete(cur, p)
+			delete(cur, sib)
+			sp.merge(ssp)
+			cur.add(parent, sp)
+			changed = true
+		}
+		if !changed {
+			return cur
+		}
+	}
+}
+
+// sibling returns the other half of parent that p is not.
+func sibling(p, parent netip.Prefix) netip.Prefix {
+	b := parent.Addr().AsSlice()
+	bit := parent.Bits()
+	b[bit/8] |= 1 << (7 - uint(bit%8))
+	upper, _ := netip.AddrFromSlice(b)
+	if p.Addr() == parent.Addr() {
+		return netip.PrefixFrom(upper, p.Bits())
+	}
+	return netip.PrefixFrom(parent.Addr(), p.Bits())
+}
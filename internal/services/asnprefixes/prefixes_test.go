@@ -0,0 +1,50 @@
+package asnprefixes
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixSet_Aggregate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.January, d, 0, 0, 0, 0, time.UTC) }
+	set := prefixSet{}
+	set.add(netip.MustParsePrefix("10.0.0.0/24"), span{day(5), day(9)})
+	set.add(netip.MustParsePrefix("10.0.1.0/24"), span{day(3), day(8)})
+	set.add(netip.MustParsePrefix("10.0.0.128/25"), span{day(1), day(2)}) // covered
+	set.add(netip.MustParsePrefix("10.0.3.0/24"), span{})                 // no sibling
+	set.add(netip.MustParsePrefix("2001:db8::/33"), span{})
+	set.add(netip.MustParsePrefix("2001:db8:8000::/33"), span{})
+
+	agg := set.aggregate()
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/23"),
+		netip.MustParsePrefix("10.0.3.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}, agg.sorted())
+	assert.Equal(t, span{day(1), day(9)}, agg[netip.MustParsePrefix("10.0.0.0/23")])
+	assert.Len(t, set, 6, "aggregate must not modify the receiver")
+}
+
+func TestPrefixSet_AddDeduplicates(t *testing.T) {
+	set := prefixSet{}
+	set.add(netip.MustParsePrefix("192.0.2.0/24"), span{first: time.Unix(200, 0)})
+	set.add(netip.MustParsePrefix("192.0.2.1/24"), span{first: time.Unix(100, 0), last: time.Unix(300, 0)})
+	assert.Len(t, set, 1)
+	assert.Equal(t, span{time.Unix(100, 0), time.Unix(300, 0)}, set[netip.MustParsePrefix("192.0.2.0/24")])
+}
+
+func TestComparePrefix_IPv4First(t *testing.T) {
+	set := prefixSet{}
+	for _, p := range []string{"2001:db8::/32", "192.0.2.0/24", "192.0.2.0/23", "10.0.0.0/8"} {
+		set.add(netip.MustParsePrefix(p), span{})
+	}
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.0.2.0/23"),
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}, set.sorted())
+}
@@ -0,0 +1,59 @@
+package asnprefixes
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Prefix is one announced prefix and its observation window.
+type Prefix struct {
+	Prefix    string `json:"prefix"`
+	FirstSeen string `json:"first_seen,omitempty"` // RFC 3339; empty when the source has no timestamps
+	LastSeen  string `json:"last_seen,omitempty"`
+}
+
+// Result holds the prefixes announced by a single ASN.
+type Result struct {
+	Input     string   `json:"input"`
+	ASN       string   `json:"asn"`
+	Sources   []string `json:"sources,omitempty"` // "ripestat" or the route data file names
+	IPv4Count int      `json:"ipv4_count"`
+	IPv6Count int      `json:"ipv6_count"`
+	Prefixes  []Prefix `json:"prefixes,omitempty"`
+}
+
+// IsEmpty reports whether no prefix was found.
+func (r *Result) IsEmpty() bool {
+	return len(r.Prefixes) == 0
+}
+
+// rows returns the Prefix / First Seen / Last Seen table cells.
+func (r *Result) rows() [][]string {
+	rows := make([][]string, 0, len(r.Prefixes))
+	for _, p := range r.Prefixes {
+		rows = append(rows, []string{p.Prefix, p.FirstSeen, p.LastSeen})
+	}
+	return rows
+}
+
+// WriteText renders the result as plain text with one prefix per line.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, p := range r.Prefixes {
+		if _, err := fmt.Fprintln(w, p.Prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the prefixes as a Prefix / First Seen / Last Seen table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 20)
+	table.Header([]string{"Prefix", "First Seen", "Last Seen"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
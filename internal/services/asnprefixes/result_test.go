@@ -0,0 +1,55 @@
+package asnprefixes_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/asnprefixes"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&asnprefixes.Result{Input: "AS1", ASN: "AS1", Sources: []string{"ripestat"}}).IsEmpty())
+	assert.False(t, (&asnprefixes.Result{Input: "AS13335", ASN: "AS13335", Prefixes: []asnprefixes.Prefix{{Prefix: "1.1.1.0/24"}}}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &asnprefixes.Result{
+		Input:     "AS15169",
+		ASN:       "AS15169",
+		IPv4Count: 2,
+		IPv6Count: 1,
+		Prefixes: []asnprefixes.Prefix{
+			{Prefix: "8.8.4.0/24"},
+			{Prefix: "8.8.8.0/24"},
+			{Prefix: "2001:4860::/32"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "8.8.4.0/24\n8.8.8.0/24\n2001:4860::/32\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &asnprefixes.Result{
+		Input:     "AS15169",
+		ASN:       "AS15169",
+		Sources:   []string{"ripestat"},
+		IPv4Count: 1,
+		IPv6Count: 1,
+		Prefixes: []asnprefixes.Prefix{
+			{Prefix: "8.8.8.0/24", FirstSeen: "2026-10-04T08:00:00Z", LastSeen: "2026-10-18T08:00:00Z"},
+			{Prefix: "2001:4860::/32"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "FIRST SEEN")
+	assert.Contains(t, out, "8.8.8.0/24")
+	assert.Contains(t, out, "2026-10-04")
+	assert.Contains(t, out, "2026-10-18")
+	assert.Contains(t, out, "2001:4860::/32")
+}
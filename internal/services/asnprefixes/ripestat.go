@@ -0,0 +1,82 @@
+package asnprefixes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// ripestatURL is the RIPEstat announced-prefixes data call. sourceapp
+// identifies the client as requested by the RIPEstat fair-use policy.
+const ripestatURL = "https://stat.ripe.net/data/announced-prefixes/data.json?resource=AS%d&sourceapp=trident"
+
+// ripestatTimeLayout is the zone-less UTC timestamp format used by RIPEstat.
+const ripestatTimeLayout = "2006-01-02T15:04:05"
+
+// ripestatResponse is the subset of the announced-prefixes response we use.
+type ripestatResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Prefixes []struct {
+			Prefix    string `json:"prefix"`
+			Timelines []struct {
+				StartTime string `json:"starttime"`
+				EndTime   string `json:"endtime"`
+			} `json:"timelines"`
+		} `json:"prefixes"`
+	} `json:"data"`
+}
+
+// fetchRIPEstat returns the prefixes RIPE RIS saw announced by asn during
+// RIPEstat's default query window (the last two weeks). A cancelled context
+// yields an empty set and no error so partial results are kept.
+func (s *Service) fetchRIPEstat(ctx context.Context, asn uint32) (prefixSet, error) {
+	var body ripestatResponse
+	resp, err := s.client.R().SetContext(ctx).SetSuccessResult(&body).Get(fmt.Sprintf(ripestatURL, asn))
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return prefixSet{}, nil
+		}
+		return nil, fmt.Errorf("%w: %s", services.ErrRequestFailed, err)
+	}
+	if resp.Response == nil || !resp.IsSuccessState() {
+		msg := resp.String()
+		if len(msg) > 200 {
+			msg = msg[:200] + "..."
+		}
+		return nil, fmt.Errorf("%w: RIPEstat HTTP %d: %q", services.ErrRequestFailed, resp.StatusCode, msg)
+	}
+	if body.Status != "ok" {
+		return nil, fmt.Errorf("%w: RIPEstat status %q", services.ErrRequestFailed, output.StripANSI(body.Status))
+	}
+
+	set := prefixSet{}
+	for _, p := range body.Data.Prefixes {
+		prefix, err := netip.ParsePrefix(p.Prefix)
+		if err != nil {
+			s.logger.Debug("asn-prefixes: skipping malformed RIPEstat prefix", "prefix", output.StripANSI(p.Prefix), "error", err)
+			continue
+		}
+		var sp span
+		for _, tl := range p.Timelines {
+			sp.merge(span{first: parseRIPEstatTime(tl.StartTime), last: parseRIPEstatTime(tl.EndTime)})
+		}
+		set.add(prefix, sp)
+	}
+	return set, nil
+}
+
+// parseRIPEstatTime parses a RIPEstat timestamp, returning the zero time for
+// empty or malformed values.
+func parseRIPEstatTime(v string) time.Time {
+	t, err := time.Parse(ripestatTimeLayout, v)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
@@ -0,0 +1,154 @@
+package asnprefixes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// DefaultRPS is the target request rate for the RIPEstat API.
+	DefaultRPS float64 = 2
+	// DefaultBurst is the burst capacity above DefaultRPS.
+	DefaultBurst = 2
+
+	// Name is the service identifier.
+	Name = "asn-prefixes"
+	// PAP is the PAP activity level when querying RIPEstat.
+	PAP = pap.AMBER
+	// MinPAP is the PAP activity level when reading local route data files,
+	// which performs no network traffic.
+	MinPAP = pap.RED
+)
+
+// Service lists the prefixes announced by an ASN, online via RIPEstat or
+// offline from local route data files.
+type Service struct {
+	client    *req.Client
+	logger    *slog.Logger
+	files     []string
+	aggregate bool
+
+	once    sync.Once
+	index   originIndex
+	loadErr error
+}
+
+// NewService creates a new asn-prefixes service. When files is non-empty the
+// MRT RIB dumps and pfx2as files are read instead of querying RIPEstat; client
+// may then be nil. aggregate collapses the prefixes into the smallest covering
+// set.
+func NewService(client *req.Client, logger *slog.Logger, files []string, aggregate bool) *Service {
+	return &Service{client: client, logger: logger, files: files, aggregate: aggregate}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns RED for offline lookups and AMBER for RIPEstat queries.
+func (s *Service) PAP() pap.Level {
+	if len(s.files) > 0 {
+		return MinPAP
+	}
+	return PAP
+}
+
+// AggregateResults combines multiple asn-prefixes results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run lists the prefixes announced by the given ASN (e.g. "AS15169").
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	input = output.StripANSI(strings.TrimSpace(input))
+	asn, err := parseASN(input)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Input: input, ASN: fmt.Sprintf("AS%d", asn)}
+
+	var set prefixSet
+	if len(s.files) > 0 {
+		if err := s.load(); err != nil {
+			return nil, err
+		}
+		set = s.index[asn]
+		for _, f := range s.files {
+			result.Sources = append(result.Sources, filepath.Base(f))
+		}
+	} else {
+		if set, err = s.fetchRIPEstat(ctx, asn); err != nil {
+			return nil, err
+		}
+		result.Sources = []string{"ripestat"}
+	}
+	if s.aggregate {
+		set = set.aggregate()
+	}
+
+	for _, p := range set.sorted() {
+		sp := set[p]
+		result.Prefixes = append(result.Prefixes, Prefix{
+			Prefix:    p.String(),
+			FirstSeen: formatTime(sp.first),
+			LastSeen:  formatTime(sp.last),
+		})
+		if p.Addr().Is4() {
+			result.IPv4Count++
+		} else {
+			result.IPv6Count++
+		}
+	}
+	return result, nil
+}
+
+// load parses every route data file once.
+func (s *Service) load() error {
+	s.once.Do(func() {
+		idx := originIndex{}
+		for _, f := range s.files {
+			if err := loadFile(f, idx); err != nil {
+				s.loadErr = fmt.Errorf("reading route data %s: %w", f, err)
+				return
+			}
+		}
+		s.index = idx
+	})
+	return s.loadErr
+}
+
+// parseASN accepts "AS15169", "as15169", or "15169".
+func parseASN(input string) (uint32, error) {
+	num := input
+	if len(num) > 2 && strings.EqualFold(num[:2], "AS") {
+		num = num[2:]
+	}
+	n, err := strconv.ParseUint(num, 10, 32)
+	if err != nil || strings.HasPrefix(num, "+") {
+		return 0, fmt.Errorf("%w: must be an ASN (e.g. AS15169): %q", services.ErrInvalidInput, input)
+	}
+	return uint32(n), nil
+}
+
+// formatTime renders t as RFC 3339, or "" when unknown.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,165 @@
+package asnprefixes_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/asnprefixes"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+const ripestatURL = "https://stat.ripe.net/data/announced-prefixes/data.json?resource=AS15169&sourceapp=trident"
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return data
+}
+
+func TestRun_RIPEstat(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, ripestatURL,
+		httpmock.NewBytesResponder(http.StatusOK, mustReadFile(t, "testdata/ripestat_as15169.json")))
+
+	svc := asnprefixes.NewService(client, testutil.NopLogger(), nil, false)
+	raw, err := svc.Run(context.Background(), "as15169")
+	require.NoError(t, err)
+	result, ok := raw.(*asnprefixes.Result)
+	require.True(t, ok, "expected *asnprefixes.Result")
+
+	assert.Equal(t, "AS15169", result.ASN)
+	assert.Equal(t, []string{"ripestat"}, result.Sources)
+	assert.Equal(t, 3, result.IPv4Count)
+	assert.Equal(t, 1, result.IPv6Count)
+	require.Len(t, result.Prefixes, 4)
+	assert.Equal(t, asnprefixes.Prefix{
+		Prefix:    "8.8.8.0/24",
+		FirstSeen: "2026-10-04T08:00:00Z",
+		LastSeen:  "2026-10-18T08:00:00Z",
+	}, result.Prefixes[2], "timelines are merged into one window")
+	assert.Equal(t, "2001:4860::/32", result.Prefixes[3].Prefix, "IPv6 sorts after IPv4")
+}
+
+func TestRun_RIPEstat_Aggregate(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, ripestatURL,
+		httpmock.NewBytesResponder(http.StatusOK, mustReadFile(t, "testdata/ripestat_as15169.json")))
+
+	svc := asnprefixes.NewService(client, testutil.NopLogger(), nil, true)
+	raw, err := svc.Run(context.Background(), "AS15169")
+	require.NoError(t, err)
+	result := raw.(*asnprefixes.Result)
+
+	var prefixes []string
+	for _, p := range result.Prefixes {
+		prefixes = append(prefixes, p.Prefix)
+	}
+	assert.Equal(t, []string{"8.8.4.0/24", "8.8.8.0/24", "2001:4860::/32"}, prefixes)
+}
+
+func TestRun_RIPEstat_HTTPError(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, ripestatURL,
+		httpmock.NewStringResponder(http.StatusInternalServerError, "boom"))
+
+	svc := asnprefixes.NewService(client, testutil.NopLogger(), nil, false)
+	_, err := svc.Run(context.Background(), "AS15169")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_RIPEstat_StatusError(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, ripestatURL,
+		httpmock.NewStringResponder(http.StatusOK, `{"status":"error","data":{}}`))
+
+	svc := asnprefixes.NewService(client, testutil.NopLogger(), nil, false)
+	_, err := svc.Run(context.Background(), "AS15169")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_ContextCancelled(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, ripestatURL,
+		httpmock.NewBytesResponder(http.StatusOK, mustReadFile(t, "testdata/ripestat_as15169.json")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc := asnprefixes.NewService(client, testutil.NopLogger(), nil, false)
+	raw, err := svc.Run(ctx, "AS15169")
+	require.NoError(t, err)
+	result, ok := raw.(*asnprefixes.Result)
+	require.True(t, ok, "expected *asnprefixes.Result")
+	assert.Equal(t, "AS15169", result.ASN)
+}
+
+func TestRun_Offline(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "routeviews-rv2-20261018-1200.pfx2as")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"8.8.8.0\t24\t15169\n8.8.4.0\t24\t15169\n1.0.0.0\t24\t13335\n2001:4860::\t32\t15169\n"), 0o600))
+
+	svc := asnprefixes.NewService(nil, testutil.NopLogger(), []string{path}, false)
+	assert.Equal(t, pap.RED, svc.PAP())
+
+	raw, err := svc.Run(context.Background(), "15169")
+	require.NoError(t, err)
+	result := raw.(*asnprefixes.Result)
+	assert.Equal(t, "AS15169", result.ASN)
+	assert.Equal(t, []string{"routeviews-rv2-20261018-1200.pfx2as"}, result.Sources)
+	require.Len(t, result.Prefixes, 3)
+	assert.Equal(t, asnprefixes.Prefix{Prefix: "8.8.4.0/24"}, result.Prefixes[0])
+
+	raw, err = svc.Run(context.Background(), "AS64500")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestRun_OfflineMissingFile(t *testing.T) {
+	svc := asnprefixes.NewService(nil, testutil.NopLogger(), []string{filepath.Join(t.TempDir(), "missing.pfx2as")}, false)
+	_, err := svc.Run(context.Background(), "AS15169")
+	require.Error(t, err)
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	svc := asnprefixes.NewService(nil, testutil.NopLogger(), nil, false)
+	for _, input := range []string{"", "AS", "ASX", "8.8.8.8", "AS-1", "+15169", "AS4294967296"} {
+		_, err := svc.Run(context.Background(), input)
+		require.ErrorIs(t, err, services.ErrInvalidInput, input)
+	}
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := asnprefixes.NewService(nil, testutil.NopLogger(), nil, false)
+	assert.Equal(t, "asn-prefixes", svc.Name())
+	assert.Equal(t, pap.AMBER, svc.PAP())
+}
+
+func TestService_AggregateResults(t *testing.T) {
+	svc := asnprefixes.NewService(nil, testutil.NopLogger(), nil, false)
+	agg := svc.AggregateResults([]services.Result{
+		&asnprefixes.Result{Input: "AS1"},
+		&asnprefixes.Result{Input: "AS2"},
+	})
+	mr, ok := agg.(*asnprefixes.MultiResult)
+	require.True(t, ok, "expected *asnprefixes.MultiResult")
+	assert.Len(t, mr.Results, 2)
+}
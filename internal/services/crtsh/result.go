@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/snapshot"
 )
 
 // Result holds the unique subdomains found in the crt.sh certificate log.
@@ -18,6 +19,15 @@ func (r *Result) IsEmpty() bool {
 	return len(r.Subdomains) == 0
 }
 
+// SnapshotRecords returns one "subdomain" record per subdomain.
+func (r *Result) SnapshotRecords() []snapshot.Record {
+	out := make([]snapshot.Record, len(r.Subdomains))
+	for i, sub := range r.Subdomains {
+		out[i] = snapshot.Record{Key: "subdomain", Value: sub}
+	}
+	return out
+}
+
 // WriteText renders the result as plain text with one subdomain per line.
 func (r *Result) WriteText(w io.Writer) error {
 	for _, sub := range r.Subdomains {
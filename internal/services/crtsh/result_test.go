@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tbckr/trident/internal/services/crtsh"
+	"github.com/tbckr/trident/internal/snapshot"
 )
 
 func TestResult_IsEmpty(t *testing.T) {
@@ -40,3 +41,11 @@ func TestResult_WriteText(t *testing.T) {
 	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
 	assert.Equal(t, []string{"api.example.com", "www.example.com"}, lines)
 }
+
+func TestResult_SnapshotRecords(t *testing.T) {
+	result := &crtsh.Result{Input: "example.com", Subdomains: []string{"api.example.com", "www.example.com"}}
+	assert.Equal(t, []snapshot.Record{
+		{Key: "subdomain", Value: "api.example.com"},
+		{Key: "subdomain", Value: "www.example.com"},
+	}, result.SnapshotRecords())
+}
@@ -0,0 +1,188 @@
+package cymru
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// bulkServer is Team Cymru's bulk IP → ASN whois interface.
+	bulkServer = "whois.cymru.com:43"
+
+	// DefaultBulkThreshold is the minimum number of IP inputs for which the bulk
+	// whois session is used instead of two DNS queries per IP.
+	DefaultBulkThreshold = 100
+
+	// bulkTimeout bounds a bulk session when ctx carries no deadline.
+	bulkTimeout = 5 * time.Minute
+)
+
+// EnableBulk turns on the bulk whois backend. Bulk runs whose IP inputs number
+// at least threshold are answered in a single TCP session through dialer;
+// smaller runs, ASN inputs, and failed sessions use the per-IP DNS lookups.
+// A threshold below 1 leaves bulk mode disabled.
+func (s *Service) EnableBulk(dialer proxy.ContextDialer, threshold int) {
+	s.dialer = dialer
+	s.bulkThreshold = threshold
+}
+
+// RunBatch implements services.BatchService. IP inputs are resolved via the
+// bulk whois interface when bulk mode is enabled and the threshold is reached;
+// every other entry is nil so the caller falls back to Run. Peer lookups are
+// not available in bulk mode, so EnablePeers disables batching.
+func (s *Service) RunBatch(ctx context.Context, inputs []string) []services.Result {
+	results := make([]services.Result, len(inputs))
+	if s.dialer == nil || s.bulkThreshold < 1 || s.peers {
+		return results
+	}
+
+	indexes := make(map[netip.Addr][]int)
+	var addrs []netip.Addr
+	for i, input := range inputs {
+		addr, err := netip.ParseAddr(input)
+		if err != nil {
+			continue
+		}
+		addr = addr.Unmap()
+		if _, seen := indexes[addr]; !seen {
+			addrs = append(addrs, addr)
+		}
+		indexes[addr] = append(indexes[addr], i)
+	}
+	if len(addrs) < s.bulkThreshold {
+		return results
+	}
+
+	rows, err := s.queryBulk(ctx, addrs)
+	if err != nil {
+		s.logger.Warn("Cymru bulk whois failed; falling back to DNS", "ips", len(addrs), "error", err)
+		return results
+	}
+	for addr, idx := range indexes {
+		row, ok := rows[addr]
+		if !ok {
+			continue // missing from the response; Run retries via DNS
+		}
+		if row.MOAS {
+			s.logger.Warn("Cymru: prefix announced by multiple origin ASNs (MOAS) — potential hijack indicator",
+				"ip", addr, "prefix", row.Prefix, "origins", row.Origins)
+		}
+		for _, i := range idx {
+			r := row
+			r.Input = output.StripANSI(inputs[i])
+			r.Origins = slices.Clone(row.Origins)
+			results[i] = &r
+		}
+	}
+	return results
+}
+
+// queryBulk runs one verbose bulk session for addrs and returns the parsed rows
+// keyed by address.
+func (s *Service) queryBulk(ctx context.Context, addrs []netip.Addr) (map[netip.Addr]Result, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, bulkTimeout)
+		defer cancel()
+	}
+
+	conn, err := s.dialer.DialContext(ctx, "tcp", bulkServer)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", bulkServer, err)
+	}
+	defer func() { _ = conn.Close() }()
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// Write concurrently with reading: the server may start answering before
+	// the request is complete, and large sessions would otherwise deadlock
+	// once both socket buffers fill.
+	writeErr := make(chan error, 1)
+	go func() {
+		var b strings.Builder
+		b.WriteString("begin\nverbose\n")
+		for _, addr := range addrs {
+			b.WriteString(addr.String())
+			b.WriteByte('\n')
+		}
+		b.WriteString("end\n")
+		_, err := io.WriteString(conn, b.String())
+		writeErr <- err
+	}()
+
+	rows, err := parseBulkResponse(conn)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("reading bulk response: %w", err)
+	}
+	if err := <-writeErr; err != nil {
+		return nil, fmt.Errorf("writing bulk request: %w", err)
+	}
+	return rows, nil
+}
+
+// parseBulkResponse parses verbose bulk output of the form:
+// "15169   | 8.8.8.8          | 8.8.8.0/24          | US | arin     | 1992-12-01 | GOOGLE, US"
+// The "Bulk mode;" banner, the column header, and error lines are skipped.
+// Unrouted addresses ("NA" ASN) yield an empty Result. A MOAS prefix appears
+// as one line per origin ASN; the extra origins are collected into Origins.
+func parseBulkResponse(r io.Reader) (map[netip.Addr]Result, error) {
+	rows := make(map[netip.Addr]Result)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		parts := strings.Split(scanner.Text(), "|")
+		if len(parts) < 7 {
+			continue
+		}
+		for i := range parts {
+			parts[i] = output.StripANSI(strings.TrimSpace(parts[i]))
+		}
+		addr, err := netip.ParseAddr(parts[1])
+		if err != nil {
+			continue // column header or malformed line
+		}
+		addr = addr.Unmap()
+		asn := parts[0]
+		if asn == "" || asn == "NA" {
+			if _, ok := rows[addr]; !ok {
+				rows[addr] = Result{}
+			}
+			continue
+		}
+		asn = "AS" + asn
+		if row, ok := rows[addr]; ok && row.ASN != "" {
+			if row.Prefix == parts[2] && !slices.Contains(row.Origins, asn) {
+				row.MOAS = true
+			}
+			appendUnique(&row.Origins, asn)
+			rows[addr] = row
+			continue
+		}
+		rows[addr] = Result{
+			ASN:         asn,
+			Prefix:      parts[2],
+			Country:     parts[3],
+			Registry:    parts[4],
+			Allocated:   parts[5],
+			Description: strings.Join(parts[6:], "|"),
+			Origins:     []string{asn},
+		}
+	}
+	return rows, scanner.Err()
+}
@@ -0,0 +1,149 @@
+package cymru_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/cymru"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+const bulkResponse = `Bulk mode; whois.cymru.com [2024-09-01 10:00:00 +0000]
+AS      | IP               | BGP Prefix          | CC | Registry | Allocated  | AS Name
+15169   | 8.8.8.8          | 8.8.8.0/24          | US | arin     | 1992-12-01 | GOOGLE, US
+13335   | 1.1.1.1          | 1.1.1.0/24          | AU | apnic    | 2011-08-11 | CLOUDFLARENET, US
+NA      | 10.0.0.1         | NA                  |    | other    |            | NA
+`
+
+// bulkDialer serves a canned bulk whois session and records the request lines.
+type bulkDialer struct {
+	response string
+	err      error
+	address  string
+	lines    []string
+}
+
+func (d *bulkDialer) DialContext(_ context.Context, _, address string) (net.Conn, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	d.address = address
+	client, server := net.Pipe()
+	go func() {
+		defer func() { _ = server.Close() }()
+		scanner := bufio.NewScanner(server)
+		for scanner.Scan() {
+			d.lines = append(d.lines, scanner.Text())
+			if scanner.Text() == "end" {
+				break
+			}
+		}
+		_, _ = server.Write([]byte(d.response))
+	}()
+	return client, nil
+}
+
+// noDNS fails the test if the per-IP DNS path is used.
+func noDNS(t *testing.T) *testutil.MockResolver {
+	return &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, host string) ([]string, error) {
+			t.Errorf("unexpected DNS lookup %q", host)
+			return nil, errors.New("unexpected")
+		},
+	}
+}
+
+func TestRunBatch_Bulk(t *testing.T) {
+	d := &bulkDialer{response: bulkResponse}
+	svc := cymru.NewService(noDNS(t), testutil.NopLogger())
+	svc.EnableBulk(d, 2)
+
+	inputs := []string{"8.8.8.8", "AS15169", "1.1.1.1", "10.0.0.1", "8.8.8.8"}
+	results := svc.RunBatch(context.Background(), inputs)
+	require.Len(t, results, len(inputs))
+
+	assert.Equal(t, "whois.cymru.com:43", d.address)
+	assert.Equal(t, []string{"begin", "verbose", "8.8.8.8", "1.1.1.1", "10.0.0.1", "end"}, d.lines,
+		"duplicate IPs are sent once and ASNs are left to Run")
+
+	google, ok := results[0].(*cymru.Result)
+	require.True(t, ok)
+	assert.Equal(t, cymru.Result{
+		Input: "8.8.8.8", ASN: "AS15169", Prefix: "8.8.8.0/24", Country: "US",
+		Registry: "arin", Allocated: "1992-12-01", Description: "GOOGLE, US", Origins: []string{"AS15169"},
+	}, *google)
+	assert.Nil(t, results[1], "ASN input falls back to Run")
+	assert.Equal(t, "AS13335", results[2].(*cymru.Result).ASN)
+	assert.True(t, results[3].IsEmpty(), "unrouted address yields an empty result")
+	assert.Equal(t, "AS15169", results[4].(*cymru.Result).ASN)
+	assert.NotSame(t, results[0], results[4])
+}
+
+func TestRunBatch_BelowThreshold(t *testing.T) {
+	d := &bulkDialer{response: bulkResponse}
+	svc := cymru.NewService(noDNS(t), testutil.NopLogger())
+	svc.EnableBulk(d, 3)
+
+	results := svc.RunBatch(context.Background(), []string{"8.8.8.8", "1.1.1.1", "AS15169"})
+	assert.Equal(t, []services.Result{nil, nil, nil}, results)
+	assert.Empty(t, d.address, "no bulk session below the threshold")
+}
+
+func TestRunBatch_Disabled(t *testing.T) {
+	svc := cymru.NewService(noDNS(t), testutil.NopLogger())
+	results := svc.RunBatch(context.Background(), []string{"8.8.8.8", "1.1.1.1"})
+	assert.Equal(t, []services.Result{nil, nil}, results)
+}
+
+func TestRunBatch_SessionFailureFallsBack(t *testing.T) {
+	d := &bulkDialer{err: errors.New("connection refused")}
+	svc := cymru.NewService(noDNS(t), testutil.NopLogger())
+	svc.EnableBulk(d, 1)
+
+	results := svc.RunBatch(context.Background(), []string{"8.8.8.8"})
+	assert.Equal(t, []services.Result{nil}, results)
+}
+
+func TestRunBatch_MissingRowFallsBack(t *testing.T) {
+	response := strings.Replace(bulkResponse, "13335   | 1.1.1.1", "Error: no ASN or IP match on line 4. |", 1)
+	d := &bulkDialer{response: response}
+	svc := cymru.NewService(noDNS(t), testutil.NopLogger())
+	svc.EnableBulk(d, 1)
+
+	results := svc.RunBatch(context.Background(), []string{"8.8.8.8", "1.1.1.1"})
+	assert.NotNil(t, results[0])
+	assert.Nil(t, results[1], "addresses missing from the response are retried via DNS")
+}
+
+func TestRunBatch_MOAS(t *testing.T) {
+	response := bulkResponse + "3356    | 8.8.8.8          | 8.8.8.0/24          | US | arin     | 1992-12-01 | LEVEL3, US\n"
+	d := &bulkDialer{response: response}
+	svc := cymru.NewService(noDNS(t), testutil.NopLogger())
+	svc.EnableBulk(d, 1)
+
+	results := svc.RunBatch(context.Background(), []string{"8.8.8.8", "1.1.1.1"})
+	google := results[0].(*cymru.Result)
+	assert.Equal(t, "AS15169", google.ASN)
+	assert.Equal(t, []string{"AS15169", "AS3356"}, google.Origins)
+	assert.True(t, google.MOAS)
+	assert.False(t, results[1].(*cymru.Result).MOAS)
+}
+
+func TestRunBatch_PeersDisableBulk(t *testing.T) {
+	d := &bulkDialer{response: bulkResponse}
+	svc := cymru.NewService(noDNS(t), testutil.NopLogger())
+	svc.EnableBulk(d, 1)
+	svc.EnablePeers()
+
+	results := svc.RunBatch(context.Background(), []string{"8.8.8.8"})
+	assert.Equal(t, []services.Result{nil}, results)
+	assert.Empty(t, d.address)
+}
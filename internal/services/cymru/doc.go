@@ -1,2 +1,3 @@
-// Package cymru implements ASN lookups via the Team Cymru DNS service.
+// Package cymru implements ASN lookups via the Team Cymru DNS service, with a
+// bulk whois (port 43) backend for large IP lists.
 package cymru
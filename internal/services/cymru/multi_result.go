@@ -17,13 +17,9 @@ type MultiResult struct {
 func (m *MultiResult) WriteTable(w io.Writer) error {
 	var rows [][]string
 	for _, r := range m.Results {
-		rows = append(rows,
-			[]string{r.Input, "ASN", r.ASN},
-			[]string{r.Input, "Prefix", r.Prefix},
-			[]string{r.Input, "Country", r.Country},
-			[]string{r.Input, "Registry", r.Registry},
-			[]string{r.Input, "Description", r.Description},
-		)
+		for _, row := range r.rows() {
+			rows = append(rows, append([]string{r.Input}, row...))
+		}
 	}
 	table := output.NewGroupedWrappingTable(w, 20, 30)
 	table.Header([]string{"Input", "Field", "Value"})
@@ -3,18 +3,25 @@ package cymru
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/tbckr/trident/internal/output"
 )
 
 // Result holds the ASN lookup result for a single IP or ASN input.
+// For IP inputs ASN is the origin of the most specific announced prefix;
+// Origins lists every origin ASN seen across all covering prefixes.
 type Result struct {
-	Input       string `json:"input"`
-	ASN         string `json:"asn,omitempty"`
-	Prefix      string `json:"prefix,omitempty"`
-	Country     string `json:"country,omitempty"`
-	Registry    string `json:"registry,omitempty"`
-	Description string `json:"description,omitempty"`
+	Input       string   `json:"input"`
+	ASN         string   `json:"asn,omitempty"`
+	Prefix      string   `json:"prefix,omitempty"`
+	Country     string   `json:"country,omitempty"`
+	Registry    string   `json:"registry,omitempty"`
+	Allocated   string   `json:"allocated,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Origins     []string `json:"origins,omitempty"`
+	MOAS        bool     `json:"moas,omitempty"` // prefix announced by multiple origin ASNs — potential hijack indicator
+	Peers       []string `json:"peers,omitempty"`
 }
 
 // IsEmpty reports whether the result contains no ASN data.
@@ -31,18 +38,34 @@ func (r *Result) WriteText(w io.Writer) error {
 	return err
 }
 
-// WriteTable renders the result as an ASCII table.
-func (r *Result) WriteTable(w io.Writer) error {
+// rows returns the Field/Value pairs in display order. The origin, MOAS, and
+// peer rows are only present when they add information.
+func (r *Result) rows() [][]string {
 	rows := [][]string{
 		{"ASN", r.ASN},
 		{"Prefix", r.Prefix},
 		{"Country", r.Country},
 		{"Registry", r.Registry},
+		{"Allocated", r.Allocated},
 		{"Description", r.Description},
 	}
+	if len(r.Origins) > 1 {
+		rows = append(rows, []string{"Origins", strings.Join(r.Origins, ", ")})
+	}
+	if r.MOAS {
+		rows = append(rows, []string{"MOAS", "yes — potential hijack indicator"})
+	}
+	if len(r.Peers) > 0 {
+		rows = append(rows, []string{"Peers", strings.Join(r.Peers, ", ")})
+	}
+	return rows
+}
+
+// WriteTable renders the result as an ASCII table.
+func (r *Result) WriteTable(w io.Writer) error {
 	table := output.NewWrappingTable(w, 20, 20)
 	table.Header([]string{"Field", "Value"})
-	if err := table.Bulk(rows); err != nil {
+	if err := table.Bulk(r.rows()); err != nil {
 		return err
 	}
 	return table.Render()
@@ -51,3 +51,32 @@ func TestResult_WriteText(t *testing.T) {
 	assert.Contains(t, out, "arin")
 	assert.Contains(t, out, "GOOGLE, US")
 }
+
+func TestResult_WriteTable_MOASAndPeers(t *testing.T) {
+	result := &cymru.Result{
+		Input:     "216.90.108.1",
+		ASN:       "AS23028",
+		Prefix:    "216.90.108.0/24",
+		Allocated: "1998-09-25",
+		Origins:   []string{"AS23028", "AS3356"},
+		MOAS:      true,
+		Peers:     []string{"AS174"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "1998-09-25")
+	assert.Contains(t, out, "AS3356")
+	assert.Contains(t, out, "potential hijack")
+	assert.Contains(t, out, "AS174")
+}
+
+func TestResult_WriteTable_SingleOriginOmitsExtraRows(t *testing.T) {
+	result := &cymru.Result{Input: "8.8.8.8", ASN: "AS15169", Origins: []string{"AS15169"}}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.NotContains(t, out, "Origins")
+	assert.NotContains(t, out, "MOAS")
+	assert.NotContains(t, out, "Peers")
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"net/netip"
+	"slices"
 	"strings"
 
+	"golang.org/x/net/proxy"
+
 	"github.com/tbckr/trident/internal/output"
 	"github.com/tbckr/trident/internal/pap"
 	"github.com/tbckr/trident/internal/services"
@@ -18,6 +22,9 @@ const cymruIPv4Template = "%s.origin.asn.cymru.com"
 // cymruIPv6Template is the Team Cymru DNS suffix for IPv6 → ASN lookups.
 const cymruIPv6Template = "%s.origin6.asn.cymru.com"
 
+// cymruPeerTemplate is the Team Cymru DNS suffix for IPv4 → peer ASN lookups.
+const cymruPeerTemplate = "%s.peer.asn.cymru.com"
+
 // cymruASNTemplate is the Team Cymru DNS suffix for ASN → info lookups.
 // Format: AS<number>.asn.cymru.com
 const cymruASNTemplate = "%s.asn.cymru.com"
@@ -29,10 +36,15 @@ const (
 	PAP = pap.AMBER
 )
 
-// Service performs ASN lookups via the Team Cymru DNS service.
+// Service performs ASN lookups via the Team Cymru DNS service, optionally
+// batching large IP lists through the bulk whois interface (see EnableBulk).
 type Service struct {
 	resolver services.DNSResolverInterface
 	logger   *slog.Logger
+
+	dialer        proxy.ContextDialer // nil → bulk whois disabled
+	bulkThreshold int
+	peers         bool
 }
 
 // NewService creates a new Cymru service.
@@ -40,6 +52,10 @@ func NewService(resolver services.DNSResolverInterface, logger *slog.Logger) *Se
 	return &Service{resolver: resolver, logger: logger}
 }
 
+// EnablePeers makes IPv4 lookups also query the upstream peer ASNs of the
+// announcing prefix via peer.asn.cymru.com (one extra DNS query per IP).
+func (s *Service) EnablePeers() { s.peers = true }
+
 // Name returns the service identifier.
 func (s *Service) Name() string { return Name }
 
@@ -81,6 +97,8 @@ func (s *Service) Run(ctx context.Context, input string) (services.Result, error
 }
 
 // lookupByIP resolves ASN info for the given IP using Team Cymru's DNS service.
+// Every TXT record is parsed: the most specific prefix supplies the primary
+// ASN, and all origin ASNs are collected so MOAS prefixes can be flagged.
 func (s *Service) lookupByIP(ctx context.Context, result *Result, ip string) (*Result, error) {
 	reversed, isV6, err := reverseIP(ip)
 	if err != nil {
@@ -96,15 +114,47 @@ func (s *Service) lookupByIP(ctx context.Context, result *Result, ip string) (*R
 		s.logger.Debug("Cymru IP lookup failed", "ip", ip, "error", err)
 		return result, nil
 	}
-	if len(txts) > 0 {
-		parseCymruIPRecord(result, txts[0])
+	var records []ipRecord
+	for _, txt := range txts {
+		if rec, ok := parseCymruIPRecord(txt); ok {
+			records = append(records, rec)
+		}
 	}
+	applyIPRecords(result, records)
 	if result.ASN != "" {
 		s.enrichASN(ctx, result, result.ASN)
 	}
+	if s.peers {
+		s.lookupPeers(ctx, result, reversed, isV6)
+	}
+	if result.MOAS {
+		s.logger.Warn("Cymru: prefix announced by multiple origin ASNs (MOAS) — potential hijack indicator",
+			"ip", ip, "prefix", result.Prefix, "origins", result.Origins)
+	}
 	return result, nil
 }
 
+// lookupPeers fills result.Peers from Team Cymru's peer.asn.cymru.com zone.
+// Team Cymru only publishes peer data for IPv4.
+func (s *Service) lookupPeers(ctx context.Context, result *Result, reversed string, isV6 bool) {
+	if isV6 {
+		s.logger.Debug("Cymru peer lookup skipped: IPv6 is not supported", "ip", result.Input)
+		return
+	}
+	txts, err := s.resolver.LookupTXT(ctx, fmt.Sprintf(cymruPeerTemplate, reversed))
+	if err != nil {
+		s.logger.Debug("Cymru peer lookup failed", "ip", result.Input, "error", err)
+		return
+	}
+	for _, txt := range txts {
+		// "701 1239 3549 3561 7132 | 4.0.0.0/9 | US | arin |"
+		asns, _, _ := strings.Cut(txt, "|")
+		for asn := range strings.FieldsSeq(output.StripANSI(asns)) {
+			appendUnique(&result.Peers, "AS"+asn)
+		}
+	}
+}
+
 // lookupByASN fetches description info for the given ASN from Team Cymru.
 func (s *Service) lookupByASN(ctx context.Context, result *Result, asn string) (*Result, error) {
 	result.ASN = asn
@@ -133,26 +183,101 @@ func (s *Service) enrichASN(ctx context.Context, result *Result, asn string) {
 	}
 }
 
+// ipRecord is one parsed origin TXT record.
+type ipRecord struct {
+	asns      []string // "AS"-prefixed; more than one for a MOAS prefix
+	prefix    string
+	country   string
+	registry  string
+	allocated string
+}
+
 // parseCymruIPRecord parses a Team Cymru TXT record of the form:
 // "15169 | 8.8.8.0/24 | US | arin | 1992-12-01"
-func parseCymruIPRecord(result *Result, txt string) {
+// MOAS prefixes list several space-separated origin ASNs in the first field:
+// "23028 3356 | 216.90.108.0/24 | US | arin | 1998-09-25"
+func parseCymruIPRecord(txt string) (ipRecord, bool) {
 	parts := strings.Split(txt, "|")
-	if len(parts) >= 1 {
-		result.ASN = "AS" + output.StripANSI(strings.TrimSpace(parts[0]))
+	for i := range parts {
+		parts[i] = output.StripANSI(strings.TrimSpace(parts[i]))
+	}
+	var rec ipRecord
+	for asn := range strings.FieldsSeq(parts[0]) {
+		appendUnique(&rec.asns, "AS"+asn)
+	}
+	if len(rec.asns) == 0 {
+		return ipRecord{}, false
 	}
 	if len(parts) >= 2 {
-		result.Prefix = output.StripANSI(strings.TrimSpace(parts[1]))
+		rec.prefix = parts[1]
 	}
 	if len(parts) >= 3 {
-		result.Country = output.StripANSI(strings.TrimSpace(parts[2]))
+		rec.country = parts[2]
 	}
 	if len(parts) >= 4 {
-		result.Registry = output.StripANSI(strings.TrimSpace(parts[3]))
+		rec.registry = parts[3]
+	}
+	if len(parts) >= 5 {
+		rec.allocated = parts[4]
+	}
+	return rec, true
+}
+
+// applyIPRecords stores the most specific record as the primary ASN/prefix and
+// collects the origin ASNs of all records. MOAS is set when one prefix is
+// announced by more than one origin ASN.
+func applyIPRecords(result *Result, records []ipRecord) {
+	if len(records) == 0 {
+		return
+	}
+	primary := 0
+	for i, rec := range records {
+		if prefixBits(rec.prefix) > prefixBits(records[primary].prefix) {
+			primary = i
+		}
+	}
+	p := records[primary]
+	result.ASN = p.asns[0]
+	result.Prefix = p.prefix
+	result.Country = p.country
+	result.Registry = p.registry
+	result.Allocated = p.allocated
+
+	origins := map[string][]string{} // prefix → origin ASNs
+	// Visit the primary record first so its ASNs lead result.Origins.
+	for _, rec := range append([]ipRecord{p}, records...) {
+		for _, asn := range rec.asns {
+			appendUnique(&result.Origins, asn)
+			list := origins[rec.prefix]
+			appendUnique(&list, asn)
+			origins[rec.prefix] = list
+		}
+	}
+	for _, asns := range origins {
+		if len(asns) > 1 {
+			result.MOAS = true
+		}
+	}
+}
+
+// prefixBits returns the prefix length of a CIDR string, or -1 when unparseable.
+func prefixBits(cidr string) int {
+	p, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return -1
+	}
+	return p.Bits()
+}
+
+func appendUnique(dst *[]string, value string) {
+	if !slices.Contains(*dst, value) {
+		*dst = append(*dst, value)
 	}
 }
 
 // parseCymruASNRecord parses a Team Cymru TXT record of the form:
 // "15169 | US | arin | 2000-03-30 | GOOGLE, US"
+// The ASN allocation date only fills Allocated when no prefix allocation date is set.
 func parseCymruASNRecord(result *Result, txt string) {
 	parts := strings.Split(txt, "|")
 	if len(parts) >= 2 {
@@ -161,6 +286,9 @@ func parseCymruASNRecord(result *Result, txt string) {
 	if len(parts) >= 3 {
 		result.Registry = output.StripANSI(strings.TrimSpace(parts[2]))
 	}
+	if len(parts) >= 4 && result.Allocated == "" {
+		result.Allocated = output.StripANSI(strings.TrimSpace(parts[3]))
+	}
 	if len(parts) >= 5 {
 		result.Description = output.StripANSI(strings.TrimSpace(parts[4]))
 	}
@@ -38,7 +38,106 @@ func TestRun_IPv4(t *testing.T) {
 	assert.Equal(t, "8.8.8.0/24", result.Prefix)
 	assert.Equal(t, "US", result.Country)
 	assert.Equal(t, "arin", result.Registry)
+	assert.Equal(t, "1992-12-01", result.Allocated)
 	assert.Equal(t, "GOOGLE, US", result.Description)
+	assert.Equal(t, []string{"AS15169"}, result.Origins)
+	assert.False(t, result.MOAS)
+	assert.Empty(t, result.Peers, "peers are only queried when enabled")
+}
+
+func TestRun_IPv4_MultipleOrigins(t *testing.T) {
+	resolver := &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, host string) ([]string, error) {
+			switch host {
+			case "1.108.90.216.origin.asn.cymru.com":
+				return []string{
+					"3356 | 216.88.0.0/14 | US | arin | 1998-09-25",
+					"23028 3356 | 216.90.108.0/24 | US | arin | 1998-09-25",
+				}, nil
+			case "AS23028.asn.cymru.com":
+				return []string{"23028 | US | arin | 2002-01-04 | TEAM-CYMRU, US"}, nil
+			}
+			return nil, errors.New("unexpected host")
+		},
+	}
+
+	svc := cymru.NewService(resolver, testutil.NopLogger())
+	raw, err := svc.Run(context.Background(), "216.90.108.1")
+	require.NoError(t, err)
+	result, ok := raw.(*cymru.Result)
+	require.True(t, ok)
+
+	assert.Equal(t, "AS23028", result.ASN, "most specific prefix supplies the primary ASN")
+	assert.Equal(t, "216.90.108.0/24", result.Prefix)
+	assert.Equal(t, "1998-09-25", result.Allocated, "prefix allocation date wins over the ASN's")
+	assert.Equal(t, []string{"AS23028", "AS3356"}, result.Origins)
+	assert.True(t, result.MOAS)
+	assert.Equal(t, "TEAM-CYMRU, US", result.Description)
+}
+
+func TestRun_IPv4_CoveringPrefixIsNotMOAS(t *testing.T) {
+	resolver := &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, host string) ([]string, error) {
+			switch host {
+			case "1.108.90.216.origin.asn.cymru.com":
+				return []string{
+					"3356 | 216.88.0.0/14 | US | arin | 1998-09-25",
+					"23028 | 216.90.108.0/24 | US | arin | 1998-09-25",
+				}, nil
+			case "AS23028.asn.cymru.com":
+				return []string{"23028 | US | arin | 2002-01-04 | TEAM-CYMRU, US"}, nil
+			}
+			return nil, errors.New("unexpected host")
+		},
+	}
+
+	svc := cymru.NewService(resolver, testutil.NopLogger())
+	raw, err := svc.Run(context.Background(), "216.90.108.1")
+	require.NoError(t, err)
+	result := raw.(*cymru.Result)
+
+	assert.Equal(t, []string{"AS23028", "AS3356"}, result.Origins)
+	assert.False(t, result.MOAS, "different origins for nested prefixes are not MOAS")
+}
+
+func TestRun_IPv4_Peers(t *testing.T) {
+	resolver := &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, host string) ([]string, error) {
+			switch host {
+			case "8.8.8.8.origin.asn.cymru.com":
+				return []string{"15169 | 8.8.8.0/24 | US | arin | 1992-12-01"}, nil
+			case "AS15169.asn.cymru.com":
+				return []string{"15169 | US | arin | 2000-03-30 | GOOGLE, US"}, nil
+			case "8.8.8.8.peer.asn.cymru.com":
+				return []string{"174 3356 | 8.8.8.0/24 | US | arin |", "3356 6939 | 8.8.8.0/24 | US | arin |"}, nil
+			}
+			return nil, errors.New("unexpected host")
+		},
+	}
+
+	svc := cymru.NewService(resolver, testutil.NopLogger())
+	svc.EnablePeers()
+	raw, err := svc.Run(context.Background(), "8.8.8.8")
+	require.NoError(t, err)
+	result := raw.(*cymru.Result)
+
+	assert.Equal(t, []string{"AS174", "AS3356", "AS6939"}, result.Peers)
+}
+
+func TestRun_ASN_Allocated(t *testing.T) {
+	resolver := &testutil.MockResolver{
+		LookupTXTFn: func(_ context.Context, host string) ([]string, error) {
+			if host == "AS15169.asn.cymru.com" {
+				return []string{"15169 | US | arin | 2000-03-30 | GOOGLE, US"}, nil
+			}
+			return nil, errors.New("unexpected host")
+		},
+	}
+
+	svc := cymru.NewService(resolver, testutil.NopLogger())
+	raw, err := svc.Run(context.Background(), "AS15169")
+	require.NoError(t, err)
+	assert.Equal(t, "2000-03-30", raw.(*cymru.Result).Allocated)
 }
 
 func TestRun_ASN(t *testing.T) {
@@ -3,7 +3,9 @@ package detect
 import (
 	"fmt"
 	"io"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/tbckr/trident/internal/output"
 )
@@ -70,3 +72,28 @@ func (r *Result) WriteTable(w io.Writer) error {
 	}
 	return table.Render()
 }
+
+// ReportSections renders a summary naming the detected providers per type,
+// followed by the detections with their evidence.
+func (r *Result) ReportSections() []output.Section {
+	sorted := sortDetections(r.Detections)
+	summary := output.Section{Title: "Summary", Facts: []output.Fact{{Label: "Domain", Value: r.Input}}}
+	byType := map[string][]string{}
+	var typeOrder []string
+	for _, d := range sorted {
+		if _, ok := byType[d.Type]; !ok {
+			typeOrder = append(typeOrder, d.Type)
+		}
+		if !slices.Contains(byType[d.Type], d.Provider) {
+			byType[d.Type] = append(byType[d.Type], d.Provider)
+		}
+	}
+	for _, t := range typeOrder {
+		summary.Facts = append(summary.Facts, output.Fact{Label: t, Value: strings.Join(byType[t], ", ")})
+	}
+	details := output.Section{Title: "Detections", Headers: []string{"Type", "Provider", "Source", "Evidence"}}
+	for _, d := range sorted {
+		details.Rows = append(details.Rows, []string{d.Type, d.Provider, d.Source, d.Evidence})
+	}
+	return []output.Section{summary, details}
+}
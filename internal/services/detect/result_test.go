@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tbckr/trident/internal/output"
 	"github.com/tbckr/trident/internal/services/detect"
 )
 
@@ -69,3 +70,24 @@ func TestResult_WriteTable(t *testing.T) {
 	assert.Contains(t, out, "AWS CloudFront")
 	assert.Contains(t, out, "cname: foo.cloudfront.net.")
 }
+
+func TestResult_ReportSections(t *testing.T) {
+	r := &detect.Result{
+		Input: "example.com",
+		Detections: []detect.Detection{
+			{Type: "Email", Provider: "Google Workspace", Evidence: "aspmx.l.google.com.", Source: "mx"},
+			{Type: "CDN", Provider: "Cloudflare", Evidence: "example.com.cdn.cloudflare.net.", Source: "cname"},
+			{Type: "Email", Provider: "Google Workspace", Evidence: "alt1.aspmx.l.google.com.", Source: "mx"},
+		},
+	}
+	sections := r.ReportSections()
+	require.Len(t, sections, 2)
+	assert.Equal(t, []output.Fact{
+		{Label: "Domain", Value: "example.com"},
+		{Label: "CDN", Value: "Cloudflare"},
+		{Label: "Email", Value: "Google Workspace"},
+	}, sections[0].Facts)
+	assert.Equal(t, "Detections", sections[1].Title)
+	assert.Len(t, sections[1].Rows, 3)
+	assert.Equal(t, []string{"CDN", "Cloudflare", "cname", "example.com.cdn.cloudflare.net."}, sections[1].Rows[0])
+}
@@ -0,0 +1,3 @@
+// Package dkim discovers published DKIM selectors for a domain via Quad9 DoH
+// and reports key type, key size, flags, and weak-key warnings.
+package dkim
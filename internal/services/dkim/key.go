@@ -0,0 +1,131 @@
+package dkim
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+const (
+	// MinRSABits is the RFC 8301 §3.2 minimum RSA key size; verifiers must
+	// reject shorter keys.
+	MinRSABits = 1024
+	// RecommendedRSABits is the RSA key size RFC 8301 §3.2 recommends for signers.
+	RecommendedRSABits = 2048
+)
+
+// Key holds the parsed tags of a DKIM public key record (RFC 6376 §3.6.1).
+type Key struct {
+	Version  string   // v= tag; "DKIM1" when present
+	Type     string   // k= tag, defaulting to "rsa"
+	Bits     int      // public key size in bits; 0 when revoked or unparsable
+	Flags    []string // t= flags, e.g. "y" (testing), "s" (strict)
+	Revoked  bool     // p= is empty
+	Warnings []string
+}
+
+// parseTags splits a DKIM tag-value list ("v=DKIM1; k=rsa; p=...") into a map.
+// Tag names are lowercased; whitespace inside values is removed (RFC 6376 §3.2).
+func parseTags(record string) map[string]string {
+	tags := map[string]string{}
+	for part := range strings.SplitSeq(record, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, dup := tags[name]; !dup {
+			tags[name] = strings.Join(strings.Fields(value), "")
+		}
+	}
+	return tags
+}
+
+// isKeyRecord reports whether record looks like a DKIM key record: either it
+// declares v=DKIM1 or it carries a p= tag.
+func isKeyRecord(record string) bool {
+	tags := parseTags(record)
+	if _, ok := tags["p"]; ok {
+		return true
+	}
+	return strings.EqualFold(tags["v"], "DKIM1")
+}
+
+// parseKey parses a DKIM key record and derives the key size and warnings.
+func parseKey(record string) Key {
+	tags := parseTags(record)
+	k := Key{Version: tags["v"], Type: strings.ToLower(tags["k"])}
+	if k.Type == "" {
+		k.Type = "rsa"
+	}
+	for f := range strings.SplitSeq(tags["t"], ":") {
+		if f != "" {
+			k.Flags = append(k.Flags, strings.ToLower(f))
+		}
+	}
+
+	p := tags["p"]
+	switch {
+	case p == "":
+		k.Revoked = true
+		k.Warnings = append(k.Warnings, "key revoked (empty p=)")
+	default:
+		bits, err := keyBits(k.Type, p)
+		if err != nil {
+			k.Warnings = append(k.Warnings, err.Error())
+		}
+		k.Bits = bits
+	}
+
+	if k.Type == "rsa" && k.Bits > 0 {
+		switch {
+		case k.Bits < MinRSABits:
+			k.Warnings = append(k.Warnings, fmt.Sprintf("weak key: %d-bit RSA is below the %d-bit minimum", k.Bits, MinRSABits))
+		case k.Bits < RecommendedRSABits:
+			k.Warnings = append(k.Warnings, fmt.Sprintf("%d-bit RSA is below the recommended %d bits", k.Bits, RecommendedRSABits))
+		}
+	}
+	for _, f := range k.Flags {
+		if f == "y" {
+			k.Warnings = append(k.Warnings, "testing mode (t=y): verifiers may ignore failures")
+		}
+	}
+	return k
+}
+
+// keyBits decodes the base64 public key and returns its size in bits.
+// RSA keys are accepted as SubjectPublicKeyInfo (the RFC 6376 format) or as a
+// bare PKCS#1 RSAPublicKey, which some signers publish.
+func keyBits(keyType, p string) (int, error) {
+	der, err := base64.StdEncoding.DecodeString(p)
+	if err != nil {
+		return 0, fmt.Errorf("invalid base64 in p=")
+	}
+	switch keyType {
+	case "rsa":
+		if pub, err := x509.ParsePKIXPublicKey(der); err == nil {
+			if rsaPub, ok := pub.(*rsa.PublicKey); ok {
+				return rsaPub.N.BitLen(), nil
+			}
+			return 0, fmt.Errorf("p= is not an RSA key")
+		}
+		if rsaPub, err := x509.ParsePKCS1PublicKey(der); err == nil {
+			return rsaPub.N.BitLen(), nil
+		}
+		return 0, fmt.Errorf("unparsable RSA public key")
+	case "ed25519":
+		// RFC 8463: p= is the raw 32-byte Ed25519 public key.
+		if len(der) != ed25519.PublicKeySize {
+			return 0, fmt.Errorf("ed25519 key has %d bytes, want %d", len(der), ed25519.PublicKeySize)
+		}
+		return ed25519.PublicKeySize * 8, nil
+	default:
+		return 0, fmt.Errorf("unknown key type %q", keyType)
+	}
+}
@@ -0,0 +1,103 @@
+package dkim
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rsaKeyB64 returns a base64 SubjectPublicKeyInfo for an RSA modulus of the
+// given size. The modulus is not a valid product of primes; only its length matters.
+func rsaKeyB64(t *testing.T, bits int) string {
+	t.Helper()
+	n := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	n.Add(n, big.NewInt(1))
+	der, err := x509.MarshalPKIXPublicKey(&rsa.PublicKey{N: n, E: 65537})
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func TestParseTags(t *testing.T) {
+	tags := parseTags("v=DKIM1; K=rsa; t=y:s ; p=MIGf MA0G\tCSqG; v=ignored")
+	assert.Equal(t, "DKIM1", tags["v"])
+	assert.Equal(t, "rsa", tags["k"])
+	assert.Equal(t, "y:s", tags["t"])
+	assert.Equal(t, "MIGfMA0GCSqG", tags["p"])
+}
+
+func TestIsKeyRecord(t *testing.T) {
+	assert.True(t, isKeyRecord("v=DKIM1; k=rsa; p=abc"))
+	assert.True(t, isKeyRecord("k=rsa; p="))
+	assert.True(t, isKeyRecord("v=DKIM1"))
+	assert.False(t, isKeyRecord("v=spf1 -all"))
+	assert.False(t, isKeyRecord("o=~"))
+}
+
+func TestParseKey_RSASizes(t *testing.T) {
+	tests := []struct {
+		bits     int
+		warnings int
+	}{
+		{512, 1},
+		{1024, 1},
+		{2048, 0},
+		{4096, 0},
+	}
+	for _, tc := range tests {
+		t.Run(big.NewInt(int64(tc.bits)).String(), func(t *testing.T) {
+			k := parseKey("v=DKIM1; k=rsa; p=" + rsaKeyB64(t, tc.bits))
+			assert.Equal(t, "rsa", k.Type)
+			assert.Equal(t, tc.bits, k.Bits)
+			assert.Len(t, k.Warnings, tc.warnings)
+		})
+	}
+	assert.Contains(t, parseKey("p=" + rsaKeyB64(t, 512)).Warnings[0], "weak key")
+}
+
+func TestParseKey_PKCS1(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	p := base64.StdEncoding.EncodeToString(x509.MarshalPKCS1PublicKey(&key.PublicKey))
+	k := parseKey("p=" + p)
+	assert.Equal(t, 1024, k.Bits)
+}
+
+func TestParseKey_Ed25519(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k := parseKey("v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(pub))
+	assert.Equal(t, "ed25519", k.Type)
+	assert.Equal(t, 256, k.Bits)
+	assert.Empty(t, k.Warnings)
+}
+
+func TestParseKey_RevokedAndFlags(t *testing.T) {
+	k := parseKey("v=DKIM1; t=y:s; p=")
+	assert.True(t, k.Revoked)
+	assert.Equal(t, []string{"y", "s"}, k.Flags)
+	assert.Equal(t, []string{"key revoked (empty p=)", "testing mode (t=y): verifiers may ignore failures"}, k.Warnings)
+}
+
+func TestParseKey_Invalid(t *testing.T) {
+	assert.Equal(t, []string{"invalid base64 in p="}, parseKey("p=!!!").Warnings)
+	assert.Equal(t, []string{"unparsable RSA public key"}, parseKey("p=AAAA").Warnings)
+	assert.Equal(t, []string{`unknown key type "dsa"`}, parseKey("k=dsa; p=AAAA").Warnings)
+	assert.Equal(t, []string{"ed25519 key has 3 bytes, want 32"}, parseKey("k=ed25519; p=AAAA").Warnings)
+}
+
+func FuzzParseKey(f *testing.F) {
+	f.Add("v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQ")
+	f.Add("k=ed25519; t=y; p=")
+	f.Add(";;==;p")
+	f.Fuzz(func(t *testing.T, record string) {
+		// Must not panic on any input.
+		parseKey(record)
+	})
+}
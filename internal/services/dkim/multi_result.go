@@ -0,0 +1,30 @@
+package dkim
+
+import (
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds DKIM results for multiple domains.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteTable renders all found selectors in a single table grouped by domain.
+// Columns: Domain / Selector / Provider / Key / Flags / Warnings.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows [][]string
+	for _, r := range m.Results {
+		for _, s := range r.Selectors {
+			rows = append(rows, append([]string{r.Input}, s.row()...))
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 20, 50)
+	table.Header([]string{"Domain", "Selector", "Provider", "Key", "Flags", "Warnings"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
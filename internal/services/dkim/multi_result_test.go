@@ -0,0 +1,68 @@
+package dkim_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/dkim"
+)
+
+func TestMultiResult_IsEmpty(t *testing.T) {
+	t.Run("empty when no results", func(t *testing.T) {
+		assert.True(t, (&dkim.MultiResult{}).IsEmpty())
+	})
+
+	t.Run("empty when no domain has a selector", func(t *testing.T) {
+		mr := &dkim.MultiResult{}
+		mr.Results = []*dkim.Result{{Input: "example.com"}, {Input: "example.org"}}
+		assert.True(t, mr.IsEmpty())
+	})
+
+	t.Run("not empty when one domain has a selector", func(t *testing.T) {
+		mr := &dkim.MultiResult{}
+		mr.Results = []*dkim.Result{
+			{Input: "example.com"},
+			{Input: "example.org", Selectors: []dkim.Selector{{Selector: "default", KeyType: "rsa"}}},
+		}
+		assert.False(t, mr.IsEmpty())
+	})
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	mr := &dkim.MultiResult{}
+	mr.Results = []*dkim.Result{
+		{
+			Input: "example.com",
+			Selectors: []dkim.Selector{
+				{Selector: "google", KeyType: "rsa", KeyBits: 2048, Provider: "Google Workspace"},
+				{Selector: "s1", KeyType: "rsa", KeyBits: 1024, Provider: "SendGrid"},
+			},
+		},
+		{Input: "example.net"},
+		{Input: "example.org", Selectors: []dkim.Selector{{Selector: "selector1", KeyType: "ed25519"}}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "DOMAIN")
+	assert.Contains(t, out, "example.com")
+	assert.Contains(t, out, "example.org")
+	assert.NotContains(t, out, "example.net")
+	assert.Contains(t, out, "SendGrid")
+	assert.Contains(t, out, "ed25519")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	mr := &dkim.MultiResult{}
+	mr.Results = []*dkim.Result{
+		{Input: "example.com", Selectors: []dkim.Selector{{Selector: "google"}, {Selector: "s1"}}},
+		{Input: "example.org", Selectors: []dkim.Selector{{Selector: "selector1"}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteText(&buf))
+	assert.Equal(t, "google\ns1\nselector1\n", buf.String())
+}
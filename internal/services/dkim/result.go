@@ -0,0 +1,82 @@
+package dkim
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Selector is a DKIM selector that publishes a key record.
+type Selector struct {
+	Selector string   `json:"selector"`
+	Host     string   `json:"host"`
+	CNAME    string   `json:"cname,omitempty"` // first CNAME target when the selector is delegated
+	Record   string   `json:"record"`
+	KeyType  string   `json:"key_type"`
+	KeyBits  int      `json:"key_bits,omitempty"`
+	Flags    []string `json:"flags,omitempty"`
+	Revoked  bool     `json:"revoked,omitempty"`
+	Provider string   `json:"provider,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Result holds the discovered DKIM selectors for a single domain.
+type Result struct {
+	Input     string     `json:"input"`
+	Selectors []Selector `json:"selectors,omitempty"`
+}
+
+// IsEmpty reports whether no DKIM selector was found.
+func (r *Result) IsEmpty() bool {
+	return len(r.Selectors) == 0
+}
+
+// keyLabel formats the key type and size, e.g. "rsa 2048" or "rsa (revoked)".
+func (s Selector) keyLabel() string {
+	switch {
+	case s.Revoked:
+		return s.KeyType + " (revoked)"
+	case s.KeyBits > 0:
+		return fmt.Sprintf("%s %d", s.KeyType, s.KeyBits)
+	default:
+		return s.KeyType
+	}
+}
+
+// row returns the table cells shared by the single and multi result tables.
+func (s Selector) row() []string {
+	return []string{
+		s.Selector,
+		s.Provider,
+		s.keyLabel(),
+		strings.Join(s.Flags, ":"),
+		strings.Join(s.Warnings, "\n"),
+	}
+}
+
+// WriteText renders the found selector names, one per line.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, s := range r.Selectors {
+		if _, err := fmt.Fprintln(w, s.Selector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the found selectors as a table.
+// Columns: Selector / Provider / Key / Flags / Warnings.
+func (r *Result) WriteTable(w io.Writer) error {
+	rows := make([][]string, 0, len(r.Selectors))
+	for _, s := range r.Selectors {
+		rows = append(rows, s.row())
+	}
+	table := output.NewWrappingTable(w, 20, 50)
+	table.Header([]string{"Selector", "Provider", "Key", "Flags", "Warnings"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
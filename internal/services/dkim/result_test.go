@@ -0,0 +1,61 @@
+package dkim_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/dkim"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&dkim.Result{Input: "example.com"}).IsEmpty())
+	assert.False(t, (&dkim.Result{Input: "example.com", Selectors: []dkim.Selector{{Selector: "default", KeyType: "rsa"}}}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &dkim.Result{
+		Input: "example.com",
+		Selectors: []dkim.Selector{
+			{Selector: "google", KeyType: "rsa", KeyBits: 2048},
+			{Selector: "s1", KeyType: "rsa", KeyBits: 1024},
+			{Selector: "old", KeyType: "rsa", Revoked: true},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "google\ns1\nold\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &dkim.Result{
+		Input: "example.com",
+		Selectors: []dkim.Selector{
+			{Selector: "google", Host: "google._domainkey.example.com", KeyType: "rsa", KeyBits: 2048, Provider: "Google Workspace"},
+			{
+				Selector: "s1", Host: "s1._domainkey.example.com", KeyType: "rsa", KeyBits: 1024, Flags: []string{"y", "s"},
+				Provider: "SendGrid", Warnings: []string{"1024-bit RSA is below the recommended 2048 bits"},
+			},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "SELECTOR")
+	assert.Contains(t, out, "Google Workspace")
+	assert.Contains(t, out, "rsa 2048")
+	assert.Contains(t, out, "y:s")
+	assert.Contains(t, out, "1024-bit RSA")
+}
+
+func TestResult_WriteTable_RevokedKey(t *testing.T) {
+	result := &dkim.Result{
+		Input:     "example.com",
+		Selectors: []dkim.Selector{{Selector: "old", Host: "old._domainkey.example.com", KeyType: "rsa", Revoked: true}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	assert.Contains(t, buf.String(), "rsa (revoked)")
+}
@@ -0,0 +1,90 @@
+package dkim
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tbckr/trident/internal/appdir"
+)
+
+//go:embed selectors.txt
+var embeddedSelectors []byte
+
+// SelectorFileName is the name of the optional user selector list in the config dir.
+const SelectorFileName = "dkim-selectors.txt"
+
+// LoadSelectors returns the embedded selector list followed by the selectors from
+// each existing file in paths, lowercased and deduplicated in first-seen order.
+// Missing files are skipped; blank lines and lines starting with '#' are ignored.
+func LoadSelectors(paths ...string) ([]string, error) {
+	seen := map[string]bool{}
+	var selectors []string
+	add := func(data []byte) {
+		for _, s := range parseSelectors(data) {
+			if !seen[s] {
+				seen[s] = true
+				selectors = append(selectors, s)
+			}
+		}
+	}
+	add(embeddedSelectors)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading selector file %q: %w", path, err)
+		}
+		add(data)
+	}
+	return selectors, nil
+}
+
+// DefaultSelectorPath returns the path of the user selector list in the config dir.
+func DefaultSelectorPath() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, SelectorFileName), nil
+}
+
+// parseSelectors extracts valid selector labels from a newline-separated list.
+// Invalid entries are dropped rather than failing the whole file.
+func parseSelectors(data []byte) []string {
+	var selectors []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line == "" || strings.HasPrefix(line, "#") || !isSelector(line) {
+			continue
+		}
+		selectors = append(selectors, line)
+	}
+	return selectors
+}
+
+// isSelector reports whether s is a syntactically valid DKIM selector: one or
+// more dot-separated labels of letters, digits, hyphens, and underscores.
+func isSelector(s string) bool {
+	if len(s) > 63*4 {
+		return false
+	}
+	for label := range strings.SplitSeq(s, ".") {
+		if label == "" || len(label) > 63 {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
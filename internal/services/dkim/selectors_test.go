@@ -0,0 +1,49 @@
+package dkim_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/dkim"
+)
+
+func TestLoadSelectors_Embedded(t *testing.T) {
+	selectors, err := dkim.LoadSelectors()
+	require.NoError(t, err)
+	assert.Contains(t, selectors, "google")
+	assert.Contains(t, selectors, "selector1")
+	assert.Contains(t, selectors, "k1")
+	assert.Contains(t, selectors, "s1")
+	assert.NotContains(t, selectors, "", "blank lines are skipped")
+}
+
+func TestLoadSelectors_UserFile(t *testing.T) {
+	dir := t.TempDir()
+	f := filepath.Join(dir, dkim.SelectorFileName)
+	content := "# custom selectors\nCorp2024\n\ngoogle\nbad selector\nmail.eu\n"
+	require.NoError(t, os.WriteFile(f, []byte(content), 0o600))
+
+	embedded, err := dkim.LoadSelectors()
+	require.NoError(t, err)
+	selectors, err := dkim.LoadSelectors(f, filepath.Join(dir, "missing.txt"))
+	require.NoError(t, err)
+
+	assert.Equal(t, embedded, selectors[:len(embedded)], "embedded selectors come first")
+	assert.Equal(t, []string{"corp2024", "mail.eu"}, selectors[len(embedded):],
+		"user selectors are lowercased, deduplicated, and invalid lines dropped")
+}
+
+func TestLoadSelectors_UnreadableFile(t *testing.T) {
+	_, err := dkim.LoadSelectors(t.TempDir()) // a directory cannot be read as a file
+	require.Error(t, err)
+}
+
+func TestDefaultSelectorPath(t *testing.T) {
+	path, err := dkim.DefaultSelectorPath()
+	require.NoError(t, err)
+	assert.Equal(t, dkim.SelectorFileName, filepath.Base(path))
+}
@@ -0,0 +1,142 @@
+package dkim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"codeberg.org/miekg/dns"
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/detect"
+	"github.com/tbckr/trident/internal/doh"
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// Name is the service identifier.
+	Name = "dkim"
+	// PAP is the PAP activity level for the DKIM service.
+	PAP = pap.AMBER
+)
+
+// Service probes a list of DKIM selectors for a domain via Quad9 DoH.
+type Service struct {
+	client    *req.Client
+	logger    *slog.Logger
+	detector  *detect.Detector
+	selectors []string
+}
+
+// NewService creates a new DKIM service that probes the given selectors.
+// Use LoadSelectors to obtain the embedded list merged with the user's file.
+func NewService(client *req.Client, logger *slog.Logger, patterns detect.Patterns, selectors []string) *Service {
+	return &Service{
+		client:    client,
+		logger:    logger,
+		detector:  detect.NewDetector(patterns),
+		selectors: selectors,
+	}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the DKIM service (Quad9 third-party API).
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple DKIM results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run queries <selector>._domainkey.<domain> TXT for every configured selector
+// in parallel and returns the selectors that publish a DKIM key record.
+// An error is returned only when every query failed; partial results are
+// returned when the context is cancelled mid-query.
+func (s *Service) Run(ctx context.Context, domain string) (services.Result, error) {
+	domain = output.StripANSI(domain)
+	if !services.IsDomain(domain) {
+		return nil, fmt.Errorf("%w: must be a valid domain name: %q", services.ErrInvalidInput, domain)
+	}
+
+	found := make([]*Selector, len(s.selectors))
+	errs := make([]error, len(s.selectors))
+	var wg sync.WaitGroup
+	for i, sel := range s.selectors {
+		wg.Go(func() {
+			found[i], errs[i] = s.probe(ctx, sel, domain)
+		})
+	}
+	wg.Wait()
+
+	result := &Result{Input: domain}
+	var firstErr error
+	failed := 0
+	for i, sel := range found {
+		if errs[i] != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		if sel != nil {
+			result.Selectors = append(result.Selectors, *sel)
+		}
+	}
+	if len(s.selectors) > 0 && failed == len(s.selectors) {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// probe looks up a single selector. It returns nil without error when the
+// selector publishes no key record or the context was cancelled.
+func (s *Service) probe(ctx context.Context, selector, domain string) (*Selector, error) {
+	host := selector + "._domainkey." + domain
+	resp, err := doh.MakeDoHRequest(ctx, s.client, host, dns.TypeTXT)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, nil
+		}
+		s.logger.Debug("dkim: query failed", "host", host, "error", err)
+		return nil, err
+	}
+
+	sel := &Selector{Selector: selector, Host: host}
+	for _, ans := range resp.Answer {
+		switch ans.Type {
+		case dns.TypeCNAME:
+			if sel.CNAME == "" {
+				sel.CNAME = output.StripANSI(ans.Data)
+			}
+		case dns.TypeTXT:
+			if data := output.StripANSI(ans.Data); sel.Record == "" && isKeyRecord(data) {
+				sel.Record = data
+			}
+		}
+	}
+	if sel.Record == "" {
+		return nil, nil
+	}
+
+	key := parseKey(sel.Record)
+	sel.KeyType = key.Type
+	sel.KeyBits = key.Bits
+	sel.Flags = key.Flags
+	sel.Revoked = key.Revoked
+	sel.Warnings = key.Warnings
+	if ds := s.detector.DKIMSelector([]string{selector}); len(ds) > 0 {
+		sel.Provider = ds[0].Provider
+	}
+	return sel, nil
+}
@@ -0,0 +1,188 @@
+package dkim_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"codeberg.org/miekg/dns"
+	"codeberg.org/miekg/dns/rdata"
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providers "github.com/tbckr/trident/internal/detect"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/dkim"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+const dohURL = "https://dns.quad9.net/dns-query"
+
+func embeddedPatterns(t *testing.T) providers.Patterns {
+	t.Helper()
+	p, err := providers.LoadPatterns()
+	require.NoError(t, err)
+	return p
+}
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func hdr(name string) dns.Header {
+	return dns.Header{Name: name + ".", Class: dns.ClassINET, TTL: 300}
+}
+
+// txt builds a TXT RR, splitting value into 255-byte character-strings as
+// real DKIM key records must be.
+func txt(name, value string) dns.RR {
+	var chunks []string
+	for len(value) > 255 {
+		chunks = append(chunks, value[:255])
+		value = value[255:]
+	}
+	chunks = append(chunks, value)
+	return &dns.TXT{Hdr: hdr(name), TXT: rdata.TXT{Txt: chunks}}
+}
+
+func cname(name, target string) dns.RR {
+	return &dns.CNAME{Hdr: hdr(name), CNAME: rdata.CNAME{Target: target + "."}}
+}
+
+func rsaKey(t *testing.T, bits int) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+// hostResponder answers TXT queries from hosts; unknown names return NXDOMAIN.
+func hostResponder(t *testing.T, hosts map[string][]dns.RR) httpmock.Responder {
+	t.Helper()
+	return func(r *http.Request) (*http.Response, error) {
+		data, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+		if err != nil {
+			return nil, fmt.Errorf("decode base64url: %w", err)
+		}
+		q := new(dns.Msg)
+		q.Data = data
+		if err := q.Unpack(); err != nil {
+			return nil, fmt.Errorf("unpack DNS query: %w", err)
+		}
+		name := strings.TrimSuffix(q.Question[0].Header().Name, ".")
+
+		m := new(dns.Msg)
+		m.Response = true
+		if rrs, ok := hosts[name]; ok {
+			m.Answer = rrs
+		} else {
+			m.Rcode = dns.RcodeNameError
+		}
+		require.NoError(t, m.Pack())
+		return httpmock.NewBytesResponse(http.StatusOK, m.Data), nil
+	}
+}
+
+func TestService_Run_DiscoversSelectors(t *testing.T) {
+	client := newTestClient(t)
+	hosts := map[string][]dns.RR{
+		"google._domainkey.example.com": {txt("google._domainkey.example.com", "v=DKIM1; k=rsa; p="+rsaKey(t, 2048))},
+		"s1._domainkey.example.com": {
+			cname("s1._domainkey.example.com", "s1.domainkey.u123.wl.sendgrid.net"),
+			txt("s1.domainkey.u123.wl.sendgrid.net", "k=rsa; t=y; p="+rsaKey(t, 1024)),
+		},
+		"old._domainkey.example.com":  {txt("old._domainkey.example.com", "v=DKIM1; p=")},
+		"spf._domainkey.example.com":  {txt("spf._domainkey.example.com", "v=spf1 -all")},
+		"some._domainkey.example.com": {txt("some._domainkey.example.com", "o=~")},
+	}
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, hostResponder(t, hosts))
+
+	svc := dkim.NewService(client, testutil.NopLogger(), embeddedPatterns(t),
+		[]string{"google", "s1", "missing", "old", "spf", "some"})
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+	result, ok := raw.(*dkim.Result)
+	require.True(t, ok, "expected *dkim.Result")
+
+	require.Len(t, result.Selectors, 3, "selectors are returned in probe order; non-DKIM TXT is ignored")
+	google := result.Selectors[0]
+	assert.Equal(t, "google", google.Selector)
+	assert.Equal(t, "google._domainkey.example.com", google.Host)
+	assert.Equal(t, "Google Workspace", google.Provider)
+	assert.Equal(t, "rsa", google.KeyType)
+	assert.Equal(t, 2048, google.KeyBits)
+	assert.Empty(t, google.Warnings)
+
+	sendgrid := result.Selectors[1]
+	assert.Equal(t, "SendGrid", sendgrid.Provider)
+	assert.Equal(t, "s1.domainkey.u123.wl.sendgrid.net.", sendgrid.CNAME)
+	assert.Equal(t, 1024, sendgrid.KeyBits)
+	assert.Equal(t, []string{"y"}, sendgrid.Flags)
+	assert.Len(t, sendgrid.Warnings, 2, "1024-bit and testing-mode warnings")
+
+	revoked := result.Selectors[2]
+	assert.True(t, revoked.Revoked)
+	assert.Empty(t, revoked.Provider)
+}
+
+func TestService_Run_NoSelectors(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, hostResponder(t, nil))
+	svc := dkim.NewService(client, testutil.NopLogger(), embeddedPatterns(t), []string{"google", "k1"})
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestService_Run_InvalidInput(t *testing.T) {
+	svc := dkim.NewService(req.NewClient(), testutil.NopLogger(), providers.Patterns{}, []string{"google"})
+	_, err := svc.Run(context.Background(), "not a domain")
+	require.ErrorIs(t, err, services.ErrInvalidInput)
+}
+
+func TestService_Run_AllQueriesFail(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+	svc := dkim.NewService(client, testutil.NopLogger(), providers.Patterns{}, []string{"google", "k1"})
+	_, err := svc.Run(context.Background(), "example.com")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestService_Run_ContextCancelled(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, hostResponder(t, nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc := dkim.NewService(client, testutil.NopLogger(), providers.Patterns{}, []string{"google"})
+	raw, err := svc.Run(ctx, "example.com")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestService_AggregateResults(t *testing.T) {
+	svc := dkim.NewService(req.NewClient(), testutil.NopLogger(), providers.Patterns{}, nil)
+	agg := svc.AggregateResults([]services.Result{&dkim.Result{Input: "a.com"}, &dkim.Result{Input: "b.com"}})
+	mr, ok := agg.(*dkim.MultiResult)
+	require.True(t, ok)
+	assert.Len(t, mr.Results, 2)
+}
+
+func TestService_NameAndPAP(t *testing.T) {
+	svc := dkim.NewService(req.NewClient(), testutil.NopLogger(), providers.Patterns{}, nil)
+	assert.Equal(t, "dkim", svc.Name())
+	assert.Equal(t, dkim.PAP, svc.PAP())
+}
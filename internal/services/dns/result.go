@@ -108,3 +108,29 @@ func (r *Result) WriteTable(w io.Writer) error {
 	}
 	return table.Render()
 }
+
+// ReportSections renders a summary of the record counts followed by one
+// section per record type present.
+func (r *Result) ReportSections() []output.Section {
+	types := []struct {
+		name   string
+		values []string
+	}{
+		{"NS", r.NS}, {"CNAME", r.CNAME}, {"A", r.A}, {"AAAA", r.AAAA},
+		{"MX", r.MX}, {"SRV", r.SRV}, {"TXT", r.TXT}, {"PTR", r.PTR},
+	}
+	summary := output.Section{Title: "Summary", Facts: []output.Fact{{Label: "Input", Value: r.Input}}}
+	var sections []output.Section
+	for _, t := range types {
+		if len(t.values) == 0 {
+			continue
+		}
+		summary.Facts = append(summary.Facts, output.Fact{Label: t.name + " records", Value: fmt.Sprintf("%d", len(t.values))})
+		s := output.Section{Title: t.name + " records", Headers: []string{"Value"}}
+		for _, v := range t.values {
+			s.Rows = append(s.Rows, []string{v})
+		}
+		sections = append(sections, s)
+	}
+	return append([]output.Section{summary}, sections...)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tbckr/trident/internal/output"
 	"github.com/tbckr/trident/internal/services/dns"
 )
 
@@ -102,3 +103,16 @@ func TestResult_WriteText(t *testing.T) {
 	assert.Less(t, aIdx, mxIdx)
 	assert.Less(t, mxIdx, srvIdx)
 }
+
+func TestResult_ReportSections(t *testing.T) {
+	r := &dns.Result{Input: "example.com", A: []string{"192.0.2.1", "192.0.2.2"}, MX: []string{"10 mx.example.com."}}
+	sections := r.ReportSections()
+	require.Len(t, sections, 3)
+	assert.Equal(t, []output.Fact{
+		{Label: "Input", Value: "example.com"},
+		{Label: "A records", Value: "2"},
+		{Label: "MX records", Value: "1"},
+	}, sections[0].Facts)
+	assert.Equal(t, output.Section{Title: "A records", Headers: []string{"Value"}, Rows: [][]string{{"192.0.2.1"}, {"192.0.2.2"}}}, sections[1])
+	assert.Equal(t, "MX records", sections[2].Title)
+}
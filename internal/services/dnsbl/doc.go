@@ -0,0 +1,5 @@
+// Package dnsbl checks IP addresses and domains against DNS-based blocklists.
+// The list of zones and the meaning of their 127.0.0.x return codes come
+// from a YAML catalog that can be overridden or downloaded, like the detect
+// patterns.
+package dnsbl
@@ -0,0 +1,90 @@
+package dnsbl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// ZoneResult is the outcome of querying one DNSBL zone.
+type ZoneResult struct {
+	Zone     string   `json:"zone"`
+	Name     string   `json:"name,omitempty"`
+	Query    string   `json:"query"`
+	Status   string   `json:"status"`             // listed | not listed | refused | error
+	Codes    []string `json:"codes,omitempty"`    // 127.0.0.x answers
+	Meanings []string `json:"meanings,omitempty"` // decoded listing or refusal reasons
+	Error    string   `json:"error,omitempty"`
+}
+
+// Result holds the DNSBL status of one IP address or domain.
+type Result struct {
+	Input string       `json:"input"`
+	Type  string       `json:"type"` // ip | domain
+	Zones []ZoneResult `json:"zones,omitempty"`
+}
+
+// IsEmpty reports whether no zone was queried.
+func (r *Result) IsEmpty() bool {
+	return len(r.Zones) == 0
+}
+
+// Listed returns the number of zones listing the input.
+func (r *Result) Listed() int {
+	n := 0
+	for _, z := range r.Zones {
+		if z.Status == StatusListed {
+			n++
+		}
+	}
+	return n
+}
+
+// detail returns the meanings, or the error for failed lookups.
+func (z ZoneResult) detail() string {
+	if z.Error != "" {
+		return z.Error
+	}
+	return strings.Join(z.Meanings, "; ")
+}
+
+// rows returns the Zone / Name / Status / Codes / Details cells.
+func (r *Result) rows() [][]string {
+	rows := make([][]string, 0, len(r.Zones))
+	for _, z := range r.Zones {
+		rows = append(rows, []string{z.Zone, z.Name, z.Status, strings.Join(z.Codes, ", "), z.detail()})
+	}
+	return rows
+}
+
+// WriteText renders one "<zone> <status> [details]" line per zone.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, z := range r.Zones {
+		if err := writeTextLine(w, "", z); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTextLine writes one zone line, optionally prefixed with the input.
+func writeTextLine(w io.Writer, prefix string, z ZoneResult) error {
+	line := prefix + z.Zone + " " + z.Status
+	if d := z.detail(); d != "" {
+		line += " " + d
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// WriteTable renders the zones as a table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 30)
+	table.Header([]string{"Zone", "Name", "Status", "Codes", "Details"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
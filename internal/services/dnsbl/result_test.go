@@ -0,0 +1,80 @@
+package dnsbl_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/dnsbl"
+)
+
+func TestResult_WriteText(t *testing.T) {
+	result := &dnsbl.Result{
+		Input: "192.0.2.1",
+		Type:  dnsbl.TypeIP,
+		Zones: []dnsbl.ZoneResult{
+			{Zone: "zen.spamhaus.org", Status: dnsbl.StatusListed, Codes: []string{"127.0.0.4", "127.0.0.11"}, Meanings: []string{"XBL", "PBL"}},
+			{Zone: "bl.spamcop.net", Status: dnsbl.StatusUnlisted},
+			{Zone: "b.barracudacentral.org", Status: dnsbl.StatusError, Error: "i/o timeout"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "zen.spamhaus.org listed XBL; PBL\nbl.spamcop.net not listed\nb.barracudacentral.org error i/o timeout\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &dnsbl.Result{
+		Input: "192.0.2.1",
+		Type:  dnsbl.TypeIP,
+		Zones: []dnsbl.ZoneResult{
+			{Zone: "zen.spamhaus.org", Name: "Spamhaus ZEN", Status: dnsbl.StatusListed, Codes: []string{"127.0.0.4", "127.0.0.11"}, Meanings: []string{"XBL", "PBL"}},
+			{Zone: "bl.spamcop.net", Name: "SpamCop", Status: dnsbl.StatusUnlisted},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "DETAILS")
+	assert.Contains(t, out, "127.0.0.4, 127.0.0.11")
+	assert.Contains(t, out, "not listed")
+}
+
+func TestResult_WriteTable_Refused(t *testing.T) {
+	result := &dnsbl.Result{
+		Input: "192.0.2.1",
+		Type:  dnsbl.TypeIP,
+		Zones: []dnsbl.ZoneResult{
+			{Zone: "zen.spamhaus.org", Name: "Spamhaus ZEN", Status: dnsbl.StatusRefused, Codes: []string{"127.255.255.254"}, Meanings: []string{"query via public resolver"}},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "refused")
+	assert.Contains(t, out, "query via public resolver")
+}
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&dnsbl.Result{Input: "192.0.2.1", Type: dnsbl.TypeIP}).IsEmpty())
+	assert.False(t, (&dnsbl.Result{Input: "192.0.2.1", Type: dnsbl.TypeIP, Zones: []dnsbl.ZoneResult{{Zone: "bl.spamcop.net", Status: dnsbl.StatusUnlisted}}}).IsEmpty())
+}
+
+func TestMultiResult(t *testing.T) {
+	m := &dnsbl.MultiResult{}
+	m.Results = []*dnsbl.Result{
+		{Input: "192.0.2.1", Type: dnsbl.TypeIP, Zones: []dnsbl.ZoneResult{{Zone: "zen.spamhaus.org", Status: dnsbl.StatusListed, Meanings: []string{"XBL", "PBL"}}}},
+		{Input: "example.com", Type: dnsbl.TypeDomain, Zones: []dnsbl.ZoneResult{{Zone: "dbl.spamhaus.org", Status: dnsbl.StatusUnlisted}}},
+	}
+
+	var text bytes.Buffer
+	require.NoError(t, m.WriteText(&text))
+	assert.Equal(t, "192.0.2.1 zen.spamhaus.org listed XBL; PBL\nexample.com dbl.spamhaus.org not listed\n", text.String())
+
+	var table bytes.Buffer
+	require.NoError(t, m.WriteTable(&table))
+	assert.Contains(t, table.String(), "INPUT")
+	assert.Contains(t, table.String(), "dbl.spamhaus.org")
+}
@@ -0,0 +1,225 @@
+package dnsbl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// Name is the service identifier.
+	Name = "dnsbl"
+	// PAP is the PAP activity level for the dnsbl service: the queries go to
+	// the blocklist operators, never to the target.
+	PAP = pap.AMBER
+)
+
+// Zone statuses reported per queried zone.
+const (
+	StatusListed   = "listed"
+	StatusUnlisted = "not listed"
+	StatusRefused  = "refused"
+	StatusError    = "error"
+)
+
+// Service checks IP addresses and domains against DNSBL zones.
+type Service struct {
+	resolver services.DNSResolverInterface
+	logger   *slog.Logger
+	zones    []Zone
+}
+
+// NewService creates a new dnsbl service querying the given zones.
+func NewService(resolver services.DNSResolverInterface, logger *slog.Logger, zones []Zone) *Service {
+	return &Service{resolver: resolver, logger: logger, zones: zones}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the dnsbl service.
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple dnsbl results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run checks an IPv4/IPv6 address against the ip zones, or a domain against
+// the domain zones. All applicable zones are queried concurrently.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	target := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(input)), ".")
+	result := &Result{Input: output.StripANSI(input)}
+
+	var (
+		prefix string
+		zones  []Zone
+	)
+	if addr, err := netip.ParseAddr(target); err == nil {
+		addr = addr.Unmap()
+		result.Type = TypeIP
+		prefix = reverseAddr(addr)
+		for _, z := range s.zones {
+			if z.Type == TypeIP && (addr.Is4() || z.IPv6) {
+				zones = append(zones, z)
+			}
+		}
+	} else if services.IsDomain(target) {
+		result.Type = TypeDomain
+		prefix = target
+		for _, z := range s.zones {
+			if z.Type == TypeDomain {
+				zones = append(zones, z)
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("%w: must be an IP address or domain: %q", services.ErrInvalidInput, input)
+	}
+
+	result.Zones = make([]ZoneResult, len(zones))
+	var wg sync.WaitGroup
+	for i, z := range zones {
+		wg.Go(func() {
+			result.Zones[i] = s.query(ctx, z, prefix+"."+z.Zone)
+		})
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		// Lookups aborted by cancellation are not meaningful answers.
+		kept := result.Zones[:0]
+		for _, zr := range result.Zones {
+			if zr.Status != StatusError {
+				kept = append(kept, zr)
+			}
+		}
+		result.Zones = kept
+	}
+	return result, nil
+}
+
+// query looks up name in zone z and decodes the answer.
+func (s *Service) query(ctx context.Context, z Zone, name string) ZoneResult {
+	zr := ZoneResult{Zone: z.Zone, Name: z.Name, Query: name}
+	addrs, err := s.resolver.LookupIPAddr(ctx, name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			zr.Status = StatusUnlisted
+			return zr
+		}
+		s.logger.Debug("DNSBL lookup failed", "zone", z.Zone, "query", name, "error", err)
+		zr.Status = StatusError
+		zr.Error = err.Error()
+		return zr
+	}
+	if len(addrs) == 0 {
+		zr.Status = StatusUnlisted
+		return zr
+	}
+	decode(z, addrs, &zr)
+	return zr
+}
+
+// decode fills zr from the A records returned for a listed query. A refusal
+// code wins over listing codes; answers outside 127.0.0.0/8 (e.g. from a
+// resolver rewriting NXDOMAIN) are reported as errors.
+func decode(z Zone, addrs []net.IPAddr, zr *ZoneResult) {
+	var codes, meanings, refusals []string
+	for _, a := range addrs {
+		ip, ok := netip.AddrFromSlice(a.IP)
+		if !ok {
+			continue
+		}
+		ip = ip.Unmap()
+		if !ip.Is4() || ip.As4()[0] != 127 {
+			zr.Status = StatusError
+			zr.Error = fmt.Sprintf("unexpected answer %s (resolver may rewrite NXDOMAIN)", ip)
+			return
+		}
+		code := ip.String()
+		if m, ok := lookupCode(z.Refused, code); ok {
+			refusals = append(refusals, m)
+			continue
+		}
+		codes = append(codes, code)
+		meanings = append(meanings, codeMeanings(z, ip)...)
+	}
+
+	switch {
+	case len(refusals) > 0:
+		zr.Status = StatusRefused
+		zr.Meanings = refusals
+	case len(codes) > 0:
+		zr.Status = StatusListed
+		zr.Codes = codes
+		zr.Meanings = meanings
+	default:
+		zr.Status = StatusUnlisted
+	}
+}
+
+// codeMeanings returns the meanings of a listing code. For bitmask zones each
+// code names one bit of the last octet; otherwise codes match exactly.
+func codeMeanings(z Zone, ip netip.Addr) []string {
+	if !z.Bitmask {
+		if m, ok := lookupCode(z.Codes, ip.String()); ok {
+			return []string{m}
+		}
+		return []string{"unknown return code " + ip.String()}
+	}
+	got := ip.As4()
+	var meanings []string
+	for _, c := range z.Codes {
+		bit, err := netip.ParseAddr(c.Code)
+		if err != nil {
+			continue
+		}
+		want := bit.As4()
+		if want[0] == got[0] && want[1] == got[1] && want[2] == got[2] && want[3]&got[3] != 0 {
+			meanings = append(meanings, c.Meaning)
+		}
+	}
+	if len(meanings) == 0 {
+		return []string{"unknown return code " + ip.String()}
+	}
+	return meanings
+}
+
+// lookupCode returns the meaning of code in codes.
+func lookupCode(codes []Code, code string) (string, bool) {
+	for _, c := range codes {
+		if c.Code == code {
+			return c.Meaning, true
+		}
+	}
+	return "", false
+}
+
+// reverseAddr returns the DNSBL query prefix for addr: reversed octets for
+// IPv4, reversed nibbles for IPv6.
+func reverseAddr(addr netip.Addr) string {
+	if addr.Is4() {
+		b := addr.As4()
+		return fmt.Sprintf("%d.%d.%d.%d", b[3], b[2], b[1], b[0])
+	}
+	b := addr.As16()
+	var sb strings.Builder
+	for i := len(b) - 1; i >= 0; i-- {
+		fmt.Fprintf(&sb, "%x.%x.", b[i]&0xf, b[i]>>4)
+	}
+	return strings.TrimSuffix(sb.String(), ".")
+}
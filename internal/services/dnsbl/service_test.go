@@ -0,0 +1,157 @@
+package dnsbl_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/dnsbl"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+var testZones = []dnsbl.Zone{
+	{
+		Zone: "ip.example.org", Name: "IP BL", Type: dnsbl.TypeIP, IPv6: true,
+		Codes:   []dnsbl.Code{{Code: "127.0.0.2", Meaning: "spam source"}, {Code: "127.0.0.4", Meaning: "exploited host"}},
+		Refused: []dnsbl.Code{{Code: "127.255.255.254", Meaning: "public resolver"}},
+	},
+	{
+		Zone: "ip4.example.org", Name: "IPv4 only", Type: dnsbl.TypeIP,
+		Codes: []dnsbl.Code{{Code: "127.0.0.2", Meaning: "listed"}},
+	},
+	{
+		Zone: "uri.example.org", Name: "URI BL", Type: dnsbl.TypeDomain, Bitmask: true,
+		Codes:   []dnsbl.Code{{Code: "127.0.0.8", Meaning: "phishing"}, {Code: "127.0.0.16", Meaning: "malware"}},
+		Refused: []dnsbl.Code{{Code: "127.0.0.1", Meaning: "refused"}},
+	},
+}
+
+// newResolver answers the given names with the given A records; every other
+// name is NXDOMAIN. Queried names are recorded.
+func newResolver(answers map[string][]string, queried *[]string) *testutil.MockResolver {
+	var mu sync.Mutex
+	return &testutil.MockResolver{
+		LookupIPAddrFn: func(_ context.Context, host string) ([]net.IPAddr, error) {
+			mu.Lock()
+			*queried = append(*queried, host)
+			mu.Unlock()
+			ips, ok := answers[host]
+			if !ok {
+				return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+			}
+			addrs := make([]net.IPAddr, len(ips))
+			for i, ip := range ips {
+				addrs[i] = net.IPAddr{IP: net.ParseIP(ip)}
+			}
+			return addrs, nil
+		},
+	}
+}
+
+func run(t *testing.T, answers map[string][]string, input string) (*dnsbl.Result, []string) {
+	t.Helper()
+	var queried []string
+	svc := dnsbl.NewService(newResolver(answers, &queried), testutil.NopLogger(), testZones)
+	raw, err := svc.Run(context.Background(), input)
+	require.NoError(t, err)
+	return raw.(*dnsbl.Result), queried
+}
+
+func TestRun_IPv4Listed(t *testing.T) {
+	result, queried := run(t, map[string][]string{
+		"2.0.0.127.ip.example.org": {"127.0.0.2", "127.0.0.4"},
+	}, "127.0.0.2")
+
+	assert.ElementsMatch(t, []string{"2.0.0.127.ip.example.org", "2.0.0.127.ip4.example.org"}, queried)
+	assert.Equal(t, dnsbl.TypeIP, result.Type)
+	require.Len(t, result.Zones, 2)
+	assert.Equal(t, dnsbl.StatusListed, result.Zones[0].Status)
+	assert.Equal(t, []string{"127.0.0.2", "127.0.0.4"}, result.Zones[0].Codes)
+	assert.Equal(t, []string{"spam source", "exploited host"}, result.Zones[0].Meanings)
+	assert.Equal(t, dnsbl.StatusUnlisted, result.Zones[1].Status)
+	assert.Equal(t, 1, result.Listed())
+}
+
+func TestRun_IPv6SkipsIPv4OnlyZones(t *testing.T) {
+	_, queried := run(t, nil, "2001:db8::1")
+	require.Len(t, queried, 1)
+	assert.Equal(t, "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip.example.org", queried[0])
+}
+
+func TestRun_DomainBitmask(t *testing.T) {
+	result, queried := run(t, map[string][]string{
+		"evil.example.com.uri.example.org": {"127.0.0.24"},
+	}, "Evil.Example.COM.")
+
+	assert.Equal(t, []string{"evil.example.com.uri.example.org"}, queried)
+	require.Len(t, result.Zones, 1)
+	assert.Equal(t, dnsbl.StatusListed, result.Zones[0].Status)
+	assert.Equal(t, []string{"phishing", "malware"}, result.Zones[0].Meanings)
+}
+
+func TestRun_Refused(t *testing.T) {
+	result, _ := run(t, map[string][]string{
+		"4.3.2.1.ip.example.org": {"127.255.255.254"},
+	}, "1.2.3.4")
+	assert.Equal(t, dnsbl.StatusRefused, result.Zones[0].Status)
+	assert.Equal(t, []string{"public resolver"}, result.Zones[0].Meanings)
+	assert.Zero(t, result.Listed())
+}
+
+func TestRun_UnknownCodeAndRewrittenNXDOMAIN(t *testing.T) {
+	result, _ := run(t, map[string][]string{
+		"4.3.2.1.ip.example.org":  {"127.0.0.99"},
+		"4.3.2.1.ip4.example.org": {"198.51.100.1"},
+	}, "1.2.3.4")
+	assert.Equal(t, dnsbl.StatusListed, result.Zones[0].Status)
+	assert.Equal(t, []string{"unknown return code 127.0.0.99"}, result.Zones[0].Meanings)
+	assert.Equal(t, dnsbl.StatusError, result.Zones[1].Status)
+	assert.Contains(t, result.Zones[1].Error, "198.51.100.1")
+}
+
+func TestRun_LookupError(t *testing.T) {
+	r := &testutil.MockResolver{
+		LookupIPAddrFn: func(_ context.Context, host string) ([]net.IPAddr, error) {
+			return nil, &net.DNSError{Err: "server misbehaving", Name: host, IsTemporary: true}
+		},
+	}
+	svc := dnsbl.NewService(r, testutil.NopLogger(), testZones)
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+	result := raw.(*dnsbl.Result)
+	assert.Equal(t, dnsbl.StatusError, result.Zones[0].Status)
+	assert.Contains(t, result.Zones[0].Error, "server misbehaving")
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	svc := dnsbl.NewService(&testutil.MockResolver{}, testutil.NopLogger(), testZones)
+	for _, input := range []string{"", "not a domain", "http://example.com/"} {
+		_, err := svc.Run(context.Background(), input)
+		assert.ErrorIs(t, err, services.ErrInvalidInput, input)
+	}
+}
+
+func TestRun_CancelledContext(t *testing.T) {
+	r := &testutil.MockResolver{
+		LookupIPAddrFn: func(ctx context.Context, _ string) ([]net.IPAddr, error) {
+			return nil, ctx.Err()
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc := dnsbl.NewService(r, testutil.NopLogger(), testZones)
+	raw, err := svc.Run(ctx, "1.2.3.4")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := dnsbl.NewService(&testutil.MockResolver{}, testutil.NopLogger(), nil)
+	assert.Equal(t, "dnsbl", svc.Name())
+	assert.Equal(t, dnsbl.PAP, svc.PAP())
+}
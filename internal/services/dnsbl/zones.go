@@ -0,0 +1,137 @@
+package dnsbl
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tbckr/trident/internal/appdir"
+)
+
+//go:embed zones.yaml
+var embeddedZones []byte
+
+// Zone types.
+const (
+	TypeIP     = "ip"
+	TypeDomain = "domain"
+)
+
+// Code maps a 127.0.0.x return code to its meaning.
+type Code struct {
+	Code    string `yaml:"code"`
+	Meaning string `yaml:"meaning"`
+}
+
+// Zone describes one DNSBL and how to decode its answers.
+type Zone struct {
+	Zone    string `yaml:"zone"`
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`    // ip | domain
+	IPv6    bool   `yaml:"ipv6"`    // ip zones only: accepts nibble-reversed IPv6 queries
+	Bitmask bool   `yaml:"bitmask"` // the last octet of the answer is a bit field
+	Codes   []Code `yaml:"codes"`   // answers meaning "listed"
+	Refused []Code `yaml:"refused"` // answers meaning the query was refused
+}
+
+// Catalog is the top-level structure of the zones file.
+type Catalog struct {
+	Zones []Zone `yaml:"zones"`
+}
+
+// ParseZones decodes and validates a zones file.
+func ParseZones(data []byte) (Catalog, error) {
+	var c Catalog
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return Catalog{}, err
+	}
+	if len(c.Zones) == 0 {
+		return Catalog{}, errors.New("no zones defined")
+	}
+	for i, z := range c.Zones {
+		if err := z.validate(); err != nil {
+			return Catalog{}, fmt.Errorf("zone %d (%s): %w", i+1, z.Zone, err)
+		}
+	}
+	return c, nil
+}
+
+// validate checks the zone name, type, and return codes.
+func (z Zone) validate() error {
+	if z.Zone == "" || strings.ContainsAny(z.Zone, " /") {
+		return fmt.Errorf("invalid zone name %q", z.Zone)
+	}
+	if z.Type != TypeIP && z.Type != TypeDomain {
+		return fmt.Errorf("type must be %q or %q, got %q", TypeIP, TypeDomain, z.Type)
+	}
+	for _, c := range slices.Concat(z.Codes, z.Refused) {
+		if a, err := netip.ParseAddr(c.Code); err != nil || !a.Is4() {
+			return fmt.Errorf("invalid return code %q", c.Code)
+		}
+	}
+	return nil
+}
+
+// LoadZones tries each path in order; the first file that exists is used.
+// Falls back to the embedded zones.yaml when no override file is found.
+func LoadZones(paths ...string) ([]Zone, error) {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading zones file %q: %w", path, err)
+		}
+		c, err := ParseZones(data)
+		if err != nil {
+			continue // corrupt file → try next path or embedded fallback
+		}
+		return c.Zones, nil
+	}
+	c, err := ParseZones(embeddedZones)
+	if err != nil {
+		return nil, fmt.Errorf("parsing embedded zones: %w", err)
+	}
+	return c.Zones, nil
+}
+
+// ResolveZonesFile returns the zones file that will actually be used.
+// If explicitFile is non-empty, it is returned as-is.
+// Otherwise, DefaultZonePaths() is searched in order; the first existing file
+// is returned. If none exist, "<embedded>" is returned.
+func ResolveZonesFile(explicitFile string) string {
+	if explicitFile != "" {
+		return explicitFile
+	}
+	paths, err := DefaultZonePaths()
+	if err != nil {
+		return "<embedded>"
+	}
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return "<embedded>"
+}
+
+// DefaultZonePaths returns the two override paths in priority order:
+// user-edited file first, then the reserved download path.
+func DefaultZonePaths() ([]string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolving config dir: %w", err)
+	}
+	return []string{
+		filepath.Join(dir, "dnsbl.yaml"),
+		filepath.Join(dir, "dnsbl-downloaded.yaml"),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package dnsbl_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/dnsbl"
+)
+
+func TestLoadZones_Embedded(t *testing.T) {
+	zones, err := dnsbl.LoadZones()
+	require.NoError(t, err)
+	names := make([]string, len(zones))
+	for i, z := range zones {
+		names[i] = z.Zone
+	}
+	assert.Contains(t, names, "zen.spamhaus.org")
+	assert.Contains(t, names, "dbl.spamhaus.org")
+	assert.Contains(t, names, "multi.surbl.org")
+}
+
+func TestLoadZones_OverrideFile(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "dnsbl.yaml")
+	content := `zones:
+  - zone: bl.example.org
+    name: Example BL
+    type: ip
+    codes:
+      - {code: 127.0.0.2, meaning: spam}
+`
+	require.NoError(t, os.WriteFile(f, []byte(content), 0o600))
+
+	zones, err := dnsbl.LoadZones(filepath.Join(t.TempDir(), "missing.yaml"), f)
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "bl.example.org", zones[0].Zone)
+	assert.Equal(t, "spam", zones[0].Codes[0].Meaning)
+}
+
+func TestLoadZones_InvalidFileFallsBack(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "dnsbl.yaml")
+	require.NoError(t, os.WriteFile(f, []byte("zones:\n  - zone: bl.example.org\n    type: mail\n"), 0o600))
+
+	zones, err := dnsbl.LoadZones(f)
+	require.NoError(t, err)
+	assert.Greater(t, len(zones), 1, "embedded catalog used")
+}
+
+func TestParseZones_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":        "zones: []\n",
+		"no zone name": "zones:\n  - type: ip\n",
+		"bad type":     "zones:\n  - zone: bl.example.org\n    type: mail\n",
+		"bad code":     "zones:\n  - zone: bl.example.org\n    type: ip\n    codes:\n      - {code: '2', meaning: x}\n",
+		"not yaml":     "zones: [",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := dnsbl.ParseZones([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestResolveZonesFile_ExplicitValue(t *testing.T) {
+	assert.Equal(t, "/custom/dnsbl.yaml", dnsbl.ResolveZonesFile("/custom/dnsbl.yaml"))
+}
@@ -0,0 +1,4 @@
+// Package filtercheck compares how filtering DNS-over-HTTPS resolvers answer
+// a domain against an unfiltered baseline, recognising each provider's block
+// signature. The resolver catalog is data-driven YAML.
+package filtercheck
@@ -0,0 +1,42 @@
+package filtercheck
+
+import (
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds filtercheck results for multiple inputs.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteText overrides the base: prefixes each resolver line with the originating input.
+func (m *MultiResult) WriteText(w io.Writer) error {
+	for _, r := range m.Results {
+		for _, v := range r.Resolvers {
+			if err := writeTextLine(w, r.Input+" ", v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteTable renders all results in a single combined table grouped by input.
+// Columns: Input / Resolver / Filter / Verdict / Reason / Answers.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows [][]string
+	for _, r := range m.Results {
+		for _, row := range r.rows() {
+			rows = append(rows, append([]string{r.Input}, row...))
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 20, 40)
+	table.Header([]string{"Input", "Resolver", "Filter", "Verdict", "Reason", "Answers"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
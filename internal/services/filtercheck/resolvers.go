@@ -0,0 +1,139 @@
+package filtercheck
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tbckr/trident/internal/appdir"
+)
+
+//go:embed resolvers.yaml
+var embeddedResolvers []byte
+
+// BlockSignature describes how a filtering resolver answers a blocked domain.
+type BlockSignature struct {
+	EDE           []uint16 `yaml:"ede"`             // RFC 8914 info codes
+	Sinkholes     []string `yaml:"sinkholes"`       // addresses or CIDR prefixes
+	NXDomainNoSOA bool     `yaml:"nxdomain_no_soa"` // NXDOMAIN with an empty authority section
+
+	prefixes []netip.Prefix
+}
+
+// Resolver is one DoH resolver from the catalog.
+type Resolver struct {
+	Name     string         `yaml:"name"`
+	Provider string         `yaml:"provider"`
+	Filter   string         `yaml:"filter"`
+	URL      string         `yaml:"url"`
+	Baseline bool           `yaml:"baseline"` // unfiltered reference resolver
+	Block    BlockSignature `yaml:"block"`
+}
+
+// Catalog is the top-level structure of the resolvers file.
+type Catalog struct {
+	Resolvers []Resolver `yaml:"resolvers"`
+}
+
+// ParseResolvers decodes and validates a resolvers file.
+func ParseResolvers(data []byte) (Catalog, error) {
+	var c Catalog
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return Catalog{}, err
+	}
+	if len(c.Resolvers) == 0 {
+		return Catalog{}, errors.New("no resolvers defined")
+	}
+	seen := make(map[string]bool, len(c.Resolvers))
+	baselines := 0
+	for i := range c.Resolvers {
+		r := &c.Resolvers[i]
+		if err := r.init(); err != nil {
+			return Catalog{}, fmt.Errorf("resolver %d (%s): %w", i+1, r.Name, err)
+		}
+		if seen[r.Name] {
+			return Catalog{}, fmt.Errorf("duplicate resolver name %q", r.Name)
+		}
+		seen[r.Name] = true
+		if r.Baseline {
+			baselines++
+		}
+	}
+	if baselines > 1 {
+		return Catalog{}, errors.New("more than one baseline resolver")
+	}
+	return c, nil
+}
+
+// init validates r and parses its sinkhole prefixes.
+func (r *Resolver) init() error {
+	if r.Name == "" || strings.ContainsAny(r.Name, " ,") {
+		return fmt.Errorf("invalid resolver name %q", r.Name)
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("url must be an https:// DoH endpoint, got %q", r.URL)
+	}
+	r.Block.prefixes = r.Block.prefixes[:0]
+	for _, s := range r.Block.Sinkholes {
+		p, err := parseSinkhole(s)
+		if err != nil {
+			return err
+		}
+		r.Block.prefixes = append(r.Block.prefixes, p)
+	}
+	return nil
+}
+
+// parseSinkhole parses an address or CIDR prefix.
+func parseSinkhole(s string) (netip.Prefix, error) {
+	if p, err := netip.ParsePrefix(s); err == nil {
+		return p.Masked(), nil
+	}
+	a, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid sinkhole %q", s)
+	}
+	return netip.PrefixFrom(a, a.BitLen()), nil
+}
+
+// LoadResolvers tries each path in order; the first file that exists is used.
+// Falls back to the embedded resolvers.yaml when no override file is found.
+func LoadResolvers(paths ...string) ([]Resolver, error) {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading resolvers file %q: %w", path, err)
+		}
+		c, err := ParseResolvers(data)
+		if err != nil {
+			continue // corrupt file → try next path or embedded fallback
+		}
+		return c.Resolvers, nil
+	}
+	c, err := ParseResolvers(embeddedResolvers)
+	if err != nil {
+		return nil, fmt.Errorf("parsing embedded resolvers: %w", err)
+	}
+	return c.Resolvers, nil
+}
+
+// DefaultResolverPath returns the user-maintained override path,
+// <config-dir>/filtercheck.yaml.
+func DefaultResolverPath() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, "filtercheck.yaml"), nil
+}
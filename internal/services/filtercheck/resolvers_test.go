@@ -0,0 +1,61 @@
+package filtercheck_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/filtercheck"
+)
+
+func TestLoadResolvers_Embedded(t *testing.T) {
+	resolvers, err := filtercheck.LoadResolvers()
+	require.NoError(t, err)
+	names := make([]string, 0, len(resolvers))
+	baselines := 0
+	for _, r := range resolvers {
+		names = append(names, r.Name)
+		if r.Baseline {
+			baselines++
+		}
+	}
+	assert.Equal(t, 1, baselines)
+	assert.Subset(t, names, []string{"quad9", "cloudflare-security", "cloudflare-family", "adguard", "cleanbrowsing-security", "opendns-familyshield"})
+}
+
+func TestLoadResolvers_OverrideAndFallback(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.yaml")
+	require.NoError(t, os.WriteFile(good, []byte("resolvers:\n  - name: only\n    url: https://dns.example/dns-query\n"), 0o600))
+	resolvers, err := filtercheck.LoadResolvers(good)
+	require.NoError(t, err)
+	require.Len(t, resolvers, 1)
+	assert.Equal(t, "only", resolvers[0].Name)
+
+	bad := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(bad, []byte("resolvers:\n  - name: x\n    url: http://insecure/\n"), 0o600))
+	resolvers, err = filtercheck.LoadResolvers(bad)
+	require.NoError(t, err)
+	assert.Greater(t, len(resolvers), 1, "embedded catalog used")
+}
+
+func TestParseResolvers_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":        "resolvers: []\n",
+		"no name":      "resolvers:\n  - url: https://a.example/\n",
+		"plain http":   "resolvers:\n  - name: a\n    url: http://a.example/\n",
+		"bad sinkhole": "resolvers:\n  - name: a\n    url: https://a.example/\n    block: {sinkholes: [nope]}\n",
+		"duplicate":    "resolvers:\n  - name: a\n    url: https://a.example/\n  - name: a\n    url: https://b.example/\n",
+		"two baselines": "resolvers:\n  - name: a\n    url: https://a.example/\n    baseline: true\n" +
+			"  - name: b\n    url: https://b.example/\n    baseline: true\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := filtercheck.ParseResolvers([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package filtercheck
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Verdict is one resolver's answer for the domain.
+type Verdict struct {
+	Resolver string   `json:"resolver"`
+	Provider string   `json:"provider,omitempty"`
+	Filter   string   `json:"filter,omitempty"`
+	Baseline bool     `json:"baseline,omitempty"`
+	Verdict  string   `json:"verdict"`          // blocked | resolved | nxdomain | error
+	Reason   string   `json:"reason,omitempty"` // matched block signature or error
+	Rcode    string   `json:"rcode,omitempty"`
+	Answers  []string `json:"answers,omitempty"` // A records
+	EDE      []string `json:"ede,omitempty"`     // Extended DNS Errors returned
+}
+
+// Result holds the per-resolver verdicts for one domain.
+type Result struct {
+	Input     string    `json:"input"`
+	Resolvers []Verdict `json:"resolvers,omitempty"`
+}
+
+// IsEmpty reports whether no resolver answered.
+func (r *Result) IsEmpty() bool {
+	return len(r.Resolvers) == 0
+}
+
+// Blocked returns the number of resolvers blocking the domain.
+func (r *Result) Blocked() int {
+	n := 0
+	for _, v := range r.Resolvers {
+		if v.Verdict == VerdictBlocked {
+			n++
+		}
+	}
+	return n
+}
+
+// name returns the resolver name, marked when it is the baseline.
+func (v Verdict) name() string {
+	if v.Baseline {
+		return v.Resolver + " (baseline)"
+	}
+	return v.Resolver
+}
+
+// rows returns the Resolver / Filter / Verdict / Reason / Answers cells.
+func (r *Result) rows() [][]string {
+	rows := make([][]string, 0, len(r.Resolvers))
+	for _, v := range r.Resolvers {
+		rows = append(rows, []string{v.name(), v.Filter, v.Verdict, v.Reason, strings.Join(v.Answers, ", ")})
+	}
+	return rows
+}
+
+// writeTextLine writes "<resolver> <verdict> [reason]", optionally prefixed.
+func writeTextLine(w io.Writer, prefix string, v Verdict) error {
+	line := prefix + v.Resolver + " " + v.Verdict
+	if v.Reason != "" {
+		line += " " + v.Reason
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// WriteText renders one "<resolver> <verdict> [reason]" line per resolver.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, v := range r.Resolvers {
+		if err := writeTextLine(w, "", v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the verdicts as a table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 30)
+	table.Header([]string{"Resolver", "Filter", "Verdict", "Reason", "Answers"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,77 @@
+package filtercheck_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/filtercheck"
+)
+
+func TestResult_WriteText(t *testing.T) {
+	result := &filtercheck.Result{
+		Input: "evil.example.com",
+		Resolvers: []filtercheck.Verdict{
+			{Resolver: "cloudflare", Baseline: true, Verdict: filtercheck.VerdictResolved, Answers: []string{"192.0.2.10"}},
+			{Resolver: "quad9", Verdict: filtercheck.VerdictBlocked, Reason: "NXDOMAIN without SOA"},
+			{Resolver: "adguard", Verdict: filtercheck.VerdictBlocked, Reason: "0.0.0.0 sinkhole"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "cloudflare resolved\nquad9 blocked NXDOMAIN without SOA\nadguard blocked 0.0.0.0 sinkhole\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &filtercheck.Result{
+		Input: "evil.example.com",
+		Resolvers: []filtercheck.Verdict{
+			{Resolver: "cloudflare", Baseline: true, Filter: "none", Verdict: filtercheck.VerdictResolved, Answers: []string{"192.0.2.10", "192.0.2.11"}},
+			{Resolver: "quad9", Filter: "malware, phishing", Verdict: filtercheck.VerdictBlocked, Reason: "NXDOMAIN without SOA"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "VERDICT")
+	assert.Contains(t, out, "cloudflare (baseline)")
+	assert.Contains(t, out, "192.0.2.10, 192.0.2.11")
+	assert.Contains(t, out, "NXDOMAIN without SOA")
+}
+
+func TestResult_WriteTable_ResolverError(t *testing.T) {
+	result := &filtercheck.Result{
+		Input:     "evil.example.com",
+		Resolvers: []filtercheck.Verdict{{Resolver: "mullvad", Verdict: filtercheck.VerdictError, Reason: "context deadline exceeded"}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "error")
+	assert.Contains(t, out, "context deadline exceeded")
+	assert.NotContains(t, out, "(baseline)")
+}
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&filtercheck.Result{Input: "example.com"}).IsEmpty())
+	assert.False(t, (&filtercheck.Result{Input: "example.com", Resolvers: []filtercheck.Verdict{{Resolver: "quad9", Verdict: filtercheck.VerdictResolved}}}).IsEmpty())
+}
+
+func TestMultiResult(t *testing.T) {
+	m := &filtercheck.MultiResult{}
+	m.Results = []*filtercheck.Result{
+		{Input: "evil.example.com", Resolvers: []filtercheck.Verdict{{Resolver: "quad9", Verdict: filtercheck.VerdictBlocked, Reason: "NXDOMAIN without SOA"}}},
+		{Input: "example.org", Resolvers: []filtercheck.Verdict{{Resolver: "quad9", Verdict: filtercheck.VerdictResolved}}},
+	}
+
+	var text bytes.Buffer
+	require.NoError(t, m.WriteText(&text))
+	assert.Equal(t, "evil.example.com quad9 blocked NXDOMAIN without SOA\nexample.org quad9 resolved\n", text.String())
+
+	var table bytes.Buffer
+	require.NoError(t, m.WriteTable(&table))
+	assert.Contains(t, table.String(), "INPUT")
+	assert.Contains(t, table.String(), "example.org")
+}
@@ -0,0 +1,174 @@
+package filtercheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+
+	"codeberg.org/miekg/dns"
+	"github.com/imroc/req/v3"
+
+	dohpkg "github.com/tbckr/trident/internal/doh"
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// DefaultRPS is the target request rate across all resolvers.
+	DefaultRPS float64 = 10
+	// DefaultBurst is the burst capacity above DefaultRPS; one input queries
+	// every resolver at once.
+	DefaultBurst = 20
+
+	// Name is the service identifier.
+	Name = "filtercheck"
+	// PAP is the PAP activity level for the filtercheck service: queries go
+	// to third-party resolvers, never to the target.
+	PAP = pap.AMBER
+)
+
+// Verdicts reported per resolver.
+const (
+	VerdictBlocked  = "blocked"
+	VerdictResolved = "resolved"
+	VerdictNXDomain = "nxdomain"
+	VerdictError    = "error"
+)
+
+// Service queries the catalog resolvers and classifies their answers.
+type Service struct {
+	client    *req.Client
+	logger    *slog.Logger
+	resolvers []Resolver
+}
+
+// NewService creates a new filtercheck service querying the given resolvers.
+func NewService(client *req.Client, logger *slog.Logger, resolvers []Resolver) *Service {
+	return &Service{client: client, logger: logger, resolvers: resolvers}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the filtercheck service.
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple filtercheck results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run queries every resolver concurrently for the domain's A record and
+// reports one verdict per resolver, baseline first.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	domain := strings.TrimSuffix(strings.ToLower(output.StripANSI(input)), ".")
+	if !services.IsDomain(domain) {
+		return nil, fmt.Errorf("%w: must be a valid domain name: %q", services.ErrInvalidInput, input)
+	}
+	result := &Result{Input: domain}
+
+	resolvers := slices.Clone(s.resolvers)
+	slices.SortStableFunc(resolvers, func(a, b Resolver) int {
+		switch {
+		case a.Baseline == b.Baseline:
+			return 0
+		case a.Baseline:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	result.Resolvers = make([]Verdict, len(resolvers))
+	var wg sync.WaitGroup
+	for i, r := range resolvers {
+		wg.Go(func() {
+			result.Resolvers[i] = s.query(ctx, r, domain)
+		})
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		// Queries aborted by cancellation are not meaningful verdicts.
+		kept := result.Resolvers[:0]
+		for _, v := range result.Resolvers {
+			if v.Verdict != VerdictError {
+				kept = append(kept, v)
+			}
+		}
+		result.Resolvers = kept
+	}
+	return result, nil
+}
+
+// query asks resolver r for the A record of domain and classifies the answer.
+func (s *Service) query(ctx context.Context, r Resolver, domain string) Verdict {
+	v := Verdict{Resolver: r.Name, Provider: r.Provider, Filter: r.Filter, Baseline: r.Baseline}
+	resp, err := dohpkg.MakeDoHRequestURL(ctx, s.client, r.URL, domain, dns.TypeA)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			s.logger.Debug("filtercheck query failed", "resolver", r.Name, "domain", domain, "error", err)
+		}
+		v.Verdict = VerdictError
+		v.Reason = err.Error()
+		return v
+	}
+	v.Rcode = dohpkg.RcodeName(resp.Status)
+	for _, a := range resp.Answer {
+		if a.Type == dns.TypeA {
+			v.Answers = append(v.Answers, a.Data)
+		}
+	}
+	for _, e := range resp.EDE {
+		v.EDE = append(v.EDE, output.StripANSI(e.String()))
+	}
+	v.Verdict, v.Reason = classify(r, resp)
+	return v
+}
+
+// classify applies r's block signature to resp. The baseline is never
+// classified as blocked.
+func classify(r Resolver, resp *dohpkg.Response) (verdict, reason string) {
+	if !r.Baseline {
+		for _, e := range resp.EDE {
+			if slices.Contains(r.Block.EDE, e.Code) {
+				return VerdictBlocked, output.StripANSI(e.String())
+			}
+		}
+		for _, a := range resp.Answer {
+			if a.Type != dns.TypeA {
+				continue
+			}
+			addr, err := netip.ParseAddr(a.Data)
+			if err != nil {
+				continue
+			}
+			for _, p := range r.Block.prefixes {
+				if p.Contains(addr) {
+					return VerdictBlocked, "sinkhole " + a.Data
+				}
+			}
+		}
+		if r.Block.NXDomainNoSOA && resp.Status == dns.RcodeNameError && !resp.HasAuthority {
+			return VerdictBlocked, "NXDOMAIN without SOA"
+		}
+	}
+	switch resp.Status {
+	case dns.RcodeSuccess:
+		return VerdictResolved, ""
+	case dns.RcodeNameError:
+		return VerdictNXDomain, ""
+	default:
+		return VerdictError, dohpkg.RcodeName(resp.Status)
+	}
+}
@@ -0,0 +1,156 @@
+package filtercheck_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"codeberg.org/miekg/dns"
+	"codeberg.org/miekg/dns/rdata"
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/filtercheck"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+const catalog = `resolvers:
+  - name: base
+    url: https://base.example/dns-query
+    baseline: true
+  - name: ede
+    url: https://ede.example/dns-query
+    block: {ede: [17]}
+  - name: sink
+    url: https://sink.example/dns-query
+    block: {sinkholes: [146.112.61.104/29]}
+  - name: nosoa
+    url: https://nosoa.example/dns-query
+    block: {nxdomain_no_soa: true}
+`
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func loadCatalog(t *testing.T) []filtercheck.Resolver {
+	t.Helper()
+	c, err := filtercheck.ParseResolvers([]byte(catalog))
+	require.NoError(t, err)
+	return c.Resolvers
+}
+
+// wire packs a DNS response. A non-nil ede is added as the only EDNS option.
+func wire(t *testing.T, rcode int, a string, soa bool, ede *dns.EDE) []byte {
+	t.Helper()
+	m := new(dns.Msg)
+	m.Response = true
+	m.Rcode = uint16(rcode)
+	if a != "" {
+		m.Answer = []dns.RR{&dns.A{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 60}, A: rdata.A{Addr: netip.MustParseAddr(a)}}}
+	}
+	if soa {
+		m.Ns = []dns.RR{&dns.SOA{Hdr: dns.Header{Name: "com.", Class: dns.ClassINET}, SOA: rdata.SOA{Ns: "a.gtld-servers.net.", Mbox: "nstld.verisign-grs.com."}}}
+	}
+	if ede == nil {
+		require.NoError(t, m.Pack())
+		return m.Data
+	}
+	// The library packs EDE without its extra text, so append it by hand and
+	// grow the OPT RDLENGTH and the option length, the option being last.
+	m.Pseudo = []dns.RR{&dns.EDE{InfoCode: ede.InfoCode}}
+	require.NoError(t, m.Pack())
+	data, n := m.Data, len(m.Data)
+	binary.BigEndian.PutUint16(data[n-8:], binary.BigEndian.Uint16(data[n-8:])+uint16(len(ede.ExtraText)))
+	binary.BigEndian.PutUint16(data[n-4:], binary.BigEndian.Uint16(data[n-4:])+uint16(len(ede.ExtraText)))
+	return append(data, ede.ExtraText...)
+}
+
+func register(host string, data []byte) {
+	httpmock.RegisterResponder(http.MethodGet, "=~^https://"+host+"/dns-query",
+		httpmock.NewBytesResponder(http.StatusOK, data))
+}
+
+func TestRun_Verdicts(t *testing.T) {
+	client := newTestClient(t)
+	register("base.example", wire(t, dns.RcodeSuccess, "93.184.216.34", false, nil))
+	register("ede.example", wire(t, dns.RcodeSuccess, "0.0.0.0", false, &dns.EDE{InfoCode: 17, ExtraText: "malware"}))
+	register("sink.example", wire(t, dns.RcodeSuccess, "146.112.61.106", false, nil))
+	register("nosoa.example", wire(t, dns.RcodeNameError, "", false, nil))
+
+	svc := filtercheck.NewService(client, testutil.NopLogger(), loadCatalog(t))
+	raw, err := svc.Run(context.Background(), "Example.COM")
+	require.NoError(t, err)
+	result := raw.(*filtercheck.Result)
+
+	assert.Equal(t, "example.com", result.Input)
+	require.Len(t, result.Resolvers, 4)
+	base, ede, sink, nosoa := result.Resolvers[0], result.Resolvers[1], result.Resolvers[2], result.Resolvers[3]
+
+	assert.True(t, base.Baseline)
+	assert.Equal(t, filtercheck.VerdictResolved, base.Verdict)
+	assert.Equal(t, []string{"93.184.216.34"}, base.Answers)
+
+	assert.Equal(t, filtercheck.VerdictBlocked, ede.Verdict)
+	assert.Equal(t, "EDE 17 (Filtered): malware", ede.Reason)
+	assert.Equal(t, filtercheck.VerdictBlocked, sink.Verdict)
+	assert.Equal(t, "sinkhole 146.112.61.106", sink.Reason)
+	assert.Equal(t, filtercheck.VerdictBlocked, nosoa.Verdict)
+	assert.Equal(t, "NXDOMAIN", nosoa.Rcode)
+	assert.Equal(t, 3, result.Blocked())
+}
+
+func TestRun_GenuineNXDomainAndErrors(t *testing.T) {
+	client := newTestClient(t)
+	register("base.example", wire(t, dns.RcodeNameError, "", true, nil))
+	register("ede.example", wire(t, dns.RcodeServerFailure, "", false, nil))
+	register("sink.example", wire(t, dns.RcodeSuccess, "192.0.2.1", false, nil))
+	httpmock.RegisterResponder(http.MethodGet, "=~^https://nosoa.example/", httpmock.NewStringResponder(http.StatusBadGateway, "bad gateway"))
+
+	svc := filtercheck.NewService(client, testutil.NopLogger(), loadCatalog(t))
+	raw, err := svc.Run(context.Background(), "missing.example.com")
+	require.NoError(t, err)
+	result := raw.(*filtercheck.Result)
+
+	require.Len(t, result.Resolvers, 4)
+	assert.Equal(t, filtercheck.VerdictNXDomain, result.Resolvers[0].Verdict)
+	assert.Equal(t, filtercheck.VerdictError, result.Resolvers[1].Verdict)
+	assert.Equal(t, "SERVFAIL", result.Resolvers[1].Reason)
+	assert.Equal(t, filtercheck.VerdictResolved, result.Resolvers[2].Verdict)
+	assert.Equal(t, filtercheck.VerdictError, result.Resolvers[3].Verdict)
+	assert.Contains(t, result.Resolvers[3].Reason, "HTTP 502")
+	assert.Zero(t, result.Blocked())
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	svc := filtercheck.NewService(req.NewClient(), testutil.NopLogger(), nil)
+	for _, input := range []string{"", "192.0.2.1", "not a domain"} {
+		_, err := svc.Run(context.Background(), input)
+		assert.ErrorIs(t, err, services.ErrInvalidInput, input)
+	}
+}
+
+func TestRun_CancelledContext(t *testing.T) {
+	client := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc := filtercheck.NewService(client, testutil.NopLogger(), loadCatalog(t))
+	raw, err := svc.Run(ctx, "example.com")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := filtercheck.NewService(req.NewClient(), testutil.NopLogger(), nil)
+	assert.Equal(t, "filtercheck", svc.Name())
+	assert.Equal(t, filtercheck.PAP, svc.PAP())
+}
@@ -0,0 +1,4 @@
+// Package ipinfo resolves IP addresses to ASN, organization, country, city,
+// and coordinates from local MaxMind-format MMDB files and iptoasn TSV dumps,
+// without any network traffic.
+package ipinfo
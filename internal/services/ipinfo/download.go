@@ -0,0 +1,173 @@
+package ipinfo
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/imroc/req/v3"
+)
+
+// Dataset describes a freely redistributable database that can be downloaded
+// into the data directory.
+type Dataset struct {
+	Name     string // identifier, e.g. "dbip-asn"
+	FileName string // installed file name in the data directory
+	BaseURL  string // default base URL the file is published under
+	License  string // attribution shown after download
+	urls     func(base string, now time.Time) []string
+}
+
+// URLs returns the candidate download URLs below base, newest first. An empty
+// base selects d.BaseURL.
+func (d Dataset) URLs(base string, now time.Time) []string {
+	if base == "" {
+		base = d.BaseURL
+	}
+	return d.urls(strings.TrimRight(base, "/"), now)
+}
+
+// dbipURLs returns the DB-IP lite URLs for the current and previous month;
+// a new month's file is published during the first days of the month.
+func dbipURLs(edition string) func(string, time.Time) []string {
+	return func(base string, now time.Time) []string {
+		now = now.UTC()
+		prev := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+		return []string{
+			fmt.Sprintf("%s/dbip-%s-lite-%s.mmdb.gz", base, edition, now.Format("2006-01")),
+			fmt.Sprintf("%s/dbip-%s-lite-%s.mmdb.gz", base, edition, prev.Format("2006-01")),
+		}
+	}
+}
+
+// Datasets lists the downloadable databases. GeoLite2 and IPinfo databases
+// require an account and are not included; copy them into the data directory.
+var Datasets = []Dataset{
+	{
+		Name:     "iptoasn",
+		FileName: "ip2asn-combined.tsv",
+		BaseURL:  "https://iptoasn.com/data",
+		License:  "iptoasn.com, PDDL v1.0",
+		urls: func(base string, _ time.Time) []string {
+			return []string{base + "/ip2asn-combined.tsv.gz"}
+		},
+	},
+	{
+		Name:     "dbip-asn",
+		FileName: "dbip-asn-lite.mmdb",
+		BaseURL:  "https://download.db-ip.com/free",
+		License:  "IP to ASN Lite by DB-IP (https://db-ip.com), CC BY 4.0",
+		urls:     dbipURLs("asn"),
+	},
+	{
+		Name:     "dbip-city",
+		FileName: "dbip-city-lite.mmdb",
+		BaseURL:  "https://download.db-ip.com/free",
+		License:  "IP to City Lite by DB-IP (https://db-ip.com), CC BY 4.0",
+		urls:     dbipURLs("city"),
+	},
+}
+
+// LookupDataset returns the dataset with the given name.
+func LookupDataset(name string) (Dataset, bool) {
+	for _, d := range Datasets {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Dataset{}, false
+}
+
+// DownloadDataset fetches the first available URL of d below baseURL (empty
+// for the default), decompresses it, validates the database, and atomically
+// installs it into dir. It returns the installed path.
+func DownloadDataset(ctx context.Context, client *req.Client, d Dataset, baseURL, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("creating ipinfo data dir: %w", err)
+	}
+	var lastErr error
+	for _, url := range d.URLs(baseURL, time.Now()) {
+		path, err := downloadURL(ctx, client, url, d, dir)
+		if err == nil {
+			return path, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return "", lastErr
+}
+
+// downloadURL streams url through gzip decompression into a temp file in dir,
+// validates it, and renames it to d.FileName.
+func downloadURL(ctx context.Context, client *req.Client, url string, d Dataset, dir string) (string, error) {
+	resp, err := client.R().SetContext(ctx).DisableAutoReadResponse().Get(url)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", d.Name, err)
+	}
+	if resp.Response == nil {
+		return "", fmt.Errorf("downloading %s: transport error (no response)", d.Name)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s from %s: unexpected status %d", d.Name, url, resp.StatusCode)
+	}
+
+	var body io.Reader = resp.Body
+	if strings.HasSuffix(url, ".gz") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("decompressing %s: %w", d.Name, err)
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	}
+
+	tmp, err := os.CreateTemp(dir, "download-*"+filepath.Ext(d.FileName))
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("writing %s: %w", d.Name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("closing temp file: %w", err)
+	}
+
+	if err := validate(tmpName); err != nil {
+		return "", fmt.Errorf("validating %s: %w", d.Name, err)
+	}
+	path := filepath.Join(dir, d.FileName)
+	if err := os.Rename(tmpName, path); err != nil {
+		return "", fmt.Errorf("installing %s: %w", d.Name, err)
+	}
+	return path, nil
+}
+
+// validate checks that path holds a readable database of the type implied by
+// its extension.
+func validate(path string) error {
+	if filepath.Ext(path) == ".mmdb" {
+		_, err := openMMDB(path)
+		return err
+	}
+	t, err := openTSV(path)
+	if err != nil {
+		return err
+	}
+	if len(t.ranges) == 0 {
+		return fmt.Errorf("no routed ranges")
+	}
+	return nil
+}
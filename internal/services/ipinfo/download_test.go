@@ -0,0 +1,110 @@
+package ipinfo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func newMockClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.C()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func TestDataset_URLs(t *testing.T) {
+	ds, ok := LookupDataset("dbip-city")
+	require.True(t, ok)
+	now := time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{
+		"https://download.db-ip.com/free/dbip-city-lite-2026-01.mmdb.gz",
+		"https://download.db-ip.com/free/dbip-city-lite-2025-12.mmdb.gz",
+	}, ds.URLs("", now))
+	assert.Equal(t, "https://mirror.example/dbip-city-lite-2026-01.mmdb.gz", ds.URLs("https://mirror.example/", now)[0])
+
+	_, ok = LookupDataset("geolite2")
+	assert.False(t, ok)
+}
+
+func TestDownloadDataset_TSV(t *testing.T) {
+	client := newMockClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://iptoasn.com/data/ip2asn-combined.tsv.gz",
+		httpmock.NewBytesResponder(http.StatusOK, gzipBytes(t, []byte(sampleTSV))))
+
+	ds, _ := LookupDataset("iptoasn")
+	dir := filepath.Join(t.TempDir(), "ipinfo")
+	path, err := DownloadDataset(context.Background(), client, ds, "", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "ip2asn-combined.tsv"), path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, sampleTSV, string(data))
+}
+
+func TestDownloadDataset_FallsBackToPreviousMonth(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src.mmdb")
+	testutil.WriteMMDB(t, src, 6, "DBIP-ASN-Lite", map[string]any{
+		"192.0.2.0/24": map[string]any{"autonomous_system_number": 64500},
+	})
+	mmdb, err := os.ReadFile(src)
+	require.NoError(t, err)
+
+	client := newMockClient(t)
+	ds, _ := LookupDataset("dbip-asn")
+	urls := ds.URLs("https://mirror.example", time.Now())
+	httpmock.RegisterResponder(http.MethodGet, urls[0], httpmock.NewStringResponder(http.StatusNotFound, ""))
+	httpmock.RegisterResponder(http.MethodGet, urls[1], httpmock.NewBytesResponder(http.StatusOK, gzipBytes(t, mmdb)))
+
+	dir := t.TempDir()
+	path, err := DownloadDataset(context.Background(), client, ds, "https://mirror.example", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "dbip-asn-lite.mmdb"), path)
+	_, err = openMMDB(path)
+	require.NoError(t, err)
+}
+
+func TestDownloadDataset_InvalidKeepsExisting(t *testing.T) {
+	client := newMockClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://iptoasn.com/data/ip2asn-combined.tsv.gz",
+		httpmock.NewBytesResponder(http.StatusOK, gzipBytes(t, []byte("<html>"))))
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "ip2asn-combined.tsv")
+	require.NoError(t, os.WriteFile(existing, []byte(sampleTSV), 0o600))
+
+	ds, _ := LookupDataset("iptoasn")
+	_, err := DownloadDataset(context.Background(), client, ds, "", dir)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, sampleTSV, string(data))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temp file must be cleaned up")
+}
@@ -0,0 +1,403 @@
+package ipinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"os"
+	"strings"
+)
+
+// mmdbMetadataMarker precedes the metadata map at the end of every MaxMind DB file.
+var mmdbMetadataMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+// mmdbDataSeparator is the size of the zero-filled gap between the search tree
+// and the data section.
+const mmdbDataSeparator = 16
+
+// mmdbReader is a minimal reader for the MaxMind DB format
+// (https://maxmind.github.io/MaxMind-DB/) used by GeoLite2, DB-IP, and IPinfo.
+// The whole file is held in memory.
+type mmdbReader struct {
+	buf        []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	dbType     string
+	treeSize   uint
+	data       []byte // data section
+	ipv4Start  uint   // node reached after 96 zero bits in an IPv6 tree
+}
+
+// openMMDB reads and validates a MaxMind DB file.
+func openMMDB(path string) (*mmdbReader, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return newMMDBReader(buf)
+}
+
+func newMMDBReader(buf []byte) (*mmdbReader, error) {
+	i := bytes.LastIndex(buf, mmdbMetadataMarker)
+	if i < 0 {
+		return nil, errors.New("not a MaxMind DB file: metadata marker not found")
+	}
+	metaBuf := buf[i+len(mmdbMetadataMarker):]
+	raw, _, err := (&mmdbDecoder{buf: metaBuf}).decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+	meta, ok := raw.(map[string]any)
+	if !ok {
+		return nil, errors.New("decoding metadata: not a map")
+	}
+
+	r := &mmdbReader{
+		buf:        buf,
+		nodeCount:  uint(asUint(meta["node_count"])),
+		recordSize: uint(asUint(meta["record_size"])),
+		ipVersion:  uint(asUint(meta["ip_version"])),
+	}
+	r.dbType, _ = meta["database_type"].(string)
+	switch r.recordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("unsupported record size %d", r.recordSize)
+	}
+	if r.ipVersion != 4 && r.ipVersion != 6 {
+		return nil, fmt.Errorf("unsupported IP version %d", r.ipVersion)
+	}
+	r.treeSize = r.nodeCount * r.recordSize / 4
+	dataStart := r.treeSize + mmdbDataSeparator
+	if dataStart > uint(i) {
+		return nil, errors.New("search tree exceeds file size")
+	}
+	r.data = buf[dataStart:i]
+
+	if r.ipVersion == 6 {
+		node := uint(0)
+		for range 96 {
+			if node >= r.nodeCount {
+				break
+			}
+			node = r.record(node, 0)
+		}
+		r.ipv4Start = node
+	}
+	return r, nil
+}
+
+// hasASN reports whether the database type names ASN data, such as
+// "GeoLite2-ASN", "DBIP-ASN-Lite", or IPinfo's "ipinfo lite.mmdb".
+func (r *mmdbReader) hasASN() bool {
+	t := strings.ToLower(r.dbType)
+	return strings.Contains(t, "asn") || strings.HasPrefix(t, "ipinfo lite")
+}
+
+// record returns the left (bit 0) or right (bit 1) record of node.
+func (r *mmdbReader) record(node uint, bit uint) uint {
+	off := node * r.recordSize / 4
+	b := r.buf[off : off+r.recordSize/4]
+	switch r.recordSize {
+	case 24:
+		if bit == 0 {
+			return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3])<<16 | uint(b[4])<<8 | uint(b[5])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xf0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0f)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default: // 32
+		if bit == 0 {
+			return uint(binary.BigEndian.Uint32(b[0:4]))
+		}
+		return uint(binary.BigEndian.Uint32(b[4:8]))
+	}
+}
+
+// lookup returns the data record for addr and the network it was found in.
+// ok is false when the address is not in the database.
+func (r *mmdbReader) lookup(addr netip.Addr) (rec any, network netip.Prefix, ok bool, err error) {
+	addr = addr.Unmap()
+	if addr.Is6() && r.ipVersion == 4 {
+		return nil, netip.Prefix{}, false, nil
+	}
+	ip := addr.AsSlice()
+	node := uint(0)
+	if addr.Is4() && r.ipVersion == 6 {
+		node = r.ipv4Start
+	}
+
+	bits := len(ip) * 8
+	i := 0
+	for ; i < bits && node < r.nodeCount; i++ {
+		bit := uint(ip[i/8]>>(7-uint(i%8))) & 1
+		node = r.record(node, bit)
+	}
+	if node == r.nodeCount {
+		return nil, netip.Prefix{}, false, nil
+	}
+	if node < r.nodeCount {
+		return nil, netip.Prefix{}, false, errors.New("invalid search tree: no terminal record")
+	}
+
+	off := node - r.nodeCount - mmdbDataSeparator
+	if off >= uint(len(r.data)) {
+		return nil, netip.Prefix{}, false, errors.New("invalid search tree: data pointer out of range")
+	}
+	rec, _, err = (&mmdbDecoder{buf: r.data}).decode(off)
+	if err != nil {
+		return nil, netip.Prefix{}, false, err
+	}
+	// IPv4 lookups start at the IPv4 subtree, so i is relative to the queried
+	// address family in both tree layouts.
+	network, err = addr.Prefix(i)
+	if err != nil {
+		return nil, netip.Prefix{}, false, err
+	}
+	return rec, network, true, nil
+}
+
+// MaxMind DB data section types.
+const (
+	mmdbExtended = iota
+	mmdbPointer
+	mmdbString
+	mmdbDouble
+	mmdbBytes
+	mmdbUint16
+	mmdbUint32
+	mmdbMap
+	mmdbInt32
+	mmdbUint64
+	mmdbUint128
+	mmdbArray
+	mmdbContainer
+	mmdbEndMarker
+	mmdbBool
+	mmdbFloat
+)
+
+// mmdbDecoder decodes values from a MaxMind DB data section.
+type mmdbDecoder struct {
+	buf []byte
+}
+
+var errMMDBTruncated = errors.New("truncated MaxMind DB data")
+
+// decode decodes the value at off and returns it with the offset of the next value.
+func (d *mmdbDecoder) decode(off uint) (any, uint, error) {
+	return d.decodeDepth(off, 0)
+}
+
+func (d *mmdbDecoder) decodeDepth(off uint, depth int) (any, uint, error) {
+	if depth > 64 {
+		return nil, 0, errors.New("MaxMind DB data nested too deeply")
+	}
+	if off >= uint(len(d.buf)) {
+		return nil, 0, errMMDBTruncated
+	}
+	ctrl := d.buf[off]
+	off++
+	typ := uint(ctrl >> 5)
+
+	if typ == mmdbPointer {
+		return d.decodePointer(ctrl, off, depth)
+	}
+	if typ == mmdbExtended {
+		if off >= uint(len(d.buf)) {
+			return nil, 0, errMMDBTruncated
+		}
+		typ = 7 + uint(d.buf[off])
+		off++
+	}
+	size, off, err := d.size(ctrl, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch typ {
+	case mmdbMap:
+		return d.decodeMap(off, size, depth)
+	case mmdbArray:
+		return d.decodeArray(off, size, depth)
+	case mmdbBool:
+		return size != 0, off, nil
+	case mmdbContainer, mmdbEndMarker:
+		return nil, off, nil
+	}
+
+	return d.decodeScalar(typ, off, size)
+}
+
+// decodeScalar decodes a string, bytes, floating-point, or integer value of
+// size bytes at off.
+func (d *mmdbDecoder) decodeScalar(typ, off, size uint) (any, uint, error) {
+	if off+size > uint(len(d.buf)) {
+		return nil, 0, errMMDBTruncated
+	}
+	b := d.buf[off : off+size]
+	var v any
+	var err error
+	switch typ {
+	case mmdbString:
+		v = string(b)
+	case mmdbBytes:
+		v = bytes.Clone(b)
+	case mmdbDouble, mmdbFloat:
+		v, err = decodeFloat(typ, b)
+	case mmdbUint16, mmdbUint32, mmdbUint64, mmdbInt32, mmdbUint128:
+		v, err = decodeUint(typ, b)
+	default:
+		err = fmt.Errorf("unknown MaxMind DB data type %d", typ)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return v, off + size, nil
+}
+
+// size decodes the payload size encoded in ctrl and the 0-3 bytes following
+// it at off, and returns it with the offset of the payload.
+func (d *mmdbDecoder) size(ctrl byte, off uint) (size, next uint, err error) {
+	size = uint(ctrl & 0x1f)
+	if size < 29 {
+		return size, off, nil
+	}
+	n := size - 28 // 1, 2, or 3 extra bytes
+	if off+n > uint(len(d.buf)) {
+		return 0, 0, errMMDBTruncated
+	}
+	v := uint(0)
+	for _, b := range d.buf[off : off+n] {
+		v = v<<8 | uint(b)
+	}
+	switch size {
+	case 29:
+		size = 29 + v
+	case 30:
+		size = 285 + v
+	default:
+		size = 65821 + v
+	}
+	return size, off + n, nil
+}
+
+// decodePointer follows the pointer whose control byte is ctrl and returns
+// the value it points to with the offset just past the pointer.
+func (d *mmdbDecoder) decodePointer(ctrl byte, off uint, depth int) (any, uint, error) {
+	ptr, next, err := d.pointer(ctrl, off)
+	if err != nil {
+		return nil, 0, err
+	}
+	v, _, err := d.decodeDepth(ptr, depth+1)
+	return v, next, err
+}
+
+// decodeMap decodes size key/value pairs starting at off.
+func (d *mmdbDecoder) decodeMap(off, size uint, depth int) (any, uint, error) {
+	m := make(map[string]any, size)
+	for range size {
+		k, next, err := d.decodeDepth(off, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, 0, errors.New("MaxMind DB map key is not a string")
+		}
+		v, next, err := d.decodeDepth(next, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		m[key] = v
+		off = next
+	}
+	return m, off, nil
+}
+
+// decodeArray decodes size values starting at off.
+func (d *mmdbDecoder) decodeArray(off, size uint, depth int) (any, uint, error) {
+	a := make([]any, 0, size)
+	for range size {
+		v, next, err := d.decodeDepth(off, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		a = append(a, v)
+		off = next
+	}
+	return a, off, nil
+}
+
+// decodeFloat decodes a double (8 bytes) or float (4 bytes) payload.
+func decodeFloat(typ uint, b []byte) (float64, error) {
+	if typ == mmdbFloat {
+		if len(b) != 4 {
+			return 0, fmt.Errorf("invalid float size %d", len(b))
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid double size %d", len(b))
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+}
+
+// decodeUint decodes an unsigned integer payload, or a signed one for int32.
+// uint128 values above 64 bits are truncated; they do not occur in the fields
+// this package reads.
+func decodeUint(typ uint, b []byte) (any, error) {
+	if len(b) > 16 {
+		return nil, fmt.Errorf("invalid integer size %d", len(b))
+	}
+	v := uint64(0)
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	if typ == mmdbInt32 {
+		return int64(int32(uint32(v))), nil
+	}
+	return v, nil
+}
+
+// pointer decodes a pointer whose control byte is ctrl; off points just past it.
+func (d *mmdbDecoder) pointer(ctrl byte, off uint) (ptr, next uint, err error) {
+	ss := uint(ctrl>>3) & 0x3
+	n := ss + 1
+	if off+n > uint(len(d.buf)) {
+		return 0, 0, errMMDBTruncated
+	}
+	b := d.buf[off : off+n]
+	vvv := uint(ctrl & 0x7)
+	switch ss {
+	case 0:
+		ptr = vvv<<8 | uint(b[0])
+	case 1:
+		ptr = (vvv<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+	case 2:
+		ptr = (vvv<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+	default:
+		ptr = uint(binary.BigEndian.Uint32(b))
+	}
+	return ptr, off + n, nil
+}
+
+// asUint converts a decoded integer value to uint64 (0 for other types).
+func asUint(v any) uint64 {
+	switch n := v.(type) {
+	case uint64:
+		return n
+	case int64:
+		if n >= 0 {
+			return uint64(n)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package ipinfo
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func TestMMDBReader_LookupIPv6Tree(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	testutil.WriteMMDB(t, path, 6, "Test-City", map[string]any{
+		"8.8.8.0/24": map[string]any{
+			"country":  map[string]any{"iso_code": "US"},
+			"location": map[string]any{"latitude": 37.751, "longitude": -97.822},
+			"flags":    []any{true, uint16(7), uint64(1) << 40},
+		},
+		"2001:db8::/32": map[string]any{"city": "Berlin"},
+	})
+	r, err := openMMDB(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Test-City", r.dbType)
+
+	rec, network, ok, err := r.lookup(netip.MustParseAddr("8.8.8.8"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "8.8.8.0/24", network.String())
+	m := rec.(map[string]any)
+	assert.Equal(t, "US", mmdbPath(m, "country.iso_code"))
+	assert.InDelta(t, 37.751, mmdbPath(m, "location.latitude"), 1e-9)
+	assert.Equal(t, []any{true, uint64(7), uint64(1) << 40}, m["flags"])
+
+	rec, network, ok, err = r.lookup(netip.MustParseAddr("2001:db8::1"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "2001:db8::/32", network.String())
+	assert.Equal(t, "Berlin", rec.(map[string]any)["city"])
+
+	_, _, ok, err = r.lookup(netip.MustParseAddr("1.1.1.1"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMMDBReader_LookupIPv4Tree(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	testutil.WriteMMDB(t, path, 4, "Test-ASN", map[string]any{
+		"192.0.2.0/24": map[string]any{"autonomous_system_number": 64500},
+	})
+	r, err := openMMDB(path)
+	require.NoError(t, err)
+
+	rec, _, ok, err := r.lookup(netip.MustParseAddr("192.0.2.10"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "AS64500", mmdbASN(rec.(map[string]any)))
+
+	_, _, ok, err = r.lookup(netip.MustParseAddr("2001:db8::1"))
+	require.NoError(t, err)
+	assert.False(t, ok, "IPv6 lookups in an IPv4 tree never match")
+}
+
+func TestOpenMMDB_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.mmdb")
+	require.NoError(t, os.WriteFile(path, []byte("not a database"), 0o600))
+	_, err := openMMDB(path)
+	require.Error(t, err)
+}
+
+func TestMMDBASN(t *testing.T) {
+	tests := []struct {
+		name string
+		rec  map[string]any
+		want string
+	}{
+		{"maxmind", map[string]any{"autonomous_system_number": uint64(15169)}, "AS15169"},
+		{"ipinfo", map[string]any{"asn": "AS13335"}, "AS13335"},
+		{"lowercase", map[string]any{"asn": "as13335"}, "AS13335"},
+		{"bare number", map[string]any{"asn": "13335"}, "AS13335"},
+		{"missing", map[string]any{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mmdbASN(tt.rec))
+		})
+	}
+}
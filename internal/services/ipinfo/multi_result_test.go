@@ -0,0 +1,56 @@
+package ipinfo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/ipinfo"
+)
+
+func TestMultiResult_IsEmpty(t *testing.T) {
+	t.Run("empty when no results", func(t *testing.T) {
+		assert.True(t, (&ipinfo.MultiResult{}).IsEmpty())
+	})
+
+	t.Run("empty when no database matched", func(t *testing.T) {
+		mr := &ipinfo.MultiResult{}
+		mr.Results = []*ipinfo.Result{{Input: "192.0.2.1"}, {Input: "198.51.100.1"}}
+		assert.True(t, mr.IsEmpty())
+	})
+
+	t.Run("not empty when one address matched", func(t *testing.T) {
+		mr := &ipinfo.MultiResult{}
+		mr.Results = []*ipinfo.Result{{Input: "192.0.2.1"}, {Input: "8.8.8.8", ASN: "AS15169", Sources: []string{"x.tsv"}}}
+		assert.False(t, mr.IsEmpty())
+	})
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	mr := &ipinfo.MultiResult{}
+	mr.Results = []*ipinfo.Result{
+		{Input: "8.8.8.8", ASN: "AS15169", Org: "GOOGLE", Country: "US", Sources: []string{"GeoLite2-ASN.mmdb"}},
+		{Input: "9.9.9.9", ASN: "AS19281", Network: "9.9.9.0/24", Sources: []string{"x.tsv"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "INPUT")
+	assert.Contains(t, out, "8.8.8.8")
+	assert.Contains(t, out, "GOOGLE")
+	assert.Contains(t, out, "9.9.9.0/24")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	mr := &ipinfo.MultiResult{}
+	mr.Results = []*ipinfo.Result{
+		{Input: "8.8.8.8", ASN: "AS15169", Org: "GOOGLE", Country: "US", City: "Mountain View"},
+		{Input: "9.9.9.9", ASN: "AS19281"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteText(&buf))
+	assert.Equal(t, "8.8.8.8 / AS15169 / GOOGLE / US / Mountain View\n9.9.9.9 / AS19281 /  /  / \n", buf.String())
+}
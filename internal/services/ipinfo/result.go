@@ -0,0 +1,73 @@
+package ipinfo
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Result holds offline IP intelligence for a single IP address.
+type Result struct {
+	Input     string   `json:"input"`
+	ASN       string   `json:"asn,omitempty"`
+	Org       string   `json:"org,omitempty"`
+	Network   string   `json:"network,omitempty"`
+	Country   string   `json:"country,omitempty"` // ISO 3166-1 alpha-2
+	City      string   `json:"city,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Sources   []string `json:"sources,omitempty"` // database files that matched
+}
+
+// IsEmpty reports whether no database matched the address.
+func (r *Result) IsEmpty() bool {
+	return len(r.Sources) == 0
+}
+
+// coordinates formats the location as "lat, lon", or "" when unknown.
+func (r *Result) coordinates() string {
+	if r.Latitude == nil || r.Longitude == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*r.Latitude, 'f', -1, 64) + ", " + strconv.FormatFloat(*r.Longitude, 'f', -1, 64)
+}
+
+// rows returns the non-empty fields as Field/Value pairs in display order.
+func (r *Result) rows() [][]string {
+	fields := [][]string{
+		{"ASN", r.ASN},
+		{"Org", r.Org},
+		{"Network", r.Network},
+		{"Country", r.Country},
+		{"City", r.City},
+		{"Coordinates", r.coordinates()},
+		{"Sources", strings.Join(r.Sources, "\n")},
+	}
+	var rows [][]string
+	for _, f := range fields {
+		if f[1] != "" {
+			rows = append(rows, f)
+		}
+	}
+	return rows
+}
+
+// WriteText renders the result as a single slash-delimited line.
+// Format: "Input / ASN / Org / Country / City"
+func (r *Result) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s / %s / %s / %s / %s\n", r.Input, r.ASN, r.Org, r.Country, r.City)
+	return err
+}
+
+// WriteTable renders the non-empty fields as a Field/Value table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 20)
+	table.Header([]string{"Field", "Value"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,68 @@
+package ipinfo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/ipinfo"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&ipinfo.Result{Input: "8.8.8.8"}).IsEmpty())
+	assert.False(t, (&ipinfo.Result{Input: "8.8.8.8", Country: "US", Sources: []string{"GeoLite2-Country.mmdb"}}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &ipinfo.Result{
+		Input:   "8.8.8.8",
+		ASN:     "AS15169",
+		Org:     "GOOGLE",
+		Country: "US",
+		City:    "Mountain View",
+		Sources: []string{"GeoLite2-ASN.mmdb", "GeoLite2-City.mmdb"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "8.8.8.8 / AS15169 / GOOGLE / US / Mountain View\n", buf.String())
+}
+
+func TestResult_WriteText_ASNOnly(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&ipinfo.Result{Input: "9.9.9.9", ASN: "AS19281", Sources: []string{"x.tsv"}}).WriteText(&buf))
+	assert.Equal(t, "9.9.9.9 / AS19281 /  /  / \n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	lat, lon := 37.386, -122.0838
+	result := &ipinfo.Result{
+		Input:     "8.8.8.8",
+		ASN:       "AS15169",
+		Org:       "GOOGLE",
+		Network:   "8.8.8.0/24",
+		Country:   "US",
+		City:      "Mountain View",
+		Latitude:  &lat,
+		Longitude: &lon,
+		Sources:   []string{"GeoLite2-ASN.mmdb", "GeoLite2-City.mmdb"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "AS15169")
+	assert.Contains(t, out, "8.8.8.0/24")
+	assert.Contains(t, out, "37.386, -122.0838")
+	assert.Contains(t, out, "GeoLite2-ASN.mmdb")
+	assert.Contains(t, out, "GeoLite2-City.mmdb")
+}
+
+func TestResult_WriteTable_OmitsEmptyFields(t *testing.T) {
+	lat := 1.5
+	var buf bytes.Buffer
+	require.NoError(t, (&ipinfo.Result{Input: "9.9.9.9", ASN: "AS19281", Latitude: &lat, Sources: []string{"x.tsv"}}).WriteTable(&buf))
+	out := buf.String()
+	assert.NotContains(t, out, "Coordinates", "a latitude without a longitude is not shown")
+	assert.NotContains(t, out, "City")
+}
@@ -0,0 +1,275 @@
+package ipinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/tbckr/trident/internal/appdir"
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// Name is the service identifier.
+	Name = "ipinfo"
+	// PAP is the PAP activity level for the ipinfo service (local files only).
+	PAP = pap.RED
+
+	// dataDirName is the config-dir subdirectory holding the databases.
+	dataDirName = "ipinfo"
+)
+
+// ErrNoDatabase is returned when the data directory holds no usable database.
+var ErrNoDatabase = errors.New("no ipinfo database found")
+
+// DefaultDataDir returns the directory scanned for *.mmdb and *.tsv databases.
+func DefaultDataDir() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, dataDirName), nil
+}
+
+// database is one loaded MMDB or TSV file.
+type database struct {
+	name string
+	mmdb *mmdbReader
+	tsv  *tsvTable
+}
+
+// Service looks up IP addresses in local databases.
+type Service struct {
+	dir    string
+	logger *slog.Logger
+
+	once    sync.Once
+	dbs     []database
+	loadErr error
+}
+
+// NewService creates a new ipinfo service reading databases from dir. Files are
+// loaded on first use: every *.mmdb file (GeoLite2 ASN/City, DB-IP lite,
+// IPinfo lite, ...) in name order, followed by every *.tsv iptoasn dump.
+func NewService(dir string, logger *slog.Logger) *Service {
+	return &Service{dir: dir, logger: logger}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the ipinfo service (offline lookups).
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple ipinfo results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Available reports whether at least one database could be loaded.
+func (s *Service) Available() bool {
+	return s.load() == nil
+}
+
+// HasASN reports whether a loaded database provides ASN data: any iptoasn
+// dump, or an MMDB file whose database type names ASN data (GeoLite2-ASN,
+// DB-IP ASN lite, IPinfo lite). City-only databases do not count.
+func (s *Service) HasASN() bool {
+	if s.load() != nil {
+		return false
+	}
+	for _, db := range s.dbs {
+		if db.tsv != nil || db.mmdb.hasASN() {
+			return true
+		}
+	}
+	return false
+}
+
+// Run looks up the IP address in every loaded database. Earlier databases take
+// precedence; later ones only fill fields that are still empty.
+func (s *Service) Run(_ context.Context, input string) (services.Result, error) {
+	input = output.StripANSI(strings.TrimSpace(input))
+	addr, err := netip.ParseAddr(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: must be a valid IP address: %q", services.ErrInvalidInput, input)
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Input: input}
+	for _, db := range s.dbs {
+		found, err := db.lookup(addr, result)
+		if err != nil {
+			s.logger.Debug("ipinfo: lookup failed", "database", db.name, "ip", input, "error", err)
+			continue
+		}
+		if found {
+			result.Sources = append(result.Sources, db.name)
+		}
+	}
+	return result, nil
+}
+
+// load opens every database in the data directory once.
+func (s *Service) load() error {
+	s.once.Do(func() {
+		entries, err := os.ReadDir(s.dir)
+		if err != nil && !os.IsNotExist(err) {
+			s.loadErr = fmt.Errorf("reading ipinfo data dir: %w", err)
+			return
+		}
+		var mmdbs, tsvs []database
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			path := filepath.Join(s.dir, e.Name())
+			switch strings.ToLower(filepath.Ext(e.Name())) {
+			case ".mmdb":
+				r, err := openMMDB(path)
+				if err != nil {
+					s.logger.Warn("ipinfo: skipping unreadable database", "path", path, "error", err)
+					continue
+				}
+				mmdbs = append(mmdbs, database{name: e.Name(), mmdb: r})
+			case ".tsv":
+				t, err := openTSV(path)
+				if err != nil {
+					s.logger.Warn("ipinfo: skipping unreadable database", "path", path, "error", err)
+					continue
+				}
+				tsvs = append(tsvs, database{name: e.Name(), tsv: t})
+			}
+		}
+		s.dbs = append(mmdbs, tsvs...)
+		if len(s.dbs) == 0 {
+			s.loadErr = fmt.Errorf("%w in %s: run \"trident download dbip\" or \"trident download iptoasn\", or copy *.mmdb / *.tsv files there", ErrNoDatabase, s.dir)
+		}
+	})
+	return s.loadErr
+}
+
+// lookup fills empty fields of result from the database and reports whether
+// the address was found.
+func (db database) lookup(addr netip.Addr, result *Result) (bool, error) {
+	if db.tsv != nil {
+		r, ok := db.tsv.lookup(addr)
+		if !ok {
+			return false, nil
+		}
+		fill(&result.ASN, r.asn)
+		fill(&result.Org, r.org)
+		fill(&result.Country, r.country)
+		fill(&result.Network, r.network())
+		return true, nil
+	}
+
+	rec, network, ok, err := db.mmdb.lookup(addr)
+	if err != nil || !ok {
+		return false, err
+	}
+	m, _ := rec.(map[string]any)
+	if len(m) == 0 {
+		return false, nil
+	}
+	fill(&result.ASN, mmdbASN(m))
+	fill(&result.Org, firstString(m, "autonomous_system_organization", "as_name", "organization", "isp"))
+	fill(&result.Country, mmdbCountry(m))
+	fill(&result.City, firstString(m, "city.names.en", "city"))
+	if result.Latitude == nil && result.Longitude == nil {
+		lat, latOK := firstFloat(m, "location.latitude", "latitude")
+		lon, lonOK := firstFloat(m, "location.longitude", "longitude")
+		if latOK && lonOK {
+			result.Latitude, result.Longitude = &lat, &lon
+		}
+	}
+	fill(&result.Network, network.String())
+	return true, nil
+}
+
+func fill(dst *string, value string) {
+	if *dst == "" {
+		*dst = output.StripANSI(value)
+	}
+}
+
+// mmdbPath returns the value at a dotted path such as "city.names.en".
+func mmdbPath(m map[string]any, path string) any {
+	var v any = m
+	for key := range strings.SplitSeq(path, ".") {
+		mm, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = mm[key]
+	}
+	return v
+}
+
+// firstString returns the first non-empty string found at paths.
+func firstString(m map[string]any, paths ...string) string {
+	for _, p := range paths {
+		if s, ok := mmdbPath(m, p).(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// firstFloat returns the first numeric value found at paths.
+func firstFloat(m map[string]any, paths ...string) (float64, bool) {
+	for _, p := range paths {
+		if f, ok := mmdbPath(m, p).(float64); ok {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// mmdbASN reads the ASN from GeoLite2/DB-IP ("autonomous_system_number") or
+// IPinfo ("asn": "AS15169") records.
+func mmdbASN(m map[string]any) string {
+	if n := asUint(m["autonomous_system_number"]); n != 0 {
+		return fmt.Sprintf("AS%d", n)
+	}
+	switch v := m["asn"].(type) {
+	case string:
+		if v == "" {
+			return ""
+		}
+		if !strings.HasPrefix(strings.ToUpper(v), "AS") {
+			return "AS" + v
+		}
+		return strings.ToUpper(v[:2]) + v[2:]
+	case uint64:
+		return fmt.Sprintf("AS%d", v)
+	}
+	return ""
+}
+
+// mmdbCountry reads the ISO country code from MaxMind-style nested records or
+// IPinfo's flat "country_code" field.
+func mmdbCountry(m map[string]any) string {
+	if c := firstString(m, "country.iso_code", "country_code", "registered_country.iso_code"); c != "" {
+		return c
+	}
+	// IPinfo "country" may hold the ISO code in older country/ASN files.
+	if c, ok := m["country"].(string); ok && len(c) == 2 {
+		return strings.ToUpper(c)
+	}
+	return ""
+}
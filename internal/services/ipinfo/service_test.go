@@ -0,0 +1,151 @@
+package ipinfo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/ipinfo"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+// writeDatabases populates dir with a GeoLite2-style ASN database, a City
+// database, and an iptoasn dump.
+func writeDatabases(t *testing.T, dir string) {
+	t.Helper()
+	testutil.WriteMMDB(t, filepath.Join(dir, "GeoLite2-ASN.mmdb"), 6, "GeoLite2-ASN", map[string]any{
+		"8.8.8.0/24": map[string]any{
+			"autonomous_system_number":       15169,
+			"autonomous_system_organization": "GOOGLE",
+		},
+	})
+	testutil.WriteMMDB(t, filepath.Join(dir, "GeoLite2-City.mmdb"), 6, "GeoLite2-City", map[string]any{
+		"8.8.0.0/16": map[string]any{
+			"city":     map[string]any{"names": map[string]any{"en": "Mountain View"}},
+			"country":  map[string]any{"iso_code": "US"},
+			"location": map[string]any{"latitude": 37.386, "longitude": -122.0838},
+		},
+	})
+	tsv := "8.8.8.0\t8.8.8.255\t15169\tUS\tGOOGLE - Google LLC\n" +
+		"9.9.9.0\t9.9.9.255\t19281\tUS\tQUAD9-AS-1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ip2asn-v4.tsv"), []byte(tsv), 0o600))
+}
+
+func TestService_Run_MergesDatabases(t *testing.T) {
+	dir := t.TempDir()
+	writeDatabases(t, dir)
+	svc := ipinfo.NewService(dir, testutil.NopLogger())
+	require.True(t, svc.Available())
+
+	raw, err := svc.Run(context.Background(), "8.8.8.8")
+	require.NoError(t, err)
+	result, ok := raw.(*ipinfo.Result)
+	require.True(t, ok, "expected *ipinfo.Result")
+
+	assert.Equal(t, "AS15169", result.ASN)
+	assert.Equal(t, "GOOGLE", result.Org, "MMDB files take precedence over TSV dumps")
+	assert.Equal(t, "8.8.8.0/24", result.Network)
+	assert.Equal(t, "US", result.Country)
+	assert.Equal(t, "Mountain View", result.City)
+	require.NotNil(t, result.Latitude)
+	require.NotNil(t, result.Longitude)
+	assert.InDelta(t, 37.386, *result.Latitude, 1e-9)
+	assert.InDelta(t, -122.0838, *result.Longitude, 1e-9)
+	assert.Equal(t, []string{"GeoLite2-ASN.mmdb", "GeoLite2-City.mmdb", "ip2asn-v4.tsv"}, result.Sources)
+}
+
+func TestService_Run_TSVOnly(t *testing.T) {
+	dir := t.TempDir()
+	writeDatabases(t, dir)
+	svc := ipinfo.NewService(dir, testutil.NopLogger())
+
+	raw, err := svc.Run(context.Background(), "9.9.9.9")
+	require.NoError(t, err)
+	result := raw.(*ipinfo.Result)
+	assert.Equal(t, "AS19281", result.ASN)
+	assert.Equal(t, "9.9.9.0/24", result.Network)
+	assert.Equal(t, []string{"ip2asn-v4.tsv"}, result.Sources)
+}
+
+func TestService_Run_NotFound(t *testing.T) {
+	dir := t.TempDir()
+	writeDatabases(t, dir)
+	svc := ipinfo.NewService(dir, testutil.NopLogger())
+
+	raw, err := svc.Run(context.Background(), "192.0.2.1")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestService_Run_InvalidInput(t *testing.T) {
+	svc := ipinfo.NewService(t.TempDir(), testutil.NopLogger())
+	for _, input := range []string{"", "example.com", "8.8.8.0/24", "AS15169"} {
+		_, err := svc.Run(context.Background(), input)
+		require.ErrorIs(t, err, services.ErrInvalidInput, input)
+	}
+}
+
+func TestService_Run_NoDatabase(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	svc := ipinfo.NewService(dir, testutil.NopLogger())
+	assert.False(t, svc.Available())
+
+	_, err := svc.Run(context.Background(), "8.8.8.8")
+	require.ErrorIs(t, err, ipinfo.ErrNoDatabase)
+}
+
+func TestService_SkipsUnreadableDatabase(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.mmdb"), []byte("garbage"), 0o600))
+	svc := ipinfo.NewService(dir, testutil.NopLogger())
+	assert.False(t, svc.Available())
+
+	writeDatabases(t, dir)
+	svc = ipinfo.NewService(dir, testutil.NopLogger())
+	assert.True(t, svc.Available())
+}
+
+func TestService_HasASN(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteMMDB(t, filepath.Join(dir, "dbip-city-lite.mmdb"), 6, "DBIP-City-Lite", map[string]any{
+		"8.8.0.0/16": map[string]any{"country": map[string]any{"iso_code": "US"}},
+	})
+	svc := ipinfo.NewService(dir, testutil.NopLogger())
+	assert.True(t, svc.Available())
+	assert.False(t, svc.HasASN(), "a City database carries no ASN data")
+
+	testutil.WriteMMDB(t, filepath.Join(dir, "dbip-asn-lite.mmdb"), 6, "DBIP-ASN-Lite", map[string]any{
+		"8.8.8.0/24": map[string]any{"autonomous_system_number": 15169},
+	})
+	svc = ipinfo.NewService(dir, testutil.NopLogger())
+	assert.True(t, svc.HasASN())
+
+	tsvDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tsvDir, "ip2asn-v4.tsv"), []byte("8.8.8.0\t8.8.8.255\t15169\tUS\tGOOGLE\n"), 0o600))
+	assert.True(t, ipinfo.NewService(tsvDir, testutil.NopLogger()).HasASN())
+
+	assert.False(t, ipinfo.NewService(t.TempDir(), testutil.NopLogger()).HasASN())
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := ipinfo.NewService(t.TempDir(), testutil.NopLogger())
+	assert.Equal(t, "ipinfo", svc.Name())
+	assert.Equal(t, pap.RED, svc.PAP())
+}
+
+func TestService_AggregateResults(t *testing.T) {
+	svc := ipinfo.NewService(t.TempDir(), testutil.NopLogger())
+	agg := svc.AggregateResults([]services.Result{
+		&ipinfo.Result{Input: "8.8.8.8"},
+		&ipinfo.Result{Input: "9.9.9.9"},
+	})
+	mr, ok := agg.(*ipinfo.MultiResult)
+	require.True(t, ok, "expected *ipinfo.MultiResult")
+	assert.Len(t, mr.Results, 2)
+}
@@ -0,0 +1,122 @@
+package ipinfo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+)
+
+// tsvRange is one row of an iptoasn.com dump:
+// "range_start<TAB>range_end<TAB>AS_number<TAB>country_code<TAB>AS_description".
+type tsvRange struct {
+	start, end netip.Addr
+	asn        string
+	country    string
+	org        string
+}
+
+// tsvTable holds iptoasn ranges sorted by start address.
+type tsvTable struct {
+	ranges []tsvRange
+}
+
+// openTSV reads an iptoasn TSV dump (ip2asn-v4.tsv, ip2asn-v6.tsv, or
+// ip2asn-combined.tsv).
+func openTSV(path string) (*tsvTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return parseTSV(f)
+}
+
+// parseTSV parses an iptoasn dump. Unrouted ranges (AS 0) are skipped.
+func parseTSV(r io.Reader) (*tsvTable, error) {
+	t := &tsvTable{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("line %d: want 5 tab-separated fields, got %d", line, len(fields))
+		}
+		start, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if fields[2] == "0" {
+			continue
+		}
+		t.ranges = append(t.ranges, tsvRange{
+			start:   start.Unmap(),
+			end:     end.Unmap(),
+			asn:     "AS" + fields[2],
+			country: fields[3],
+			org:     fields[4],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(t.ranges, func(a, b tsvRange) int { return a.start.Compare(b.start) })
+	return t, nil
+}
+
+// lookup returns the range containing addr.
+func (t *tsvTable) lookup(addr netip.Addr) (tsvRange, bool) {
+	addr = addr.Unmap()
+	// First range starting after addr; the candidate is the one before it.
+	i, _ := slices.BinarySearchFunc(t.ranges, addr, func(r tsvRange, a netip.Addr) int {
+		if r.start.Compare(a) <= 0 {
+			return -1
+		}
+		return 1
+	})
+	if i == 0 {
+		return tsvRange{}, false
+	}
+	r := t.ranges[i-1]
+	if r.start.BitLen() != addr.BitLen() || addr.Compare(r.end) > 0 {
+		return tsvRange{}, false
+	}
+	return r, true
+}
+
+// network formats the range as a CIDR when it is exactly one prefix, or as
+// "start - end" otherwise.
+func (r tsvRange) network() string {
+	for bits := 0; bits <= r.start.BitLen(); bits++ {
+		p, err := r.start.Prefix(bits)
+		if err != nil || p.Addr() != r.start {
+			continue
+		}
+		if lastAddr(p) == r.end {
+			return p.String()
+		}
+	}
+	return r.start.String() + " - " + r.end.String()
+}
+
+// lastAddr returns the highest address in p.
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - uint(i%8))
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
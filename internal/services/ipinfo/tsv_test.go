@@ -0,0 +1,53 @@
+package ipinfo
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleTSV = "1.0.0.0\t1.0.0.255\t13335\tUS\tCLOUDFLARENET\n" +
+	"1.0.1.0\t1.0.3.255\t0\tNone\tNot routed\n" +
+	"1.0.4.0\t1.0.6.255\t38803\tAU\tWPL-AS-AP Wirefreebroadband Pty Ltd\n" +
+	"2001:200::\t2001:200:ffff:ffff:ffff:ffff:ffff:ffff\t2500\tJP\tWIDE-BB WIDE Project\n"
+
+func TestParseTSV_Lookup(t *testing.T) {
+	table, err := parseTSV(strings.NewReader(sampleTSV))
+	require.NoError(t, err)
+	assert.Len(t, table.ranges, 3, "AS 0 rows are skipped")
+
+	tests := []struct {
+		addr    string
+		asn     string
+		network string
+	}{
+		{"1.0.0.1", "AS13335", "1.0.0.0/24"},
+		{"::ffff:1.0.0.1", "AS13335", "1.0.0.0/24"},
+		{"1.0.5.1", "AS38803", "1.0.4.0 - 1.0.6.255"},
+		{"2001:200::1", "AS2500", "2001:200::/32"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			r, ok := table.lookup(netip.MustParseAddr(tt.addr))
+			require.True(t, ok)
+			assert.Equal(t, tt.asn, r.asn)
+			assert.Equal(t, tt.network, r.network())
+		})
+	}
+
+	for _, addr := range []string{"1.0.2.1", "0.255.255.255", "9.9.9.9", "2001:201::1", "::1"} {
+		_, ok := table.lookup(netip.MustParseAddr(addr))
+		assert.False(t, ok, addr)
+	}
+}
+
+func TestParseTSV_Invalid(t *testing.T) {
+	_, err := parseTSV(strings.NewReader("1.0.0.0\t1.0.0.255\t13335\n"))
+	require.Error(t, err)
+
+	_, err = parseTSV(strings.NewReader("bogus\t1.0.0.255\t13335\tUS\tX\n"))
+	require.Error(t, err)
+}
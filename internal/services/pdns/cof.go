@@ -0,0 +1,149 @@
+package pdns
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// CIRCLURL is the query endpoint of CIRCL's passive DNS, which requires
+	// an account (free for the security community).
+	CIRCLURL = "https://www.circl.lu/pdns/query"
+
+	// COFRPS is the target request rate for each COF endpoint.
+	COFRPS float64 = 1.0
+	// COFBurst is the burst capacity above COFRPS.
+	COFBurst = 2
+)
+
+// cofRecord is one line of a passive DNS Common Output Format response
+// (draft-dulaunoy-dnsop-passive-dns-cof).
+type cofRecord struct {
+	RRName    string `json:"rrname"`
+	RRType    string `json:"rrtype"`
+	RData     any    `json:"rdata"` // string, or an array of strings for some servers
+	TimeFirst int64  `json:"time_first"`
+	TimeLast  int64  `json:"time_last"`
+	Count     int    `json:"count"`
+}
+
+// cofSource queries a COF-speaking passive DNS server such as CIRCL's.
+type cofSource struct {
+	client   *req.Client
+	name     string
+	endpoint string
+	username string
+	password string
+}
+
+// NewCOFSource returns a Source that queries endpoint + "/" + query and parses
+// a newline-delimited COF response. Basic authentication is used when
+// username is non-empty.
+func NewCOFSource(client *req.Client, name, endpoint, username, password string) Source {
+	return &cofSource{
+		client:   client,
+		name:     name,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		username: username,
+		password: password,
+	}
+}
+
+// Name returns the configured source name.
+func (s *cofSource) Name() string { return s.name }
+
+// PAP returns the PAP activity level of a third-party COF server.
+func (s *cofSource) PAP() pap.Level { return pap.AMBER }
+
+// Lookup returns the observations the COF server holds for query.
+func (s *cofSource) Lookup(ctx context.Context, query string) ([]Observation, error) {
+	r := s.client.R().SetContext(ctx).SetHeader("Accept", "application/x-ndjson")
+	if s.username != "" {
+		r = r.SetBasicAuth(s.username, s.password)
+	}
+	resp, err := r.Get(s.endpoint + "/" + url.PathEscape(query))
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %s", services.ErrRequestFailed, err)
+	}
+	if resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.Response == nil || !resp.IsSuccessState() {
+		return nil, fmt.Errorf("%w: HTTP %d: %q", services.ErrRequestFailed, resp.StatusCode, truncate(resp.String()))
+	}
+	return parseCOF(resp.String())
+}
+
+// parseCOF parses newline-delimited COF records; a JSON array of records is
+// accepted too. Records with an array rdata yield one observation per value.
+func parseCOF(body string) ([]Observation, error) {
+	var records []cofRecord
+	if trimmed := strings.TrimSpace(body); strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &records); err != nil {
+			return nil, fmt.Errorf("%w: decoding COF array: %w", services.ErrRequestFailed, err)
+		}
+	} else {
+		scanner := bufio.NewScanner(strings.NewReader(body))
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			var rec cofRecord
+			if err := json.Unmarshal([]byte(line), &rec); err != nil {
+				return nil, fmt.Errorf("%w: decoding COF line: %w", services.ErrRequestFailed, err)
+			}
+			records = append(records, rec)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("%w: reading COF response: %w", services.ErrRequestFailed, err)
+		}
+	}
+
+	var obs []Observation
+	for _, rec := range records {
+		for _, rdata := range rdataValues(rec.RData) {
+			obs = append(obs, Observation{
+				RRName:    rec.RRName,
+				RRType:    rec.RRType,
+				RData:     rdata,
+				FirstSeen: unixTime(rec.TimeFirst),
+				LastSeen:  unixTime(rec.TimeLast),
+				Count:     rec.Count,
+			})
+		}
+	}
+	return obs, nil
+}
+
+// rdataValues flattens a COF rdata field, which is a string or a list.
+func rdataValues(v any) []string {
+	switch rd := v.(type) {
+	case string:
+		return []string{rd}
+	case []any:
+		out := make([]string, 0, len(rd))
+		for _, item := range rd {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
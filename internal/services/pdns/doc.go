@@ -0,0 +1,9 @@
+// Package pdns provides a service that merges passive DNS observations from
+// several keyless sources: Mnemonic's public PDNS API, ThreatMiner, and any
+// number of Common Output Format (COF) endpoints such as CIRCL's.
+//
+// Every source is queried concurrently through its own HTTP client so each
+// can carry its own rate limiter. Observations of the same (rrname, rrtype,
+// rdata) tuple are merged: the observation window is widened, counts are
+// summed, and the contributing sources are listed.
+package pdns
@@ -0,0 +1,102 @@
+package pdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	mnemonicBaseURL = "https://api.mnemonic.no/pdns/v3/"
+	// mnemonicLimit is the page size requested from Mnemonic; only the first
+	// page is fetched.
+	mnemonicLimit = 1000
+
+	// MnemonicName is the source identifier of Mnemonic's public PDNS API.
+	MnemonicName = "mnemonic"
+	// MnemonicRPS is the target request rate for the Mnemonic source.
+	MnemonicRPS float64 = 1.0
+	// MnemonicBurst is the burst capacity above MnemonicRPS.
+	MnemonicBurst = 1
+)
+
+// mnemonicResponse is the envelope of a Mnemonic PDNS v3 lookup.
+type mnemonicResponse struct {
+	ResponseCode int `json:"responseCode"`
+	Data         []struct {
+		Query     string `json:"query"`
+		Answer    string `json:"answer"`
+		RRType    string `json:"rrtype"`
+		FirstSeen int64  `json:"firstSeenTimestamp"` // Unix milliseconds
+		LastSeen  int64  `json:"lastSeenTimestamp"`  // Unix milliseconds
+		Times     int    `json:"times"`
+	} `json:"data"`
+}
+
+// mnemonicSource queries Mnemonic's public passive DNS API.
+type mnemonicSource struct {
+	client  *req.Client
+	baseURL string
+}
+
+// NewMnemonicSource returns a Source backed by Mnemonic's public PDNS API,
+// which needs no API key for low-volume use.
+func NewMnemonicSource(client *req.Client) Source {
+	return &mnemonicSource{client: client, baseURL: mnemonicBaseURL}
+}
+
+// Name returns the source identifier.
+func (s *mnemonicSource) Name() string { return MnemonicName }
+
+// PAP returns the PAP activity level of the Mnemonic API.
+func (s *mnemonicSource) PAP() pap.Level { return pap.AMBER }
+
+// Lookup returns the observations Mnemonic holds for query.
+func (s *mnemonicSource) Lookup(ctx context.Context, query string) ([]Observation, error) {
+	var body mnemonicResponse
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetQueryParam("limit", fmt.Sprint(mnemonicLimit)).
+		SetSuccessResult(&body).
+		Get(s.baseURL + url.PathEscape(query))
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %s", services.ErrRequestFailed, err)
+	}
+	if resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.Response == nil || !resp.IsSuccessState() {
+		return nil, fmt.Errorf("%w: HTTP %d: %q", services.ErrRequestFailed, resp.StatusCode, truncate(resp.String()))
+	}
+
+	obs := make([]Observation, 0, len(body.Data))
+	for _, d := range body.Data {
+		obs = append(obs, Observation{
+			RRName:    d.Query,
+			RRType:    d.RRType,
+			RData:     d.Answer,
+			FirstSeen: unixTime(d.FirstSeen / 1000),
+			LastSeen:  unixTime(d.LastSeen / 1000),
+			Count:     d.Times,
+		})
+	}
+	return obs, nil
+}
+
+// truncate shortens an error body for inclusion in an error message.
+func truncate(body string) string {
+	if len(body) > 200 {
+		return body[:200] + "..."
+	}
+	return body
+}
@@ -0,0 +1,43 @@
+package pdns
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds pdns results for multiple inputs.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteText overrides the base: prefixes each record with the originating input.
+func (m *MultiResult) WriteText(w io.Writer) error {
+	for _, r := range m.Results {
+		for _, rec := range r.Records {
+			if _, err := fmt.Fprintf(w, "%s %s %s %s\n", r.Input, rec.RRName, rec.RRType, rec.RData); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteTable renders all results in a single combined table grouped by input.
+// Columns: Input / Name / Type / Data / First Seen / Last Seen / Count / Sources.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows [][]string
+	for _, r := range m.Results {
+		for _, row := range r.rows() {
+			rows = append(rows, append([]string{r.Input}, row...))
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 20, 40)
+	table.Header([]string{"Input", "Name", "Type", "Data", "First Seen", "Last Seen", "Count", "Sources"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,75 @@
+package pdns
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Record is a passive DNS tuple merged across sources.
+type Record struct {
+	RRName    string   `json:"rrname"`
+	RRType    string   `json:"rrtype"`
+	RData     string   `json:"rdata"`
+	FirstSeen string   `json:"first_seen,omitempty"` // RFC 3339
+	LastSeen  string   `json:"last_seen,omitempty"`  // RFC 3339
+	Count     int      `json:"count,omitempty"`      // summed over sources; 0 when unknown
+	Sources   []string `json:"sources"`
+}
+
+// Result holds the merged passive DNS records for one domain or IP.
+type Result struct {
+	Input   string   `json:"input"`
+	Records []Record `json:"records,omitempty"`
+	Sources []string `json:"sources,omitempty"` // sources that answered
+}
+
+// IsEmpty reports whether no source returned a record.
+func (r *Result) IsEmpty() bool {
+	return len(r.Records) == 0
+}
+
+// rows returns the Name / Type / Data / First Seen / Last Seen / Count /
+// Sources cells.
+func (r *Result) rows() [][]string {
+	rows := make([][]string, 0, len(r.Records))
+	for _, rec := range r.Records {
+		count := ""
+		if rec.Count > 0 {
+			count = strconv.Itoa(rec.Count)
+		}
+		rows = append(rows, []string{
+			rec.RRName,
+			rec.RRType,
+			rec.RData,
+			rec.FirstSeen,
+			rec.LastSeen,
+			count,
+			strings.Join(rec.Sources, ", "),
+		})
+	}
+	return rows
+}
+
+// WriteText renders one "<rrname> <rrtype> <rdata>" line per record.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, rec := range r.Records {
+		if _, err := fmt.Fprintf(w, "%s %s %s\n", rec.RRName, rec.RRType, rec.RData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the records as a table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 30)
+	table.Header([]string{"Name", "Type", "Data", "First Seen", "Last Seen", "Count", "Sources"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,89 @@
+package pdns_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/pdns"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&pdns.Result{Input: "example.com", Sources: []string{"circl"}}).IsEmpty())
+	assert.False(t, (&pdns.Result{Input: "example.com", Records: []pdns.Record{{RRName: "example.com", RRType: "A", RData: "192.0.2.1"}}}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &pdns.Result{
+		Input: "example.com",
+		Records: []pdns.Record{
+			{RRName: "example.com", RRType: "A", RData: "192.0.2.1"},
+			{RRName: "example.com", RRType: "A", RData: "192.0.2.2"},
+			{RRName: "example.com", RRType: "NS", RData: "ns1.example.net"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "example.com A 192.0.2.1\nexample.com A 192.0.2.2\nexample.com NS ns1.example.net\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &pdns.Result{
+		Input: "example.com",
+		Records: []pdns.Record{
+			{RRName: "example.com", RRType: "A", RData: "192.0.2.1", LastSeen: "2026-03-09T00:00:00Z", Count: 7, Sources: []string{"circl", "mnemonic"}},
+		},
+		Sources: []string{"circl", "mnemonic"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "NAME")
+	assert.Contains(t, out, "192.0.2.1")
+	assert.Contains(t, out, "2026-03-09")
+	assert.Contains(t, out, "7")
+	assert.Contains(t, out, "circl, mnemonic")
+}
+
+func TestResult_WriteTable_UnknownCount(t *testing.T) {
+	result := &pdns.Result{
+		Input:   "example.com",
+		Records: []pdns.Record{{RRName: "example.com", RRType: "NS", RData: "ns1.example.net", Sources: []string{"mnemonic"}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "ns1.example.net")
+	assert.NotContains(t, out, " 0 ", "a zero count is left blank")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	m := &pdns.MultiResult{}
+	m.Results = []*pdns.Result{
+		{Input: "example.com", Records: []pdns.Record{{RRName: "www.example.com", RRType: "CNAME", RData: "example.com"}}},
+		{Input: "example.net"},
+		{Input: "192.0.2.1", Records: []pdns.Record{{RRName: "example.org", RRType: "A", RData: "192.0.2.1"}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteText(&buf))
+	assert.Equal(t, "example.com www.example.com CNAME example.com\n192.0.2.1 example.org A 192.0.2.1\n", buf.String())
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	m := &pdns.MultiResult{}
+	m.Results = []*pdns.Result{
+		{Input: "example.com", Records: []pdns.Record{
+			{RRName: "example.com", RRType: "A", RData: "192.0.2.1", Sources: []string{"circl"}},
+			{RRName: "example.com", RRType: "NS", RData: "ns1.example.net", Sources: []string{"mnemonic"}},
+		}},
+		{Input: "example.org"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "INPUT")
+	assert.Contains(t, out, "ns1.example.net")
+	assert.NotContains(t, out, "example.org", "inputs without records have no rows")
+}
@@ -0,0 +1,227 @@
+package pdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// Name is the service identifier.
+	Name = "pdns"
+	// PAP is the PAP activity level of the pdns service: every source is a
+	// third-party API.
+	PAP = pap.AMBER
+)
+
+// Service merges passive DNS observations from several sources.
+type Service struct {
+	logger  *slog.Logger
+	sources []Source
+}
+
+// NewService creates a new pdns service querying every source in sources.
+func NewService(logger *slog.Logger, sources []Source) *Service {
+	return &Service{logger: logger, sources: sources}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the pdns service.
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple pdns results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run queries every source for the domain or IP input concurrently and merges
+// identical (rrname, rrtype, rdata) tuples. Source failures are logged; an
+// error is returned only when every source failed. Partial results are
+// returned when ctx is cancelled.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	input = output.StripANSI(strings.TrimSpace(input))
+	query, err := normalizeQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.sources) == 0 {
+		return nil, fmt.Errorf("%w: no passive DNS source available", services.ErrRequestFailed)
+	}
+
+	result := &Result{Input: input}
+	merged := map[recordKey]*recordAgg{}
+	var errs []error
+	for _, l := range s.lookupAll(ctx, query) {
+		if l.err != nil {
+			s.logger.Debug("pdns: source lookup failed", "source", l.source, "query", query, "error", l.err)
+			errs = append(errs, fmt.Errorf("%s: %w", l.source, l.err))
+			continue
+		}
+		result.Sources = append(result.Sources, l.source)
+		for _, o := range l.obs {
+			key, ok := normalizeKey(o)
+			if !ok {
+				continue
+			}
+			agg := merged[key]
+			if agg == nil {
+				agg = &recordAgg{sources: map[string]bool{}}
+				merged[key] = agg
+			}
+			agg.add(l.source, o)
+		}
+	}
+	if len(result.Sources) == 0 && len(errs) > 0 && ctx.Err() == nil {
+		return nil, errors.Join(errs...)
+	}
+
+	for key, agg := range merged {
+		result.Records = append(result.Records, agg.record(key))
+	}
+	slices.SortFunc(result.Records, compareRecords)
+	return result, nil
+}
+
+// lookup is the outcome of one source query.
+type lookup struct {
+	source string
+	obs    []Observation
+	err    error
+}
+
+// lookupAll queries every source concurrently; each source is throttled by
+// the rate limiter of its own HTTP client.
+func (s *Service) lookupAll(ctx context.Context, query string) []lookup {
+	out := make([]lookup, len(s.sources))
+	var wg sync.WaitGroup
+	for i, src := range s.sources {
+		wg.Go(func() {
+			obs, err := src.Lookup(ctx, query)
+			out[i] = lookup{source: src.Name(), obs: obs, err: err}
+		})
+	}
+	wg.Wait()
+	return out
+}
+
+// normalizeQuery validates a domain or IP input and returns its canonical
+// form: lowercase without a trailing dot, or the unmapped address.
+func normalizeQuery(input string) (string, error) {
+	if addr, err := netip.ParseAddr(input); err == nil {
+		return addr.Unmap().String(), nil
+	}
+	name := strings.ToLower(strings.TrimSuffix(input, "."))
+	if !services.IsDomain(name) {
+		return "", fmt.Errorf("%w: must be a domain or IP address: %q", services.ErrInvalidInput, input)
+	}
+	return name, nil
+}
+
+// recordKey identifies a passive DNS tuple across sources.
+type recordKey struct {
+	rrname, rrtype, rdata string
+}
+
+// normalizeKey canonicalises an observation so identical tuples from
+// different sources compare equal: names are lowercased without the trailing
+// dot, types are uppercased, and address rdata is normalised. TXT rdata is
+// kept verbatim.
+func normalizeKey(o Observation) (recordKey, bool) {
+	rrname := canonicalName(o.RRName)
+	rrtype := strings.ToUpper(strings.TrimSpace(output.StripANSI(o.RRType)))
+	rdata := strings.TrimSpace(output.StripANSI(o.RData))
+	switch rrtype {
+	case "A", "AAAA":
+		addr, err := netip.ParseAddr(rdata)
+		if err != nil {
+			return recordKey{}, false
+		}
+		rdata = addr.Unmap().String()
+	case "TXT":
+	default:
+		rdata = canonicalName(rdata)
+	}
+	if rrname == "" || rrtype == "" || rdata == "" {
+		return recordKey{}, false
+	}
+	return recordKey{rrname: rrname, rrtype: rrtype, rdata: rdata}, true
+}
+
+func canonicalName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(output.StripANSI(name)), "."))
+}
+
+// recordAgg accumulates the observations of one tuple.
+type recordAgg struct {
+	first, last time.Time
+	count       int
+	sources     map[string]bool
+}
+
+// add widens the observation window, sums the count, and records the source.
+func (a *recordAgg) add(source string, o Observation) {
+	if !o.FirstSeen.IsZero() && (a.first.IsZero() || o.FirstSeen.Before(a.first)) {
+		a.first = o.FirstSeen
+	}
+	if !o.LastSeen.IsZero() && (a.last.IsZero() || o.LastSeen.After(a.last)) {
+		a.last = o.LastSeen
+	}
+	a.count += o.Count
+	a.sources[source] = true
+}
+
+func (a *recordAgg) record(key recordKey) Record {
+	sources := make([]string, 0, len(a.sources))
+	for src := range a.sources {
+		sources = append(sources, src)
+	}
+	slices.Sort(sources)
+	return Record{
+		RRName:    key.rrname,
+		RRType:    key.rrtype,
+		RData:     key.rdata,
+		FirstSeen: formatTime(a.first),
+		LastSeen:  formatTime(a.last),
+		Count:     a.count,
+		Sources:   sources,
+	}
+}
+
+// compareRecords orders records by most recent last-seen first, then by
+// rrname, rrtype, and rdata. RFC 3339 strings in UTC sort chronologically.
+func compareRecords(a, b Record) int {
+	if c := strings.Compare(b.LastSeen, a.LastSeen); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.RRName, b.RRName); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.RRType, b.RRType); c != 0 {
+		return c
+	}
+	return strings.Compare(a.RData, b.RData)
+}
+
+// formatTime renders t as RFC 3339, or "" when unknown.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,209 @@
+package pdns_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/pdns"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return data
+}
+
+// fakeSource returns canned observations regardless of the query.
+type fakeSource struct {
+	name string
+	obs  []pdns.Observation
+	err  error
+}
+
+func (f *fakeSource) Name() string   { return f.name }
+func (f *fakeSource) PAP() pap.Level { return pap.AMBER }
+
+func (f *fakeSource) Lookup(_ context.Context, _ string) ([]pdns.Observation, error) {
+	return f.obs, f.err
+}
+
+func day(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+
+func TestRun_MergesIdenticalTuples(t *testing.T) {
+	a := &fakeSource{name: "a", obs: []pdns.Observation{
+		{RRName: "Example.com.", RRType: "a", RData: "192.0.2.1", FirstSeen: day(5), LastSeen: day(9), Count: 3},
+		{RRName: "example.com", RRType: "NS", RData: "NS1.Example.net.", LastSeen: day(1)},
+	}}
+	b := &fakeSource{name: "b", obs: []pdns.Observation{
+		{RRName: "example.com", RRType: "A", RData: "192.0.2.1", FirstSeen: day(2), LastSeen: day(7), Count: 4},
+		{RRName: "example.com", RRType: "A", RData: "not-an-ip"},
+	}}
+
+	svc := pdns.NewService(testutil.NopLogger(), []pdns.Source{a, b})
+	raw, err := svc.Run(context.Background(), "Example.com")
+	require.NoError(t, err)
+	result := raw.(*pdns.Result)
+
+	assert.Equal(t, []string{"a", "b"}, result.Sources)
+	require.Len(t, result.Records, 2)
+	assert.Equal(t, pdns.Record{
+		RRName:    "example.com",
+		RRType:    "A",
+		RData:     "192.0.2.1",
+		FirstSeen: "2026-03-02T00:00:00Z",
+		LastSeen:  "2026-03-09T00:00:00Z",
+		Count:     7,
+		Sources:   []string{"a", "b"},
+	}, result.Records[0])
+	assert.Equal(t, "ns1.example.net", result.Records[1].RData)
+	assert.Equal(t, []string{"a"}, result.Records[1].Sources)
+}
+
+func TestRun_PartialFailure(t *testing.T) {
+	ok := &fakeSource{name: "ok", obs: []pdns.Observation{{RRName: "example.com", RRType: "A", RData: "192.0.2.1"}}}
+	bad := &fakeSource{name: "bad", err: services.ErrRequestFailed}
+
+	svc := pdns.NewService(testutil.NopLogger(), []pdns.Source{ok, bad})
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Len(t, raw.(*pdns.Result).Records, 1)
+	assert.Equal(t, []string{"ok"}, raw.(*pdns.Result).Sources)
+}
+
+func TestRun_AllSourcesFail(t *testing.T) {
+	a := &fakeSource{name: "a", err: errors.New("boom")}
+	b := &fakeSource{name: "b", err: services.ErrRequestFailed}
+
+	svc := pdns.NewService(testutil.NopLogger(), []pdns.Source{a, b})
+	_, err := svc.Run(context.Background(), "example.com")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, services.ErrRequestFailed)
+	assert.Contains(t, err.Error(), "a: boom")
+}
+
+func TestRun_NoSources(t *testing.T) {
+	svc := pdns.NewService(testutil.NopLogger(), nil)
+	_, err := svc.Run(context.Background(), "example.com")
+	assert.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	svc := pdns.NewService(testutil.NopLogger(), []pdns.Source{&fakeSource{name: "a"}})
+	for _, bad := range []string{"", "not valid!", "192.0.2.0/24"} {
+		_, err := svc.Run(context.Background(), bad)
+		assert.ErrorIs(t, err, services.ErrInvalidInput, "input %q", bad)
+	}
+}
+
+func TestMnemonicSource(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet,
+		"https://api.mnemonic.no/pdns/v3/example.com?limit=1000",
+		httpmock.NewBytesResponder(http.StatusOK, mustReadFile(t, "testdata/mnemonic_example.json")),
+	)
+
+	obs, err := pdns.NewMnemonicSource(client).Lookup(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.Len(t, obs, 2)
+	assert.Equal(t, pdns.Observation{
+		RRName:    "example.com",
+		RRType:    "a",
+		RData:     "93.184.216.34",
+		FirstSeen: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LastSeen:  time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Count:     12,
+	}, obs[0])
+}
+
+func TestMnemonicSource_HTTPFailure(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet,
+		"https://api.mnemonic.no/pdns/v3/example.com?limit=1000",
+		httpmock.NewStringResponder(http.StatusTooManyRequests, `{"responseCode":429}`),
+	)
+
+	_, err := pdns.NewMnemonicSource(client).Lookup(context.Background(), "example.com")
+	assert.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestCOFSource(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://pdns.example.org/query/example.com",
+		func(r *http.Request) (*http.Response, error) {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "analyst" || pass != "s3cret" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, "unauthorized"), nil
+			}
+			return httpmock.NewBytesResponse(http.StatusOK, mustReadFile(t, "testdata/cof_example.ndjson")), nil
+		},
+	)
+
+	src := pdns.NewCOFSource(client, "circl", "https://pdns.example.org/query/", "analyst", "s3cret")
+	assert.Equal(t, "circl", src.Name())
+	obs, err := src.Lookup(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.Len(t, obs, 3, "array rdata yields one observation per value")
+	assert.Equal(t, "example.com.", obs[0].RRName)
+	assert.Equal(t, 5, obs[0].Count)
+	assert.Equal(t, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), obs[0].FirstSeen)
+	assert.Equal(t, "20 mx2.example.com.", obs[2].RData)
+
+	wrong := pdns.NewCOFSource(client, "circl", "https://pdns.example.org/query", "analyst", "wrong")
+	_, err = wrong.Lookup(context.Background(), "example.com")
+	assert.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestThreatMinerSource(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet,
+		"https://api.threatminer.org/v2/host.php?q=192.0.2.1&rt=2",
+		httpmock.NewStringResponder(http.StatusOK, `{"status_code":"200","results":[{"domain":"example.com","first_seen":"2021-01-01 00:00:00","last_seen":"2024-01-01 12:00:00"}]}`),
+	)
+
+	obs, err := pdns.NewThreatMinerSource(client, testutil.NopLogger()).Lookup(context.Background(), "192.0.2.1")
+	require.NoError(t, err)
+	require.Len(t, obs, 1)
+	assert.Equal(t, pdns.Observation{
+		RRName:    "example.com",
+		RRType:    "A",
+		RData:     "192.0.2.1",
+		FirstSeen: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LastSeen:  time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}, obs[0])
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "only the pdns section is queried")
+}
+
+func TestService_AggregateResults(t *testing.T) {
+	svc := pdns.NewService(testutil.NopLogger(), nil)
+	agg := svc.AggregateResults([]services.Result{&pdns.Result{Input: "a.com"}, &pdns.Result{Input: "b.com"}})
+	mr, ok := agg.(*pdns.MultiResult)
+	require.True(t, ok)
+	assert.Len(t, mr.Results, 2)
+}
+
+func TestService_PAP(t *testing.T) {
+	svc := pdns.NewService(testutil.NopLogger(), nil)
+	assert.Equal(t, pap.AMBER, svc.PAP())
+	assert.Equal(t, "pdns", svc.Name())
+}
@@ -0,0 +1,34 @@
+package pdns
+
+import (
+	"context"
+	"time"
+
+	"github.com/tbckr/trident/internal/pap"
+)
+
+// Observation is one passive-DNS record as reported by a single source.
+type Observation struct {
+	RRName    string
+	RRType    string
+	RData     string
+	FirstSeen time.Time // zero when unknown
+	LastSeen  time.Time // zero when unknown
+	Count     int       // times seen; 0 when unknown
+}
+
+// Source is a passive-DNS backend. The query is a domain name or an IP
+// address; implementations return (nil, nil) when they have no data.
+type Source interface {
+	Name() string
+	PAP() pap.Level
+	Lookup(ctx context.Context, query string) ([]Observation, error)
+}
+
+// unixTime converts Unix seconds to UTC, returning the zero time for 0.
+func unixTime(sec int64) time.Time {
+	if sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
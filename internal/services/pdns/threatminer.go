@@ -0,0 +1,88 @@
+package pdns
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/threatminer"
+)
+
+// threatMinerTimeLayout is the timestamp format of ThreatMiner passive DNS.
+const threatMinerTimeLayout = "2006-01-02 15:04:05"
+
+// threatMinerSource adapts ThreatMiner's domain and host passive DNS.
+type threatMinerSource struct {
+	svc *threatminer.Service
+}
+
+// NewThreatMinerSource returns a Source backed by ThreatMiner passive DNS.
+// The client should carry the ThreatMiner rate limiter.
+func NewThreatMinerSource(client *req.Client, logger *slog.Logger) Source {
+	svc := threatminer.NewService(client, logger)
+	svc.EnableSections([]threatminer.Section{threatminer.SectionPDNS})
+	return threatMinerSource{svc: svc}
+}
+
+// Name returns the source identifier.
+func (s threatMinerSource) Name() string { return threatminer.Name }
+
+// PAP returns the PAP activity level of the ThreatMiner API.
+func (s threatMinerSource) PAP() pap.Level { return threatminer.PAP }
+
+// Lookup returns ThreatMiner's passive DNS for a domain or IP. ThreatMiner
+// does not report record types, so A or AAAA is derived from the address.
+func (s threatMinerSource) Lookup(ctx context.Context, query string) ([]Observation, error) {
+	raw, err := s.svc.Run(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := raw.(*threatminer.Result)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected ThreatMiner result %T", services.ErrRequestFailed, raw)
+	}
+	_, err = netip.ParseAddr(query)
+	isIP := err == nil
+	obs := make([]Observation, 0, len(res.PassiveDNS))
+	for _, e := range res.PassiveDNS {
+		name, ip := e.Domain, e.IP
+		switch {
+		case isIP && ip == "":
+			ip = query
+		case !isIP && name == "":
+			name = query
+		}
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			continue
+		}
+		rrtype := "A"
+		if addr.Unmap().Is6() {
+			rrtype = "AAAA"
+		}
+		obs = append(obs, Observation{
+			RRName:    name,
+			RRType:    rrtype,
+			RData:     addr.Unmap().String(),
+			FirstSeen: parseTime(e.FirstSeen),
+			LastSeen:  parseTime(e.LastSeen),
+		})
+	}
+	return obs, nil
+}
+
+// parseTime parses a ThreatMiner timestamp as UTC, returning the zero time
+// on failure.
+func parseTime(v string) time.Time {
+	t, err := time.Parse(threatMinerTimeLayout, v)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
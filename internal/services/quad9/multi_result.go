@@ -13,18 +13,14 @@ type MultiResult struct {
 }
 
 // WriteTable renders all verdicts in a single combined table.
-// Columns: Domain / Blocked.
+// Columns: Domain / Blocked / Reason.
 func (m *MultiResult) WriteTable(w io.Writer) error {
 	var rows [][]string
 	for _, r := range m.Results {
-		blocked := "false"
-		if r.Blocked {
-			blocked = "true"
-		}
-		rows = append(rows, []string{r.Input, blocked})
+		rows = append(rows, r.row())
 	}
-	table := output.NewWrappingTable(w, 30, 6)
-	table.Header([]string{"Domain", "Blocked"})
+	table := output.NewWrappingTable(w, 30, 10)
+	table.Header([]string{"Domain", "Blocked", "Reason"})
 	if err := table.Bulk(rows); err != nil {
 		return err
 	}
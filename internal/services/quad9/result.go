@@ -4,13 +4,18 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/tbckr/trident/internal/doh"
 	"github.com/tbckr/trident/internal/output"
 )
 
 // Result holds the Quad9 threat-intelligence verdict for a single domain.
 type Result struct {
-	Input   string `json:"input"`
-	Blocked bool   `json:"blocked"`
+	Input   string     `json:"input"`
+	Blocked bool       `json:"blocked"`
+	Reason  string     `json:"reason,omitempty"` // block signal: an EDE or "NXDOMAIN without SOA"
+	Rcode   string     `json:"rcode,omitempty"`
+	Flags   *doh.Flags `json:"flags,omitempty"`
+	EDE     []doh.EDE  `json:"ede,omitempty"` // Extended DNS Errors returned by Quad9
 }
 
 // IsEmpty reports whether the result is unpopulated (no input was set).
@@ -28,15 +33,20 @@ func (r *Result) WriteText(w io.Writer) error {
 	return err
 }
 
-// WriteTable renders the result as an ASCII table with Domain and Blocked columns.
-func (r *Result) WriteTable(w io.Writer) error {
+// row returns the Domain / Blocked / Reason cells.
+func (r *Result) row() []string {
 	blocked := "false"
 	if r.Blocked {
 		blocked = "true"
 	}
-	table := output.NewWrappingTable(w, 30, 6)
-	table.Header([]string{"Domain", "Blocked"})
-	if err := table.Bulk([][]string{{r.Input, blocked}}); err != nil {
+	return []string{r.Input, blocked, r.Reason}
+}
+
+// WriteTable renders the result as an ASCII table with Domain, Blocked, and Reason columns.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 30, 10)
+	table.Header([]string{"Domain", "Blocked", "Reason"})
+	if err := table.Bulk([][]string{r.row()}); err != nil {
 		return err
 	}
 	return table.Render()
@@ -38,7 +38,7 @@ func TestResult_WriteText_NotBlocked(t *testing.T) {
 }
 
 func TestResult_WriteTable(t *testing.T) {
-	r := &quad9.Result{Input: "example.com", Blocked: true}
+	r := &quad9.Result{Input: "example.com", Blocked: true, Reason: "EDE 15 (Blocked)"}
 	var buf bytes.Buffer
 	err := r.WriteTable(&buf)
 	require.NoError(t, err)
@@ -46,8 +46,10 @@ func TestResult_WriteTable(t *testing.T) {
 	out := buf.String()
 	assert.Contains(t, out, "DOMAIN")
 	assert.Contains(t, out, "BLOCKED")
+	assert.Contains(t, out, "REASON")
 	assert.Contains(t, out, "example.com")
 	assert.Contains(t, out, "true")
+	assert.Contains(t, out, "EDE 15 (Blocked)")
 }
 
 func TestResult_WriteTable_NotBlocked(t *testing.T) {
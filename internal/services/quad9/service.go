@@ -23,7 +23,8 @@ const (
 )
 
 // Service queries Quad9 DNS-over-HTTPS to detect whether a domain is blocked.
-// Quad9 returns NXDOMAIN (Status=3) with a "blocked" comment for known-malicious domains.
+// Quad9 answers known-malicious domains with NXDOMAIN (Status=3) and an
+// Extended DNS Error marking the block.
 type Service struct {
 	client *req.Client
 	logger *slog.Logger
@@ -50,9 +51,10 @@ func (s *Service) AggregateResults(results []services.Result) services.Result {
 }
 
 // Run queries Quad9 DoH with an A record request to determine whether the domain is blocked.
-// A domain is considered blocked when Quad9 returns NXDOMAIN (Status=3) with an empty authority
-// section, indicating a Quad9 threat-intelligence verdict. Genuine NXDOMAIN responses include
-// a SOA record in the authority section.
+// A blocking Extended DNS Error (Blocked, Censored, Filtered, or Prohibited) is the primary
+// signal. Responses without one fall back to the NXDOMAIN heuristic: a blocked domain gets
+// NXDOMAIN (Status=3) with an empty authority section, whereas genuine NXDOMAIN responses
+// include a SOA record in the authority section.
 func (s *Service) Run(ctx context.Context, domain string) (services.Result, error) {
 	domain = output.StripANSI(domain)
 	if !services.IsDomain(domain) {
@@ -70,8 +72,19 @@ func (s *Service) Run(ctx context.Context, domain string) (services.Result, erro
 		return nil, err
 	}
 
-	if resp.Status == dns.RcodeNameError && !resp.HasAuthority {
+	result.Rcode = dohpkg.RcodeName(resp.Status)
+	result.Flags = &resp.Flags
+	result.EDE = resp.EDE
+	for i := range result.EDE {
+		result.EDE[i].Text = output.StripANSI(result.EDE[i].Text)
+	}
+
+	if e, ok := resp.BlockingEDE(); ok {
+		result.Blocked = true
+		result.Reason = output.StripANSI(e.String())
+	} else if resp.Status == dns.RcodeNameError && !resp.HasAuthority {
 		result.Blocked = true
+		result.Reason = "NXDOMAIN without SOA"
 	}
 
 	return result, nil
@@ -3,6 +3,8 @@ package quad9_test
 import (
 	"context"
 	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/netip"
@@ -69,6 +71,65 @@ func TestService_Run_Blocked(t *testing.T) {
 	require.True(t, ok, "expected *quad9.Result")
 	assert.Equal(t, "malicious.example", result.Input)
 	assert.True(t, result.Blocked)
+	assert.Equal(t, "NXDOMAIN without SOA", result.Reason)
+	assert.Equal(t, "NXDOMAIN", result.Rcode)
+}
+
+func TestService_Run_BlockedByEDE(t *testing.T) {
+	client := newTestClient(t)
+
+	// An EDE marks the block even when the authority section carries a SOA.
+	m := new(dns.Msg)
+	m.Response = true
+	m.Rcode = dns.RcodeNameError
+	m.Ns = []dns.RR{&dns.SOA{Hdr: dns.Header{Name: "example.", Class: dns.ClassINET}, SOA: rdata.SOA{Ns: "ns1.example.", Mbox: "hostmaster.example."}}}
+	m.Pseudo = []dns.RR{&dns.EDE{InfoCode: 15}}
+	require.NoError(t, m.Pack())
+	// The library packs EDE without its extra text, so append it by hand and
+	// grow the OPT RDLENGTH and the option length, the option being last.
+	const text = "malware"
+	data, n := m.Data, len(m.Data)
+	binary.BigEndian.PutUint16(data[n-8:], binary.BigEndian.Uint16(data[n-8:])+uint16(len(text)))
+	binary.BigEndian.PutUint16(data[n-4:], binary.BigEndian.Uint16(data[n-4:])+uint16(len(text)))
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, wireResponder(append(data, text...)))
+
+	svc := quad9.NewService(client, testutil.NopLogger())
+	raw, err := svc.Run(context.Background(), "malicious.example")
+	require.NoError(t, err)
+
+	result := raw.(*quad9.Result)
+	assert.True(t, result.Blocked)
+	assert.Equal(t, "EDE 15 (Blocked): malware", result.Reason)
+	require.Len(t, result.EDE, 1)
+	assert.Equal(t, uint16(15), result.EDE[0].Code)
+
+	out, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"ede":[{"code":15,"name":"Blocked","text":"malware"}]`)
+	assert.Contains(t, string(out), `"flags":{"aa":false,"tc":false,"ra":false,"ad":false,"cd":false}`)
+}
+
+func TestService_Run_NonBlockingEDE(t *testing.T) {
+	client := newTestClient(t)
+
+	aRR := &dns.A{Hdr: dns.Header{Name: "example.com.", Class: dns.ClassINET, TTL: 300}}
+	aRR.Addr = netip.MustParseAddr("93.184.216.34")
+	m := new(dns.Msg)
+	m.Response = true
+	m.Answer = []dns.RR{aRR}
+	m.Pseudo = []dns.RR{&dns.EDE{InfoCode: 3}} // Stale Answer
+	require.NoError(t, m.Pack())
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, wireResponder(m.Data))
+
+	svc := quad9.NewService(client, testutil.NopLogger())
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	result := raw.(*quad9.Result)
+	assert.False(t, result.Blocked)
+	assert.Empty(t, result.Reason)
+	require.Len(t, result.EDE, 1)
+	assert.Equal(t, "Stale Answer", result.EDE[0].Name())
 }
 
 func TestService_Run_NotBlocked(t *testing.T) {
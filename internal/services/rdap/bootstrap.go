@@ -0,0 +1,239 @@
+package rdap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/appdir"
+)
+
+// BootstrapBaseURL is the IANA location of the RDAP bootstrap registries (RFC 9224).
+const BootstrapBaseURL = "https://data.iana.org/rdap/"
+
+// bootstrapDirName is the config-dir subdirectory holding the cached bootstrap files.
+const bootstrapDirName = "rdap-bootstrap"
+
+// Registry identifies one of the IANA RDAP bootstrap registries.
+type Registry string
+
+// Registry constants for the four bootstrap registries.
+const (
+	RegistryDNS  Registry = "dns"
+	RegistryIPv4 Registry = "ipv4"
+	RegistryIPv6 Registry = "ipv6"
+	RegistryASN  Registry = "asn"
+)
+
+// Registries lists every bootstrap registry.
+var Registries = []Registry{RegistryDNS, RegistryIPv4, RegistryIPv6, RegistryASN}
+
+// FileName returns the bootstrap file name of the registry, e.g. "dns.json".
+func (r Registry) FileName() string { return string(r) + ".json" }
+
+// bootstrapFile is the RFC 9224 bootstrap registry format. Each service is a
+// pair of [entries, base URLs].
+type bootstrapFile struct {
+	Version     string       `json:"version"`
+	Publication string       `json:"publication"`
+	Services    [][][]string `json:"services"`
+}
+
+// parseBootstrap decodes and validates a bootstrap registry file.
+func parseBootstrap(data []byte) (*bootstrapFile, error) {
+	var f bootstrapFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing bootstrap file: %w", err)
+	}
+	if len(f.Services) == 0 {
+		return nil, errors.New("parsing bootstrap file: no services")
+	}
+	return &f, nil
+}
+
+// DefaultBootstrapDir returns the directory holding the cached bootstrap files.
+func DefaultBootstrapDir() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, bootstrapDirName), nil
+}
+
+// FetchBootstrap downloads and validates a bootstrap registry from baseURL.
+func FetchBootstrap(ctx context.Context, client *req.Client, baseURL string, reg Registry) ([]byte, error) {
+	url := strings.TrimSuffix(baseURL, "/") + "/" + reg.FileName()
+	resp, err := client.R().SetContext(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("downloading RDAP bootstrap %s: %w", reg.FileName(), err)
+	}
+	if resp.Response == nil {
+		return nil, fmt.Errorf("downloading RDAP bootstrap %s: transport error (no response)", reg.FileName())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading RDAP bootstrap %s: unexpected status %d", reg.FileName(), resp.StatusCode)
+	}
+	data := resp.Bytes()
+	if _, err := parseBootstrap(data); err != nil {
+		return nil, fmt.Errorf("validating RDAP bootstrap %s: %w", reg.FileName(), err)
+	}
+	return data, nil
+}
+
+// SaveBootstrap atomically writes a bootstrap registry into dir.
+func SaveBootstrap(dir string, reg Registry, data []byte) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating bootstrap dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, string(reg)+"-*.json")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing RDAP bootstrap %s: %w", reg.FileName(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+	if err := os.Rename(tmpName, filepath.Join(dir, reg.FileName())); err != nil {
+		return fmt.Errorf("installing RDAP bootstrap %s: %w", reg.FileName(), err)
+	}
+	return nil
+}
+
+// bootstrap lazily loads registries from the cache dir, downloading and
+// caching any registry that is missing or unreadable.
+type bootstrap struct {
+	client  *req.Client
+	logger  *slog.Logger
+	dir     string
+	baseURL string
+
+	mu    sync.Mutex
+	files map[Registry]*bootstrapFile
+}
+
+// load returns the parsed registry, fetching it from IANA on first use when
+// the cached copy is missing or corrupt.
+func (b *bootstrap) load(ctx context.Context, reg Registry) (*bootstrapFile, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if f, ok := b.files[reg]; ok {
+		return f, nil
+	}
+
+	path := filepath.Join(b.dir, reg.FileName())
+	if data, err := os.ReadFile(path); err == nil {
+		if f, err := parseBootstrap(data); err == nil {
+			b.files[reg] = f
+			return f, nil
+		}
+		b.logger.Debug("rdap: ignoring corrupt bootstrap cache", "path", path)
+	}
+
+	data, err := FetchBootstrap(ctx, b.client, b.baseURL, reg)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseBootstrap(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := SaveBootstrap(b.dir, reg, data); err != nil {
+		b.logger.Debug("rdap: caching bootstrap failed", "registry", reg, "error", err)
+	}
+	b.files[reg] = f
+	return f, nil
+}
+
+// domainServer returns the base URL for the longest registered suffix of domain.
+func (f *bootstrapFile) domainServer(domain string) string {
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(domain, ".")), ".")
+	for i := range labels {
+		suffix := strings.Join(labels[i:], ".")
+		for _, svc := range f.Services {
+			if len(svc) < 2 {
+				continue
+			}
+			for _, entry := range svc[0] {
+				if strings.EqualFold(entry, suffix) {
+					return pickURL(svc[1])
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// ipServer returns the base URL for the most specific prefix containing addr.
+func (f *bootstrapFile) ipServer(addr netip.Addr) string {
+	best, bestBits := "", -1
+	for _, svc := range f.Services {
+		if len(svc) < 2 {
+			continue
+		}
+		for _, entry := range svc[0] {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil || !prefix.Contains(addr) || prefix.Bits() <= bestBits {
+				continue
+			}
+			best, bestBits = pickURL(svc[1]), prefix.Bits()
+		}
+	}
+	return best
+}
+
+// asnServer returns the base URL for the range containing asn.
+// Entries are single numbers ("1877") or inclusive ranges ("1-1876").
+func (f *bootstrapFile) asnServer(asn uint32) string {
+	for _, svc := range f.Services {
+		if len(svc) < 2 {
+			continue
+		}
+		for _, entry := range svc[0] {
+			lo, hi, found := strings.Cut(entry, "-")
+			if !found {
+				hi = lo
+			}
+			from, err1 := strconv.ParseUint(lo, 10, 32)
+			to, err2 := strconv.ParseUint(hi, 10, 32)
+			if err1 == nil && err2 == nil && uint64(asn) >= from && uint64(asn) <= to {
+				return pickURL(svc[1])
+			}
+		}
+	}
+	return ""
+}
+
+// pickURL prefers an HTTPS base URL and guarantees a trailing slash.
+func pickURL(urls []string) string {
+	chosen := ""
+	for _, u := range urls {
+		if strings.HasPrefix(u, "https://") {
+			chosen = u
+			break
+		}
+		if chosen == "" {
+			chosen = u
+		}
+	}
+	if chosen != "" && !strings.HasSuffix(chosen, "/") {
+		chosen += "/"
+	}
+	return chosen
+}
@@ -0,0 +1,107 @@
+package rdap
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func loadFixture(t *testing.T, reg Registry) *bootstrapFile {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", reg.FileName()))
+	require.NoError(t, err)
+	f, err := parseBootstrap(data)
+	require.NoError(t, err)
+	return f
+}
+
+func TestDomainServer(t *testing.T) {
+	f := loadFixture(t, RegistryDNS)
+	assert.Equal(t, "https://rdap.verisign.com/com/v1/", f.domainServer("www.Example.COM."))
+	assert.Equal(t, "https://rdap.nominet.uk/uk/", f.domainServer("example.co.uk"))
+	assert.Equal(t, "https://rdap.publicinterestregistry.org/rdap/", f.domainServer("example.org"), "https preferred, slash appended")
+	assert.Empty(t, f.domainServer("example.invalid"))
+}
+
+func TestIPServer(t *testing.T) {
+	assert.Equal(t, "https://rdap.arin.net/registry/", loadFixture(t, RegistryIPv4).ipServer(netip.MustParseAddr("8.8.8.8")))
+	assert.Empty(t, loadFixture(t, RegistryIPv4).ipServer(netip.MustParseAddr("192.0.2.1")))
+	assert.Equal(t, "https://rdap.db.ripe.net/", loadFixture(t, RegistryIPv6).ipServer(netip.MustParseAddr("2001:67c::1")))
+}
+
+func TestIPServer_LongestPrefix(t *testing.T) {
+	f := &bootstrapFile{Services: [][][]string{
+		{{"10.0.0.0/8"}, {"https://broad.example/"}},
+		{{"10.1.0.0/16"}, {"https://narrow.example/"}},
+	}}
+	assert.Equal(t, "https://narrow.example/", f.ipServer(netip.MustParseAddr("10.1.2.3")))
+	assert.Equal(t, "https://broad.example/", f.ipServer(netip.MustParseAddr("10.2.2.3")))
+}
+
+func TestASNServer(t *testing.T) {
+	f := loadFixture(t, RegistryASN)
+	assert.Equal(t, "https://rdap.arin.net/registry/", f.asnServer(1))
+	assert.Equal(t, "https://rdap.arin.net/registry/", f.asnServer(15169))
+	assert.Equal(t, "https://rdap.db.ripe.net/", f.asnServer(1900))
+	assert.Empty(t, f.asnServer(4200000000))
+}
+
+func TestParseBootstrap_Invalid(t *testing.T) {
+	_, err := parseBootstrap([]byte("not json"))
+	require.Error(t, err)
+	_, err = parseBootstrap([]byte(`{"version":"1.0","services":[]}`))
+	require.Error(t, err)
+}
+
+func TestFetchAndSaveBootstrap(t *testing.T) {
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	data, err := os.ReadFile(filepath.Join("testdata", "dns.json"))
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodGet, "https://mirror.example/rdap/dns.json",
+		httpmock.NewBytesResponder(http.StatusOK, data))
+	httpmock.RegisterResponder(http.MethodGet, "https://mirror.example/rdap/asn.json",
+		httpmock.NewStringResponder(http.StatusOK, "<html>"))
+
+	got, err := FetchBootstrap(context.Background(), client, "https://mirror.example/rdap/", RegistryDNS)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	_, err = FetchBootstrap(context.Background(), client, "https://mirror.example/rdap", RegistryASN)
+	require.Error(t, err, "invalid JSON is rejected")
+
+	dir := filepath.Join(t.TempDir(), "nested")
+	require.NoError(t, SaveBootstrap(dir, RegistryDNS, got))
+	saved, err := os.ReadFile(filepath.Join(dir, "dns.json"))
+	require.NoError(t, err)
+	assert.Equal(t, data, saved)
+}
+
+func TestBootstrapLoad_CorruptCacheRefetched(t *testing.T) {
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	data, err := os.ReadFile(filepath.Join("testdata", "asn.json"))
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodGet, BootstrapBaseURL+"asn.json", httpmock.NewBytesResponder(http.StatusOK, data))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "asn.json"), []byte("{corrupt"), 0o600))
+	b := &bootstrap{client: client, logger: testutil.NopLogger(), dir: dir, baseURL: BootstrapBaseURL, files: map[Registry]*bootstrapFile{}}
+	f, err := b.load(context.Background(), RegistryASN)
+	require.NoError(t, err)
+	assert.Equal(t, "https://rdap.db.ripe.net/", f.asnServer(3333))
+}
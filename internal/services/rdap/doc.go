@@ -0,0 +1,3 @@
+// Package rdap queries registration data for domains, IP addresses, and ASNs
+// via RDAP, locating the authoritative server through IANA's bootstrap registries.
+package rdap
@@ -0,0 +1,30 @@
+package rdap
+
+import (
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds RDAP results for multiple inputs.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteTable renders all results in a single combined table grouped by input.
+// Columns: Input / Field / Value. Input cells are merged hierarchically.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows [][]string
+	for _, r := range m.Results {
+		for _, row := range r.rows() {
+			rows = append(rows, append([]string{r.Input}, row...))
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 20, 30)
+	table.Header([]string{"Input", "Field", "Value"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,56 @@
+package rdap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/rdap"
+)
+
+func TestMultiResult_IsEmpty(t *testing.T) {
+	t.Run("empty when no results", func(t *testing.T) {
+		assert.True(t, (&rdap.MultiResult{}).IsEmpty())
+	})
+
+	t.Run("empty when no server returned an object", func(t *testing.T) {
+		mr := &rdap.MultiResult{}
+		mr.Results = []*rdap.Result{{Input: "example.com", Server: "https://x/"}, {Input: "example.org"}}
+		assert.True(t, mr.IsEmpty())
+	})
+
+	t.Run("not empty when one input has an object", func(t *testing.T) {
+		mr := &rdap.MultiResult{}
+		mr.Results = []*rdap.Result{{Input: "example.com"}, {Input: "AS15169", ObjectType: "autnum", Name: "GOOGLE"}}
+		assert.False(t, mr.IsEmpty())
+	})
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	mr := &rdap.MultiResult{}
+	mr.Results = []*rdap.Result{
+		{Input: "example.com", ObjectType: "domain", Name: "example.com", Registrar: "Example Registrar"},
+		{Input: "8.8.8.8", ObjectType: "ip network", Name: "GOGL", Network: "8.8.8.0/24"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "INPUT")
+	assert.Contains(t, out, "example.com")
+	assert.Contains(t, out, "Example Registrar")
+	assert.Contains(t, out, "8.8.8.0/24")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	mr := &rdap.MultiResult{}
+	mr.Results = []*rdap.Result{
+		{Input: "example.com", Name: "example.com", Registrar: "Example Registrar"},
+		{Input: "AS15169", Name: "GOOGLE"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteText(&buf))
+	assert.Equal(t, "example.com / Example Registrar /  /  / \nGOOGLE /  /  /  / \n", buf.String())
+}
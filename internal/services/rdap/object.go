@@ -0,0 +1,219 @@
+package rdap
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// object is the subset of an RFC 9083 RDAP response used for normalization.
+// Domain, IP network, and autnum responses share these members.
+type object struct {
+	ObjectClassName string       `json:"objectClassName"`
+	Handle          string       `json:"handle"`
+	LDHName         string       `json:"ldhName"`
+	Name            string       `json:"name"`
+	Country         string       `json:"country"`
+	StartAddress    string       `json:"startAddress"`
+	EndAddress      string       `json:"endAddress"`
+	StartAutnum     *uint32      `json:"startAutnum"`
+	EndAutnum       *uint32      `json:"endAutnum"`
+	Status          []string     `json:"status"`
+	Events          []event      `json:"events"`
+	Entities        []entity     `json:"entities"`
+	Nameservers     []nameserver `json:"nameservers"`
+	Links           []link       `json:"links"`
+	CIDRs           []cidr       `json:"cidr0_cidrs"`
+}
+
+type event struct {
+	Action string `json:"eventAction"`
+	Date   string `json:"eventDate"`
+}
+
+type entity struct {
+	Handle     string          `json:"handle"`
+	Roles      []string        `json:"roles"`
+	VCardArray json.RawMessage `json:"vcardArray"`
+	Entities   []entity        `json:"entities"`
+}
+
+type nameserver struct {
+	LDHName string `json:"ldhName"`
+}
+
+type link struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+	Type string `json:"type"`
+}
+
+// cidr is an entry of the widely deployed cidr0 extension.
+type cidr struct {
+	V4Prefix string `json:"v4prefix"`
+	V6Prefix string `json:"v6prefix"`
+	Length   int    `json:"length"`
+}
+
+// vcard holds the string values of a jCard (RFC 7095) keyed by property name.
+type vcard map[string][]string
+
+// parseVCard decodes a jCard array ["vcard", [[name, params, type, value...], ...]].
+// Structured values (e.g. adr) are flattened by joining their non-empty strings.
+func parseVCard(raw json.RawMessage) vcard {
+	card := vcard{}
+	var outer []json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &outer) != nil || len(outer) < 2 {
+		return card
+	}
+	var props [][]any
+	if json.Unmarshal(outer[1], &props) != nil {
+		return card
+	}
+	for _, p := range props {
+		if len(p) < 4 {
+			continue
+		}
+		name, ok := p[0].(string)
+		if !ok {
+			continue
+		}
+		if v := flattenValue(p[3:]); v != "" {
+			card[strings.ToLower(name)] = append(card[strings.ToLower(name)], v)
+		}
+	}
+	return card
+}
+
+// flattenValue joins the non-empty string leaves of a jCard value.
+func flattenValue(values []any) string {
+	var parts []string
+	for _, v := range values {
+		switch x := v.(type) {
+		case string:
+			if x = strings.TrimSpace(x); x != "" {
+				parts = append(parts, x)
+			}
+		case []any:
+			if s := flattenValue(x); s != "" {
+				parts = append(parts, s)
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// first returns the first value of property name, or "".
+func (c vcard) first(name string) string {
+	if v := c[name]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// findEntity returns the first entity, searched depth-first, that has role.
+func findEntity(entities []entity, role string) *entity {
+	for i := range entities {
+		if slices.Contains(entities[i].Roles, role) {
+			return &entities[i]
+		}
+		if e := findEntity(entities[i].Entities, role); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// relatedURL returns the href of the first "related" RDAP link that points to
+// a different server than self, typically the registrar's RDAP service.
+func (o *object) relatedURL(self string) string {
+	for _, l := range o.Links {
+		if l.Rel != "related" || l.Href == "" || l.Href == self {
+			continue
+		}
+		if l.Type == "" || strings.Contains(l.Type, "rdap+json") {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+// normalize fills the empty fields of r from o. Fields already set are kept,
+// so a registry response can be merged with the registrar's response.
+func (o *object) normalize(r *Result) {
+	set := func(dst *string, v string) {
+		if *dst == "" {
+			*dst = output.StripANSI(strings.TrimSpace(v))
+		}
+	}
+	set(&r.ObjectType, o.ObjectClassName)
+	set(&r.Handle, o.Handle)
+	set(&r.Name, strings.ToLower(o.LDHName))
+	set(&r.Name, o.Name)
+	set(&r.Country, o.Country)
+	set(&r.Network, o.network())
+
+	for _, e := range o.Events {
+		switch e.Action {
+		case "registration":
+			set(&r.Created, e.Date)
+		case "expiration":
+			set(&r.Expires, e.Date)
+		case "last changed":
+			set(&r.Updated, e.Date)
+		}
+	}
+	if e := findEntity(o.Entities, "registrar"); e != nil {
+		card := parseVCard(e.VCardArray)
+		set(&r.Registrar, card.first("fn"))
+		set(&r.Registrar, e.Handle)
+	}
+	if e := findEntity(o.Entities, "registrant"); e != nil {
+		card := parseVCard(e.VCardArray)
+		set(&r.RegistrantOrg, card.first("org"))
+		set(&r.RegistrantOrg, card.first("fn"))
+	}
+	if len(r.AbuseContacts) == 0 {
+		if e := findEntity(o.Entities, "abuse"); e != nil {
+			card := parseVCard(e.VCardArray)
+			for _, v := range append(card["email"], card["tel"]...) {
+				r.AbuseContacts = append(r.AbuseContacts, output.StripANSI(strings.TrimPrefix(v, "tel:")))
+			}
+		}
+	}
+	if len(r.Status) == 0 {
+		for _, s := range o.Status {
+			r.Status = append(r.Status, output.StripANSI(s))
+		}
+	}
+	if len(r.Nameservers) == 0 {
+		for _, ns := range o.Nameservers {
+			r.Nameservers = append(r.Nameservers, output.StripANSI(strings.ToLower(ns.LDHName)))
+		}
+	}
+}
+
+// network describes the registered range of IP network and autnum objects.
+func (o *object) network() string {
+	var prefixes []string
+	for _, c := range o.CIDRs {
+		switch {
+		case c.V4Prefix != "":
+			prefixes = append(prefixes, fmt.Sprintf("%s/%d", c.V4Prefix, c.Length))
+		case c.V6Prefix != "":
+			prefixes = append(prefixes, fmt.Sprintf("%s/%d", c.V6Prefix, c.Length))
+		}
+	}
+	switch {
+	case len(prefixes) > 0:
+		return strings.Join(prefixes, ", ")
+	case o.StartAddress != "" && o.EndAddress != "":
+		return o.StartAddress + " - " + o.EndAddress
+	case o.StartAutnum != nil && o.EndAutnum != nil && *o.StartAutnum != *o.EndAutnum:
+		return fmt.Sprintf("AS%d - AS%d", *o.StartAutnum, *o.EndAutnum)
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package rdap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVCard(t *testing.T) {
+	raw := json.RawMessage(`["vcard", [
+		["version", {}, "text", "4.0"],
+		["FN", {}, "text", "Example Org"],
+		["email", {}, "text", "a@example.com"],
+		["email", {}, "text", "b@example.com"],
+		["adr", {}, "text", ["", "", "1 Main St", "Town", "", "12345", "US"]],
+		["broken"]
+	]]`)
+	card := parseVCard(raw)
+	assert.Equal(t, "Example Org", card.first("fn"))
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, card["email"])
+	assert.Equal(t, "1 Main St Town 12345 US", card.first("adr"))
+	assert.Empty(t, card.first("org"))
+}
+
+func TestParseVCard_Invalid(t *testing.T) {
+	assert.Empty(t, parseVCard(nil))
+	assert.Empty(t, parseVCard(json.RawMessage(`"vcard"`)))
+	assert.Empty(t, parseVCard(json.RawMessage(`["vcard", "x"]`)))
+}
+
+func TestFindEntity_Nested(t *testing.T) {
+	entities := []entity{{
+		Handle: "REG", Roles: []string{"registrar"},
+		Entities: []entity{{Handle: "ABUSE", Roles: []string{"abuse"}}},
+	}}
+	assert.Equal(t, "REG", findEntity(entities, "registrar").Handle)
+	assert.Equal(t, "ABUSE", findEntity(entities, "abuse").Handle)
+	assert.Nil(t, findEntity(entities, "registrant"))
+}
+
+func TestObject_NetworkRange(t *testing.T) {
+	start, end := uint32(64496), uint32(64511)
+	assert.Equal(t, "AS64496 - AS64511", (&object{StartAutnum: &start, EndAutnum: &end}).network())
+	assert.Equal(t, "192.0.2.0 - 192.0.2.255", (&object{StartAddress: "192.0.2.0", EndAddress: "192.0.2.255"}).network())
+	assert.Equal(t, "2001:db8::/32", (&object{CIDRs: []cidr{{V6Prefix: "2001:db8::", Length: 32}}}).network())
+}
@@ -0,0 +1,78 @@
+package rdap
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Result holds normalized RDAP registration data for a domain, IP, or ASN.
+type Result struct {
+	Input         string   `json:"input"`
+	ObjectType    string   `json:"object_type,omitempty"` // "domain", "ip network", or "autnum"
+	Server        string   `json:"server,omitempty"`      // RDAP base URL that answered
+	Handle        string   `json:"handle,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Registrar     string   `json:"registrar,omitempty"`
+	RegistrantOrg string   `json:"registrant_org,omitempty"`
+	Country       string   `json:"country,omitempty"`
+	Network       string   `json:"network,omitempty"` // CIDRs or address/ASN range
+	Created       string   `json:"created,omitempty"`
+	Updated       string   `json:"updated,omitempty"`
+	Expires       string   `json:"expires,omitempty"`
+	Status        []string `json:"status,omitempty"`
+	Nameservers   []string `json:"nameservers,omitempty"`
+	AbuseContacts []string `json:"abuse_contacts,omitempty"`
+}
+
+// IsEmpty reports whether the RDAP server returned no object for the input.
+func (r *Result) IsEmpty() bool {
+	return r.ObjectType == "" && r.Handle == "" && r.Name == ""
+}
+
+// rows returns the non-empty fields as Field/Value pairs in display order.
+func (r *Result) rows() [][]string {
+	fields := [][]string{
+		{"Type", r.ObjectType},
+		{"Handle", r.Handle},
+		{"Name", r.Name},
+		{"Registrar", r.Registrar},
+		{"Registrant Org", r.RegistrantOrg},
+		{"Country", r.Country},
+		{"Network", r.Network},
+		{"Created", r.Created},
+		{"Updated", r.Updated},
+		{"Expires", r.Expires},
+		{"Status", strings.Join(r.Status, "\n")},
+		{"Nameservers", strings.Join(r.Nameservers, "\n")},
+		{"Abuse Contacts", strings.Join(r.AbuseContacts, "\n")},
+		{"Server", r.Server},
+	}
+	var rows [][]string
+	for _, f := range fields {
+		if f[1] != "" {
+			rows = append(rows, f)
+		}
+	}
+	return rows
+}
+
+// WriteText renders the result as a single pipe-delimited line.
+// Format: "Name / Registrar / Registrant Org / Created / Expires"
+func (r *Result) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s / %s / %s / %s / %s\n",
+		r.Name, r.Registrar, r.RegistrantOrg, r.Created, r.Expires)
+	return err
+}
+
+// WriteTable renders the non-empty fields as a Field/Value table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 20)
+	table.Header([]string{"Field", "Value"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,62 @@
+package rdap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/rdap"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&rdap.Result{Input: "example.com", Server: "https://x/"}).IsEmpty())
+	assert.False(t, (&rdap.Result{Input: "AS15169", ObjectType: "autnum"}).IsEmpty())
+	assert.False(t, (&rdap.Result{Input: "8.8.8.8", Handle: "NET-8-8-8-0-2"}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &rdap.Result{
+		Input:         "example.com",
+		ObjectType:    "domain",
+		Name:          "example.com",
+		Registrar:     "Example Registrar",
+		RegistrantOrg: "Example Org",
+		Created:       "1995-08-14T04:00:00Z",
+		Expires:       "2026-08-13T04:00:00Z",
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "example.com / Example Registrar / Example Org / 1995-08-14T04:00:00Z / 2026-08-13T04:00:00Z\n", buf.String())
+}
+
+func TestResult_WriteText_Partial(t *testing.T) {
+	result := &rdap.Result{Input: "8.8.8.8", ObjectType: "ip network", Name: "GOGL", Created: "2014-03-14T16:52:05-04:00"}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "GOGL /  /  / 2014-03-14T16:52:05-04:00 / \n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &rdap.Result{
+		Input:         "example.com",
+		ObjectType:    "domain",
+		Server:        "https://rdap.verisign.com/com/v1/",
+		Name:          "example.com",
+		Registrar:     "Example Registrar",
+		Status:        []string{"client transfer prohibited", "server delete prohibited"},
+		Nameservers:   []string{"a.iana-servers.net", "b.iana-servers.net"},
+		AbuseContacts: []string{"abuse@registrar.example"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "Registrar")
+	assert.Contains(t, out, "Example Registrar")
+	assert.Contains(t, out, "server delete prohibited")
+	assert.Contains(t, out, "b.iana-servers.net")
+	assert.Contains(t, out, "abuse@registrar.example")
+	assert.NotContains(t, out, "Country", "empty fields are omitted")
+	assert.NotContains(t, out, "Registrant Org", "empty fields are omitted")
+}
@@ -0,0 +1,168 @@
+package rdap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// DefaultRPS is the target request rate for the RDAP service.
+	// Registry RDAP servers rate-limit aggressively, so the default is conservative.
+	DefaultRPS float64 = 2
+	// DefaultBurst is the burst capacity above DefaultRPS.
+	DefaultBurst = 4
+
+	// Name is the service identifier.
+	Name = "rdap"
+	// PAP is the PAP activity level for the RDAP service.
+	PAP = pap.AMBER
+)
+
+// Service queries RDAP servers for domain, IP, and ASN registration data.
+type Service struct {
+	client *req.Client
+	logger *slog.Logger
+	boot   *bootstrap
+}
+
+// NewService creates a new RDAP service. bootstrapDir holds the cached IANA
+// bootstrap files; missing files are downloaded from BootstrapBaseURL on first use.
+func NewService(client *req.Client, logger *slog.Logger, bootstrapDir string) *Service {
+	return &Service{
+		client: client,
+		logger: logger,
+		boot: &bootstrap{
+			client:  client,
+			logger:  logger,
+			dir:     bootstrapDir,
+			baseURL: BootstrapBaseURL,
+			files:   map[Registry]*bootstrapFile{},
+		},
+	}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the RDAP service (external API query).
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple RDAP results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run looks up registration data for a domain, IP address, or ASN (e.g. AS15169).
+// Domain lookups follow the registry's "related" link to the registrar's RDAP
+// server and merge both responses. An unknown object yields an empty result.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	input = output.StripANSI(strings.TrimSpace(input))
+	result := &Result{Input: input}
+
+	path, reg, key, err := classify(input)
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.boot.load(ctx, reg)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("%w: %v", services.ErrRequestFailed, err)
+	}
+
+	var base string
+	switch reg {
+	case RegistryDNS:
+		base = f.domainServer(key)
+	case RegistryASN:
+		n, _ := strconv.ParseUint(key, 10, 32)
+		base = f.asnServer(uint32(n))
+	default:
+		base = f.ipServer(netip.MustParseAddr(key))
+	}
+	if base == "" {
+		return nil, fmt.Errorf("%w: no RDAP server registered for %q", services.ErrRequestFailed, input)
+	}
+
+	url := base + path + "/" + key
+	result.Server = base
+	obj, err := s.fetch(ctx, url)
+	if err != nil || obj == nil {
+		return result, err
+	}
+	obj.normalize(result)
+
+	if related := obj.relatedURL(url); reg == RegistryDNS && related != "" {
+		// Registrar data is supplementary; failures do not fail the lookup.
+		if regObj, err := s.fetch(ctx, related); err != nil {
+			s.logger.Debug("rdap: registrar lookup failed", "url", related, "error", err)
+		} else if regObj != nil {
+			regObj.normalize(result)
+		}
+	}
+	return result, nil
+}
+
+// classify maps input to its RDAP path segment, bootstrap registry, and lookup key.
+func classify(input string) (path string, reg Registry, key string, err error) {
+	if addr, err := netip.ParseAddr(input); err == nil {
+		if addr.Is4() || addr.Is4In6() {
+			return "ip", RegistryIPv4, addr.Unmap().String(), nil
+		}
+		return "ip", RegistryIPv6, addr.String(), nil
+	}
+	if upper := strings.ToUpper(input); strings.HasPrefix(upper, "AS") {
+		if _, err := strconv.ParseUint(upper[2:], 10, 32); err == nil {
+			return "autnum", RegistryASN, upper[2:], nil
+		}
+	}
+	if services.IsDomain(input) {
+		return "domain", RegistryDNS, strings.ToLower(strings.TrimSuffix(input, ".")), nil
+	}
+	return "", "", "", fmt.Errorf("%w: must be a domain, IP address, or ASN (e.g. AS15169): %q", services.ErrInvalidInput, input)
+}
+
+// fetch retrieves and decodes a single RDAP object. A 404 yields (nil, nil);
+// context cancellation is reported as (nil, nil) so partial results are kept.
+func (s *Service) fetch(ctx context.Context, url string) (*object, error) {
+	var obj object
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/rdap+json").
+		SetSuccessResult(&obj).
+		Get(url)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: RDAP request error for %q: %v", services.ErrRequestFailed, url, err)
+	}
+	if resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.Response == nil || !resp.IsSuccessState() {
+		body := resp.String()
+		if len(body) > 200 {
+			body = body[:200] + "..."
+		}
+		return nil, fmt.Errorf("%w: RDAP server returned HTTP %d for %q: %q", services.ErrRequestFailed, resp.StatusCode, url, body)
+	}
+	return &obj, nil
+}
@@ -0,0 +1,214 @@
+package rdap_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/rdap"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+// seedBootstrap returns a bootstrap dir pre-populated with the test registries.
+func seedBootstrap(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, reg := range rdap.Registries {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, reg.FileName()), fixture(t, reg.FileName()), 0o600))
+	}
+	return dir
+}
+
+func run(t *testing.T, client *req.Client, dir, input string) *rdap.Result {
+	t.Helper()
+	svc := rdap.NewService(client, testutil.NopLogger(), dir)
+	raw, err := svc.Run(context.Background(), input)
+	require.NoError(t, err)
+	result, ok := raw.(*rdap.Result)
+	require.True(t, ok, "expected *rdap.Result")
+	return result
+}
+
+func TestRun_Domain(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.verisign.com/com/v1/domain/example.com",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "domain_registry.json")))
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.registrar.example/domain/EXAMPLE.COM",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "domain_registrar.json")))
+
+	result := run(t, client, seedBootstrap(t), "Example.com")
+
+	assert.Equal(t, "domain", result.ObjectType)
+	assert.Equal(t, "https://rdap.verisign.com/com/v1/", result.Server)
+	assert.Equal(t, "example.com", result.Name)
+	assert.Equal(t, "RESERVED-Internet Assigned Numbers Authority", result.Registrar)
+	assert.Equal(t, "Internet Assigned Numbers Authority", result.RegistrantOrg, "registrant comes from the registrar response")
+	assert.Equal(t, "1995-08-14T04:00:00Z", result.Created, "registry dates take precedence")
+	assert.Equal(t, "2026-08-13T04:00:00Z", result.Expires)
+	assert.Equal(t, "2025-08-14T07:01:39Z", result.Updated)
+	assert.Equal(t, []string{"client delete prohibited", "client transfer prohibited"}, result.Status)
+	assert.Equal(t, []string{"a.iana-servers.net", "b.iana-servers.net"}, result.Nameservers)
+	assert.Equal(t, []string{"abuse@registrar.example", "+1.3103015800"}, result.AbuseContacts)
+}
+
+func TestRun_DomainRegistrarFailureIgnored(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.verisign.com/com/v1/domain/example.com",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "domain_registry.json")))
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.registrar.example/domain/EXAMPLE.COM",
+		httpmock.NewStringResponder(http.StatusInternalServerError, "boom"))
+
+	result := run(t, client, seedBootstrap(t), "example.com")
+	assert.Equal(t, "RESERVED-Internet Assigned Numbers Authority", result.Registrar)
+	assert.Empty(t, result.RegistrantOrg)
+}
+
+func TestRun_IP(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.arin.net/registry/ip/8.8.8.8",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "ip_network.json")))
+
+	result := run(t, client, seedBootstrap(t), "8.8.8.8")
+	assert.Equal(t, "ip network", result.ObjectType)
+	assert.Equal(t, "GOGL", result.Name)
+	assert.Equal(t, "Google LLC", result.RegistrantOrg)
+	assert.Equal(t, "US", result.Country)
+	assert.Equal(t, "8.8.8.0/24", result.Network)
+	assert.Equal(t, []string{"network-abuse@google.com"}, result.AbuseContacts)
+}
+
+func TestRun_IPv6(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.db.ripe.net/ip/2001:67c:2e8::2",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "ip_network.json")))
+
+	result := run(t, client, seedBootstrap(t), "2001:67c:2e8::2")
+	assert.Equal(t, "https://rdap.db.ripe.net/", result.Server)
+}
+
+func TestRun_ASN(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.arin.net/registry/autnum/15169",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "autnum.json")))
+
+	result := run(t, client, seedBootstrap(t), "as15169")
+	assert.Equal(t, "autnum", result.ObjectType)
+	assert.Equal(t, "AS15169", result.Handle)
+	assert.Equal(t, "GOOGLE", result.Name)
+	assert.Equal(t, "Google LLC", result.RegistrantOrg)
+	assert.Empty(t, result.Network, "single-ASN ranges are not shown")
+}
+
+func TestRun_NotFound(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.verisign.com/com/v1/domain/unregistered.com",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"errorCode":404}`))
+
+	result := run(t, client, seedBootstrap(t), "unregistered.com")
+	assert.True(t, result.IsEmpty())
+}
+
+func TestRun_HTTPError(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.verisign.com/com/v1/domain/example.com",
+		httpmock.NewStringResponder(http.StatusTooManyRequests, "slow down"))
+
+	svc := rdap.NewService(client, testutil.NopLogger(), seedBootstrap(t))
+	_, err := svc.Run(context.Background(), "example.com")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_NoServer(t *testing.T) {
+	svc := rdap.NewService(newTestClient(t), testutil.NopLogger(), seedBootstrap(t))
+	_, err := svc.Run(context.Background(), "example.invalid")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+	assert.Contains(t, err.Error(), "no RDAP server")
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	svc := rdap.NewService(req.NewClient(), testutil.NopLogger(), t.TempDir())
+	for _, input := range []string{"not a domain", "AS", "ASxyz", ""} {
+		_, err := svc.Run(context.Background(), input)
+		require.ErrorIs(t, err, services.ErrInvalidInput, input)
+	}
+}
+
+func TestRun_BootstrapDownloadedAndCached(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, rdap.BootstrapBaseURL+"asn.json",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "asn.json")))
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.db.ripe.net/autnum/3333",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "autnum.json")))
+
+	dir := filepath.Join(t.TempDir(), "rdap-bootstrap")
+	svc := rdap.NewService(client, testutil.NopLogger(), dir)
+	_, err := svc.Run(context.Background(), "AS3333")
+	require.NoError(t, err)
+	_, err = svc.Run(context.Background(), "AS3333")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET "+rdap.BootstrapBaseURL+"asn.json"], "bootstrap is fetched once")
+	assert.FileExists(t, filepath.Join(dir, "asn.json"))
+}
+
+func TestRun_BootstrapDownloadFails(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, rdap.BootstrapBaseURL+"dns.json",
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	svc := rdap.NewService(client, testutil.NopLogger(), t.TempDir())
+	_, err := svc.Run(context.Background(), "example.com")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_ContextCancelled(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://rdap.verisign.com/com/v1/domain/example.com",
+		httpmock.NewBytesResponder(http.StatusOK, fixture(t, "domain_registry.json")))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	svc := rdap.NewService(client, testutil.NopLogger(), seedBootstrap(t))
+	raw, err := svc.Run(ctx, "example.com")
+	require.NoError(t, err)
+	result, ok := raw.(*rdap.Result)
+	require.True(t, ok, "expected *rdap.Result")
+	assert.Equal(t, "example.com", result.Input)
+}
+
+func TestService_AggregateResults(t *testing.T) {
+	svc := rdap.NewService(req.NewClient(), testutil.NopLogger(), t.TempDir())
+	agg := svc.AggregateResults([]services.Result{&rdap.Result{Input: "a.com"}, &rdap.Result{Input: "8.8.8.8"}})
+	mr, ok := agg.(*rdap.MultiResult)
+	require.True(t, ok)
+	assert.Len(t, mr.Results, 2)
+}
+
+func TestService_NameAndPAP(t *testing.T) {
+	svc := rdap.NewService(req.NewClient(), testutil.NopLogger(), t.TempDir())
+	assert.Equal(t, "rdap", svc.Name())
+	assert.Equal(t, rdap.PAP, svc.PAP())
+}
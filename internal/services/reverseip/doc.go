@@ -0,0 +1,4 @@
+// Package reverseip discovers the domains co-hosted on an IP address or small
+// CIDR range by merging every available passive-DNS source, deduplicating
+// domains with first/last seen, and filtering out shared-hosting noise.
+package reverseip
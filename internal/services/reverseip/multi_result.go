@@ -0,0 +1,37 @@
+package reverseip
+
+import (
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds reverseip results for multiple inputs.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteTable renders all results in a single combined table grouped by input,
+// followed by the IPs filtered as shared hosting across all inputs.
+// Columns: Input / Domain / IPs / First Seen / Last Seen / Sources.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows, shared [][]string
+	for _, r := range m.Results {
+		for _, row := range r.rows() {
+			rows = append(rows, append([]string{r.Input}, row...))
+		}
+		shared = append(shared, r.sharedRows()...)
+	}
+	if len(rows) > 0 {
+		table := output.NewGroupedWrappingTable(w, 20, 20)
+		table.Header([]string{"Input", "Domain", "IPs", "First Seen", "Last Seen", "Sources"})
+		if err := table.Bulk(rows); err != nil {
+			return err
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+	return writeSharedTable(w, shared)
+}
@@ -0,0 +1,76 @@
+package reverseip_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/reverseip"
+)
+
+func TestMultiResult_IsEmpty(t *testing.T) {
+	t.Run("empty when no results", func(t *testing.T) {
+		assert.True(t, (&reverseip.MultiResult{}).IsEmpty())
+	})
+
+	t.Run("empty when no input has domains", func(t *testing.T) {
+		mr := &reverseip.MultiResult{}
+		mr.Results = []*reverseip.Result{{Input: "192.0.2.1"}, {Input: "198.51.100.7"}}
+		assert.True(t, mr.IsEmpty())
+	})
+
+	t.Run("not empty when one input has a shared IP", func(t *testing.T) {
+		mr := &reverseip.MultiResult{}
+		mr.Results = []*reverseip.Result{
+			{Input: "192.0.2.1"},
+			{Input: "192.0.2.3", SharedIPs: []reverseip.SharedIP{{IP: "192.0.2.3", Domains: 812}}},
+		}
+		assert.False(t, mr.IsEmpty())
+	})
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	mr := &reverseip.MultiResult{}
+	mr.Results = []*reverseip.Result{
+		{
+			Input:     "192.0.2.0/30",
+			Domains:   []reverseip.Domain{{Domain: "example.com", IPs: []string{"192.0.2.1"}, Sources: []string{"threatminer"}}},
+			SharedIPs: []reverseip.SharedIP{{IP: "192.0.2.3", Domains: 812}},
+		},
+		{Input: "198.51.100.7", Domains: []reverseip.Domain{{Domain: "other.example", IPs: []string{"198.51.100.7"}, Sources: []string{"threatminer"}}}},
+		{Input: "203.0.113.9", SharedIPs: []reverseip.SharedIP{{IP: "203.0.113.9", Domains: 640}}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "INPUT")
+	assert.Contains(t, out, "198.51.100.7")
+	assert.Contains(t, out, "other.example")
+	assert.Contains(t, out, "812")
+	assert.Contains(t, out, "640")
+}
+
+func TestMultiResult_WriteTable_OnlySharedIPs(t *testing.T) {
+	mr := &reverseip.MultiResult{}
+	mr.Results = []*reverseip.Result{{Input: "192.0.2.3", SharedIPs: []reverseip.SharedIP{{IP: "192.0.2.3", Domains: 812}}}}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "SHARED IP")
+	assert.NotContains(t, out, "INPUT")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	mr := &reverseip.MultiResult{}
+	mr.Results = []*reverseip.Result{
+		{Input: "192.0.2.1", Domains: []reverseip.Domain{{Domain: "example.com"}, {Domain: "example.org"}}},
+		{Input: "198.51.100.7", Domains: []reverseip.Domain{{Domain: "other.example"}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteText(&buf))
+	assert.Equal(t, "example.com\nexample.org\nother.example\n", buf.String())
+}
@@ -0,0 +1,100 @@
+package reverseip
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Domain is one co-hosted domain and where and when it was observed.
+type Domain struct {
+	Domain    string   `json:"domain"`
+	IPs       []string `json:"ips"`
+	FirstSeen string   `json:"first_seen,omitempty"` // RFC 3339
+	LastSeen  string   `json:"last_seen,omitempty"`  // RFC 3339
+	Sources   []string `json:"sources"`
+}
+
+// SharedIP is an address whose domains were filtered as shared hosting.
+type SharedIP struct {
+	IP      string `json:"ip"`
+	Domains int    `json:"domains"` // distinct domains seen on the IP
+}
+
+// Result holds the domains co-hosted on an IP or CIDR range.
+type Result struct {
+	Input     string     `json:"input"`
+	Domains   []Domain   `json:"domains,omitempty"`
+	SharedIPs []SharedIP `json:"shared_ips,omitempty"`
+}
+
+// IsEmpty reports whether no domain was found and no IP was filtered.
+func (r *Result) IsEmpty() bool {
+	return len(r.Domains) == 0 && len(r.SharedIPs) == 0
+}
+
+// rows returns the Domain / IPs / First Seen / Last Seen / Sources cells.
+func (r *Result) rows() [][]string {
+	rows := make([][]string, 0, len(r.Domains))
+	for _, d := range r.Domains {
+		rows = append(rows, []string{
+			d.Domain,
+			strings.Join(d.IPs, "\n"),
+			d.FirstSeen,
+			d.LastSeen,
+			strings.Join(d.Sources, ", "),
+		})
+	}
+	return rows
+}
+
+// sharedRows returns the IP / Domains cells of filtered shared-hosting IPs.
+func (r *Result) sharedRows() [][]string {
+	rows := make([][]string, 0, len(r.SharedIPs))
+	for _, s := range r.SharedIPs {
+		rows = append(rows, []string{s.IP, strconv.Itoa(s.Domains)})
+	}
+	return rows
+}
+
+// WriteText renders the result as plain text with one domain per line.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, d := range r.Domains {
+		if _, err := fmt.Fprintln(w, d.Domain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the domains, followed by the IPs filtered as shared
+// hosting when there are any.
+func (r *Result) WriteTable(w io.Writer) error {
+	if len(r.Domains) > 0 {
+		table := output.NewWrappingTable(w, 20, 20)
+		table.Header([]string{"Domain", "IPs", "First Seen", "Last Seen", "Sources"})
+		if err := table.Bulk(r.rows()); err != nil {
+			return err
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+	return writeSharedTable(w, r.sharedRows())
+}
+
+// writeSharedTable renders the shared-hosting IPs, if any.
+func writeSharedTable(w io.Writer, rows [][]string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	table := output.NewWrappingTable(w, 20, 20)
+	table.Header([]string{"Shared IP", "Domains (filtered)"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
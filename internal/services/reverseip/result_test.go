@@ -0,0 +1,68 @@
+package reverseip_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/reverseip"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&reverseip.Result{Input: "192.0.2.1"}).IsEmpty())
+	assert.False(t, (&reverseip.Result{Input: "192.0.2.1", Domains: []reverseip.Domain{{Domain: "example.com", IPs: []string{"192.0.2.1"}}}}).IsEmpty())
+	assert.False(t, (&reverseip.Result{Input: "192.0.2.3", SharedIPs: []reverseip.SharedIP{{IP: "192.0.2.3", Domains: 500}}}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &reverseip.Result{
+		Input: "192.0.2.0/30",
+		Domains: []reverseip.Domain{
+			{Domain: "example.com", IPs: []string{"192.0.2.1"}},
+			{Domain: "example.org", IPs: []string{"192.0.2.2"}},
+		},
+		SharedIPs: []reverseip.SharedIP{{IP: "192.0.2.3", Domains: 812}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "example.com\nexample.org\n", buf.String(), "shared IPs are not listed")
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &reverseip.Result{
+		Input: "192.0.2.0/30",
+		Domains: []reverseip.Domain{
+			{Domain: "example.com", IPs: []string{"192.0.2.1", "192.0.2.2"}, FirstSeen: "2026-03-02T00:00:00Z", LastSeen: "2026-03-09T00:00:00Z", Sources: []string{"threatminer"}},
+			{Domain: "example.org", IPs: []string{"192.0.2.2"}, Sources: []string{"threatminer"}},
+		},
+		SharedIPs: []reverseip.SharedIP{{IP: "192.0.2.3", Domains: 812}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "example.com")
+	assert.Contains(t, out, "192.0.2.2")
+	assert.Contains(t, out, "threatminer")
+	assert.Contains(t, out, "SHARED IP")
+	assert.Contains(t, out, "812")
+}
+
+func TestResult_WriteTable_OnlySharedIPs(t *testing.T) {
+	result := &reverseip.Result{Input: "192.0.2.3", SharedIPs: []reverseip.SharedIP{{IP: "192.0.2.3", Domains: 812}}}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "SHARED IP")
+	assert.NotContains(t, out, "FIRST SEEN")
+}
+
+func TestResult_WriteTable_NoSharedIPs(t *testing.T) {
+	result := &reverseip.Result{Input: "192.0.2.1", Domains: []reverseip.Domain{{Domain: "example.com", IPs: []string{"192.0.2.1"}}}}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "example.com")
+	assert.NotContains(t, out, "SHARED IP")
+}
@@ -0,0 +1,232 @@
+package reverseip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// Name is the service identifier.
+	Name = "reverseip"
+	// PAP is the PAP activity level of the reverseip service: every current
+	// passive-DNS source is a third-party API.
+	PAP = pap.AMBER
+	// MaxAddresses caps the number of addresses a CIDR input may expand to
+	// (an IPv4 /24 or IPv6 /120).
+	MaxAddresses = 256
+)
+
+// Service merges passive-DNS sources into a reverse IP view.
+type Service struct {
+	logger    *slog.Logger
+	sources   []Source
+	threshold int
+}
+
+// NewService creates a new reverseip service querying every source in
+// sources. IPs with more than threshold distinct domains are treated as shared
+// hosting; a threshold of 0 disables the filter.
+func NewService(logger *slog.Logger, threshold int, sources []Source) *Service {
+	return &Service{logger: logger, sources: sources, threshold: threshold}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the reverseip service.
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple reverseip results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run queries every source for each address of the IP or CIDR input and
+// merges the domains. Source failures are logged; an error is returned only
+// when every query failed. Partial results are returned when ctx is cancelled.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	input = output.StripANSI(strings.TrimSpace(input))
+	ips, err := expand(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.sources) == 0 {
+		return nil, fmt.Errorf("%w: no passive-DNS source available", services.ErrRequestFailed)
+	}
+
+	result := &Result{Input: input}
+	domains := map[string]*domainAgg{}
+	var errs []error
+	succeeded := false
+	for _, ip := range ips {
+		if ctx.Err() != nil {
+			break
+		}
+		perIP := map[string]bool{}
+		for _, l := range s.lookupAll(ctx, ip) {
+			if l.err != nil {
+				s.logger.Debug("reverseip: source lookup failed", "source", l.source, "ip", ip, "error", l.err)
+				errs = append(errs, fmt.Errorf("%s: %w", l.source, l.err))
+				continue
+			}
+			succeeded = true
+			for _, rec := range l.records {
+				name := normalizeDomain(rec.Domain)
+				if name == "" {
+					continue
+				}
+				perIP[name] = true
+				agg := domains[name]
+				if agg == nil {
+					agg = &domainAgg{ips: map[string]bool{}, sources: map[string]bool{}}
+					domains[name] = agg
+				}
+				agg.ips[ip] = true
+				agg.sources[l.source] = true
+				agg.merge(rec.FirstSeen, rec.LastSeen)
+			}
+		}
+		if s.threshold > 0 && len(perIP) > s.threshold {
+			result.SharedIPs = append(result.SharedIPs, SharedIP{IP: ip, Domains: len(perIP)})
+			for name := range perIP {
+				delete(domains[name].ips, ip)
+			}
+		}
+	}
+	if !succeeded && len(errs) > 0 && ctx.Err() == nil {
+		return nil, errors.Join(errs...)
+	}
+
+	for name, agg := range domains {
+		if len(agg.ips) == 0 {
+			continue // only seen on shared-hosting IPs
+		}
+		result.Domains = append(result.Domains, Domain{
+			Domain:    name,
+			IPs:       sortedAddrs(agg.ips),
+			FirstSeen: formatTime(agg.first),
+			LastSeen:  formatTime(agg.last),
+			Sources:   sortedKeys(agg.sources),
+		})
+	}
+	slices.SortFunc(result.Domains, func(a, b Domain) int { return strings.Compare(a.Domain, b.Domain) })
+	return result, nil
+}
+
+// lookup is the outcome of one source query.
+type lookup struct {
+	source  string
+	records []Record
+	err     error
+}
+
+// lookupAll queries every source for ip concurrently.
+func (s *Service) lookupAll(ctx context.Context, ip string) []lookup {
+	out := make([]lookup, len(s.sources))
+	var wg sync.WaitGroup
+	for i, src := range s.sources {
+		wg.Go(func() {
+			records, err := src.Lookup(ctx, ip)
+			out[i] = lookup{source: src.Name(), records: records, err: err}
+		})
+	}
+	wg.Wait()
+	return out
+}
+
+// domainAgg accumulates the observations of one domain.
+type domainAgg struct {
+	first, last time.Time
+	ips         map[string]bool
+	sources     map[string]bool
+}
+
+// merge widens the observation window to include first and last.
+func (a *domainAgg) merge(first, last time.Time) {
+	if !first.IsZero() && (a.first.IsZero() || first.Before(a.first)) {
+		a.first = first
+	}
+	if !last.IsZero() && (a.last.IsZero() || last.After(a.last)) {
+		a.last = last
+	}
+}
+
+// expand returns the addresses of an IP or CIDR input.
+func expand(input string) ([]string, error) {
+	if addr, err := netip.ParseAddr(input); err == nil {
+		return []string{addr.Unmap().String()}, nil
+	}
+	prefix, err := netip.ParsePrefix(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: must be an IP address or CIDR: %q", services.ErrInvalidInput, input)
+	}
+	prefix = prefix.Masked()
+	if hostBits := prefix.Addr().BitLen() - prefix.Bits(); hostBits > 8 {
+		return nil, fmt.Errorf("%w: CIDR %q exceeds %d addresses", services.ErrInvalidInput, input, MaxAddresses)
+	}
+	var ips []string
+	for a := prefix.Addr(); prefix.Contains(a); a = a.Next() {
+		ips = append(ips, a.String())
+		if !a.Next().IsValid() {
+			break
+		}
+	}
+	return ips, nil
+}
+
+// normalizeDomain lowercases a domain and strips the trailing dot; invalid
+// names yield "".
+func normalizeDomain(d string) string {
+	d = strings.ToLower(strings.TrimSuffix(output.StripANSI(strings.TrimSpace(d)), "."))
+	if !services.IsDomain(d) {
+		return ""
+	}
+	return d
+}
+
+// sortedAddrs returns the IPs of set in address order.
+func sortedAddrs(set map[string]bool) []string {
+	addrs := make([]netip.Addr, 0, len(set))
+	for ip := range set {
+		addrs = append(addrs, netip.MustParseAddr(ip))
+	}
+	slices.SortFunc(addrs, netip.Addr.Compare)
+	out := make([]string, len(addrs))
+	for i, a := range addrs {
+		out[i] = a.String()
+	}
+	return out
+}
+
+// sortedKeys returns the keys of set in lexical order.
+func sortedKeys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// formatTime renders t as RFC 3339, or "" when unknown.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,210 @@
+package reverseip_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/pdns"
+	"github.com/tbckr/trident/internal/services/reverseip"
+	"github.com/tbckr/trident/internal/services/threatminer"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+// fakeSource returns canned records per IP and records the IPs it was asked for.
+type fakeSource struct {
+	name    string
+	records map[string][]reverseip.Record
+	err     error
+
+	mu      sync.Mutex
+	queried []string
+}
+
+func (f *fakeSource) Name() string   { return f.name }
+func (f *fakeSource) PAP() pap.Level { return pap.AMBER }
+
+func (f *fakeSource) Lookup(_ context.Context, ip string) ([]reverseip.Record, error) {
+	f.mu.Lock()
+	f.queried = append(f.queried, ip)
+	f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.records[ip], nil
+}
+
+func day(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+
+func TestRun_MergesSources(t *testing.T) {
+	a := &fakeSource{name: "a", records: map[string][]reverseip.Record{
+		"192.0.2.1": {
+			{Domain: "Example.com.", FirstSeen: day(5), LastSeen: day(9)},
+			{Domain: "shop.example.net"},
+		},
+	}}
+	b := &fakeSource{name: "b", records: map[string][]reverseip.Record{
+		"192.0.2.1": {
+			{Domain: "example.com", FirstSeen: day(2), LastSeen: day(7)},
+			{Domain: "not a domain"},
+		},
+	}}
+
+	svc := reverseip.NewService(testutil.NopLogger(), 0, []reverseip.Source{a, b})
+	raw, err := svc.Run(context.Background(), "192.0.2.1")
+	require.NoError(t, err)
+	result, ok := raw.(*reverseip.Result)
+	require.True(t, ok, "expected *reverseip.Result")
+
+	require.Len(t, result.Domains, 2)
+	assert.Equal(t, reverseip.Domain{
+		Domain:    "example.com",
+		IPs:       []string{"192.0.2.1"},
+		FirstSeen: "2026-03-02T00:00:00Z",
+		LastSeen:  "2026-03-09T00:00:00Z",
+		Sources:   []string{"a", "b"},
+	}, result.Domains[0])
+	assert.Equal(t, "shop.example.net", result.Domains[1].Domain)
+	assert.Empty(t, result.SharedIPs)
+}
+
+func TestRun_CIDR(t *testing.T) {
+	src := &fakeSource{name: "a", records: map[string][]reverseip.Record{
+		"192.0.2.1": {{Domain: "example.com"}},
+		"192.0.2.3": {{Domain: "example.com"}, {Domain: "example.org"}},
+	}}
+	svc := reverseip.NewService(testutil.NopLogger(), 0, []reverseip.Source{src})
+	raw, err := svc.Run(context.Background(), "192.0.2.1/30")
+	require.NoError(t, err)
+	result := raw.(*reverseip.Result)
+
+	assert.ElementsMatch(t, []string{"192.0.2.0", "192.0.2.1", "192.0.2.2", "192.0.2.3"}, src.queried)
+	require.Len(t, result.Domains, 2)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.3"}, result.Domains[0].IPs)
+}
+
+func TestRun_SharedHostingFilter(t *testing.T) {
+	busy := make([]reverseip.Record, 0, 4)
+	for i := range 4 {
+		busy = append(busy, reverseip.Record{Domain: fmt.Sprintf("site%d.example", i)})
+	}
+	busy = append(busy, reverseip.Record{Domain: "target.example"})
+	src := &fakeSource{name: "a", records: map[string][]reverseip.Record{
+		"192.0.2.1": busy,
+		"192.0.2.2": {{Domain: "target.example"}},
+	}}
+
+	svc := reverseip.NewService(testutil.NopLogger(), 3, []reverseip.Source{src})
+	raw, err := svc.Run(context.Background(), "192.0.2.0/30")
+	require.NoError(t, err)
+	result := raw.(*reverseip.Result)
+
+	assert.Equal(t, []reverseip.SharedIP{{IP: "192.0.2.1", Domains: 5}}, result.SharedIPs)
+	require.Len(t, result.Domains, 1, "domains only seen on shared IPs are dropped")
+	assert.Equal(t, "target.example", result.Domains[0].Domain)
+	assert.Equal(t, []string{"192.0.2.2"}, result.Domains[0].IPs)
+}
+
+func TestRun_PartialSourceFailure(t *testing.T) {
+	ok := &fakeSource{name: "ok", records: map[string][]reverseip.Record{"192.0.2.1": {{Domain: "example.com"}}}}
+	bad := &fakeSource{name: "bad", err: errors.New("boom")}
+	svc := reverseip.NewService(testutil.NopLogger(), 0, []reverseip.Source{ok, bad})
+
+	raw, err := svc.Run(context.Background(), "192.0.2.1")
+	require.NoError(t, err)
+	assert.Len(t, raw.(*reverseip.Result).Domains, 1)
+}
+
+func TestRun_AllSourcesFail(t *testing.T) {
+	bad := &fakeSource{name: "bad", err: fmt.Errorf("%w: HTTP 500", services.ErrRequestFailed)}
+	svc := reverseip.NewService(testutil.NopLogger(), 0, []reverseip.Source{bad})
+
+	_, err := svc.Run(context.Background(), "192.0.2.1")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	svc := reverseip.NewService(testutil.NopLogger(), 0, []reverseip.Source{&fakeSource{name: "a"}})
+	for _, input := range []string{"", "example.com", "192.0.2.0/23", "2001:db8::/64", "AS15169"} {
+		_, err := svc.Run(context.Background(), input)
+		require.ErrorIs(t, err, services.ErrInvalidInput, input)
+	}
+}
+
+func TestRun_ThreatMinerSource(t *testing.T) {
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	httpmock.RegisterResponder(http.MethodGet, "https://api.threatminer.org/v2/host.php?q=93.184.216.34&rt=2",
+		httpmock.NewStringResponder(http.StatusOK, `{"status_code":"200","status_message":"Results found.","results":[
+			{"ip":"93.184.216.34","domain":"example.com","first_seen":"2021-01-01 00:00:00","last_seen":"2024-01-01 00:00:00"},
+			{"ip":"93.184.216.34","domain":"www.example.com","first_seen":"2022-06-01 12:30:00","last_seen":"2022-07-01 00:00:00"}]}`))
+
+	src := reverseip.NewThreatMinerSource(threatminer.NewService(client, testutil.NopLogger()))
+	assert.Equal(t, "threatminer", src.Name())
+	assert.Equal(t, pap.AMBER, src.PAP())
+
+	svc := reverseip.NewService(testutil.NopLogger(), 0, []reverseip.Source{src})
+	raw, err := svc.Run(context.Background(), "93.184.216.34")
+	require.NoError(t, err)
+	result := raw.(*reverseip.Result)
+	require.Len(t, result.Domains, 2)
+	assert.Equal(t, "2021-01-01T00:00:00Z", result.Domains[0].FirstSeen)
+	assert.Equal(t, "2024-01-01T00:00:00Z", result.Domains[0].LastSeen)
+	assert.Equal(t, []string{"threatminer"}, result.Domains[0].Sources)
+}
+
+func TestRun_PDNSSource(t *testing.T) {
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	httpmock.RegisterResponder(http.MethodGet,
+		"https://api.mnemonic.no/pdns/v3/192.0.2.1?limit=1000",
+		httpmock.NewStringResponder(http.StatusOK, `{"responseCode":200,"data":[
+			{"query":"www.example.com","answer":"192.0.2.1","rrtype":"a","firstSeenTimestamp":1609459200000,"lastSeenTimestamp":1704067200000},
+			{"query":"example.com","answer":"192.0.2.99","rrtype":"a"},
+			{"query":"1.2.0.192.in-addr.arpa","answer":"host.example.net","rrtype":"ptr"}
+		]}`),
+	)
+
+	src := reverseip.NewPDNSSource(pdns.NewMnemonicSource(client))
+	svc := reverseip.NewService(testutil.NopLogger(), 0, []reverseip.Source{src})
+	raw, err := svc.Run(context.Background(), "192.0.2.1")
+	require.NoError(t, err)
+	result := raw.(*reverseip.Result)
+	require.Len(t, result.Domains, 1, "only A/AAAA records pointing at the IP are kept")
+	assert.Equal(t, "www.example.com", result.Domains[0].Domain)
+	assert.Equal(t, "2021-01-01T00:00:00Z", result.Domains[0].FirstSeen)
+	assert.Equal(t, []string{"mnemonic"}, result.Domains[0].Sources)
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := reverseip.NewService(testutil.NopLogger(), 0, nil)
+	assert.Equal(t, "reverseip", svc.Name())
+	assert.Equal(t, pap.AMBER, svc.PAP())
+
+	_, err := svc.Run(context.Background(), "192.0.2.1")
+	require.ErrorIs(t, err, services.ErrRequestFailed, "no sources configured")
+}
+
+func TestService_AggregateResults(t *testing.T) {
+	svc := reverseip.NewService(testutil.NopLogger(), 0, nil)
+	agg := svc.AggregateResults([]services.Result{
+		&reverseip.Result{Input: "192.0.2.1"},
+		&reverseip.Result{Input: "192.0.2.2"},
+	})
+	mr, ok := agg.(*reverseip.MultiResult)
+	require.True(t, ok, "expected *reverseip.MultiResult")
+	assert.Len(t, mr.Results, 2)
+}
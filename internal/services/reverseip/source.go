@@ -0,0 +1,119 @@
+package reverseip
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/pdns"
+	"github.com/tbckr/trident/internal/services/threatminer"
+)
+
+// Record is one passive-DNS observation of a domain resolving to an IP.
+type Record struct {
+	Domain    string
+	FirstSeen time.Time // zero when unknown
+	LastSeen  time.Time // zero when unknown
+}
+
+// Source is a passive-DNS backend that lists the domains seen on an IP.
+// Implementations return (nil, nil) when the source has no data for the IP.
+type Source interface {
+	Name() string
+	PAP() pap.Level
+	Lookup(ctx context.Context, ip string) ([]Record, error)
+}
+
+// threatMinerTimeLayout is the timestamp format of ThreatMiner passive DNS.
+const threatMinerTimeLayout = "2006-01-02 15:04:05"
+
+// threatMinerSource adapts the ThreatMiner host passive-DNS endpoint.
+type threatMinerSource struct {
+	svc *threatminer.Service
+}
+
+// NewThreatMinerSource returns a Source backed by ThreatMiner's IP passive DNS.
+func NewThreatMinerSource(svc *threatminer.Service) Source {
+	return threatMinerSource{svc: svc}
+}
+
+// Name returns the source identifier.
+func (s threatMinerSource) Name() string { return threatminer.Name }
+
+// PAP returns the PAP activity level of the ThreatMiner API.
+func (s threatMinerSource) PAP() pap.Level { return threatminer.PAP }
+
+// Lookup returns the passive-DNS domains ThreatMiner has seen on ip.
+func (s threatMinerSource) Lookup(ctx context.Context, ip string) ([]Record, error) {
+	raw, err := s.svc.Run(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := raw.(*threatminer.Result)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected ThreatMiner result %T", services.ErrRequestFailed, raw)
+	}
+	records := make([]Record, 0, len(res.PassiveDNS))
+	for _, e := range res.PassiveDNS {
+		records = append(records, Record{
+			Domain:    e.Domain,
+			FirstSeen: parseTime(threatMinerTimeLayout, e.FirstSeen),
+			LastSeen:  parseTime(threatMinerTimeLayout, e.LastSeen),
+		})
+	}
+	return records, nil
+}
+
+// pdnsSource adapts a pdns.Source, keeping the A and AAAA records that point
+// at the queried IP.
+type pdnsSource struct {
+	src pdns.Source
+}
+
+// NewPDNSSource returns a Source backed by a passive DNS source of the pdns
+// service, such as Mnemonic or a COF endpoint.
+func NewPDNSSource(src pdns.Source) Source {
+	return pdnsSource{src: src}
+}
+
+// Name returns the source identifier of the wrapped source.
+func (s pdnsSource) Name() string { return s.src.Name() }
+
+// PAP returns the PAP activity level of the wrapped source.
+func (s pdnsSource) PAP() pap.Level { return s.src.PAP() }
+
+// Lookup returns the names the wrapped source has seen resolving to ip.
+func (s pdnsSource) Lookup(ctx context.Context, ip string) ([]Record, error) {
+	obs, err := s.src.Lookup(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+	want, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", services.ErrInvalidInput, ip)
+	}
+	var records []Record
+	for _, o := range obs {
+		if rrtype := strings.ToUpper(o.RRType); rrtype != "A" && rrtype != "AAAA" {
+			continue
+		}
+		if addr, err := netip.ParseAddr(strings.TrimSpace(o.RData)); err != nil || addr.Unmap() != want.Unmap() {
+			continue
+		}
+		records = append(records, Record{Domain: o.RRName, FirstSeen: o.FirstSeen, LastSeen: o.LastSeen})
+	}
+	return records, nil
+}
+
+// parseTime parses v in layout as UTC, returning the zero time on failure.
+func parseTime(layout, v string) time.Time {
+	t, err := time.Parse(layout, v)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
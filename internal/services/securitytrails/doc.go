@@ -0,0 +1,3 @@
+// Package securitytrails provides a keyed service for SecurityTrails
+// subdomain enumeration.
+package securitytrails
@@ -0,0 +1,29 @@
+package securitytrails
+
+import (
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds SecurityTrails results for multiple domains.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteTable renders all subdomains grouped by input domain.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows [][]string
+	for _, r := range m.Results {
+		for _, s := range r.Subdomains {
+			rows = append(rows, []string{r.Input, s})
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 30, 20)
+	table.Header([]string{"Domain", "Subdomain"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
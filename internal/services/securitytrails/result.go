@@ -0,0 +1,43 @@
+package securitytrails
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Result holds the subdomains SecurityTrails knows for one domain.
+type Result struct {
+	Input      string   `json:"input"`
+	Subdomains []string `json:"subdomains,omitempty"`
+}
+
+// IsEmpty reports whether no subdomains were found.
+func (r *Result) IsEmpty() bool {
+	return len(r.Subdomains) == 0
+}
+
+// WriteText writes one subdomain per line.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, s := range r.Subdomains {
+		if _, err := fmt.Fprintln(w, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the subdomains as a single-column table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 30, 6)
+	table.Header([]string{"Subdomain"})
+	rows := make([][]string, 0, len(r.Subdomains))
+	for _, s := range r.Subdomains {
+		rows = append(rows, []string{s})
+	}
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
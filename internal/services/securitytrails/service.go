@@ -0,0 +1,118 @@
+package securitytrails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	baseURL = "https://api.securitytrails.com/v1"
+
+	// DefaultRPS is the target request rate for the SecurityTrails API.
+	DefaultRPS float64 = 1.0
+	// DefaultBurst is the burst capacity above DefaultRPS.
+	DefaultBurst = 1
+
+	// Name is the service identifier.
+	Name = "securitytrails"
+	// PAP is the PAP activity level for the SecurityTrails service.
+	PAP = pap.AMBER
+)
+
+// subdomainsResponse is the body of /domain/{domain}/subdomains: labels
+// relative to the queried domain.
+type subdomainsResponse struct {
+	Subdomains []string `json:"subdomains"`
+}
+
+// Service queries the SecurityTrails API.
+type Service struct {
+	client *req.Client
+	logger *slog.Logger
+	apiKey string
+}
+
+// NewService creates a new SecurityTrails service authenticating with apiKey.
+func NewService(client *req.Client, logger *slog.Logger, apiKey string) *Service {
+	return &Service{client: client, logger: logger, apiKey: apiKey}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP classification for this service.
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple SecurityTrails results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run lists the subdomains SecurityTrails knows for the domain.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("%w for %s", services.ErrMissingAPIKey, Name)
+	}
+	input = output.StripANSI(strings.TrimSpace(input))
+	domain := strings.ToLower(strings.TrimSuffix(input, "."))
+	if !services.IsDomain(domain) {
+		return nil, fmt.Errorf("%w: must be a valid domain name: %q", services.ErrInvalidInput, input)
+	}
+
+	result := &Result{Input: input}
+	var body subdomainsResponse
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetHeader("APIKEY", s.apiKey).
+		SetSuccessResult(&body).
+		Get(baseURL + "/domain/" + domain + "/subdomains")
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("%w: %s", services.ErrRequestFailed, err)
+	}
+	if resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+		return result, nil
+	}
+	if resp.Response != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return nil, fmt.Errorf("%w: HTTP %d: SecurityTrails rejected the API key", services.ErrRequestFailed, resp.StatusCode)
+	}
+	if resp.Response == nil || !resp.IsSuccessState() {
+		text := resp.String()
+		if len(text) > 200 {
+			text = text[:200] + "..."
+		}
+		return nil, fmt.Errorf("%w: HTTP %d: %q", services.ErrRequestFailed, resp.StatusCode, text)
+	}
+
+	seen := map[string]bool{}
+	for _, label := range body.Subdomains {
+		label = strings.ToLower(strings.Trim(output.StripANSI(strings.TrimSpace(label)), "."))
+		if label == "" {
+			continue
+		}
+		fqdn := label + "." + domain
+		if !seen[fqdn] {
+			seen[fqdn] = true
+			result.Subdomains = append(result.Subdomains, fqdn)
+		}
+	}
+	slices.Sort(result.Subdomains)
+	return result, nil
+}
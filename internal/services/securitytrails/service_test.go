@@ -0,0 +1,91 @@
+package securitytrails_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/securitytrails"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+const subdomainsURL = "https://api.securitytrails.com/v1/domain/example.com/subdomains"
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func TestRun_Subdomains(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, subdomainsURL,
+		func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "test-key", r.Header.Get("APIKEY"))
+			return httpmock.NewStringResponse(http.StatusOK,
+				`{"subdomains":["www","mail","WWW",""],"subdomain_count":3}`), nil
+		})
+
+	svc := securitytrails.NewService(client, testutil.NopLogger(), "test-key")
+	raw, err := svc.Run(context.Background(), "Example.com.")
+	require.NoError(t, err)
+	result := raw.(*securitytrails.Result)
+	assert.Equal(t, []string{"mail.example.com", "www.example.com"}, result.Subdomains)
+
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "mail.example.com\nwww.example.com\n", buf.String())
+}
+
+func TestRun_NotFound(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, subdomainsURL,
+		httpmock.NewStringResponder(http.StatusNotFound, `{"message":"not found"}`))
+
+	svc := securitytrails.NewService(client, testutil.NopLogger(), "test-key")
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestRun_BadKey(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, subdomainsURL,
+		httpmock.NewStringResponder(http.StatusForbidden, `{"message":"Invalid authentication credentials"}`))
+
+	svc := securitytrails.NewService(client, testutil.NopLogger(), "wrong")
+	_, err := svc.Run(context.Background(), "example.com")
+	assert.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_MissingKeyAndInvalidInput(t *testing.T) {
+	_, err := securitytrails.NewService(req.NewClient(), testutil.NopLogger(), "").Run(context.Background(), "example.com")
+	assert.ErrorIs(t, err, services.ErrMissingAPIKey)
+
+	_, err = securitytrails.NewService(req.NewClient(), testutil.NopLogger(), "k").Run(context.Background(), "192.0.2.1")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := securitytrails.NewService(req.NewClient(), testutil.NopLogger(), "k")
+	assert.Equal(t, "securitytrails", svc.Name())
+	assert.Equal(t, pap.AMBER, svc.PAP())
+
+	agg := svc.AggregateResults([]services.Result{
+		&securitytrails.Result{Input: "a.com", Subdomains: []string{"www.a.com"}},
+		&securitytrails.Result{Input: "b.com", Subdomains: []string{"mail.b.com"}},
+	})
+	var buf bytes.Buffer
+	require.NoError(t, agg.(*securitytrails.MultiResult).WriteTable(&buf))
+	assert.Contains(t, buf.String(), "mail.b.com")
+}
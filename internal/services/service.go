@@ -20,6 +20,9 @@ var ErrRequestFailed = apperr.ErrRequestFailed
 // ErrPAPBlocked is re-exported from apperr for backward compatibility.
 var ErrPAPBlocked = apperr.ErrPAPBlocked
 
+// ErrMissingAPIKey is re-exported from apperr for keyed services.
+var ErrMissingAPIKey = apperr.ErrMissingAPIKey
+
 // Result is the common interface every service's Run output must satisfy.
 type Result interface {
 	IsEmpty() bool
@@ -40,3 +43,13 @@ type AggregateService interface {
 	Service
 	MinPAP() pap.Level
 }
+
+// BatchService is implemented by services that can answer many inputs in a single
+// upstream session (e.g. a bulk whois interface). RunBatch returns one entry per
+// input in input order; a nil entry means the input was not handled and the caller
+// must fall back to Run for it. Implementations decide when batching pays off and
+// return all-nil entries to decline.
+type BatchService interface {
+	Service
+	RunBatch(ctx context.Context, inputs []string) []Result
+}
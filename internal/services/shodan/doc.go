@@ -0,0 +1,3 @@
+// Package shodan provides a keyed service for Shodan host lookups: open
+// ports, service banners, and known vulnerabilities of an IP address.
+package shodan
@@ -0,0 +1,44 @@
+package shodan
+
+import (
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds Shodan results for multiple IP addresses.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteTable renders the host fields of all results grouped by input,
+// followed by a combined services table when any host has services.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows, svcRows [][]string
+	for _, r := range m.Results {
+		for _, row := range r.rows() {
+			rows = append(rows, append([]string{r.Input}, row...))
+		}
+		for _, row := range r.serviceRows() {
+			svcRows = append(svcRows, append([]string{r.Input}, row...))
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 20, 30)
+	table.Header([]string{"Input", "Field", "Value"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+	if len(svcRows) == 0 {
+		return nil
+	}
+	svcTable := output.NewGroupedWrappingTable(w, 20, 30)
+	svcTable.Header([]string{"Input", "Port", "Transport", "Product", "Version"})
+	if err := svcTable.Bulk(svcRows); err != nil {
+		return err
+	}
+	return svcTable.Render()
+}
@@ -0,0 +1,113 @@
+package shodan
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Banner is one service Shodan observed on the host.
+type Banner struct {
+	Port      int    `json:"port"`
+	Transport string `json:"transport,omitempty"` // tcp | udp
+	Product   string `json:"product,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
+
+// Result holds Shodan's view of one IP address.
+type Result struct {
+	Input      string   `json:"input"`
+	Found      bool     `json:"found"`
+	Org        string   `json:"org,omitempty"`
+	ISP        string   `json:"isp,omitempty"`
+	ASN        string   `json:"asn,omitempty"`
+	Country    string   `json:"country,omitempty"` // ISO 3166-1 alpha-2
+	City       string   `json:"city,omitempty"`
+	OS         string   `json:"os,omitempty"`
+	Hostnames  []string `json:"hostnames,omitempty"`
+	Domains    []string `json:"domains,omitempty"`
+	Ports      []int    `json:"ports,omitempty"`
+	Vulns      []string `json:"vulns,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	LastUpdate string   `json:"last_update,omitempty"`
+	Services   []Banner `json:"services,omitempty"`
+}
+
+// IsEmpty reports whether Shodan has no data for the IP.
+func (r *Result) IsEmpty() bool {
+	return !r.Found
+}
+
+// rows returns the non-empty host fields as Field/Value pairs.
+func (r *Result) rows() [][]string {
+	ports := make([]string, len(r.Ports))
+	for i, p := range r.Ports {
+		ports[i] = strconv.Itoa(p)
+	}
+	fields := [][]string{
+		{"Org", r.Org},
+		{"ISP", r.ISP},
+		{"ASN", r.ASN},
+		{"Country", r.Country},
+		{"City", r.City},
+		{"OS", r.OS},
+		{"Hostnames", strings.Join(r.Hostnames, "\n")},
+		{"Domains", strings.Join(r.Domains, "\n")},
+		{"Ports", strings.Join(ports, ", ")},
+		{"Vulns", strings.Join(r.Vulns, ", ")},
+		{"Tags", strings.Join(r.Tags, ", ")},
+		{"Last Update", r.LastUpdate},
+	}
+	var rows [][]string
+	for _, f := range fields {
+		if f[1] != "" {
+			rows = append(rows, f)
+		}
+	}
+	return rows
+}
+
+// serviceRows returns the Port / Transport / Product / Version cells.
+func (r *Result) serviceRows() [][]string {
+	rows := make([][]string, 0, len(r.Services))
+	for _, b := range r.Services {
+		rows = append(rows, []string{strconv.Itoa(b.Port), b.Transport, b.Product, b.Version})
+	}
+	return rows
+}
+
+// WriteText renders one "<ip> <port>/<transport> <product> <version>" line
+// per service.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, b := range r.Services {
+		line := strings.TrimSpace(fmt.Sprintf("%s %d/%s %s %s", r.Input, b.Port, b.Transport, b.Product, b.Version))
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the host fields, followed by the observed services.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 20)
+	table.Header([]string{"Field", "Value"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+	if len(r.Services) == 0 {
+		return nil
+	}
+	svcTable := output.NewWrappingTable(w, 20, 20)
+	svcTable.Header([]string{"Port", "Transport", "Product", "Version"})
+	if err := svcTable.Bulk(r.serviceRows()); err != nil {
+		return err
+	}
+	return svcTable.Render()
+}
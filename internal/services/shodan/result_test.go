@@ -0,0 +1,84 @@
+package shodan_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/shodan"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&shodan.Result{Input: "192.0.2.10"}).IsEmpty())
+	assert.False(t, (&shodan.Result{Input: "192.0.2.10", Found: true}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &shodan.Result{
+		Input: "192.0.2.10",
+		Found: true,
+		Services: []shodan.Banner{
+			{Port: 22, Transport: "tcp", Product: "OpenSSH", Version: "9.6"},
+			{Port: 80, Transport: "tcp", Product: "nginx"},
+			{Port: 443, Transport: "tcp"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "192.0.2.10 22/tcp OpenSSH 9.6\n192.0.2.10 80/tcp nginx\n192.0.2.10 443/tcp\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &shodan.Result{
+		Input: "192.0.2.10",
+		Found: true,
+		Org:   "Example Hosting",
+		Ports: []int{22, 443},
+		Vulns: []string{"CVE-2023-48795"},
+		Services: []shodan.Banner{
+			{Port: 22, Transport: "tcp", Product: "OpenSSH", Version: "9.6"},
+			{Port: 443, Transport: "tcp"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "22, 443")
+	assert.Contains(t, out, "CVE-2023-48795")
+	assert.Contains(t, out, "OpenSSH")
+	assert.Contains(t, out, "TRANSPORT")
+}
+
+func TestResult_WriteTable_NoServices(t *testing.T) {
+	result := &shodan.Result{Input: "192.0.2.10", Found: true, Org: "Example Hosting", Country: "DE"}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "Example Hosting")
+	assert.NotContains(t, out, "TRANSPORT")
+	assert.NotContains(t, out, "Ports", "empty fields are omitted")
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	m := &shodan.MultiResult{}
+	m.Results = []*shodan.Result{
+		{Input: "192.0.2.10", Found: true, Org: "Example Hosting", Services: []shodan.Banner{{Port: 22, Transport: "tcp", Product: "OpenSSH"}}},
+		{Input: "192.0.2.11", Found: true, Org: "Other"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "192.0.2.11")
+	assert.Contains(t, out, "Other")
+	assert.Contains(t, out, "OpenSSH")
+}
+
+func TestMultiResult_WriteTable_NoServices(t *testing.T) {
+	m := &shodan.MultiResult{}
+	m.Results = []*shodan.Result{{Input: "192.0.2.11", Found: true, Org: "Other"}}
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteTable(&buf))
+	assert.NotContains(t, buf.String(), "TRANSPORT")
+}
@@ -0,0 +1,171 @@
+package shodan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	baseURL = "https://api.shodan.io"
+
+	// DefaultRPS is the target request rate for the Shodan API.
+	DefaultRPS float64 = 1.0
+	// DefaultBurst is the burst capacity above DefaultRPS.
+	DefaultBurst = 1
+
+	// Name is the service identifier.
+	Name = "shodan"
+	// PAP is the PAP activity level for the Shodan service (Shodan's own
+	// scan data; the target is not contacted).
+	PAP = pap.AMBER
+)
+
+// hostResponse is the subset of /shodan/host/{ip} decoded by trident.
+type hostResponse struct {
+	IPStr       string   `json:"ip_str"`
+	Org         string   `json:"org"`
+	ISP         string   `json:"isp"`
+	ASN         string   `json:"asn"`
+	CountryCode string   `json:"country_code"`
+	City        string   `json:"city"`
+	OS          string   `json:"os"`
+	Hostnames   []string `json:"hostnames"`
+	Domains     []string `json:"domains"`
+	Ports       []int    `json:"ports"`
+	Vulns       []string `json:"vulns"`
+	Tags        []string `json:"tags"`
+	LastUpdate  string   `json:"last_update"`
+	Data        []struct {
+		Port      int    `json:"port"`
+		Transport string `json:"transport"`
+		Product   string `json:"product"`
+		Version   string `json:"version"`
+	} `json:"data"`
+}
+
+// Service queries the Shodan host API.
+type Service struct {
+	client *req.Client
+	logger *slog.Logger
+	apiKey string
+}
+
+// NewService creates a new Shodan service authenticating with apiKey.
+func NewService(client *req.Client, logger *slog.Logger, apiKey string) *Service {
+	return &Service{client: client, logger: logger, apiKey: apiKey}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP classification for this service.
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple Shodan results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run looks up the IP address in Shodan. An IP Shodan has not seen yields an
+// empty result.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("%w for %s", services.ErrMissingAPIKey, Name)
+	}
+	input = output.StripANSI(strings.TrimSpace(input))
+	addr, err := netip.ParseAddr(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: must be a valid IP address: %q", services.ErrInvalidInput, input)
+	}
+
+	var host hostResponse
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetQueryParam("key", s.apiKey).
+		SetSuccessResult(&host).
+		Get(baseURL + "/shodan/host/" + addr.Unmap().String())
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return &Result{Input: input}, nil
+		}
+		// The request URL carries the key; never include it in the error.
+		return nil, fmt.Errorf("%w: Shodan request failed", services.ErrRequestFailed)
+	}
+	if resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+		return &Result{Input: input}, nil
+	}
+	if resp.Response != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return nil, fmt.Errorf("%w: HTTP %d: Shodan rejected the API key", services.ErrRequestFailed, resp.StatusCode)
+	}
+	if resp.Response == nil || !resp.IsSuccessState() {
+		body := resp.String()
+		if len(body) > 200 {
+			body = body[:200] + "..."
+		}
+		return nil, fmt.Errorf("%w: HTTP %d: %q", services.ErrRequestFailed, resp.StatusCode, body)
+	}
+	return newResult(input, &host), nil
+}
+
+// newResult flattens a Shodan host response into a Result.
+func newResult(input string, h *hostResponse) *Result {
+	r := &Result{
+		Input:      input,
+		Found:      true,
+		Org:        output.StripANSI(h.Org),
+		ISP:        output.StripANSI(h.ISP),
+		ASN:        output.StripANSI(h.ASN),
+		Country:    output.StripANSI(h.CountryCode),
+		City:       output.StripANSI(h.City),
+		OS:         output.StripANSI(h.OS),
+		Hostnames:  stripAll(h.Hostnames),
+		Domains:    stripAll(h.Domains),
+		Ports:      slices.Sorted(slices.Values(h.Ports)),
+		Vulns:      stripAll(h.Vulns),
+		Tags:       stripAll(h.Tags),
+		LastUpdate: output.StripANSI(h.LastUpdate),
+	}
+	slices.Sort(r.Vulns)
+	for _, d := range h.Data {
+		r.Services = append(r.Services, Banner{
+			Port:      d.Port,
+			Transport: output.StripANSI(d.Transport),
+			Product:   output.StripANSI(d.Product),
+			Version:   output.StripANSI(d.Version),
+		})
+	}
+	slices.SortFunc(r.Services, func(a, b Banner) int {
+		if a.Port != b.Port {
+			return a.Port - b.Port
+		}
+		return strings.Compare(a.Transport, b.Transport)
+	})
+	return r
+}
+
+func stripAll(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = output.StripANSI(v)
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package shodan_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/shodan"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func TestRun_Host(t *testing.T) {
+	client := newTestClient(t)
+	data, err := os.ReadFile("testdata/host.json")
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodGet, "https://api.shodan.io/shodan/host/192.0.2.10?key=test-key",
+		httpmock.NewBytesResponder(http.StatusOK, data))
+
+	svc := shodan.NewService(client, testutil.NopLogger(), "test-key")
+	raw, err := svc.Run(context.Background(), "192.0.2.10")
+	require.NoError(t, err)
+	result := raw.(*shodan.Result)
+
+	assert.True(t, result.Found)
+	assert.Equal(t, "AS64500", result.ASN)
+	assert.Equal(t, []int{22, 80, 443}, result.Ports)
+	assert.Equal(t, []string{"CVE-2021-44228", "CVE-2023-44487"}, result.Vulns)
+	require.Len(t, result.Services, 3)
+	assert.Equal(t, shodan.Banner{Port: 22, Transport: "tcp", Product: "OpenSSH", Version: "9.6"}, result.Services[0])
+}
+
+func TestRun_NotFound(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://api.shodan.io/shodan/host/192.0.2.11?key=test-key",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"error": "No information available for that IP."}`))
+
+	svc := shodan.NewService(client, testutil.NopLogger(), "test-key")
+	raw, err := svc.Run(context.Background(), "192.0.2.11")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestRun_BadKey(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://api.shodan.io/shodan/host/192.0.2.10?key=wrong",
+		httpmock.NewStringResponder(http.StatusUnauthorized, `{"error": "Invalid API key"}`))
+
+	svc := shodan.NewService(client, testutil.NopLogger(), "wrong")
+	_, err := svc.Run(context.Background(), "192.0.2.10")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+	assert.NotContains(t, err.Error(), "wrong", "the key must not leak into errors")
+}
+
+func TestRun_NetworkErrorDoesNotLeakKey(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://api.shodan.io/shodan/host/192.0.2.10?key=s3cret",
+		httpmock.NewErrorResponder(assert.AnError))
+
+	svc := shodan.NewService(client, testutil.NopLogger(), "s3cret")
+	_, err := svc.Run(context.Background(), "192.0.2.10")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+	assert.NotContains(t, err.Error(), "s3cret")
+}
+
+func TestRun_MissingKeyAndInvalidInput(t *testing.T) {
+	_, err := shodan.NewService(req.NewClient(), testutil.NopLogger(), "").Run(context.Background(), "192.0.2.10")
+	assert.ErrorIs(t, err, services.ErrMissingAPIKey)
+
+	_, err = shodan.NewService(req.NewClient(), testutil.NopLogger(), "k").Run(context.Background(), "example.com")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := shodan.NewService(req.NewClient(), testutil.NopLogger(), "k")
+	assert.Equal(t, "shodan", svc.Name())
+	assert.Equal(t, pap.AMBER, svc.PAP())
+	agg := svc.AggregateResults([]services.Result{&shodan.Result{Input: "a"}})
+	_, ok := agg.(*shodan.MultiResult)
+	assert.True(t, ok)
+}
@@ -0,0 +1,3 @@
+// Package spf resolves SPF include trees via Quad9 DoH and flattens them to
+// the authorized IPv4/IPv6 networks.
+package spf
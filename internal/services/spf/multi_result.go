@@ -0,0 +1,54 @@
+package spf
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds SPF results for multiple domains.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteTable renders every include tree in a single table grouped by domain,
+// followed by a per-domain summary of lookup counts and flattened networks.
+// Columns: Domain / Term / Provider / Networks / Note.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows, summary [][]string
+	for _, r := range m.Results {
+		if r.Tree == nil {
+			continue
+		}
+		for _, tr := range treeRows(r.Tree) {
+			rows = append(rows, []string{r.Input, tr.term, tr.provider, tr.networks, tr.note})
+		}
+		lookups := fmt.Sprintf("%d / %d", r.DNSLookups, LookupLimit)
+		if r.LookupLimitExceeded {
+			lookups += " (exceeded)"
+		}
+		summary = append(summary, []string{
+			r.Input,
+			lookups,
+			fmt.Sprintf("%d / %d", r.VoidLookups, VoidLookupLimit),
+			fmt.Sprintf("%d", len(r.IPv4)),
+			fmt.Sprintf("%d", len(r.IPv6)),
+		})
+	}
+	tree := output.NewGroupedWrappingTable(w, 20, 50)
+	tree.Header([]string{"Domain", "Term", "Provider", "Networks", "Note"})
+	if err := tree.Bulk(rows); err != nil {
+		return err
+	}
+	if err := tree.Render(); err != nil {
+		return err
+	}
+	table := output.NewWrappingTable(w, 20, 40)
+	table.Header([]string{"Domain", "DNS Lookups", "Void Lookups", "IPv4", "IPv6"})
+	if err := table.Bulk(summary); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,81 @@
+package spf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/spf"
+)
+
+func TestMultiResult_IsEmpty(t *testing.T) {
+	t.Run("empty when no results", func(t *testing.T) {
+		assert.True(t, (&spf.MultiResult{}).IsEmpty())
+	})
+
+	t.Run("empty when no domain has a record", func(t *testing.T) {
+		mr := &spf.MultiResult{}
+		mr.Results = []*spf.Result{{Input: "example.com"}, {Input: "example.org"}}
+		assert.True(t, mr.IsEmpty())
+	})
+
+	t.Run("not empty when one domain has a record", func(t *testing.T) {
+		mr := &spf.MultiResult{}
+		mr.Results = []*spf.Result{
+			{Input: "example.com"},
+			{Input: "example.org", Tree: &spf.Node{Domain: "example.org", Record: "v=spf1 -all"}},
+		}
+		assert.False(t, mr.IsEmpty())
+	})
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	mr := &spf.MultiResult{}
+	mr.Results = []*spf.Result{
+		{
+			Input: "example.com",
+			Tree: &spf.Node{
+				Domain: "example.com",
+				Record: "v=spf1 include:_spf.google.com ~all",
+				Children: []*spf.Node{
+					{Mechanism: "include:_spf.google.com", Qualifier: "+", Domain: "_spf.google.com", Provider: "Google Workspace"},
+					{Mechanism: "~all", Qualifier: "~"},
+				},
+			},
+			DNSLookups: 1,
+		},
+		{Input: "example.net"},
+		{
+			Input:               "example.org",
+			Tree:                &spf.Node{Domain: "example.org", Record: "v=spf1 ip4:192.0.2.0/24 -all", Children: []*spf.Node{{Mechanism: "ip4:192.0.2.0/24", Qualifier: "+", Networks: []string{"192.0.2.0/24"}}}},
+			DNSLookups:          11,
+			LookupLimitExceeded: true,
+			IPv4:                []string{"192.0.2.0/24"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "DOMAIN")
+	assert.Contains(t, out, "example.com")
+	assert.Contains(t, out, "example.org")
+	assert.NotContains(t, out, "example.net")
+	assert.Contains(t, out, "include:_spf.google.com")
+	assert.Contains(t, out, "DNS LOOKUPS")
+	assert.Contains(t, out, "11 / 10 (exceeded)")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	mr := &spf.MultiResult{}
+	mr.Results = []*spf.Result{
+		{Input: "example.com", Tree: &spf.Node{Domain: "example.com"}, IPv4: []string{"192.0.2.0/24"}},
+		{Input: "example.net"},
+		{Input: "example.org", Tree: &spf.Node{Domain: "example.org"}, IPv6: []string{"2001:db8::/32"}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteText(&buf))
+	assert.Equal(t, "192.0.2.0/24\n2001:db8::/32\n", buf.String())
+}
@@ -0,0 +1,166 @@
+package spf
+
+import (
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// term is a single parsed SPF mechanism or modifier (RFC 7208 §4.6.1).
+type term struct {
+	raw       string
+	qualifier byte   // '+', '-', '~', '?'; '+' when omitted
+	name      string // lowercase mechanism or modifier name
+	value     string // domain-spec or IP network; empty when absent
+	cidr4     int    // IPv4 prefix length for a/mx; -1 when absent
+	cidr6     int    // IPv6 prefix length for a/mx; -1 when absent
+	modifier  bool
+}
+
+// isSPFRecord reports whether txt is an SPF version 1 record.
+func isSPFRecord(txt string) bool {
+	lower := strings.ToLower(strings.TrimSpace(txt))
+	return lower == "v=spf1" || strings.HasPrefix(lower, "v=spf1 ")
+}
+
+// parseTerms splits an SPF record into its terms, skipping the version tag.
+func parseTerms(record string) []term {
+	fields := strings.Fields(record)
+	if len(fields) == 0 {
+		return nil
+	}
+	terms := make([]term, 0, len(fields)-1)
+	for _, f := range fields[1:] {
+		terms = append(terms, parseTerm(f))
+	}
+	return terms
+}
+
+// parseTerm parses a single SPF term such as "~all", "include:_spf.google.com",
+// "a:mail.example.com/24//64" or "redirect=_spf.example.com".
+func parseTerm(raw string) term {
+	t := term{raw: raw, qualifier: '+', cidr4: -1, cidr6: -1}
+	s := raw
+
+	// Modifiers are name=value where name contains no ':' or '/'.
+	if eq := strings.IndexByte(s, '='); eq > 0 && !strings.ContainsAny(s[:eq], ":/") {
+		t.modifier = true
+		t.name = strings.ToLower(s[:eq])
+		t.value = s[eq+1:]
+		return t
+	}
+
+	if strings.ContainsRune("+-~?", rune(s[0])) {
+		t.qualifier = s[0]
+		s = s[1:]
+	}
+	name, rest, hasValue := strings.Cut(s, ":")
+	if !hasValue {
+		// "a/24" and "mx//64" carry a CIDR suffix but no domain-spec.
+		if slash := strings.IndexByte(name, '/'); slash >= 0 {
+			rest = name[slash:]
+			name = name[:slash]
+		}
+	}
+	t.name = strings.ToLower(name)
+
+	switch t.name {
+	case "a", "mx":
+		domain := rest
+		if slash := strings.IndexByte(rest, '/'); slash >= 0 {
+			domain = rest[:slash]
+			t.cidr4, t.cidr6 = parseDualCIDR(rest[slash:])
+		}
+		if hasValue {
+			t.value = domain
+		}
+	default:
+		t.value = rest
+	}
+	return t
+}
+
+// parseDualCIDR parses the dual-cidr-length suffix of a/mx mechanisms:
+// "/24", "//64" or "/24//64". Missing or invalid lengths are returned as -1.
+func parseDualCIDR(s string) (int, int) {
+	v4, v6 := -1, -1
+	four, six, hasSix := strings.Cut(s, "//")
+	four = strings.TrimPrefix(four, "/")
+	if n, err := strconv.Atoi(four); err == nil && n >= 0 && n <= 32 {
+		v4 = n
+	}
+	if hasSix {
+		if n, err := strconv.Atoi(six); err == nil && n >= 0 && n <= 128 {
+			v6 = n
+		}
+	}
+	return v4, v6
+}
+
+// hasMacro reports whether a domain-spec contains an SPF macro (RFC 7208 §7),
+// which cannot be expanded without a sender context.
+func hasMacro(domain string) bool {
+	return strings.Contains(domain, "%{")
+}
+
+// networkFor returns the network covering addr with the given prefix length,
+// or the single-host network when bits is negative.
+func networkFor(addr netip.Addr, bits int) netip.Prefix {
+	if bits < 0 || bits > addr.BitLen() {
+		bits = addr.BitLen()
+	}
+	p, _ := addr.Prefix(bits)
+	return p
+}
+
+// parseNetwork parses an ip4:/ip6: value, accepting bare addresses as host networks.
+func parseNetwork(value string) (netip.Prefix, bool) {
+	if p, err := netip.ParsePrefix(value); err == nil {
+		return p.Masked(), true
+	}
+	if a, err := netip.ParseAddr(value); err == nil {
+		return networkFor(a, -1), true
+	}
+	return netip.Prefix{}, false
+}
+
+// flatten deduplicates networks and drops any network fully contained in
+// another, returning separate sorted IPv4 and IPv6 lists in CIDR notation.
+func flatten(networks []netip.Prefix) (v4, v6 []string) {
+	uniq := map[netip.Prefix]bool{}
+	for _, n := range networks {
+		uniq[n.Masked()] = true
+	}
+	sorted := make([]netip.Prefix, 0, len(uniq))
+	for n := range uniq {
+		sorted = append(sorted, n)
+	}
+	// Shorter prefixes first within the same start address so supernets are kept.
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := sorted[i].Addr().Compare(sorted[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return sorted[i].Bits() < sorted[j].Bits()
+	})
+	var kept []netip.Prefix
+	for _, n := range sorted {
+		covered := false
+		for _, k := range kept {
+			if k.Addr().Is4() == n.Addr().Is4() && k.Bits() <= n.Bits() && k.Contains(n.Addr()) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+		kept = append(kept, n)
+		if n.Addr().Is4() {
+			v4 = append(v4, n.String())
+		} else {
+			v6 = append(v6, n.String())
+		}
+	}
+	return v4, v6
+}
@@ -0,0 +1,75 @@
+package spf
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTerm(t *testing.T) {
+	tests := []struct {
+		raw       string
+		qualifier byte
+		name      string
+		value     string
+		cidr4     int
+		cidr6     int
+		modifier  bool
+	}{
+		{"-all", '-', "all", "", -1, -1, false},
+		{"include:_spf.google.com", '+', "include", "_spf.google.com", -1, -1, false},
+		{"~include:spf.example.net", '~', "include", "spf.example.net", -1, -1, false},
+		{"a", '+', "a", "", -1, -1, false},
+		{"a/24", '+', "a", "", 24, -1, false},
+		{"mx//64", '+', "mx", "", -1, 64, false},
+		{"a:mail.example.com/28//96", '+', "a", "mail.example.com", 28, 96, false},
+		{"ip4:192.0.2.0/24", '+', "ip4", "192.0.2.0/24", -1, -1, false},
+		{"ip6:2001:db8::/32", '+', "ip6", "2001:db8::/32", -1, -1, false},
+		{"redirect=_spf.example.com", '+', "redirect", "_spf.example.com", -1, -1, true},
+		{"exp=explain.example.com", '+', "exp", "explain.example.com", -1, -1, true},
+		{"exists:%{i}.spf.example.com", '+', "exists", "%{i}.spf.example.com", -1, -1, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.raw, func(t *testing.T) {
+			got := parseTerm(tc.raw)
+			assert.Equal(t, tc.qualifier, got.qualifier)
+			assert.Equal(t, tc.name, got.name)
+			assert.Equal(t, tc.value, got.value)
+			assert.Equal(t, tc.cidr4, got.cidr4)
+			assert.Equal(t, tc.cidr6, got.cidr6)
+			assert.Equal(t, tc.modifier, got.modifier)
+		})
+	}
+}
+
+func TestIsSPFRecord(t *testing.T) {
+	assert.True(t, isSPFRecord("v=spf1 -all"))
+	assert.True(t, isSPFRecord("V=SPF1 include:example.com ~all"))
+	assert.True(t, isSPFRecord("v=spf1"))
+	assert.False(t, isSPFRecord("v=spf10 -all"))
+	assert.False(t, isSPFRecord("google-site-verification=abc"))
+}
+
+func TestFlatten(t *testing.T) {
+	v4, v6 := flatten([]netip.Prefix{
+		netip.MustParsePrefix("10.1.0.0/16"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("2001:db8:1::/48"),
+	})
+	assert.Equal(t, []string{"10.0.0.0/8", "192.0.2.1/32"}, v4)
+	assert.Equal(t, []string{"2001:db8::/32"}, v6)
+}
+
+func FuzzParseTerms(f *testing.F) {
+	f.Add("v=spf1 ip4:192.0.2.0/24 include:_spf.google.com ~all")
+	f.Add("v=spf1 a:/ mx:// -")
+	f.Add("v=spf1 redirect= =x ?")
+	f.Fuzz(func(t *testing.T, record string) {
+		// Must not panic on any input.
+		parseTerms(record)
+	})
+}
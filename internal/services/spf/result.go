@@ -0,0 +1,173 @@
+package spf
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Node is a single term in the resolved SPF tree. The root node represents the
+// queried domain; include and redirect nodes carry the target's record and
+// their own children.
+type Node struct {
+	Mechanism string   `json:"mechanism,omitempty"` // term as written, e.g. "include:_spf.google.com"; empty for the root
+	Qualifier string   `json:"qualifier,omitempty"` // "+", "-", "~", "?"; empty for the root and modifiers
+	Domain    string   `json:"domain,omitempty"`    // looked-up domain for include/redirect/a/mx/ptr/exists
+	Record    string   `json:"record,omitempty"`    // SPF record of the root, include, or redirect target
+	Provider  string   `json:"provider,omitempty"`  // provider detected from the include target
+	Networks  []string `json:"networks,omitempty"`  // CIDRs this term resolved to
+	Loop      bool     `json:"loop,omitempty"`
+	Note      string   `json:"note,omitempty"` // error or evaluation remark, e.g. "no SPF record"
+	Children  []*Node  `json:"children,omitempty"`
+}
+
+// Result holds the resolved SPF tree and flattened networks for a single domain.
+type Result struct {
+	Input               string   `json:"input"`
+	Tree                *Node    `json:"tree,omitempty"`
+	DNSLookups          int      `json:"dns_lookups"`
+	VoidLookups         int      `json:"void_lookups"`
+	LookupLimitExceeded bool     `json:"lookup_limit_exceeded"`
+	VoidLimitExceeded   bool     `json:"void_limit_exceeded"`
+	Loops               []string `json:"loops,omitempty"`
+	IPv4                []string `json:"ipv4,omitempty"`
+	IPv6                []string `json:"ipv6,omitempty"`
+}
+
+// IsEmpty reports whether no SPF record was found for the input.
+func (r *Result) IsEmpty() bool {
+	return r.Tree == nil
+}
+
+// WriteText renders the flattened authorized networks, one CIDR per line
+// (IPv4 first, then IPv6). Suitable for piping into firewall or allow-list tooling.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, n := range append(append([]string{}, r.IPv4...), r.IPv6...) {
+		if _, err := fmt.Fprintln(w, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// treeRow is a single rendered line of the SPF tree.
+type treeRow struct {
+	term     string
+	provider string
+	networks string
+	note     string
+}
+
+// treeRows flattens the tree depth-first into display rows, drawing the
+// hierarchy with box-drawing prefixes in the term column.
+func treeRows(root *Node) []treeRow {
+	rows := []treeRow{{term: root.Domain, note: root.Note}}
+	var visit func(n *Node, indent string)
+	visit = func(n *Node, indent string) {
+		for i, c := range n.Children {
+			branch, next := "├─ ", "│  "
+			if i == len(n.Children)-1 {
+				branch, next = "└─ ", "   "
+			}
+			note := c.Note
+			if note == "" && c.Qualifier != "" && c.Qualifier != "+" {
+				note = qualifierLabel(c.Qualifier)
+			}
+			rows = append(rows, treeRow{
+				term:     indent + branch + c.Mechanism,
+				provider: c.Provider,
+				networks: strings.Join(c.Networks, "\n"),
+				note:     note,
+			})
+			visit(c, indent+next)
+		}
+	}
+	visit(root, "")
+	return rows
+}
+
+// qualifierLabel returns the RFC 7208 result name for a non-pass qualifier.
+func qualifierLabel(q string) string {
+	switch q {
+	case "-":
+		return "fail"
+	case "~":
+		return "softfail"
+	case "?":
+		return "neutral"
+	default:
+		return ""
+	}
+}
+
+// summaryRows returns the lookup-count and loop summary as Field/Value rows.
+func (r *Result) summaryRows() [][]string {
+	lookups := fmt.Sprintf("%d / %d", r.DNSLookups, LookupLimit)
+	if r.LookupLimitExceeded {
+		lookups += " (exceeded)"
+	}
+	voids := fmt.Sprintf("%d / %d", r.VoidLookups, VoidLookupLimit)
+	if r.VoidLimitExceeded {
+		voids += " (exceeded)"
+	}
+	rows := [][]string{
+		{"DNS Lookups", lookups},
+		{"Void Lookups", voids},
+	}
+	for _, l := range r.Loops {
+		rows = append(rows, []string{"Loop", l})
+	}
+	rows = append(rows,
+		[]string{"IPv4 Networks", fmt.Sprintf("%d", len(r.IPv4))},
+		[]string{"IPv6 Networks", fmt.Sprintf("%d", len(r.IPv6))},
+	)
+	return rows
+}
+
+// WriteTable renders the include tree, a lookup summary, and the flattened
+// authorized networks as three consecutive tables.
+func (r *Result) WriteTable(w io.Writer) error {
+	if r.Tree == nil {
+		return nil
+	}
+	var rows [][]string
+	for _, tr := range treeRows(r.Tree) {
+		rows = append(rows, []string{tr.term, tr.provider, tr.networks, tr.note})
+	}
+	tree := output.NewWrappingTable(w, 20, 40)
+	tree.Header([]string{"Term", "Provider", "Networks", "Note"})
+	if err := tree.Bulk(rows); err != nil {
+		return err
+	}
+	if err := tree.Render(); err != nil {
+		return err
+	}
+
+	summary := output.NewWrappingTable(w, 20, 20)
+	summary.Header([]string{"Field", "Value"})
+	if err := summary.Bulk(r.summaryRows()); err != nil {
+		return err
+	}
+	if err := summary.Render(); err != nil {
+		return err
+	}
+
+	if len(r.IPv4)+len(r.IPv6) == 0 {
+		return nil
+	}
+	var netRows [][]string
+	for _, n := range r.IPv4 {
+		netRows = append(netRows, []string{"IPv4", n})
+	}
+	for _, n := range r.IPv6 {
+		netRows = append(netRows, []string{"IPv6", n})
+	}
+	nets := output.NewGroupedWrappingTable(w, 20, 20)
+	nets.Header([]string{"Family", "Authorized Network"})
+	if err := nets.Bulk(netRows); err != nil {
+		return err
+	}
+	return nets.Render()
+}
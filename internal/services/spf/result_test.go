@@ -0,0 +1,110 @@
+package spf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/spf"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&spf.Result{Input: "example.com"}).IsEmpty())
+	assert.False(t, (&spf.Result{Input: "example.com", Tree: &spf.Node{Domain: "example.com", Record: "v=spf1 -all"}}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &spf.Result{
+		Input: "example.com",
+		Tree:  &spf.Node{Domain: "example.com"},
+		IPv4:  []string{"192.0.2.0/24", "198.51.100.0/24"},
+		IPv6:  []string{"2001:db8::/32"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "192.0.2.0/24\n198.51.100.0/24\n2001:db8::/32\n", buf.String())
+}
+
+func TestResult_WriteText_NoNetworks(t *testing.T) {
+	result := &spf.Result{
+		Input: "example.com",
+		Tree:  &spf.Node{Domain: "example.com", Record: "v=spf1 -all", Children: []*spf.Node{{Mechanism: "-all", Qualifier: "-"}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Empty(t, buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &spf.Result{
+		Input: "example.com",
+		Tree: &spf.Node{
+			Domain: "example.com",
+			Record: "v=spf1 ip4:192.0.2.0/24 include:_spf.google.com ~all",
+			Children: []*spf.Node{
+				{Mechanism: "ip4:192.0.2.0/24", Qualifier: "+", Networks: []string{"192.0.2.0/24"}},
+				{
+					Mechanism: "include:_spf.google.com", Qualifier: "+", Domain: "_spf.google.com",
+					Provider: "Google Workspace",
+					Children: []*spf.Node{
+						{Mechanism: "ip6:2001:4860:4000::/36", Qualifier: "+", Networks: []string{"2001:4860:4000::/36"}},
+					},
+				},
+				{Mechanism: "~all", Qualifier: "~"},
+			},
+		},
+		DNSLookups: 1,
+		IPv4:       []string{"192.0.2.0/24"},
+		IPv6:       []string{"2001:4860:4000::/36"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "TERM")
+	assert.Contains(t, out, "├─ ip4:192.0.2.0/24")
+	assert.Contains(t, out, "└─ ip6:2001:4860:4000::/36")
+	assert.Contains(t, out, "└─ ~all")
+	assert.Contains(t, out, "softfail")
+	assert.Contains(t, out, "Google Workspace")
+	assert.Contains(t, out, "1 / 10")
+	assert.Contains(t, out, "AUTHORIZED NETWORK")
+}
+
+func TestResult_WriteTable_NoNetworks(t *testing.T) {
+	result := &spf.Result{
+		Input: "example.com",
+		Tree:  &spf.Node{Domain: "example.com", Record: "v=spf1 -all", Children: []*spf.Node{{Mechanism: "-all", Qualifier: "-"}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "└─ -all")
+	assert.Contains(t, out, "fail")
+	assert.NotContains(t, out, "AUTHORIZED NETWORK")
+}
+
+func TestResult_WriteTable_LimitExceeded(t *testing.T) {
+	result := &spf.Result{
+		Input: "example.com",
+		Tree: &spf.Node{
+			Domain:   "example.com",
+			Record:   "v=spf1 include:example.com -all",
+			Children: []*spf.Node{{Mechanism: "include:example.com", Qualifier: "+", Domain: "example.com", Loop: true, Note: "loop"}},
+		},
+		DNSLookups:          12,
+		LookupLimitExceeded: true,
+		Loops:               []string{"example.com -> example.com"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	assert.Contains(t, buf.String(), "12 / 10 (exceeded)")
+	assert.Contains(t, buf.String(), "example.com -> example.com")
+}
+
+func TestResult_WriteTable_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&spf.Result{Input: "example.com"}).WriteTable(&buf))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,350 @@
+package spf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"codeberg.org/miekg/dns"
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/detect"
+	"github.com/tbckr/trident/internal/doh"
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// Name is the service identifier.
+	Name = "spf"
+	// PAP is the PAP activity level for the SPF service.
+	PAP = pap.AMBER
+
+	// LookupLimit is the RFC 7208 §4.6.4 limit on DNS-querying terms
+	// (include, a, mx, ptr, exists, redirect) per SPF evaluation.
+	LookupLimit = 10
+	// VoidLookupLimit is the RFC 7208 §4.6.4 limit on lookups that return
+	// NXDOMAIN or an empty answer.
+	VoidLookupLimit = 2
+
+	// maxMXHosts is the RFC 7208 §4.6.4 limit on MX hosts resolved per mx mechanism.
+	maxMXHosts = 10
+	// maxQueries caps the DoH queries issued per input. It sits far above
+	// LookupLimit so the full tree of an over-limit record is still shown, while
+	// preventing pathological records from causing unbounded recursion.
+	maxQueries = 100
+)
+
+// Service resolves SPF records and their include trees via Quad9 DoH.
+type Service struct {
+	client   *req.Client
+	logger   *slog.Logger
+	detector *detect.Detector
+}
+
+// NewService creates a new SPF service with the given HTTP client, logger, and patterns.
+func NewService(client *req.Client, logger *slog.Logger, patterns detect.Patterns) *Service {
+	return &Service{
+		client:   client,
+		logger:   logger,
+		detector: detect.NewDetector(patterns),
+	}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the SPF service (Quad9 third-party API).
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple SPF results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// walk holds the mutable state of a single SPF tree resolution.
+type walk struct {
+	svc      *Service
+	result   *Result
+	networks []netip.Prefix
+	queries  int
+	stopped  bool // set on context cancellation or when maxQueries is reached
+}
+
+// Run resolves the SPF record of domain into a tree, counts DNS lookups against
+// the RFC 7208 limits, detects include loops, and flattens all pass-qualified
+// mechanisms into the authorized IPv4/IPv6 networks.
+// Partial results are returned when the context is cancelled mid-walk.
+func (s *Service) Run(ctx context.Context, domain string) (services.Result, error) {
+	domain = output.StripANSI(domain)
+	if !services.IsDomain(domain) {
+		return nil, fmt.Errorf("%w: must be a valid domain name: %q", services.ErrInvalidInput, domain)
+	}
+
+	result := &Result{Input: domain}
+	w := &walk{svc: s, result: result}
+
+	root := &Node{Domain: domain}
+	if err := w.resolveRecord(ctx, root, domain, nil); err != nil {
+		return nil, err
+	}
+	if root.Record == "" {
+		return result, nil
+	}
+	result.Tree = root
+	result.LookupLimitExceeded = result.DNSLookups > LookupLimit
+	result.VoidLimitExceeded = result.VoidLookups > VoidLookupLimit
+	result.IPv4, result.IPv6 = flatten(w.networks)
+	return result, nil
+}
+
+// query performs a single DoH lookup and returns the answers of the requested type.
+// void reports whether the lookup returned NXDOMAIN or no matching answers.
+// Context cancellation stops the walk and is reported as a nil error.
+func (w *walk) query(ctx context.Context, name string, qtype uint16) (answers []string, void bool, err error) {
+	if w.stopped {
+		return nil, false, nil
+	}
+	if w.queries >= maxQueries {
+		w.svc.logger.Debug("spf: query cap reached", "input", w.result.Input, "cap", maxQueries)
+		w.stopped = true
+		return nil, false, nil
+	}
+	w.queries++
+	resp, err := doh.MakeDoHRequest(ctx, w.svc.client, name, qtype)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			w.stopped = true
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	for _, ans := range resp.Answer {
+		if ans.Type == qtype {
+			answers = append(answers, output.StripANSI(ans.Data))
+		}
+	}
+	return answers, resp.Status == dns.RcodeNameError || len(answers) == 0, nil
+}
+
+// resolveRecord fetches the SPF record for domain into node and expands its terms.
+// path holds the domains on the current include/redirect chain for loop detection.
+// Only a failure to query the root domain is returned as an error; failures
+// further down the tree are recorded on the affected node.
+func (w *walk) resolveRecord(ctx context.Context, node *Node, domain string, path []string) error {
+	txts, void, err := w.query(ctx, domain, dns.TypeTXT)
+	if err != nil {
+		if len(path) == 0 {
+			return err
+		}
+		w.svc.logger.Debug("spf: TXT lookup failed", "domain", domain, "error", err)
+		node.Note = "lookup failed"
+		return nil
+	}
+	if void && len(path) > 0 {
+		w.result.VoidLookups++
+	}
+
+	var records []string
+	for _, txt := range txts {
+		if isSPFRecord(txt) {
+			records = append(records, txt)
+		}
+	}
+	switch {
+	case len(records) == 0:
+		if len(path) > 0 && !w.stopped {
+			node.Note = "no SPF record"
+		}
+		return nil
+	case len(records) > 1:
+		node.Note = fmt.Sprintf("%d SPF records (permerror)", len(records))
+	}
+	node.Record = records[0]
+
+	path = append(slices.Clone(path), normalizeDomain(domain))
+	terms := parseTerms(node.Record)
+	hasAll := false
+	for _, t := range terms {
+		if !t.modifier && t.name == "all" {
+			hasAll = true
+		}
+	}
+	for _, t := range terms {
+		if t.modifier && t.name != "redirect" {
+			continue // exp= and unknown modifiers do not affect authorization
+		}
+		child := &Node{Mechanism: t.raw, Qualifier: string(t.qualifier)}
+		if t.modifier {
+			child.Qualifier = ""
+		}
+		node.Children = append(node.Children, child)
+		if t.modifier && hasAll {
+			child.Note = "ignored: record has all"
+			continue
+		}
+		w.expand(ctx, child, t, domain, path)
+	}
+	return nil
+}
+
+// expand resolves a single term into child, counting lookups and collecting
+// networks authorized by pass-qualified mechanisms.
+func (w *walk) expand(ctx context.Context, child *Node, t term, domain string, path []string) {
+	pass := t.qualifier == '+'
+	target := t.value
+	if target == "" {
+		target = domain
+	}
+
+	switch t.name {
+	case "all":
+		return
+	case "ip4", "ip6":
+		n, ok := parseNetwork(t.value)
+		if !ok || n.Addr().Is4() != (t.name == "ip4") {
+			child.Note = "invalid network"
+			return
+		}
+		child.Networks = []string{n.String()}
+		if pass {
+			w.networks = append(w.networks, n)
+		}
+		return
+	case "include", "redirect":
+		w.result.DNSLookups++
+		child.Domain = target
+		child.Provider = w.provider(t)
+		if hasMacro(target) {
+			child.Note = "macro not expanded"
+			return
+		}
+		if containsDomain(path, target) {
+			child.Loop = true
+			child.Note = "loop"
+			w.result.Loops = append(w.result.Loops, strings.Join(append(path, normalizeDomain(target)), " -> "))
+			return
+		}
+		before := len(w.networks)
+		_ = w.resolveRecord(ctx, child, target, path)
+		if !pass {
+			// A non-pass include never authorizes the networks it contains.
+			w.networks = w.networks[:before]
+		}
+	case "a", "mx":
+		w.result.DNSLookups++
+		child.Domain = target
+		if hasMacro(target) {
+			child.Note = "macro not expanded"
+			return
+		}
+		hosts := []string{target}
+		if t.name == "mx" {
+			hosts = w.mxHosts(ctx, child, target)
+		}
+		w.resolveHosts(ctx, child, hosts, t, pass)
+	case "ptr", "exists":
+		w.result.DNSLookups++
+		child.Domain = t.value
+		child.Note = "not flattenable"
+	default:
+		child.Note = "unknown mechanism"
+	}
+}
+
+// mxHosts resolves the exchange hostnames for an mx mechanism.
+func (w *walk) mxHosts(ctx context.Context, child *Node, domain string) []string {
+	answers, void, err := w.query(ctx, domain, dns.TypeMX)
+	if err != nil {
+		w.svc.logger.Debug("spf: MX lookup failed", "domain", domain, "error", err)
+		child.Note = "lookup failed"
+		return nil
+	}
+	if void {
+		w.result.VoidLookups++
+		return nil
+	}
+	var hosts []string
+	for _, ans := range answers {
+		// MX data format: "10 aspmx.l.google.com."
+		parts := strings.Fields(ans)
+		if len(parts) >= 2 {
+			hosts = append(hosts, parts[len(parts)-1])
+		}
+	}
+	if len(hosts) > maxMXHosts {
+		child.Note = fmt.Sprintf("%d MX hosts exceed limit of %d (permerror)", len(hosts), maxMXHosts)
+		hosts = hosts[:maxMXHosts]
+	}
+	return hosts
+}
+
+// resolveHosts resolves A and AAAA records for hosts and attaches the resulting
+// networks (with the term's dual-CIDR lengths applied) to child.
+func (w *walk) resolveHosts(ctx context.Context, child *Node, hosts []string, t term, pass bool) {
+	var found []netip.Prefix
+	for _, host := range hosts {
+		for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+			answers, _, err := w.query(ctx, host, qtype)
+			if err != nil {
+				w.svc.logger.Debug("spf: address lookup failed", "host", host, "error", err)
+				continue
+			}
+			for _, ans := range answers {
+				addr, err := netip.ParseAddr(ans)
+				if err != nil {
+					continue
+				}
+				bits := t.cidr4
+				if addr.Is6() {
+					bits = t.cidr6
+				}
+				found = append(found, networkFor(addr, bits))
+			}
+		}
+	}
+	if t.name == "a" && len(found) == 0 && !w.stopped {
+		w.result.VoidLookups++
+	}
+	for _, n := range found {
+		child.Networks = append(child.Networks, n.String())
+	}
+	if pass {
+		w.networks = append(w.networks, found...)
+	}
+}
+
+// provider returns the provider name detected for an include or redirect term.
+// The term is matched as written (e.g. "include:_spf.google.com") against the
+// TXT patterns, which are keyed on the include syntax found in SPF records.
+func (w *walk) provider(t term) string {
+	probe := t.raw
+	if t.modifier {
+		probe = "include:" + t.value
+	} else {
+		probe = strings.TrimLeft(probe, "+-~?")
+	}
+	if ds := w.svc.detector.TXTRecord([]string{probe}); len(ds) > 0 {
+		return ds[0].Provider
+	}
+	return ""
+}
+
+// normalizeDomain lowercases domain and strips a trailing dot.
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(domain, "."))
+}
+
+// containsDomain reports whether domain is already present on path.
+func containsDomain(path []string, domain string) bool {
+	return slices.Contains(path, normalizeDomain(domain))
+}
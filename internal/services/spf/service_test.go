@@ -0,0 +1,287 @@
+package spf_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"codeberg.org/miekg/dns"
+	"codeberg.org/miekg/dns/rdata"
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providers "github.com/tbckr/trident/internal/detect"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/spf"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+const dohURL = "https://dns.quad9.net/dns-query"
+
+func embeddedPatterns(t *testing.T) providers.Patterns {
+	t.Helper()
+	p, err := providers.LoadPatterns()
+	require.NoError(t, err)
+	return p
+}
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+// zone maps "name/TYPE" to the RRs returned for that query.
+type zone map[string][]dns.RR
+
+func hdr(name string) dns.Header {
+	return dns.Header{Name: name + ".", Class: dns.ClassINET, TTL: 300}
+}
+
+func txt(name, value string) dns.RR {
+	return &dns.TXT{Hdr: hdr(name), TXT: rdata.TXT{Txt: []string{value}}}
+}
+
+func a(name, addr string) dns.RR {
+	rr := &dns.A{Hdr: hdr(name)}
+	rr.Addr = netip.MustParseAddr(addr)
+	return rr
+}
+
+func aaaa(name, addr string) dns.RR {
+	rr := &dns.AAAA{Hdr: hdr(name)}
+	rr.Addr = netip.MustParseAddr(addr)
+	return rr
+}
+
+func mx(name, host string) dns.RR {
+	return &dns.MX{Hdr: hdr(name), MX: rdata.MX{Preference: 10, Mx: host + "."}}
+}
+
+// zoneResponder answers DoH queries from z; unknown names return NXDOMAIN.
+func zoneResponder(t *testing.T, z zone) httpmock.Responder {
+	t.Helper()
+	return func(r *http.Request) (*http.Response, error) {
+		data, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+		if err != nil {
+			return nil, fmt.Errorf("decode base64url: %w", err)
+		}
+		q := new(dns.Msg)
+		q.Data = data
+		if err := q.Unpack(); err != nil {
+			return nil, fmt.Errorf("unpack DNS query: %w", err)
+		}
+		name := strings.TrimSuffix(q.Question[0].Header().Name, ".")
+		qtype := dns.RRToType(q.Question[0])
+
+		m := new(dns.Msg)
+		m.Response = true
+		known := false
+		for key, rrs := range z {
+			if strings.HasPrefix(key, name+"/") {
+				known = true
+			}
+			if key == fmt.Sprintf("%s/%d", name, qtype) {
+				m.Answer = rrs
+			}
+		}
+		if !known {
+			m.Rcode = dns.RcodeNameError
+			m.Ns = []dns.RR{&dns.SOA{Hdr: hdr("com"), SOA: rdata.SOA{Ns: "a.gtld-servers.net.", Mbox: "nstld.verisign-grs.com."}}}
+		}
+		require.NoError(t, m.Pack())
+		return httpmock.NewBytesResponse(http.StatusOK, m.Data), nil
+	}
+}
+
+func key(name string, qtype uint16) string { return fmt.Sprintf("%s/%d", name, qtype) }
+
+func runSPF(t *testing.T, z zone, domain string) *spf.Result {
+	t.Helper()
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, zoneResponder(t, z))
+	svc := spf.NewService(client, testutil.NopLogger(), embeddedPatterns(t))
+	raw, err := svc.Run(context.Background(), domain)
+	require.NoError(t, err)
+	result, ok := raw.(*spf.Result)
+	require.True(t, ok, "expected *spf.Result")
+	return result
+}
+
+func TestService_Run_IncludeTree(t *testing.T) {
+	z := zone{
+		key("example.com", dns.TypeTXT): {
+			txt("example.com", "google-site-verification=abc"),
+			txt("example.com", "v=spf1 ip4:192.0.2.0/24 a mx include:_spf.google.com ~all"),
+		},
+		key("example.com", dns.TypeA):    {a("example.com", "198.51.100.10")},
+		key("example.com", dns.TypeAAAA): {aaaa("example.com", "2001:db8::10")},
+		key("example.com", dns.TypeMX):   {mx("example.com", "mail.example.com")},
+		key("mail.example.com", dns.TypeA): {
+			a("mail.example.com", "192.0.2.25"), // inside ip4:192.0.2.0/24 → collapsed
+		},
+		key("_spf.google.com", dns.TypeTXT): {
+			txt("_spf.google.com", "v=spf1 include:_netblocks.google.com ~all"),
+		},
+		key("_netblocks.google.com", dns.TypeTXT): {
+			txt("_netblocks.google.com", "v=spf1 ip4:35.190.247.0/24 ip6:2001:4860:4000::/36 ~all"),
+		},
+	}
+
+	result := runSPF(t, z, "example.com")
+	require.NotNil(t, result.Tree)
+	assert.Equal(t, "v=spf1 ip4:192.0.2.0/24 a mx include:_spf.google.com ~all", result.Tree.Record)
+	require.Len(t, result.Tree.Children, 5)
+
+	include := result.Tree.Children[3]
+	assert.Equal(t, "include:_spf.google.com", include.Mechanism)
+	assert.Equal(t, "Google Workspace", include.Provider)
+	require.Len(t, include.Children, 2)
+	assert.Equal(t, "include:_netblocks.google.com", include.Children[0].Mechanism)
+	assert.Equal(t, []string{"35.190.247.0/24"}, include.Children[0].Children[0].Networks)
+
+	assert.Equal(t, 4, result.DNSLookups, "a + mx + 2 includes")
+	assert.Equal(t, 0, result.VoidLookups)
+	assert.False(t, result.LookupLimitExceeded)
+	assert.Equal(t, []string{"35.190.247.0/24", "192.0.2.0/24", "198.51.100.10/32"}, result.IPv4)
+	assert.Equal(t, []string{"2001:db8::10/128", "2001:4860:4000::/36"}, result.IPv6)
+}
+
+func TestService_Run_NoSPFRecord(t *testing.T) {
+	z := zone{
+		key("example.com", dns.TypeTXT): {txt("example.com", "google-site-verification=abc")},
+	}
+	result := runSPF(t, z, "example.com")
+	assert.True(t, result.IsEmpty())
+}
+
+func TestService_Run_Loop(t *testing.T) {
+	z := zone{
+		key("example.com", dns.TypeTXT):      {txt("example.com", "v=spf1 include:_spf.example.com -all")},
+		key("_spf.example.com", dns.TypeTXT): {txt("_spf.example.com", "v=spf1 include:example.com -all")},
+	}
+	result := runSPF(t, z, "example.com")
+	require.Len(t, result.Loops, 1)
+	assert.Equal(t, "example.com -> _spf.example.com -> example.com", result.Loops[0])
+	loopNode := result.Tree.Children[0].Children[0]
+	assert.True(t, loopNode.Loop)
+	assert.Equal(t, 2, result.DNSLookups)
+}
+
+func TestService_Run_LookupLimitExceeded(t *testing.T) {
+	var terms []string
+	z := zone{}
+	for i := range 11 {
+		host := fmt.Sprintf("s%d.ex.net", i) // short names keep the record within one 255-byte TXT string
+		terms = append(terms, "include:"+host)
+		z[key(host, dns.TypeTXT)] = []dns.RR{txt(host, fmt.Sprintf("v=spf1 ip4:203.0.113.%d -all", i))}
+	}
+	z[key("example.com", dns.TypeTXT)] = []dns.RR{txt("example.com", "v=spf1 "+strings.Join(terms, " ")+" -all")}
+
+	result := runSPF(t, z, "example.com")
+	assert.Equal(t, 11, result.DNSLookups)
+	assert.True(t, result.LookupLimitExceeded)
+	assert.Len(t, result.IPv4, 11, "tree is still fully resolved and flattened")
+}
+
+func TestService_Run_VoidLookups(t *testing.T) {
+	z := zone{
+		key("example.com", dns.TypeTXT): {
+			txt("example.com", "v=spf1 include:gone1.example.net include:gone2.example.net a:gone3.example.net -all"),
+		},
+	}
+	result := runSPF(t, z, "example.com")
+	assert.Equal(t, 3, result.VoidLookups)
+	assert.True(t, result.VoidLimitExceeded)
+	assert.Equal(t, "no SPF record", result.Tree.Children[0].Note)
+}
+
+func TestService_Run_RedirectAndQualifiers(t *testing.T) {
+	z := zone{
+		key("example.com", dns.TypeTXT):      {txt("example.com", "v=spf1 -ip4:192.0.2.1 redirect=_spf.example.com")},
+		key("_spf.example.com", dns.TypeTXT): {txt("_spf.example.com", "v=spf1 ip4:198.51.100.0/24 -all")},
+	}
+	result := runSPF(t, z, "example.com")
+	assert.Equal(t, 1, result.DNSLookups)
+	assert.Equal(t, []string{"198.51.100.0/24"}, result.IPv4, "fail-qualified networks are not authorized")
+	redirect := result.Tree.Children[1]
+	assert.Equal(t, "redirect=_spf.example.com", redirect.Mechanism)
+	assert.Equal(t, "_spf.example.com", redirect.Domain)
+}
+
+func TestService_Run_RedirectIgnoredWithAll(t *testing.T) {
+	z := zone{
+		key("example.com", dns.TypeTXT): {txt("example.com", "v=spf1 ip4:192.0.2.1 -all redirect=_spf.example.com")},
+	}
+	result := runSPF(t, z, "example.com")
+	assert.Equal(t, 0, result.DNSLookups)
+	assert.Equal(t, "ignored: record has all", result.Tree.Children[2].Note)
+}
+
+func TestService_Run_NotFlattenable(t *testing.T) {
+	z := zone{
+		key("example.com", dns.TypeTXT): {txt("example.com", "v=spf1 ptr exists:%{i}._spf.example.com -all")},
+	}
+	result := runSPF(t, z, "example.com")
+	assert.Equal(t, 2, result.DNSLookups)
+	assert.Equal(t, "not flattenable", result.Tree.Children[0].Note)
+	assert.Empty(t, result.IPv4)
+}
+
+func TestService_Run_InvalidInput(t *testing.T) {
+	svc := spf.NewService(req.NewClient(), testutil.NopLogger(), embeddedPatterns(t))
+	for _, bad := range []string{"", "not_a_domain", "192.0.2.1"} {
+		_, err := svc.Run(context.Background(), bad)
+		require.Error(t, err, "input %q should be invalid", bad)
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+	}
+}
+
+func TestService_Run_HTTPError(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+
+	svc := spf.NewService(client, testutil.NopLogger(), embeddedPatterns(t))
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, services.ErrRequestFailed)
+	assert.Nil(t, raw)
+}
+
+func TestService_Run_ContextCancelled(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, zoneResponder(t, zone{}))
+	svc := spf.NewService(client, testutil.NopLogger(), embeddedPatterns(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	raw, err := svc.Run(ctx, "example.com")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestService_AggregateResults(t *testing.T) {
+	svc := spf.NewService(req.NewClient(), testutil.NopLogger(), embeddedPatterns(t))
+	agg := svc.AggregateResults([]services.Result{
+		&spf.Result{Input: "a.com"},
+		&spf.Result{Input: "b.com"},
+	})
+	mr, ok := agg.(*spf.MultiResult)
+	require.True(t, ok, "expected *spf.MultiResult")
+	assert.Len(t, mr.Results, 2)
+}
+
+func TestService_NameAndPAP(t *testing.T) {
+	svc := spf.NewService(req.NewClient(), testutil.NopLogger(), embeddedPatterns(t))
+	assert.Equal(t, "spf", svc.Name())
+	assert.Equal(t, "amber", svc.PAP().String())
+}
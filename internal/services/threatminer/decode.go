@@ -0,0 +1,324 @@
+package threatminer
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// The decoders below turn the "results" array of one ThreatMiner report type
+// into Result fields. Every string taken from the API is passed through
+// output.StripANSI.
+
+func decodePDNS(raw json.RawMessage, r *Result) error {
+	var entries []PDNSEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return err
+	}
+	for i := range entries {
+		entries[i].IP = output.StripANSI(entries[i].IP)
+		entries[i].Domain = output.StripANSI(entries[i].Domain)
+		entries[i].FirstSeen = output.StripANSI(entries[i].FirstSeen)
+		entries[i].LastSeen = output.StripANSI(entries[i].LastSeen)
+	}
+	r.PassiveDNS = entries
+	return nil
+}
+
+func decodeSubdomains(raw json.RawMessage, r *Result) error {
+	return decodeStrings(raw, &r.Subdomains)
+}
+
+func decodeSamples(raw json.RawMessage, r *Result) error {
+	return decodeStrings(raw, &r.RelatedSamples)
+}
+
+func decodeSSLCerts(raw json.RawMessage, r *Result) error {
+	return decodeStrings(raw, &r.SSLCertificates)
+}
+
+func decodeEmailDomains(raw json.RawMessage, r *Result) error {
+	return decodeStrings(raw, &r.Domains)
+}
+
+// decodeSSLHosts reads the plain list of IPs that served a certificate.
+func decodeSSLHosts(raw json.RawMessage, r *Result) error {
+	var ips []string
+	if err := decodeStrings(raw, &ips); err != nil {
+		return err
+	}
+	for _, ip := range ips {
+		r.Hosts = append(r.Hosts, HostEntry{IP: ip})
+	}
+	return nil
+}
+
+// decodeDomainWhois reads domain.php rt=1:
+// [{"domain": ..., "whois": {"reg_info": {...}, "nameservers": [...], "emails": {...}}}].
+func decodeDomainWhois(raw json.RawMessage, r *Result) error {
+	var records []struct {
+		Whois struct {
+			RegInfo struct {
+				Registrar      string `json:"registrar"`
+				CreationDate   string `json:"creation_date"`
+				UpdatedDate    string `json:"updated_date"`
+				ExpirationDate string `json:"expiration_date"`
+			} `json:"reg_info"`
+			NameServers []string          `json:"nameservers"`
+			Emails      map[string]string `json:"emails"`
+		} `json:"whois"`
+	}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	w := records[0].Whois
+	info := &WhoisInfo{
+		Registrar: output.StripANSI(w.RegInfo.Registrar),
+		Created:   output.StripANSI(w.RegInfo.CreationDate),
+		Updated:   output.StripANSI(w.RegInfo.UpdatedDate),
+		Expires:   output.StripANSI(w.RegInfo.ExpirationDate),
+	}
+	for _, ns := range w.NameServers {
+		if ns = output.StripANSI(strings.TrimSpace(ns)); ns != "" {
+			info.NameServers = append(info.NameServers, ns)
+		}
+	}
+	for _, e := range w.Emails {
+		if e = output.StripANSI(strings.TrimSpace(e)); e != "" && !slices.Contains(info.Emails, e) {
+			info.Emails = append(info.Emails, e)
+		}
+	}
+	slices.Sort(info.Emails)
+	r.Whois = info
+	return nil
+}
+
+// decodeHostWhois reads host.php rt=1:
+// [{"reverse_name": ..., "bgp_prefix": ..., "cc": ..., "asn": ..., "asn_name": ..., "org_name": ...}].
+func decodeHostWhois(raw json.RawMessage, r *Result) error {
+	var records []struct {
+		ReverseName string          `json:"reverse_name"`
+		BGPPrefix   string          `json:"bgp_prefix"`
+		CC          string          `json:"cc"`
+		ASN         json.RawMessage `json:"asn"`
+		ASNName     string          `json:"asn_name"`
+		OrgName     string          `json:"org_name"`
+	}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	rec := records[0]
+	r.Whois = &WhoisInfo{
+		ReverseName: output.StripANSI(rec.ReverseName),
+		ASN:         output.StripANSI(rawScalar(rec.ASN)),
+		ASName:      output.StripANSI(rec.ASNName),
+		BGPPrefix:   output.StripANSI(rec.BGPPrefix),
+		Country:     output.StripANSI(rec.CC),
+		Org:         output.StripANSI(rec.OrgName),
+	}
+	return nil
+}
+
+func decodeURIs(raw json.RawMessage, r *Result) error {
+	var entries []URIEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return err
+	}
+	for i := range entries {
+		entries[i].URI = output.StripANSI(entries[i].URI)
+		entries[i].Domain = output.StripANSI(entries[i].Domain)
+		entries[i].IP = output.StripANSI(entries[i].IP)
+		entries[i].LastSeen = output.StripANSI(entries[i].LastSeen)
+	}
+	r.URIs = entries
+	return nil
+}
+
+// decodeReports reads report tagging results: [{"filename", "year", "URL"}].
+func decodeReports(raw json.RawMessage, r *Result) error {
+	var entries []struct {
+		Filename string          `json:"filename"`
+		Year     json.RawMessage `json:"year"`
+		URL      string          `json:"URL"`
+	}
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		r.Reports = append(r.Reports, Report{
+			Filename: output.StripANSI(e.Filename),
+			Year:     output.StripANSI(rawScalar(e.Year)),
+			URL:      output.StripANSI(e.URL),
+		})
+	}
+	return nil
+}
+
+func decodeMetadata(raw json.RawMessage, r *Result) error {
+	var metas []HashMetadata
+	if err := json.Unmarshal(raw, &metas); err != nil {
+		return err
+	}
+	if len(metas) == 0 {
+		return nil
+	}
+	m := metas[0]
+	m.MD5 = output.StripANSI(m.MD5)
+	m.SHA1 = output.StripANSI(m.SHA1)
+	m.SHA256 = output.StripANSI(m.SHA256)
+	m.FileType = output.StripANSI(m.FileType)
+	m.FileName = output.StripANSI(m.FileName)
+	m.FileSize = output.StripANSI(m.FileSize)
+	r.HashInfo = &m
+	return nil
+}
+
+// decodeHTTPTraffic reads sample.php rt=2: [{"http_traffic": [{...}]}].
+func decodeHTTPTraffic(raw json.RawMessage, r *Result) error {
+	var records []struct {
+		HTTPTraffic []struct {
+			Domain    string          `json:"domain"`
+			IP        string          `json:"ip"`
+			Port      json.RawMessage `json:"port"`
+			Method    string          `json:"method"`
+			URL       string          `json:"url"`
+			UserAgent string          `json:"user_agent"`
+		} `json:"http_traffic"`
+	}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		for _, h := range rec.HTTPTraffic {
+			r.HTTPTraffic = append(r.HTTPTraffic, HTTPRequest{
+				Domain:    output.StripANSI(h.Domain),
+				IP:        output.StripANSI(h.IP),
+				Port:      output.StripANSI(rawScalar(h.Port)),
+				Method:    output.StripANSI(h.Method),
+				URL:       output.StripANSI(h.URL),
+				UserAgent: output.StripANSI(h.UserAgent),
+			})
+		}
+	}
+	return nil
+}
+
+// decodeSampleHosts reads sample.php rt=3:
+// [{"domains": [{"ip": ..., "domain": ...}], "hosts": ["ip", ...]}].
+// Hosts already listed with a domain are not repeated.
+func decodeSampleHosts(raw json.RawMessage, r *Result) error {
+	var records []struct {
+		Domains []HostEntry `json:"domains"`
+		Hosts   []string    `json:"hosts"`
+	}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, rec := range records {
+		for _, d := range rec.Domains {
+			d.Domain = output.StripANSI(d.Domain)
+			d.IP = output.StripANSI(d.IP)
+			seen[d.IP] = true
+			r.Hosts = append(r.Hosts, d)
+		}
+	}
+	for _, rec := range records {
+		for _, ip := range rec.Hosts {
+			if ip = output.StripANSI(ip); !seen[ip] {
+				seen[ip] = true
+				r.Hosts = append(r.Hosts, HostEntry{IP: ip})
+			}
+		}
+	}
+	return nil
+}
+
+// decodeMutants reads sample.php rt=4: [{"mutants": [...]}].
+func decodeMutants(raw json.RawMessage, r *Result) error {
+	var records []struct {
+		Mutants []string `json:"mutants"`
+	}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		for _, m := range rec.Mutants {
+			r.Mutants = append(r.Mutants, output.StripANSI(m))
+		}
+	}
+	return nil
+}
+
+// decodeRegistry reads sample.php rt=5: [{"registry_keys": [...]}].
+func decodeRegistry(raw json.RawMessage, r *Result) error {
+	var records []struct {
+		RegistryKeys []string `json:"registry_keys"`
+	}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		for _, k := range rec.RegistryKeys {
+			r.RegistryKeys = append(r.RegistryKeys, output.StripANSI(k))
+		}
+	}
+	return nil
+}
+
+// decodeAV reads sample.php rt=6: [{"av_detections": [{"av": ..., "detection": ...}]}].
+func decodeAV(raw json.RawMessage, r *Result) error {
+	var records []struct {
+		AVDetections []AVDetection `json:"av_detections"`
+	}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		for _, d := range rec.AVDetections {
+			d.AV = output.StripANSI(d.AV)
+			d.Detection = output.StripANSI(d.Detection)
+			r.AVDetections = append(r.AVDetections, d)
+		}
+	}
+	return nil
+}
+
+// decodeStrings reads a plain JSON string array into dst.
+func decodeStrings(raw json.RawMessage, dst *[]string) error {
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return err
+	}
+	cleaned := make([]string, 0, len(list))
+	for _, v := range list {
+		cleaned = append(cleaned, output.StripANSI(v))
+	}
+	*dst = cleaned
+	return nil
+}
+
+// rawScalar formats a JSON string or number as a string; ThreatMiner is not
+// consistent about quoting numeric fields such as ports, years, and ASNs.
+func rawScalar(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String()
+	}
+	return string(raw)
+}
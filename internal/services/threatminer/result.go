@@ -3,6 +3,7 @@ package threatminer
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/tbckr/trident/internal/output"
 )
@@ -25,63 +26,206 @@ type HashMetadata struct {
 	FileSize string `json:"file_size"`
 }
 
+// WhoisInfo holds WHOIS data for a domain (registration fields) or an IP
+// address (routing fields).
+type WhoisInfo struct {
+	Registrar   string   `json:"registrar,omitempty"`
+	Created     string   `json:"created,omitempty"`
+	Updated     string   `json:"updated,omitempty"`
+	Expires     string   `json:"expires,omitempty"`
+	NameServers []string `json:"name_servers,omitempty"`
+	Emails      []string `json:"emails,omitempty"`
+	ReverseName string   `json:"reverse_name,omitempty"`
+	ASN         string   `json:"asn,omitempty"`
+	ASName      string   `json:"as_name,omitempty"`
+	BGPPrefix   string   `json:"bgp_prefix,omitempty"`
+	Country     string   `json:"country,omitempty"`
+	Org         string   `json:"org,omitempty"`
+}
+
+// URIEntry is a URL observed on a domain or IP.
+type URIEntry struct {
+	URI      string `json:"uri"`
+	Domain   string `json:"domain,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	LastSeen string `json:"last_seen,omitempty"`
+}
+
+// Report is a public threat report that mentions the input.
+type Report struct {
+	Filename string `json:"filename"`
+	Year     string `json:"year,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// HTTPRequest is one HTTP request made by a sample in the sandbox.
+type HTTPRequest struct {
+	Domain    string `json:"domain,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	Port      string `json:"port,omitempty"`
+	Method    string `json:"method,omitempty"`
+	URL       string `json:"url,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// HostEntry is a host contacted by a sample or serving a certificate.
+type HostEntry struct {
+	Domain string `json:"domain,omitempty"`
+	IP     string `json:"ip"`
+}
+
+// AVDetection is one antivirus verdict for a sample.
+type AVDetection struct {
+	AV        string `json:"av"`
+	Detection string `json:"detection"`
+}
+
 // Result holds the output of a ThreatMiner query.
 type Result struct {
 	Input      string      `json:"input"`
 	InputType  string      `json:"input_type"`
+	Whois      *WhoisInfo  `json:"whois,omitempty"`
 	PassiveDNS []PDNSEntry `json:"passive_dns,omitempty"`
 	Subdomains []string    `json:"subdomains,omitempty"`
+	URIs       []URIEntry  `json:"uris,omitempty"`
+	// RelatedSamples holds hashes of samples associated with a domain or IP.
+	RelatedSamples  []string `json:"related_samples,omitempty"`
+	SSLCertificates []string `json:"ssl_certificates,omitempty"` // SHA1 hashes seen on an IP
+	Reports         []Report `json:"reports,omitempty"`
 	// Hash-specific fields — non-nil only for hash queries
-	HashInfo *HashMetadata `json:"hash_info,omitempty"`
+	HashInfo     *HashMetadata `json:"hash_info,omitempty"`
+	HTTPTraffic  []HTTPRequest `json:"http_traffic,omitempty"`
+	Hosts        []HostEntry   `json:"hosts,omitempty"` // also used for SSL certificate queries
+	Mutants      []string      `json:"mutants,omitempty"`
+	RegistryKeys []string      `json:"registry_keys,omitempty"`
+	AVDetections []AVDetection `json:"av_detections,omitempty"`
+	// Domains holds domains registered with an email address.
+	Domains []string `json:"domains,omitempty"`
 }
 
 // IsEmpty returns true when the result contains no data.
 func (r *Result) IsEmpty() bool {
-	return len(r.PassiveDNS) == 0 && len(r.Subdomains) == 0 && r.HashInfo == nil
+	for _, t := range subTables {
+		if len(t.rows(r)) > 0 {
+			return false
+		}
+	}
+	return true
 }
 
-// WriteTable writes a human-readable table to w.
-func (r *Result) WriteTable(w io.Writer) error {
-	if r.InputType == string(inputHash) && r.HashInfo != nil {
-		h := r.HashInfo
-		tbl := output.NewWrappingTable(w, 20, 20)
-		tbl.Header([]string{"Field", "Value"})
-		rows := [][]string{
-			{"MD5", h.MD5},
-			{"SHA1", h.SHA1},
-			{"SHA256", h.SHA256},
-			{"File Type", h.FileType},
-			{"File Name", h.FileName},
-			{"File Size", h.FileSize},
-		}
-		if err := tbl.Bulk(rows); err != nil {
-			return err
-		}
-		return tbl.Render()
+// subTable describes one section of the table output. The MultiResult
+// variant prepends an Input column and renders with the multi widths.
+type subTable struct {
+	header                  []string
+	minWidth, overhead      int
+	multiMinWidth, multiOvh int
+	rows                    func(r *Result) [][]string
+}
+
+// subTables lists the table sections in display order.
+var subTables = []subTable{
+	{[]string{"Field", "Value"}, 20, 20, 20, 30, hashRows},
+	{[]string{"Field", "Value"}, 20, 20, 20, 30, whoisRows},
+	{[]string{"IP", "Domain", "First Seen", "Last Seen"}, 20, 30, 20, 40, func(r *Result) [][]string {
+		return mapRows(r.PassiveDNS, func(e PDNSEntry) []string { return []string{e.IP, e.Domain, e.FirstSeen, e.LastSeen} })
+	}},
+	{[]string{"Subdomain"}, 30, 6, 30, 20, func(r *Result) [][]string { return listRows(r.Subdomains) }},
+	{[]string{"URI", "Domain", "IP", "Last Seen"}, 30, 30, 30, 40, func(r *Result) [][]string {
+		return mapRows(r.URIs, func(e URIEntry) []string { return []string{e.URI, e.Domain, e.IP, e.LastSeen} })
+	}},
+	{[]string{"Related Sample"}, 30, 6, 30, 20, func(r *Result) [][]string { return listRows(r.RelatedSamples) }},
+	{[]string{"SSL Certificate"}, 30, 6, 30, 20, func(r *Result) [][]string { return listRows(r.SSLCertificates) }},
+	{[]string{"Domain", "IP"}, 20, 20, 20, 30, func(r *Result) [][]string {
+		return mapRows(r.Hosts, func(e HostEntry) []string { return []string{e.Domain, e.IP} })
+	}},
+	{[]string{"Method", "Domain", "IP", "Port", "URL", "User Agent"}, 20, 40, 20, 50, func(r *Result) [][]string {
+		return mapRows(r.HTTPTraffic, func(e HTTPRequest) []string {
+			return []string{e.Method, e.Domain, e.IP, e.Port, e.URL, e.UserAgent}
+		})
+	}},
+	{[]string{"Mutant"}, 30, 6, 30, 20, func(r *Result) [][]string { return listRows(r.Mutants) }},
+	{[]string{"Registry Key"}, 30, 6, 30, 20, func(r *Result) [][]string { return listRows(r.RegistryKeys) }},
+	{[]string{"AV", "Detection"}, 20, 20, 20, 30, func(r *Result) [][]string {
+		return mapRows(r.AVDetections, func(e AVDetection) []string { return []string{e.AV, e.Detection} })
+	}},
+	{[]string{"Report", "Year", "URL"}, 30, 20, 30, 30, func(r *Result) [][]string {
+		return mapRows(r.Reports, func(e Report) []string { return []string{e.Filename, e.Year, e.URL} })
+	}},
+	{[]string{"Domain"}, 30, 6, 30, 20, func(r *Result) [][]string { return listRows(r.Domains) }},
+}
+
+// hashRows returns the hash metadata as Field/Value rows. All fields are
+// listed, including empty ones, so the layout is stable across samples.
+func hashRows(r *Result) [][]string {
+	h := r.HashInfo
+	if h == nil {
+		return nil
 	}
+	return [][]string{
+		{"MD5", h.MD5},
+		{"SHA1", h.SHA1},
+		{"SHA256", h.SHA256},
+		{"File Type", h.FileType},
+		{"File Name", h.FileName},
+		{"File Size", h.FileSize},
+	}
+}
 
-	if len(r.PassiveDNS) > 0 {
-		tbl := output.NewWrappingTable(w, 20, 30)
-		tbl.Header([]string{"IP", "Domain", "First Seen", "Last Seen"})
-		rows := make([][]string, 0, len(r.PassiveDNS))
-		for _, e := range r.PassiveDNS {
-			rows = append(rows, []string{e.IP, e.Domain, e.FirstSeen, e.LastSeen})
-		}
-		if err := tbl.Bulk(rows); err != nil {
-			return err
-		}
-		if err := tbl.Render(); err != nil {
-			return err
+// whoisRows returns the non-empty WHOIS fields as Field/Value rows.
+func whoisRows(r *Result) [][]string {
+	wi := r.Whois
+	if wi == nil {
+		return nil
+	}
+	fields := [][]string{
+		{"Registrar", wi.Registrar},
+		{"Created", wi.Created},
+		{"Updated", wi.Updated},
+		{"Expires", wi.Expires},
+		{"Name Servers", strings.Join(wi.NameServers, "\n")},
+		{"Emails", strings.Join(wi.Emails, "\n")},
+		{"Reverse Name", wi.ReverseName},
+		{"ASN", wi.ASN},
+		{"AS Name", wi.ASName},
+		{"BGP Prefix", wi.BGPPrefix},
+		{"Country", wi.Country},
+		{"Org", wi.Org},
+	}
+	var rows [][]string
+	for _, f := range fields {
+		if f[1] != "" {
+			rows = append(rows, f)
 		}
 	}
+	return rows
+}
+
+func listRows(values []string) [][]string {
+	rows := make([][]string, 0, len(values))
+	for _, v := range values {
+		rows = append(rows, []string{v})
+	}
+	return rows
+}
 
-	if len(r.Subdomains) > 0 {
-		tbl := output.NewWrappingTable(w, 30, 6)
-		tbl.Header([]string{"Subdomain"})
-		rows := make([][]string, 0, len(r.Subdomains))
-		for _, s := range r.Subdomains {
-			rows = append(rows, []string{s})
+func mapRows[T any](entries []T, row func(T) []string) [][]string {
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, row(e))
+	}
+	return rows
+}
+
+// WriteTable writes a human-readable table to w, one sub-table per section
+// that holds data.
+func (r *Result) WriteTable(w io.Writer) error {
+	for _, t := range subTables {
+		rows := t.rows(r)
+		if len(rows) == 0 {
+			continue
 		}
+		tbl := output.NewWrappingTable(w, t.minWidth, t.overhead)
+		tbl.Header(t.header)
 		if err := tbl.Bulk(rows); err != nil {
 			return err
 		}
@@ -89,41 +233,65 @@ func (r *Result) WriteTable(w io.Writer) error {
 			return err
 		}
 	}
-
 	return nil
 }
 
-// WriteText writes one record per line to w.
+// textLines returns the plain-text records of r in display order.
 //
-// For passive DNS: "<ip> <domain>" per entry.
-// For subdomains: one subdomain per line.
-// For hashes: "<field>: <value>" per field.
-func (r *Result) WriteText(w io.Writer) error {
-	if r.InputType == string(inputHash) && r.HashInfo != nil {
-		h := r.HashInfo
-		fields := [][2]string{
-			{"MD5", h.MD5},
-			{"SHA1", h.SHA1},
-			{"SHA256", h.SHA256},
-			{"FileType", h.FileType},
-			{"FileName", h.FileName},
-			{"FileSize", h.FileSize},
-		}
-		for _, f := range fields {
-			if _, err := fmt.Fprintf(w, "%s: %s\n", f[0], f[1]); err != nil {
-				return err
-			}
+// For hashes and WHOIS: "<field>: <value>" per field.
+// For passive DNS and hosts: "<ip> <domain>" per entry.
+// For HTTP traffic: "<method> <domain><url>" per request.
+// For AV detections: "<av>: <detection>" per engine.
+// For reports: "<filename> <url>" per report.
+// Everything else: one value per line.
+func (r *Result) textLines() []string {
+	var lines []string
+	if h := r.HashInfo; h != nil {
+		lines = append(lines,
+			"MD5: "+h.MD5,
+			"SHA1: "+h.SHA1,
+			"SHA256: "+h.SHA256,
+			"FileType: "+h.FileType,
+			"FileName: "+h.FileName,
+			"FileSize: "+h.FileSize,
+		)
+	}
+	for _, row := range whoisRows(r) {
+		for v := range strings.SplitSeq(row[1], "\n") {
+			lines = append(lines, strings.ReplaceAll(row[0], " ", "")+": "+v)
 		}
-		return nil
 	}
-
 	for _, e := range r.PassiveDNS {
-		if _, err := fmt.Fprintf(w, "%s %s\n", e.IP, e.Domain); err != nil {
-			return err
-		}
+		lines = append(lines, e.IP+" "+e.Domain)
+	}
+	lines = append(lines, r.Subdomains...)
+	for _, e := range r.URIs {
+		lines = append(lines, e.URI)
+	}
+	lines = append(lines, r.RelatedSamples...)
+	lines = append(lines, r.SSLCertificates...)
+	for _, e := range r.Hosts {
+		lines = append(lines, strings.TrimSpace(e.IP+" "+e.Domain))
 	}
-	for _, s := range r.Subdomains {
-		if _, err := fmt.Fprintln(w, s); err != nil {
+	for _, e := range r.HTTPTraffic {
+		lines = append(lines, e.Method+" "+e.Domain+e.URL)
+	}
+	lines = append(lines, r.Mutants...)
+	lines = append(lines, r.RegistryKeys...)
+	for _, e := range r.AVDetections {
+		lines = append(lines, e.AV+": "+e.Detection)
+	}
+	for _, e := range r.Reports {
+		lines = append(lines, strings.TrimSpace(e.Filename+" "+e.URL))
+	}
+	lines = append(lines, r.Domains...)
+	return lines
+}
+
+// WriteText writes one record per line to w.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, line := range r.textLines() {
+		if _, err := fmt.Fprintln(w, line); err != nil {
 			return err
 		}
 	}
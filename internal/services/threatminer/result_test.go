@@ -82,3 +82,40 @@ func TestResult_WriteText_Hash(t *testing.T) {
 	assert.Contains(t, out, "MD5: d41d8cd98f00b204e9800998ecf8427e")
 	assert.Contains(t, out, "FileType: PE32")
 }
+
+func TestResult_WriteTable_Sections(t *testing.T) {
+	result := &threatminer.Result{
+		Input:     "1.2.3.4",
+		InputType: "ip",
+		Whois:     &threatminer.WhoisInfo{ASN: "15133", BGPPrefix: "1.2.3.0/24"},
+		URIs:      []threatminer.URIEntry{{URI: "http://1.2.3.4/a.exe", IP: "1.2.3.4"}},
+		Reports:   []threatminer.Report{{Filename: "APT_Report.pdf", Year: "2015"}},
+		AVDetections: []threatminer.AVDetection{
+			{AV: "Sophos", Detection: "W32/Agent"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "BGP Prefix")
+	assert.Contains(t, out, "1.2.3.0/24")
+	assert.Contains(t, out, "http://1.2.3.4/a.exe")
+	assert.Contains(t, out, "APT_Report.pdf")
+	assert.Contains(t, out, "W32/Agent")
+	assert.NotContains(t, out, "REGISTRAR", "empty WHOIS fields are omitted")
+}
+
+func TestResult_WriteText_Sections(t *testing.T) {
+	result := &threatminer.Result{
+		Input:       "d41d8cd98f00b204e9800998ecf8427e",
+		InputType:   "hash",
+		HTTPTraffic: []threatminer.HTTPRequest{{Method: "GET", Domain: "evil.example", URL: "/gate.php"}},
+		Hosts:       []threatminer.HostEntry{{Domain: "evil.example", IP: "198.51.100.7"}, {IP: "203.0.113.9"}},
+		AVDetections: []threatminer.AVDetection{
+			{AV: "Sophos", Detection: "W32/Agent"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "198.51.100.7 evil.example\n203.0.113.9\nGET evil.example/gate.php\nSophos: W32/Agent\n", buf.String())
+}
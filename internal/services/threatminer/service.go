@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/imroc/req/v3"
@@ -17,11 +19,7 @@ import (
 )
 
 const (
-	baseURL      = "https://api.threatminer.org/v2"
-	domainPDNS   = baseURL + "/domain.php?q=%s&rt=2"
-	domainSubs   = baseURL + "/domain.php?q=%s&rt=5"
-	ipPDNS       = baseURL + "/host.php?q=%s&rt=2"
-	hashMetadata = baseURL + "/sample.php?q=%s&rt=1"
+	baseURL = "https://api.threatminer.org/v2"
 
 	// DefaultRPS is the target request rate for the ThreatMiner service.
 	DefaultRPS float64 = 1.0
@@ -32,6 +30,10 @@ const (
 	Name = "threatminer"
 	// PAP is the PAP activity level for the ThreatMiner service.
 	PAP = pap.AMBER
+
+	// sslPrefix marks an SSL certificate SHA1 input, which is otherwise
+	// indistinguishable from a SHA1 file hash.
+	sslPrefix = "ssl:"
 )
 
 // inputType classifies the kind of input accepted by the service.
@@ -41,8 +43,124 @@ const (
 	inputDomain inputType = "domain"
 	inputIP     inputType = "ip"
 	inputHash   inputType = "hash"
+	inputSSL    inputType = "ssl"
+	inputEmail  inputType = "email"
 )
 
+// Section names one ThreatMiner report type that can be selected with
+// EnableSections.
+type Section string
+
+// Sections supported by the ThreatMiner API. Each applies to the input types
+// listed in endpoints; selecting a section that does not apply to an input
+// has no effect for that input.
+const (
+	SectionWhois      Section = "whois"      // domain, ip
+	SectionPDNS       Section = "pdns"       // domain, ip
+	SectionURIs       Section = "uris"       // domain, ip
+	SectionSamples    Section = "samples"    // domain, ip: related sample hashes
+	SectionSubdomains Section = "subdomains" // domain
+	SectionReports    Section = "reports"    // domain, ip, hash, ssl: report tagging
+	SectionSSL        Section = "ssl"        // ip: SSL certificate hashes
+	SectionMetadata   Section = "metadata"   // hash
+	SectionHTTP       Section = "http"       // hash: HTTP traffic
+	SectionHosts      Section = "hosts"      // hash, ssl
+	SectionMutants    Section = "mutants"    // hash
+	SectionRegistry   Section = "registry"   // hash: registry keys
+	SectionAV         Section = "av"         // hash: AV detections
+	SectionDomains    Section = "domains"    // email: registered domains
+)
+
+// endpoint maps a section of one input type to a ThreatMiner API call.
+type endpoint struct {
+	section Section
+	path    string // e.g. "domain.php"
+	rt      int    // report type query parameter
+	byDef   bool   // queried when no sections are selected
+	decode  func(raw json.RawMessage, r *Result) error
+}
+
+// endpoints lists the API calls per input type in display order.
+var endpoints = map[inputType][]endpoint{
+	inputDomain: {
+		{SectionWhois, "domain.php", 1, false, decodeDomainWhois},
+		{SectionPDNS, "domain.php", 2, true, decodePDNS},
+		{SectionURIs, "domain.php", 3, false, decodeURIs},
+		{SectionSamples, "domain.php", 4, false, decodeSamples},
+		{SectionSubdomains, "domain.php", 5, true, decodeSubdomains},
+		{SectionReports, "domain.php", 6, false, decodeReports},
+	},
+	inputIP: {
+		{SectionWhois, "host.php", 1, false, decodeHostWhois},
+		{SectionPDNS, "host.php", 2, true, decodePDNS},
+		{SectionURIs, "host.php", 3, false, decodeURIs},
+		{SectionSamples, "host.php", 4, false, decodeSamples},
+		{SectionSSL, "host.php", 5, false, decodeSSLCerts},
+		{SectionReports, "host.php", 6, false, decodeReports},
+	},
+	inputHash: {
+		{SectionMetadata, "sample.php", 1, true, decodeMetadata},
+		{SectionHTTP, "sample.php", 2, false, decodeHTTPTraffic},
+		{SectionHosts, "sample.php", 3, false, decodeSampleHosts},
+		{SectionMutants, "sample.php", 4, false, decodeMutants},
+		{SectionRegistry, "sample.php", 5, false, decodeRegistry},
+		{SectionAV, "sample.php", 6, false, decodeAV},
+		{SectionReports, "sample.php", 7, false, decodeReports},
+	},
+	inputSSL: {
+		{SectionHosts, "ssl.php", 1, true, decodeSSLHosts},
+		{SectionReports, "ssl.php", 2, false, decodeReports},
+	},
+	inputEmail: {
+		{SectionDomains, "email.php", 1, true, decodeEmailDomains},
+	},
+}
+
+// ParseSections validates section names. "all" selects every section; an
+// empty list selects the defaults.
+func ParseSections(names []string) ([]Section, error) {
+	known := map[Section]bool{}
+	for _, eps := range endpoints {
+		for _, ep := range eps {
+			known[ep.section] = true
+		}
+	}
+	var out []Section
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if n == "all" {
+			out = out[:0]
+			for s := range known {
+				out = append(out, s)
+			}
+			slices.Sort(out)
+			return out, nil
+		}
+		if !known[Section(n)] {
+			return nil, fmt.Errorf("%w: unknown ThreatMiner section %q (valid: %s, all)",
+				services.ErrInvalidInput, n, strings.Join(SectionNames(), ", "))
+		}
+		out = append(out, Section(n))
+	}
+	return out, nil
+}
+
+// SectionNames returns every section name in sorted order.
+func SectionNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, eps := range endpoints {
+		for _, ep := range eps {
+			if !seen[string(ep.section)] {
+				seen[string(ep.section)] = true
+				names = append(names, string(ep.section))
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 // apiResponse is the JSON envelope returned by ThreatMiner.
 type apiResponse struct {
 	StatusCode    string          `json:"status_code"`
@@ -52,8 +170,9 @@ type apiResponse struct {
 
 // Service queries the ThreatMiner API.
 type Service struct {
-	client *req.Client
-	logger *slog.Logger
+	client   *req.Client
+	logger   *slog.Logger
+	sections map[Section]bool // nil = defaults
 }
 
 // NewService creates a new ThreatMiner Service.
@@ -61,6 +180,20 @@ func NewService(client *req.Client, logger *slog.Logger) *Service {
 	return &Service{client: client, logger: logger}
 }
 
+// EnableSections restricts queries to the given sections instead of the
+// defaults (pdns and subdomains for domains, pdns for IPs, metadata for
+// hashes, hosts for SSL certificates, domains for emails).
+func (s *Service) EnableSections(sections []Section) {
+	if len(sections) == 0 {
+		s.sections = nil
+		return
+	}
+	s.sections = make(map[Section]bool, len(sections))
+	for _, sec := range sections {
+		s.sections[sec] = true
+	}
+}
+
 // Name returns the service identifier.
 func (s *Service) Name() string { return Name }
 
@@ -76,107 +209,46 @@ func (s *Service) AggregateResults(results []services.Result) services.Result {
 	return mr
 }
 
-// Run queries ThreatMiner for the given input (domain, IP, or hash).
+// Run queries ThreatMiner for the given input (domain, IP, file hash,
+// "ssl:<sha1>" certificate hash, or email address). Every selected section
+// applicable to the input type is queried in turn.
 func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
-	itype, err := classify(input)
+	itype, query, err := classify(input)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", services.ErrInvalidInput, input)
 	}
 
 	result := &Result{Input: output.StripANSI(input), InputType: string(itype)}
-
-	switch itype {
-	case inputDomain:
-		if err := s.fetchDomainData(ctx, input, result); err != nil {
-			return nil, err
-		}
-	case inputIP:
-		if err := s.fetchIPData(ctx, input, result); err != nil {
-			return nil, err
+	for _, ep := range endpoints[itype] {
+		if !s.selected(ep) {
+			continue
 		}
-	case inputHash:
-		if err := s.fetchHashData(ctx, input, result); err != nil {
+		raw, err := s.fetch(ctx, fmt.Sprintf("%s/%s?q=%s&rt=%d", baseURL, ep.path, url.QueryEscape(query), ep.rt))
+		if err != nil {
 			return nil, err
 		}
-	}
-
-	return result, nil
-}
-
-func (s *Service) fetchDomainData(ctx context.Context, domain string, result *Result) error {
-	pdns, err := s.fetch(ctx, fmt.Sprintf(domainPDNS, domain))
-	if err != nil {
-		return err
-	}
-	if pdns != nil {
-		var entries []PDNSEntry
-		if err := json.Unmarshal(pdns, &entries); err == nil {
-			for i := range entries {
-				entries[i].IP = output.StripANSI(entries[i].IP)
-				entries[i].Domain = output.StripANSI(entries[i].Domain)
-			}
-			result.PassiveDNS = entries
+		if raw == nil {
+			continue
 		}
-	}
-
-	subs, err := s.fetch(ctx, fmt.Sprintf(domainSubs, domain))
-	if err != nil {
-		return err
-	}
-	if subs != nil {
-		var subList []string
-		if err := json.Unmarshal(subs, &subList); err == nil {
-			cleaned := make([]string, 0, len(subList))
-			for _, sub := range subList {
-				cleaned = append(cleaned, output.StripANSI(sub))
-			}
-			result.Subdomains = cleaned
+		if err := ep.decode(raw, result); err != nil {
+			s.logger.Debug("threatminer: skipping undecodable section",
+				"input", input, "section", ep.section, "error", err)
 		}
 	}
-	return nil
+	return result, nil
 }
 
-func (s *Service) fetchIPData(ctx context.Context, ip string, result *Result) error {
-	pdns, err := s.fetch(ctx, fmt.Sprintf(ipPDNS, ip))
-	if err != nil {
-		return err
-	}
-	if pdns != nil {
-		var entries []PDNSEntry
-		if err := json.Unmarshal(pdns, &entries); err == nil {
-			for i := range entries {
-				entries[i].IP = output.StripANSI(entries[i].IP)
-				entries[i].Domain = output.StripANSI(entries[i].Domain)
-			}
-			result.PassiveDNS = entries
-		}
+// selected reports whether ep is queried under the current section selection.
+func (s *Service) selected(ep endpoint) bool {
+	if s.sections == nil {
+		return ep.byDef
 	}
-	return nil
-}
-
-func (s *Service) fetchHashData(ctx context.Context, hash string, result *Result) error {
-	raw, err := s.fetch(ctx, fmt.Sprintf(hashMetadata, hash))
-	if err != nil {
-		return err
-	}
-	if raw != nil {
-		var metas []HashMetadata
-		if err := json.Unmarshal(raw, &metas); err == nil && len(metas) > 0 {
-			m := metas[0]
-			m.MD5 = output.StripANSI(m.MD5)
-			m.SHA1 = output.StripANSI(m.SHA1)
-			m.SHA256 = output.StripANSI(m.SHA256)
-			m.FileType = output.StripANSI(m.FileType)
-			m.FileName = output.StripANSI(m.FileName)
-			result.HashInfo = &m
-		}
-	}
-	return nil
+	return s.sections[ep.section]
 }
 
 // fetch performs a GET request and returns the raw results JSON, or nil on 404/no-results.
-func (s *Service) fetch(ctx context.Context, url string) (json.RawMessage, error) {
-	resp, err := s.client.R().SetContext(ctx).Get(url)
+func (s *Service) fetch(ctx context.Context, rawURL string) (json.RawMessage, error) {
+	resp, err := s.client.R().SetContext(ctx).Get(rawURL)
 	if err != nil {
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			return nil, nil
@@ -204,21 +276,34 @@ func (s *Service) fetch(ctx context.Context, url string) (json.RawMessage, error
 	return envelope.Results, nil
 }
 
-// classify determines the input type.
-func classify(input string) (inputType, error) {
+// classify determines the input type and the query value sent to the API.
+func classify(input string) (inputType, string, error) {
 	if input == "" {
-		return "", fmt.Errorf("empty input")
+		return "", "", fmt.Errorf("empty input")
 	}
 	if net.ParseIP(input) != nil {
-		return inputIP, nil
+		return inputIP, input, nil
+	}
+	if len(input) > len(sslPrefix) && strings.EqualFold(input[:len(sslPrefix)], sslPrefix) {
+		cert := input[len(sslPrefix):]
+		if len(cert) != 40 || !isHex(cert) {
+			return "", "", fmt.Errorf("SSL certificate hash must be a SHA1 hex string")
+		}
+		return inputSSL, strings.ToLower(cert), nil
 	}
 	if isHash(input) {
-		return inputHash, nil
+		return inputHash, input, nil
+	}
+	if local, domain, ok := strings.Cut(input, "@"); ok {
+		if local == "" || strings.ContainsAny(local, " \t\"<>") || !services.IsDomain(domain) {
+			return "", "", fmt.Errorf("invalid email address")
+		}
+		return inputEmail, input, nil
 	}
 	if services.IsDomain(input) {
-		return inputDomain, nil
+		return inputDomain, input, nil
 	}
-	return "", fmt.Errorf("unrecognised input")
+	return "", "", fmt.Errorf("unrecognised input")
 }
 
 // isHash returns true for 32-char (MD5), 40-char (SHA1), or 64-char (SHA256) hex strings.
@@ -140,13 +140,153 @@ func TestRun_Hash_SHA256(t *testing.T) {
 	assert.Equal(t, "hash", result.InputType)
 }
 
+// ---------- Sections ----------
+
+func registerFixture(t *testing.T, url, fixture string) {
+	t.Helper()
+	httpmock.RegisterResponder(http.MethodGet, url,
+		httpmock.NewBytesResponder(http.StatusOK, mustReadFile(t, "testdata/"+fixture)))
+}
+
+func TestRun_Domain_Sections(t *testing.T) {
+	client := newTestClient(t)
+	registerFixture(t, "https://api.threatminer.org/v2/domain.php?q=example.com&rt=1", "domain_whois.json")
+	registerFixture(t, "https://api.threatminer.org/v2/domain.php?q=example.com&rt=3", "domain_uris.json")
+	registerFixture(t, "https://api.threatminer.org/v2/domain.php?q=example.com&rt=6", "domain_reports.json")
+
+	svc := threatminer.NewService(client, testutil.NopLogger())
+	sections, err := threatminer.ParseSections([]string{"whois", "uris", "reports", "av"})
+	require.NoError(t, err)
+	svc.EnableSections(sections)
+
+	raw, err := svc.Run(context.Background(), "example.com")
+	require.NoError(t, err)
+	result := raw.(*threatminer.Result)
+
+	require.NotNil(t, result.Whois)
+	assert.Equal(t, "RESERVED-Internet Assigned Numbers Authority", result.Whois.Registrar)
+	assert.Equal(t, []string{"a.iana-servers.net", "b.iana-servers.net"}, result.Whois.NameServers)
+	assert.Equal(t, []string{"admin@example.com"}, result.Whois.Emails)
+	require.Len(t, result.URIs, 1)
+	assert.Equal(t, "http://example.com/payload.exe", result.URIs[0].URI)
+	require.Len(t, result.Reports, 1)
+	assert.Equal(t, "2015", result.Reports[0].Year)
+	assert.Empty(t, result.PassiveDNS, "pdns was not selected")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestRun_IP_Sections(t *testing.T) {
+	client := newTestClient(t)
+	registerFixture(t, "https://api.threatminer.org/v2/host.php?q=93.184.216.34&rt=1", "ip_whois.json")
+	registerFixture(t, "https://api.threatminer.org/v2/host.php?q=93.184.216.34&rt=5", "ip_ssl.json")
+
+	svc := threatminer.NewService(client, testutil.NopLogger())
+	svc.EnableSections([]threatminer.Section{threatminer.SectionWhois, threatminer.SectionSSL})
+
+	raw, err := svc.Run(context.Background(), "93.184.216.34")
+	require.NoError(t, err)
+	result := raw.(*threatminer.Result)
+
+	require.NotNil(t, result.Whois)
+	assert.Equal(t, "15133", result.Whois.ASN)
+	assert.Equal(t, "93.184.216.0/24", result.Whois.BGPPrefix)
+	assert.Equal(t, []string{"42a8d5b3a867a59a79f44ffadd61460780fe58f2"}, result.SSLCertificates)
+}
+
+func TestRun_Hash_Sections(t *testing.T) {
+	client := newTestClient(t)
+	hash := "d41d8cd98f00b204e9800998ecf8427e"
+	base := "https://api.threatminer.org/v2/sample.php?q=" + hash
+	registerFixture(t, base+"&rt=2", "hash_http.json")
+	registerFixture(t, base+"&rt=3", "hash_hosts.json")
+	registerFixture(t, base+"&rt=4", "hash_mutants.json")
+	registerFixture(t, base+"&rt=5", "hash_registry.json")
+	registerFixture(t, base+"&rt=6", "hash_av.json")
+
+	svc := threatminer.NewService(client, testutil.NopLogger())
+	sections, err := threatminer.ParseSections([]string{"http", "hosts", "mutants", "registry", "av"})
+	require.NoError(t, err)
+	svc.EnableSections(sections)
+
+	raw, err := svc.Run(context.Background(), hash)
+	require.NoError(t, err)
+	result := raw.(*threatminer.Result)
+
+	assert.Nil(t, result.HashInfo, "metadata was not selected")
+	require.Len(t, result.HTTPTraffic, 1)
+	assert.Equal(t, "80", result.HTTPTraffic[0].Port)
+	assert.Equal(t, "/gate.php", result.HTTPTraffic[0].URL)
+	assert.Equal(t, []threatminer.HostEntry{
+		{Domain: "evil.example", IP: "198.51.100.7"},
+		{IP: "203.0.113.9"},
+	}, result.Hosts)
+	assert.Equal(t, []string{`Global\MutexA`, "MutexB"}, result.Mutants)
+	assert.Len(t, result.RegistryKeys, 1)
+	assert.Equal(t, []threatminer.AVDetection{
+		{AV: "ESET-NOD32", Detection: "Trojan.Generic"},
+		{AV: "Sophos", Detection: "W32/Agent"},
+	}, result.AVDetections)
+}
+
+func TestRun_SSLCertificate(t *testing.T) {
+	client := newTestClient(t)
+	registerFixture(t, "https://api.threatminer.org/v2/ssl.php?q=42a8d5b3a867a59a79f44ffadd61460780fe58f2&rt=1", "ssl_hosts.json")
+
+	svc := threatminer.NewService(client, testutil.NopLogger())
+	raw, err := svc.Run(context.Background(), "ssl:42A8D5B3A867A59A79F44FFADD61460780FE58F2")
+	require.NoError(t, err)
+	result := raw.(*threatminer.Result)
+
+	assert.Equal(t, "ssl", result.InputType)
+	assert.Equal(t, []threatminer.HostEntry{{IP: "198.51.100.7"}, {IP: "203.0.113.9"}}, result.Hosts)
+}
+
+func TestRun_Email(t *testing.T) {
+	client := newTestClient(t)
+	registerFixture(t, "https://api.threatminer.org/v2/email.php?q=admin%40example.com&rt=1", "email_domains.json")
+
+	svc := threatminer.NewService(client, testutil.NopLogger())
+	raw, err := svc.Run(context.Background(), "admin@example.com")
+	require.NoError(t, err)
+	result := raw.(*threatminer.Result)
+
+	assert.Equal(t, "email", result.InputType)
+	assert.Equal(t, []string{"example.com", "example.net"}, result.Domains)
+}
+
+func TestRun_UndecodableSectionSkipped(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet,
+		"https://api.threatminer.org/v2/host.php?q=1.2.3.4&rt=2",
+		httpmock.NewStringResponder(http.StatusOK, `{"status_code":"200","results":{"unexpected":true}}`),
+	)
+
+	svc := threatminer.NewService(client, testutil.NopLogger())
+	raw, err := svc.Run(context.Background(), "1.2.3.4")
+	require.NoError(t, err)
+	assert.True(t, raw.(*threatminer.Result).IsEmpty())
+}
+
+func TestParseSections(t *testing.T) {
+	sections, err := threatminer.ParseSections([]string{"PDNS", " whois "})
+	require.NoError(t, err)
+	assert.Equal(t, []threatminer.Section{threatminer.SectionPDNS, threatminer.SectionWhois}, sections)
+
+	all, err := threatminer.ParseSections([]string{"all"})
+	require.NoError(t, err)
+	assert.Len(t, all, len(threatminer.SectionNames()))
+
+	_, err = threatminer.ParseSections([]string{"bogus"})
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+}
+
 // ---------- Invalid input ----------
 
 func TestRun_InvalidInput(t *testing.T) {
 	client := newTestClient(t)
 	svc := threatminer.NewService(client, testutil.NopLogger())
 
-	for _, bad := range []string{"", "not valid!", "has space.com"} {
+	for _, bad := range []string{"", "not valid!", "has space.com", "ssl:abc", "@example.com", "user@not valid"} {
 		_, err := svc.Run(context.Background(), bad)
 		require.Error(t, err, "input %q should be invalid", bad)
 		assert.ErrorIs(t, err, services.ErrInvalidInput)
@@ -0,0 +1,3 @@
+// Package typo generates typosquat and lookalike permutations of a domain and
+// checks their registration, mail capability, and Quad9 blocked verdict via DoH.
+package typo
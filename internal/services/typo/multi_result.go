@@ -0,0 +1,35 @@
+package typo
+
+import (
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds typo results for multiple domains.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteTable renders the reported permutations of all domains in a single
+// table grouped by source domain. The DNS and verdict columns are included
+// when any result was checked.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	checked := false
+	for _, r := range m.Results {
+		checked = checked || r.Checked
+	}
+	var rows [][]string
+	for _, r := range m.Results {
+		for _, p := range r.hits() {
+			rows = append(rows, append([]string{r.Domain}, p.row(checked)...))
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 20, 40)
+	table.Header(append([]string{"Source"}, header(checked)...))
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
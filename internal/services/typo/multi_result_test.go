@@ -0,0 +1,79 @@
+package typo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/typo"
+)
+
+func TestMultiResult_IsEmpty(t *testing.T) {
+	t.Run("empty when no results", func(t *testing.T) {
+		assert.True(t, (&typo.MultiResult{}).IsEmpty())
+	})
+
+	t.Run("not empty when one domain has permutations", func(t *testing.T) {
+		mr := &typo.MultiResult{}
+		mr.Results = []*typo.Result{
+			{Input: "example.com"},
+			{Input: "example.org", Permutations: []typo.Permutation{{Domain: "exmple.org", Fuzzer: typo.FuzzerOmission}}},
+		}
+		assert.False(t, mr.IsEmpty())
+	})
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	mr := &typo.MultiResult{}
+	mr.Results = []*typo.Result{
+		{
+			Input: "example.com", Domain: "example.com", Checked: true,
+			Permutations: []typo.Permutation{
+				{Domain: "exmple.com", Fuzzer: typo.FuzzerOmission, Registered: true, A: []string{"192.0.2.1"}},
+				{Domain: "exampel.com", Fuzzer: typo.FuzzerTransposition},
+			},
+		},
+		{
+			Input: "example.org", Domain: "example.org", Checked: true,
+			Permutations: []typo.Permutation{{Domain: "examp1e.org", Fuzzer: typo.FuzzerHomoglyph, Malicious: true}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "SOURCE")
+	assert.Contains(t, out, "example.org")
+	assert.Contains(t, out, "exmple.com")
+	assert.Contains(t, out, "examp1e.org")
+	assert.NotContains(t, out, "exampel.com")
+	assert.Contains(t, out, "VERDICT")
+}
+
+func TestMultiResult_WriteTable_GenerateOnly(t *testing.T) {
+	mr := &typo.MultiResult{}
+	mr.Results = []*typo.Result{
+		{Input: "example.com", Domain: "example.com", Permutations: []typo.Permutation{{Domain: "exampel.com", Fuzzer: typo.FuzzerTransposition}}},
+		{Input: "example.org", Domain: "example.org", Permutations: []typo.Permutation{{Domain: "exmple.org", Fuzzer: typo.FuzzerOmission}}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "exampel.com")
+	assert.Contains(t, out, "exmple.org")
+	assert.NotContains(t, out, "VERDICT")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	mr := &typo.MultiResult{}
+	mr.Results = []*typo.Result{
+		{Input: "example.com", Checked: true, Permutations: []typo.Permutation{{Domain: "exmple.com", Registered: true}, {Domain: "exampel.com"}}},
+		{Input: "example.org", Checked: true, Permutations: []typo.Permutation{{Domain: "examp1e.org", Malicious: true}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteText(&buf))
+	assert.Equal(t, "exmple.com\nexamp1e.org\n", buf.String())
+}
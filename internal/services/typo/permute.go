@@ -0,0 +1,211 @@
+package typo
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Fuzzer names identify the permutation technique that produced a domain.
+const (
+	FuzzerOmission      = "omission"
+	FuzzerTransposition = "transposition"
+	FuzzerHomoglyph     = "homoglyph"
+	FuzzerBitsquat      = "bitsquat"
+	FuzzerTLDSwap       = "tld-swap"
+	FuzzerHyphenation   = "hyphenation"
+	FuzzerVowelSwap     = "vowel-swap"
+	FuzzerIDNHomograph  = "idn-homograph"
+)
+
+// swapTLDs are the public suffixes substituted by the tld-swap fuzzer.
+var swapTLDs = []string{
+	"com", "net", "org", "info", "biz", "co", "io", "app", "dev", "xyz",
+	"online", "site", "shop", "top", "club", "live", "us", "eu", "de", "co.uk",
+}
+
+// asciiHomoglyphs maps a character sequence to ASCII sequences that look alike.
+var asciiHomoglyphs = map[string][]string{
+	"a": {"4"}, "b": {"6"}, "d": {"cl"}, "e": {"3"}, "g": {"q", "9"},
+	"i": {"1", "l"}, "l": {"1", "i"}, "m": {"rn", "nn"}, "n": {"r"},
+	"o": {"0"}, "q": {"g"}, "s": {"5"}, "u": {"v"}, "v": {"u"}, "w": {"vv"},
+	"z": {"2"}, "0": {"o"}, "1": {"l", "i"}, "rn": {"m"}, "vv": {"w"}, "cl": {"d"},
+}
+
+// idnHomoglyphs maps ASCII letters to visually identical non-Latin characters
+// (mostly Cyrillic) used in IDN homograph attacks.
+var idnHomoglyphs = map[byte][]rune{
+	'a': {'а', 'ạ'}, 'c': {'с'}, 'e': {'е', 'ė'}, 'h': {'һ'}, 'i': {'і'},
+	'j': {'ј'}, 'k': {'κ'}, 'o': {'о', 'ο'}, 'p': {'р'}, 's': {'ѕ'},
+	'x': {'х'}, 'y': {'у'},
+}
+
+const vowels = "aeiou"
+
+// Permutation is a generated lookalike domain together with the technique
+// that produced it and, once checked, its DNS and blocked status.
+type Permutation struct {
+	Domain      string   `json:"domain"`            // ASCII (punycode for IDN homographs)
+	Unicode     string   `json:"unicode,omitempty"` // display form of IDN homographs
+	Fuzzer      string   `json:"fuzzer"`
+	Registered  bool     `json:"registered,omitempty"`
+	MailCapable bool     `json:"mail_capable,omitempty"`
+	Malicious   bool     `json:"malicious,omitempty"` // Quad9 blocked verdict
+	A           []string `json:"a,omitempty"`
+	MX          []string `json:"mx,omitempty"`
+	NS          []string `json:"ns,omitempty"`
+}
+
+// generator accumulates unique, syntactically valid permutations of label.suffix.
+type generator struct {
+	label, suffix string
+	seen          map[string]bool
+	perms         []Permutation
+}
+
+// Generate returns every unique permutation of the registrable domain
+// label.suffix (e.g. "example" + "co.uk"), in fuzzer order. The original
+// domain is never included.
+func Generate(label, suffix string) []Permutation {
+	g := &generator{label: label, suffix: suffix, seen: map[string]bool{label + "." + suffix: true}}
+	g.omission()
+	g.transposition()
+	g.homoglyph()
+	g.bitsquat()
+	g.tldSwap()
+	g.hyphenation()
+	g.vowelSwap()
+	g.idnHomograph()
+	return g.perms
+}
+
+// add records label.suffix when the label is a valid LDH hostname label.
+func (g *generator) add(fuzzer, label, suffix string) {
+	if !isLDHLabel(label) {
+		return
+	}
+	domain := label + "." + suffix
+	if g.seen[domain] {
+		return
+	}
+	g.seen[domain] = true
+	g.perms = append(g.perms, Permutation{Domain: domain, Fuzzer: fuzzer})
+}
+
+func (g *generator) omission() {
+	for i := range len(g.label) {
+		g.add(FuzzerOmission, g.label[:i]+g.label[i+1:], g.suffix)
+	}
+}
+
+func (g *generator) transposition() {
+	for i := range len(g.label) - 1 {
+		if g.label[i] == g.label[i+1] {
+			continue
+		}
+		b := []byte(g.label)
+		b[i], b[i+1] = b[i+1], b[i]
+		g.add(FuzzerTransposition, string(b), g.suffix)
+	}
+}
+
+func (g *generator) homoglyph() {
+	for i := range len(g.label) {
+		for seq, repls := range asciiHomoglyphs {
+			if !strings.HasPrefix(g.label[i:], seq) {
+				continue
+			}
+			for _, r := range repls {
+				g.add(FuzzerHomoglyph, g.label[:i]+r+g.label[i+len(seq):], g.suffix)
+			}
+		}
+	}
+	// Map iteration order is random; keep output deterministic.
+	sortFuzzer(g.perms, FuzzerHomoglyph)
+}
+
+func (g *generator) bitsquat() {
+	for i := range len(g.label) {
+		for bit := range 8 {
+			c := g.label[i] ^ (1 << bit)
+			if isLDHByte(c) && c != '-' {
+				g.add(FuzzerBitsquat, g.label[:i]+string(c)+g.label[i+1:], g.suffix)
+			}
+		}
+	}
+}
+
+func (g *generator) tldSwap() {
+	for _, tld := range swapTLDs {
+		if tld != g.suffix {
+			g.add(FuzzerTLDSwap, g.label, tld)
+		}
+	}
+}
+
+func (g *generator) hyphenation() {
+	for i := 1; i < len(g.label); i++ {
+		g.add(FuzzerHyphenation, g.label[:i]+"-"+g.label[i:], g.suffix)
+	}
+}
+
+func (g *generator) vowelSwap() {
+	for i := range len(g.label) {
+		if !strings.ContainsRune(vowels, rune(g.label[i])) {
+			continue
+		}
+		for _, v := range []byte(vowels) {
+			if v != g.label[i] {
+				g.add(FuzzerVowelSwap, g.label[:i]+string(v)+g.label[i+1:], g.suffix)
+			}
+		}
+	}
+}
+
+func (g *generator) idnHomograph() {
+	for i := range len(g.label) {
+		for _, r := range idnHomoglyphs[g.label[i]] {
+			unicode := g.label[:i] + string(r) + g.label[i+1:]
+			ascii, err := idna.Punycode.ToASCII(unicode)
+			if err != nil {
+				continue
+			}
+			domain := ascii + "." + g.suffix
+			if g.seen[domain] {
+				continue
+			}
+			g.seen[domain] = true
+			g.perms = append(g.perms, Permutation{Domain: domain, Unicode: unicode + "." + g.suffix, Fuzzer: FuzzerIDNHomograph})
+		}
+	}
+}
+
+// sortFuzzer sorts the trailing run of permutations produced by fuzzer by domain.
+func sortFuzzer(perms []Permutation, fuzzer string) {
+	start := len(perms)
+	for start > 0 && perms[start-1].Fuzzer == fuzzer {
+		start--
+	}
+	tail := perms[start:]
+	slices.SortFunc(tail, func(a, b Permutation) int { return strings.Compare(a.Domain, b.Domain) })
+}
+
+// isLDHByte reports whether c is a lowercase letter, digit, or hyphen.
+func isLDHByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-'
+}
+
+// isLDHLabel reports whether s is a valid lowercase hostname label:
+// 1–63 LDH characters, not starting or ending with a hyphen.
+func isLDHLabel(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := range len(s) {
+		if !isLDHByte(s[i]) {
+			return false
+		}
+	}
+	return true
+}
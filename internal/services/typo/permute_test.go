@@ -0,0 +1,86 @@
+package typo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func domainsBy(perms []Permutation, fuzzer string) []string {
+	var out []string
+	for _, p := range perms {
+		if p.Fuzzer == fuzzer {
+			out = append(out, p.Domain)
+		}
+	}
+	return out
+}
+
+func TestGenerate_Fuzzers(t *testing.T) {
+	perms := Generate("paypal", "com")
+
+	assert.Contains(t, domainsBy(perms, FuzzerOmission), "paypl.com")
+	assert.Contains(t, domainsBy(perms, FuzzerTransposition), "apypal.com")
+	assert.Contains(t, domainsBy(perms, FuzzerHomoglyph), "paypa1.com")
+	assert.Contains(t, domainsBy(perms, FuzzerBitsquat), "qaypal.com")
+	assert.Contains(t, domainsBy(perms, FuzzerTLDSwap), "paypal.net")
+	assert.Contains(t, domainsBy(perms, FuzzerTLDSwap), "paypal.co.uk")
+	assert.Contains(t, domainsBy(perms, FuzzerHyphenation), "pay-pal.com")
+	assert.Contains(t, domainsBy(perms, FuzzerVowelSwap), "paypol.com")
+	assert.NotEmpty(t, domainsBy(perms, FuzzerIDNHomograph))
+}
+
+func TestGenerate_MultiCharHomoglyphs(t *testing.T) {
+	homoglyphs := domainsBy(Generate("modern", "com"), FuzzerHomoglyph)
+	assert.Contains(t, homoglyphs, "rnodern.com", "m → rn")
+	assert.Contains(t, homoglyphs, "modem.com", "rn → m")
+	assert.Contains(t, homoglyphs, "moclern.com", "d → cl")
+}
+
+func TestGenerate_IDNHomograph(t *testing.T) {
+	var idn []Permutation
+	for _, p := range Generate("apple", "com") {
+		if p.Fuzzer == FuzzerIDNHomograph {
+			idn = append(idn, p)
+		}
+	}
+	require.NotEmpty(t, idn)
+	assert.Equal(t, "аpple.com", idn[0].Unicode, "Cyrillic а")
+	assert.Equal(t, "xn--pple-43d.com", idn[0].Domain)
+}
+
+func TestGenerate_UniqueValidAndExcludesOriginal(t *testing.T) {
+	perms := Generate("example", "com")
+	seen := map[string]bool{}
+	for _, p := range perms {
+		assert.NotEqual(t, "example.com", p.Domain)
+		assert.False(t, seen[p.Domain], "duplicate %s", p.Domain)
+		seen[p.Domain] = true
+	}
+	assert.NotContains(t, domainsBy(perms, FuzzerHyphenation), "-example.com")
+	assert.NotContains(t, domainsBy(perms, FuzzerTLDSwap), "example.com")
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	assert.Equal(t, Generate("microsoft", "com"), Generate("microsoft", "com"))
+}
+
+func TestIsLDHLabel(t *testing.T) {
+	assert.True(t, isLDHLabel("exa-mple1"))
+	assert.False(t, isLDHLabel(""))
+	assert.False(t, isLDHLabel("-example"))
+	assert.False(t, isLDHLabel("example-"))
+	assert.False(t, isLDHLabel("exa_mple"))
+	assert.False(t, isLDHLabel("Example"))
+}
+
+func FuzzGenerate(f *testing.F) {
+	f.Add("example", "com")
+	f.Add("a", "co.uk")
+	f.Add("", "")
+	f.Fuzz(func(t *testing.T, label, suffix string) {
+		// Must not panic on any input.
+		Generate(label, suffix)
+	})
+}
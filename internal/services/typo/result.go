@@ -0,0 +1,145 @@
+package typo
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Result holds the permutations generated for a single domain.
+type Result struct {
+	Input        string        `json:"input"`
+	Domain       string        `json:"domain"`  // registrable domain the permutations were derived from
+	Checked      bool          `json:"checked"` // false in --generate-only mode
+	Permutations []Permutation `json:"permutations,omitempty"`
+}
+
+// IsEmpty reports whether no permutation was generated.
+func (r *Result) IsEmpty() bool {
+	return len(r.Permutations) == 0
+}
+
+// hits returns the permutations worth reporting: every permutation in
+// generate-only mode, otherwise only those that are registered or blocked.
+func (r *Result) hits() []Permutation {
+	if !r.Checked {
+		return r.Permutations
+	}
+	var out []Permutation
+	for _, p := range r.Permutations {
+		if p.Registered || p.Malicious {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// displayName returns the domain with its Unicode form for IDN homographs.
+func (p Permutation) displayName() string {
+	if p.Unicode != "" {
+		return p.Domain + "\n(" + p.Unicode + ")"
+	}
+	return p.Domain
+}
+
+// verdict summarizes the check outcome, e.g. "registered, mail" or "malicious".
+func (p Permutation) verdict() string {
+	var parts []string
+	if p.Registered {
+		parts = append(parts, "registered")
+	}
+	if p.MailCapable {
+		parts = append(parts, "mail")
+	}
+	if p.Malicious {
+		parts = append(parts, "malicious")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// row returns the table cells for p; checked adds the DNS and verdict columns.
+func (p Permutation) row(checked bool) []string {
+	if !checked {
+		return []string{p.Fuzzer, p.displayName()}
+	}
+	return []string{
+		p.Fuzzer,
+		p.displayName(),
+		strings.Join(p.A, "\n"),
+		strings.Join(p.MX, "\n"),
+		strings.Join(p.NS, "\n"),
+		p.verdict(),
+	}
+}
+
+// header returns the table header matching row.
+func header(checked bool) []string {
+	if !checked {
+		return []string{"Fuzzer", "Domain"}
+	}
+	return []string{"Fuzzer", "Domain", "A", "MX", "NS", "Verdict"}
+}
+
+// summary counts the generated, registered, mail-capable, and malicious permutations.
+func (r *Result) summary() (registered, mail, malicious int) {
+	for _, p := range r.Permutations {
+		if p.Registered {
+			registered++
+		}
+		if p.MailCapable {
+			mail++
+		}
+		if p.Malicious {
+			malicious++
+		}
+	}
+	return registered, mail, malicious
+}
+
+// WriteText renders the reported permutations, one domain per line: every
+// permutation in generate-only mode, otherwise the registered or blocked ones.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, p := range r.hits() {
+		if _, err := fmt.Fprintln(w, p.Domain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the reported permutations as a table. Checked results
+// are followed by a summary of registered, mail-capable, and malicious counts.
+func (r *Result) WriteTable(w io.Writer) error {
+	hits := r.hits()
+	if len(hits) > 0 {
+		rows := make([][]string, 0, len(hits))
+		for _, p := range hits {
+			rows = append(rows, p.row(r.Checked))
+		}
+		table := output.NewWrappingTable(w, 20, 40)
+		table.Header(header(r.Checked))
+		if err := table.Bulk(rows); err != nil {
+			return err
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+	if !r.Checked {
+		return nil
+	}
+	registered, mail, malicious := r.summary()
+	summary := output.NewWrappingTable(w, 20, 20)
+	summary.Header([]string{"Field", "Value"})
+	if err := summary.Bulk([][]string{
+		{"Permutations", fmt.Sprintf("%d", len(r.Permutations))},
+		{"Registered", fmt.Sprintf("%d", registered)},
+		{"Mail-Capable", fmt.Sprintf("%d", mail)},
+		{"Malicious", fmt.Sprintf("%d", malicious)},
+	}); err != nil {
+		return err
+	}
+	return summary.Render()
+}
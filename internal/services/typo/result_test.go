@@ -0,0 +1,105 @@
+package typo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/typo"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&typo.Result{Input: "example.com"}).IsEmpty())
+	assert.False(t, (&typo.Result{Input: "example.com", Permutations: []typo.Permutation{{Domain: "exmple.com", Fuzzer: typo.FuzzerOmission}}}).IsEmpty())
+}
+
+func TestResult_WriteText_Checked(t *testing.T) {
+	result := &typo.Result{
+		Input:   "example.com",
+		Domain:  "example.com",
+		Checked: true,
+		Permutations: []typo.Permutation{
+			{Domain: "exmple.com", Fuzzer: typo.FuzzerOmission, Registered: true},
+			{Domain: "examp1e.com", Fuzzer: typo.FuzzerHomoglyph, Malicious: true},
+			{Domain: "exampel.com", Fuzzer: typo.FuzzerTransposition},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "exmple.com\nexamp1e.com\n", buf.String(),
+		"only registered or blocked permutations are listed")
+}
+
+func TestResult_WriteText_GenerateOnly(t *testing.T) {
+	result := &typo.Result{
+		Input:  "example.com",
+		Domain: "example.com",
+		Permutations: []typo.Permutation{
+			{Domain: "exmple.com", Fuzzer: typo.FuzzerOmission},
+			{Domain: "exampel.com", Fuzzer: typo.FuzzerTransposition},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "exmple.com\nexampel.com\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &typo.Result{
+		Input:   "example.com",
+		Domain:  "example.com",
+		Checked: true,
+		Permutations: []typo.Permutation{
+			{
+				Domain: "exmple.com", Fuzzer: typo.FuzzerOmission, Registered: true, MailCapable: true,
+				A: []string{"192.0.2.1"}, MX: []string{"10 mail.exmple.com."}, NS: []string{"ns1.parking.example."},
+			},
+			{Domain: "examp1e.com", Fuzzer: typo.FuzzerHomoglyph, Malicious: true},
+			{Domain: "exampel.com", Fuzzer: typo.FuzzerTransposition},
+			{Domain: "xn--xample-2of.com", Unicode: "еxample.com", Fuzzer: typo.FuzzerIDNHomograph, Registered: true},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "VERDICT")
+	assert.Contains(t, out, "registered, mail")
+	assert.Contains(t, out, "malicious")
+	assert.Contains(t, out, "(еxample.com)")
+	assert.Contains(t, out, "Mail-Capable")
+	assert.NotContains(t, out, "exampel.com")
+}
+
+func TestResult_WriteTable_NoHits(t *testing.T) {
+	result := &typo.Result{
+		Input:        "example.com",
+		Domain:       "example.com",
+		Checked:      true,
+		Permutations: []typo.Permutation{{Domain: "exampel.com", Fuzzer: typo.FuzzerTransposition}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.NotContains(t, out, "VERDICT", "no permutation table without hits")
+	assert.Contains(t, out, "Permutations")
+}
+
+func TestResult_WriteTable_GenerateOnly(t *testing.T) {
+	result := &typo.Result{
+		Input:  "example.com",
+		Domain: "example.com",
+		Permutations: []typo.Permutation{
+			{Domain: "exmple.com", Fuzzer: typo.FuzzerOmission},
+			{Domain: "exampel.com", Fuzzer: typo.FuzzerTransposition},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "FUZZER")
+	assert.Contains(t, out, "exampel.com")
+	assert.NotContains(t, out, "VERDICT")
+	assert.NotContains(t, out, "Mail-Capable")
+}
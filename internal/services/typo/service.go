@@ -0,0 +1,174 @@
+package typo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"codeberg.org/miekg/dns"
+	"github.com/imroc/req/v3"
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/tbckr/trident/internal/doh"
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// Name is the service identifier.
+	Name = "typo"
+	// PAP is the PAP activity level for registration checks via Quad9 DoH.
+	PAP = pap.AMBER
+	// MinPAP is the PAP activity level of --generate-only mode, which performs
+	// no network activity.
+	MinPAP = pap.RED
+)
+
+// Service generates lookalike permutations of a domain and, unless
+// generateOnly is set, checks each one via Quad9 DoH.
+type Service struct {
+	client       *req.Client
+	logger       *slog.Logger
+	generateOnly bool
+}
+
+// NewService creates a new typo service. When generateOnly is true no DNS
+// queries are made and client may be nil.
+func NewService(client *req.Client, logger *slog.Logger, generateOnly bool) *Service {
+	return &Service{client: client, logger: logger, generateOnly: generateOnly}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns RED in generate-only mode and AMBER when permutations are
+// checked against Quad9 (third-party API).
+func (s *Service) PAP() pap.Level {
+	if s.generateOnly {
+		return MinPAP
+	}
+	return PAP
+}
+
+// AggregateResults combines multiple typo results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run generates permutations of the registrable domain of input and, unless
+// in generate-only mode, checks each permutation in parallel.
+// Partial results are returned when the context is cancelled mid-check.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	input = output.StripANSI(input)
+	if !services.IsDomain(input) {
+		return nil, fmt.Errorf("%w: must be a valid domain name: %q", services.ErrInvalidInput, input)
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(strings.TrimSuffix(input, ".")))
+	if err != nil {
+		return nil, fmt.Errorf("%w: no registrable domain in %q", services.ErrInvalidInput, input)
+	}
+	label, suffix, _ := strings.Cut(domain, ".")
+
+	result := &Result{Input: input, Domain: domain, Permutations: Generate(label, suffix)}
+	if s.generateOnly {
+		return result, nil
+	}
+	result.Checked = true
+
+	errs := make([]error, len(result.Permutations))
+	var wg sync.WaitGroup
+	for i := range result.Permutations {
+		wg.Go(func() {
+			errs[i] = s.check(ctx, &result.Permutations[i])
+		})
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if len(errs) > 0 && failed == len(errs) {
+		return nil, errs[0]
+	}
+	return result, nil
+}
+
+// check queries A for p.Domain. A blocking Extended DNS Error, or NXDOMAIN
+// without authority, is Quad9's blocked verdict; a genuine NXDOMAIN (with SOA
+// authority) means the domain is unregistered. MX and NS are only queried for
+// registered domains.
+// Context cancellation leaves p unchecked and returns nil.
+func (s *Service) check(ctx context.Context, p *Permutation) error {
+	resp, err := doh.MakeDoHRequest(ctx, s.client, p.Domain, dns.TypeA)
+	if err != nil {
+		return s.queryErr(p.Domain, "A", err)
+	}
+	if _, blocked := resp.BlockingEDE(); blocked {
+		p.Malicious = true
+		return nil
+	}
+	if resp.Status == dns.RcodeNameError {
+		p.Malicious = !resp.HasAuthority
+		return nil
+	}
+	if resp.Status != dns.RcodeSuccess {
+		s.logger.Debug("typo: unexpected rcode", "domain", p.Domain, "rcode", resp.Status)
+		return nil
+	}
+	p.Registered = true
+	p.A = answers(resp, dns.TypeA)
+
+	for _, q := range []struct {
+		qtype uint16
+		name  string
+		dst   *[]string
+	}{
+		{dns.TypeMX, "MX", &p.MX},
+		{dns.TypeNS, "NS", &p.NS},
+	} {
+		resp, err := doh.MakeDoHRequest(ctx, s.client, p.Domain, q.qtype)
+		if err != nil {
+			_ = s.queryErr(p.Domain, q.name, err) // MX/NS failures do not fail the check
+			continue
+		}
+		*q.dst = answers(resp, q.qtype)
+	}
+	for _, mx := range p.MX {
+		// A null MX ("0 .", RFC 7505) explicitly declares that the domain accepts no mail.
+		if mx != "0 ." {
+			p.MailCapable = true
+		}
+	}
+	return nil
+}
+
+// queryErr returns nil for context cancellation and err otherwise.
+func (s *Service) queryErr(domain, qtype string, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	s.logger.Debug("typo: query failed", "domain", domain, "type", qtype, "error", err)
+	return err
+}
+
+// answers returns the sanitized data of all answers of type qtype.
+func answers(resp *doh.Response, qtype uint16) []string {
+	var out []string
+	for _, ans := range resp.Answer {
+		if ans.Type == qtype {
+			out = append(out, output.StripANSI(ans.Data))
+		}
+	}
+	return out
+}
@@ -0,0 +1,168 @@
+package typo_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"codeberg.org/miekg/dns"
+	"codeberg.org/miekg/dns/rdata"
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/typo"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+const dohURL = "https://dns.quad9.net/dns-query"
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func hdr(name string) dns.Header {
+	return dns.Header{Name: name + ".", Class: dns.ClassINET, TTL: 300}
+}
+
+// registered answers A/MX/NS for ok domains and returns Quad9's blocked
+// response (NXDOMAIN without authority) for blocked domains. All other names
+// get a genuine NXDOMAIN with an SOA in the authority section.
+func responder(t *testing.T, ok map[string]bool, blocked map[string]bool) httpmock.Responder {
+	t.Helper()
+	return func(r *http.Request) (*http.Response, error) {
+		data, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+		if err != nil {
+			return nil, fmt.Errorf("decode base64url: %w", err)
+		}
+		q := new(dns.Msg)
+		q.Data = data
+		if err := q.Unpack(); err != nil {
+			return nil, fmt.Errorf("unpack DNS query: %w", err)
+		}
+		name := strings.TrimSuffix(q.Question[0].Header().Name, ".")
+
+		m := new(dns.Msg)
+		m.Response = true
+		switch {
+		case ok[name]:
+			switch dns.RRToType(q.Question[0]) {
+			case dns.TypeA:
+				rr := &dns.A{Hdr: hdr(name)}
+				rr.Addr = netip.MustParseAddr("192.0.2.1")
+				m.Answer = []dns.RR{rr}
+			case dns.TypeMX:
+				m.Answer = []dns.RR{&dns.MX{Hdr: hdr(name), MX: rdata.MX{Preference: 10, Mx: "mail." + name + "."}}}
+			case dns.TypeNS:
+				m.Answer = []dns.RR{&dns.NS{Hdr: hdr(name), NS: rdata.NS{Ns: "ns1.parking.example."}}}
+			}
+		case blocked[name]:
+			m.Rcode = dns.RcodeNameError
+		default:
+			m.Rcode = dns.RcodeNameError
+			m.Ns = []dns.RR{&dns.SOA{Hdr: hdr("com"), SOA: rdata.SOA{Ns: "a.gtld-servers.net.", Mbox: "nstld.verisign-grs.com."}}}
+		}
+		require.NoError(t, m.Pack())
+		return httpmock.NewBytesResponse(http.StatusOK, m.Data), nil
+	}
+}
+
+func TestService_Run_Checked(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL,
+		responder(t, map[string]bool{"exmple.com": true}, map[string]bool{"examp1e.com": true}))
+
+	svc := typo.NewService(client, testutil.NopLogger(), false)
+	raw, err := svc.Run(context.Background(), "www.example.com")
+	require.NoError(t, err)
+	result, ok := raw.(*typo.Result)
+	require.True(t, ok, "expected *typo.Result")
+
+	assert.Equal(t, "example.com", result.Domain, "permutations derive from the registrable domain")
+	assert.True(t, result.Checked)
+
+	byDomain := map[string]typo.Permutation{}
+	for _, p := range result.Permutations {
+		byDomain[p.Domain] = p
+	}
+	hit := byDomain["exmple.com"]
+	assert.True(t, hit.Registered)
+	assert.True(t, hit.MailCapable)
+	assert.False(t, hit.Malicious)
+	assert.Equal(t, []string{"192.0.2.1"}, hit.A)
+	assert.Equal(t, []string{"10 mail.exmple.com."}, hit.MX)
+	assert.Equal(t, []string{"ns1.parking.example."}, hit.NS)
+
+	flagged := byDomain["examp1e.com"]
+	assert.True(t, flagged.Malicious)
+	assert.False(t, flagged.Registered)
+
+	free := byDomain["exampel.com"]
+	assert.False(t, free.Registered)
+	assert.False(t, free.Malicious)
+}
+
+func TestService_Run_GenerateOnly(t *testing.T) {
+	client := newTestClient(t)
+	svc := typo.NewService(client, testutil.NopLogger(), true)
+	raw, err := svc.Run(context.Background(), "example.co.uk")
+	require.NoError(t, err)
+	result, ok := raw.(*typo.Result)
+	require.True(t, ok)
+	assert.Equal(t, "example.co.uk", result.Domain)
+	assert.False(t, result.Checked)
+	assert.NotEmpty(t, result.Permutations)
+	assert.Zero(t, httpmock.GetTotalCallCount(), "generate-only mode makes no requests")
+}
+
+func TestService_Run_InvalidInput(t *testing.T) {
+	svc := typo.NewService(nil, testutil.NopLogger(), true)
+	for _, input := range []string{"not a domain", "co.uk"} {
+		_, err := svc.Run(context.Background(), input)
+		require.ErrorIs(t, err, services.ErrInvalidInput, input)
+	}
+}
+
+func TestService_Run_AllQueriesFail(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+	svc := typo.NewService(client, testutil.NopLogger(), false)
+	_, err := svc.Run(context.Background(), "example.com")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestService_Run_ContextCancelled(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "=~^"+dohURL, responder(t, nil, nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc := typo.NewService(client, testutil.NopLogger(), false)
+	raw, err := svc.Run(ctx, "example.com")
+	require.NoError(t, err)
+	assert.False(t, raw.IsEmpty(), "permutations are still returned unchecked")
+}
+
+func TestService_PAP(t *testing.T) {
+	assert.Equal(t, pap.AMBER, typo.NewService(nil, testutil.NopLogger(), false).PAP())
+	assert.Equal(t, pap.RED, typo.NewService(nil, testutil.NopLogger(), true).PAP())
+	assert.Equal(t, "typo", typo.NewService(nil, testutil.NopLogger(), true).Name())
+}
+
+func TestService_AggregateResults(t *testing.T) {
+	svc := typo.NewService(nil, testutil.NopLogger(), true)
+	agg := svc.AggregateResults([]services.Result{&typo.Result{Input: "a.com"}, &typo.Result{Input: "b.com"}})
+	mr, ok := agg.(*typo.MultiResult)
+	require.True(t, ok)
+	assert.Len(t, mr.Results, 2)
+}
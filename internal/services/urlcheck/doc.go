@@ -0,0 +1,8 @@
+// Package urlcheck checks URLs and domains against public URL reputation and
+// phishing feeds: URLhaus, PhishTank-format dumps, the OpenPhish community
+// feed, and ThreatFox.
+//
+// By default the feeds are read from local copies fetched with
+// "trident download urlcheck", so lookups generate no network traffic (PAP
+// RED). The online mode queries the live APIs instead (PAP AMBER).
+package urlcheck
@@ -0,0 +1,378 @@
+package urlcheck
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/appdir"
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Feed names.
+const (
+	FeedURLhaus   = "urlhaus"
+	FeedPhishTank = "phishtank"
+	FeedOpenPhish = "openphish"
+	FeedThreatFox = "threatfox"
+)
+
+// dataDirName is the config-dir subdirectory holding the downloaded feeds.
+const dataDirName = "urlcheck"
+
+// ErrNoFeeds is returned when the data directory holds no downloaded feed.
+var ErrNoFeeds = errors.New("no urlcheck feed found")
+
+// Entry is one indicator listed by a feed.
+type Entry struct {
+	// Indicator is the listed URL, domain, or ip:port.
+	Indicator  string
+	ThreatType string
+	Tags       []string
+	FirstSeen  string // RFC 3339 when parseable, raw otherwise
+	// url and host are the normalized match keys; url is empty for domain
+	// and ip:port indicators.
+	url, host string
+}
+
+// Feed describes a downloadable reputation feed.
+type Feed struct {
+	Name     string // identifier, e.g. "urlhaus"
+	FileName string // installed file name in the data directory
+	URL      string // default download URL
+	License  string // attribution shown after download
+	parse    func(r io.Reader) ([]Entry, error)
+}
+
+// Feeds lists the supported feeds in lookup order.
+var Feeds = []Feed{
+	{
+		Name:     FeedURLhaus,
+		FileName: "urlhaus.csv",
+		URL:      "https://urlhaus.abuse.ch/downloads/csv_online/",
+		License:  "URLhaus by abuse.ch, CC0",
+		parse:    parseURLhaus,
+	},
+	{
+		Name:     FeedPhishTank,
+		FileName: "phishtank.csv",
+		URL:      "http://data.phishtank.com/data/online-valid.csv",
+		License:  "PhishTank by Cisco Talos, CC BY-SA 2.5",
+		parse:    parsePhishTank,
+	},
+	{
+		Name:     FeedOpenPhish,
+		FileName: "openphish.txt",
+		URL:      "https://openphish.com/feed.txt",
+		License:  "OpenPhish community feed (non-commercial use)",
+		parse:    parseOpenPhish,
+	},
+	{
+		Name:     FeedThreatFox,
+		FileName: "threatfox.csv",
+		URL:      "https://threatfox.abuse.ch/export/csv/recent/",
+		License:  "ThreatFox by abuse.ch, CC0",
+		parse:    parseThreatFox,
+	},
+}
+
+// FeedNames returns the names of all supported feeds.
+func FeedNames() []string {
+	names := make([]string, len(Feeds))
+	for i, f := range Feeds {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// LookupFeed returns the feed with the given name.
+func LookupFeed(name string) (Feed, bool) {
+	for _, f := range Feeds {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Feed{}, false
+}
+
+// DefaultDataDir returns the directory the feeds are downloaded to.
+func DefaultDataDir() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, dataDirName), nil
+}
+
+// DownloadFeed fetches f from rawURL (empty for the default), validates it,
+// and atomically installs it into dir. It returns the installed path and the
+// number of entries.
+func DownloadFeed(ctx context.Context, client *req.Client, f Feed, rawURL, dir string) (string, int, error) {
+	if rawURL == "" {
+		rawURL = f.URL
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", 0, fmt.Errorf("creating urlcheck data dir: %w", err)
+	}
+	resp, err := client.R().SetContext(ctx).DisableAutoReadResponse().Get(rawURL)
+	if err != nil {
+		return "", 0, fmt.Errorf("downloading %s: %w", f.Name, err)
+	}
+	if resp.Response == nil {
+		return "", 0, fmt.Errorf("downloading %s: transport error (no response)", f.Name)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("downloading %s from %s: unexpected status %d", f.Name, rawURL, resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(dir, "download-*"+filepath.Ext(f.FileName))
+	if err != nil {
+		return "", 0, fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		_ = tmp.Close()
+		return "", 0, fmt.Errorf("writing %s: %w", f.Name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", 0, fmt.Errorf("closing temp file: %w", err)
+	}
+
+	entries, err := loadFile(f, tmpName)
+	if err != nil {
+		return "", 0, fmt.Errorf("validating %s: %w", f.Name, err)
+	}
+	path := filepath.Join(dir, f.FileName)
+	if err := os.Rename(tmpName, path); err != nil {
+		return "", 0, fmt.Errorf("installing %s: %w", f.Name, err)
+	}
+	return path, len(entries), nil
+}
+
+// loadFile parses the feed file at path.
+func loadFile(f Feed, path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return f.parse(file)
+}
+
+// parseURLhaus reads the URLhaus CSV dump. Comment lines start with "#"; the
+// last comment line names the columns.
+func parseURLhaus(r io.Reader) ([]Entry, error) {
+	cols := map[string]int{"dateadded": 1, "url": 2, "threat": 5, "tags": 6}
+	return parseCSV(r, cols, func(col func(string) string) (Entry, bool) {
+		return newURLEntry(col("url"), col("threat"), splitTags(col("tags")), col("dateadded"))
+	})
+}
+
+// parsePhishTank reads a PhishTank-format CSV dump with a header row:
+// phish_id,url,phish_detail_url,submission_time,verified,verification_time,online,target.
+func parsePhishTank(r io.Reader) ([]Entry, error) {
+	cols := map[string]int{"url": 1, "submission_time": 3, "target": 7}
+	return parseCSV(r, cols, func(col func(string) string) (Entry, bool) {
+		var tags []string
+		if t := col("target"); t != "" && t != "Other" {
+			tags = []string{t}
+		}
+		return newURLEntry(col("url"), "phishing", tags, col("submission_time"))
+	})
+}
+
+// parseThreatFox reads the ThreatFox CSV export. URL, domain, and ip:port
+// indicators are kept; hashes are skipped.
+func parseThreatFox(r io.Reader) ([]Entry, error) {
+	cols := map[string]int{"first_seen_utc": 0, "ioc_value": 2, "ioc_type": 3, "threat_type": 4, "malware_printable": 7, "tags": 11}
+	return parseCSV(r, cols, func(col func(string) string) (Entry, bool) {
+		tags := splitTags(col("tags"))
+		if m := col("malware_printable"); m != "" && m != "Unknown malware" && !slices.Contains(tags, m) {
+			tags = append([]string{m}, tags...)
+		}
+		value := col("ioc_value")
+		switch col("ioc_type") {
+		case "url":
+			return newURLEntry(value, col("threat_type"), tags, col("first_seen_utc"))
+		case "domain":
+			return newHostEntry(value, value, col("threat_type"), tags, col("first_seen_utc"))
+		case "ip:port":
+			i := strings.LastIndex(value, ":")
+			if i <= 0 {
+				return Entry{}, false
+			}
+			return newHostEntry(value, value[:i], col("threat_type"), tags, col("first_seen_utc"))
+		}
+		return Entry{}, false
+	})
+}
+
+// parseOpenPhish reads the OpenPhish community feed: one URL per line.
+func parseOpenPhish(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if e, ok := newURLEntry(line, "phishing", nil, ""); ok {
+			entries = append(entries, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// parseCSV reads a CSV feed. Leading "#" comment lines are skipped; the last
+// comment line, or a first non-comment line containing a column name from
+// cols, is taken as the header and overrides the default column positions.
+func parseCSV(r io.Reader, cols map[string]int, row func(col func(string) string) (Entry, bool)) ([]Entry, error) {
+	br := bufio.NewReader(r)
+	var header string
+	for {
+		peek, err := br.Peek(1)
+		if err != nil || peek[0] != '#' {
+			break
+		}
+		line, err := br.ReadString('\n')
+		if c := strings.TrimSpace(strings.TrimPrefix(line, "#")); c != "" {
+			header = c
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.LazyQuotes = true
+	cr.ReuseRecord = true
+
+	pos := make(map[string]int, len(cols))
+	for k, v := range cols {
+		pos[k] = v
+	}
+	applyHeader := func(fields []string) bool {
+		found := false
+		for i, f := range fields {
+			f = strings.TrimSpace(f)
+			if _, ok := cols[f]; ok {
+				pos[f] = i
+				found = true
+			}
+		}
+		return found
+	}
+	if header != "" {
+		applyHeader(strings.Split(header, ","))
+	}
+
+	var entries []Entry
+	first := true
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			first = false
+			if header == "" && applyHeader(rec) {
+				continue
+			}
+		}
+		col := func(name string) string {
+			if i := pos[name]; i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		if e, ok := row(col); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// newURLEntry builds an entry for a listed URL; invalid URLs are skipped.
+func newURLEntry(raw, threat string, tags []string, firstSeen string) (Entry, bool) {
+	u, host, ok := normalizeURL(raw)
+	if !ok {
+		return Entry{}, false
+	}
+	return Entry{
+		Indicator:  output.StripANSI(raw),
+		ThreatType: output.StripANSI(threat),
+		Tags:       tags,
+		FirstSeen:  normalizeTime(firstSeen),
+		url:        u,
+		host:       host,
+	}, true
+}
+
+// newHostEntry builds an entry for a listed domain or ip:port matched by
+// host; invalid hosts are skipped.
+func newHostEntry(indicator, host, threat string, tags []string, firstSeen string) (Entry, bool) {
+	host = normalizeHost(host)
+	if !validHost(host) {
+		return Entry{}, false
+	}
+	return Entry{
+		Indicator:  output.StripANSI(indicator),
+		ThreatType: output.StripANSI(threat),
+		Tags:       tags,
+		FirstSeen:  normalizeTime(firstSeen),
+		host:       host,
+	}, true
+}
+
+// splitTags splits a comma-separated tag list, dropping "None".
+func splitTags(s string) []string {
+	var tags []string
+	for t := range strings.SplitSeq(s, ",") {
+		if t = output.StripANSI(strings.TrimSpace(t)); t != "" && t != "None" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// timeLayouts are the timestamp formats used by the feeds and APIs.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+}
+
+// normalizeTime returns s as RFC 3339 UTC when it matches a known layout, or
+// s unchanged otherwise.
+func normalizeTime(s string) string {
+	s = output.StripANSI(strings.TrimSpace(s))
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return s
+}
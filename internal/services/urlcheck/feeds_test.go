@@ -0,0 +1,92 @@
+package urlcheck
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseFixture(t *testing.T, feed string) []Entry {
+	t.Helper()
+	f, ok := LookupFeed(feed)
+	require.True(t, ok)
+	entries, err := loadFile(f, filepath.Join("testdata", f.FileName))
+	require.NoError(t, err)
+	return entries
+}
+
+func TestParseURLhaus(t *testing.T) {
+	entries := parseFixture(t, FeedURLhaus)
+	require.Len(t, entries, 2)
+	e := entries[0]
+	assert.Equal(t, "http://192.0.2.15:44915/i", e.Indicator)
+	assert.Equal(t, "malware_download", e.ThreatType)
+	assert.Equal(t, []string{"32-bit", "elf", "mips", "Mozi"}, e.Tags)
+	assert.Equal(t, "2026-10-17T10:10:07Z", e.FirstSeen)
+	assert.Equal(t, "192.0.2.15", e.host)
+}
+
+func TestParsePhishTank(t *testing.T) {
+	entries := parseFixture(t, FeedPhishTank)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "phishing", entries[0].ThreatType)
+	assert.Equal(t, []string{"PayPal"}, entries[0].Tags)
+	assert.Equal(t, "2026-10-15T06:00:00Z", entries[0].FirstSeen)
+	assert.Nil(t, entries[1].Tags, "target Other is not a tag")
+}
+
+func TestParseOpenPhish(t *testing.T) {
+	entries := parseFixture(t, FeedOpenPhish)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "https://login.example.net/secure/", entries[0].url)
+	assert.Empty(t, entries[0].FirstSeen)
+}
+
+func TestParseThreatFox(t *testing.T) {
+	entries := parseFixture(t, FeedThreatFox)
+	require.Len(t, entries, 2, "hash indicators are skipped")
+	assert.Equal(t, "evil.example.com", entries[0].host)
+	assert.Empty(t, entries[0].url)
+	assert.Equal(t, []string{"Agent Tesla", "AgentTesla"}, entries[0].Tags)
+	assert.Equal(t, "192.0.2.15", entries[1].host)
+	assert.Equal(t, []string{"Mozi"}, entries[1].Tags)
+}
+
+func TestParseCSV_Malformed(t *testing.T) {
+	_, err := parsePhishTank(strings.NewReader("phish_id,url\n1,\"unterminated\n2,x\"y\"\"\n"))
+	assert.NoError(t, err, "lazy quotes tolerate sloppy dumps")
+}
+
+func TestDownloadFeed(t *testing.T) {
+	client := req.C()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	data, err := os.ReadFile("testdata/openphish.txt")
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodGet, "https://openphish.com/feed.txt",
+		httpmock.NewBytesResponder(http.StatusOK, data))
+	httpmock.RegisterResponder(http.MethodGet, "https://mirror.example/urlhaus.csv",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	dir := filepath.Join(t.TempDir(), "urlcheck")
+	feed, _ := LookupFeed(FeedOpenPhish)
+	path, n, err := DownloadFeed(context.Background(), client, feed, "", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "openphish.txt"), path)
+	assert.Equal(t, 2, n)
+
+	feed, _ = LookupFeed(FeedURLhaus)
+	_, _, err = DownloadFeed(context.Background(), client, feed, "https://mirror.example/urlhaus.csv", dir)
+	require.Error(t, err)
+	_, statErr := os.Stat(filepath.Join(dir, "urlhaus.csv"))
+	assert.True(t, os.IsNotExist(statErr), "failed download must not install a file")
+}
@@ -0,0 +1,48 @@
+package urlcheck
+
+import (
+	"context"
+)
+
+// checker is one feed or API consulted for a target.
+type checker interface {
+	name() string
+	check(ctx context.Context, t Target) ([]Entry, error)
+}
+
+// index is an in-memory feed keyed by normalized URL and host.
+type index struct {
+	feed   string
+	byURL  map[string][]Entry
+	byHost map[string][]Entry
+}
+
+// newIndex indexes the entries of feed.
+func newIndex(feed string, entries []Entry) *index {
+	idx := &index{feed: feed, byURL: map[string][]Entry{}, byHost: map[string][]Entry{}}
+	for _, e := range entries {
+		if e.url != "" {
+			idx.byURL[e.url] = append(idx.byURL[e.url], e)
+		}
+		idx.byHost[e.host] = append(idx.byHost[e.host], e)
+	}
+	return idx
+}
+
+func (idx *index) name() string { return idx.feed }
+
+// check returns the entries listing t. A URL matches identical listed URLs
+// and listed domains or ip:port indicators of its host; a bare host matches
+// every entry on that host.
+func (idx *index) check(_ context.Context, t Target) ([]Entry, error) {
+	if t.URL == "" {
+		return idx.byHost[t.Host], nil
+	}
+	matches := append([]Entry(nil), idx.byURL[t.URL]...)
+	for _, e := range idx.byHost[t.Host] {
+		if e.url == "" {
+			matches = append(matches, e)
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,43 @@
+package urlcheck
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+// MultiResult holds urlcheck results for multiple inputs.
+type MultiResult struct {
+	services.MultiResultBase[Result, *Result]
+}
+
+// WriteText overrides the base: prefixes each match with the originating input.
+func (m *MultiResult) WriteText(w io.Writer) error {
+	for _, r := range m.Results {
+		for _, match := range r.Matches {
+			if _, err := fmt.Fprintf(w, "%s %s %s %s\n", r.Input, match.Feed, match.ThreatType, match.Indicator); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteTable renders all results in a single combined table grouped by input.
+// Columns: Input / Feed / Indicator / Threat Type / Tags / First Seen.
+func (m *MultiResult) WriteTable(w io.Writer) error {
+	var rows [][]string
+	for _, r := range m.Results {
+		for _, row := range r.rows() {
+			rows = append(rows, append([]string{r.Input}, row...))
+		}
+	}
+	table := output.NewGroupedWrappingTable(w, 20, 40)
+	table.Header([]string{"Input", "Feed", "Indicator", "Threat Type", "Tags", "First Seen"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,263 @@
+package urlcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	urlhausAPI   = "https://urlhaus-api.abuse.ch/v1"
+	threatfoxAPI = "https://threatfox-api.abuse.ch/api/v1/"
+	phishtankAPI = "https://checkurl.phishtank.com/checkurl/"
+)
+
+// onlineCheckers returns the live-API checkers in feed order. OpenPhish has no
+// query API; its community feed is fetched once and matched in memory.
+func onlineCheckers(client *req.Client) []checker {
+	return []checker{
+		&urlhausChecker{client: client},
+		&phishtankChecker{client: client},
+		&openphishChecker{client: client},
+		&threatfoxChecker{client: client},
+	}
+}
+
+// checkResponse maps a failed HTTP response to an ErrRequestFailed error.
+func checkResponse(resp *req.Response, err error) error {
+	if err != nil {
+		return fmt.Errorf("%w: %s", services.ErrRequestFailed, err)
+	}
+	if resp.Response == nil || !resp.IsSuccessState() {
+		body := resp.String()
+		if len(body) > 200 {
+			body = body[:200] + "..."
+		}
+		return fmt.Errorf("%w: HTTP %d: %q", services.ErrRequestFailed, resp.StatusCode, body)
+	}
+	return nil
+}
+
+// isCancelled reports whether err stems from a cancelled or expired context.
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+type urlhausURL struct {
+	URL       string   `json:"url"`
+	Threat    string   `json:"threat"`
+	Tags      []string `json:"tags"`
+	DateAdded string   `json:"date_added"`
+}
+
+// urlhausChecker queries the URLhaus url and host endpoints.
+type urlhausChecker struct {
+	client *req.Client
+}
+
+func (c *urlhausChecker) name() string { return FeedURLhaus }
+
+func (c *urlhausChecker) check(ctx context.Context, t Target) ([]Entry, error) {
+	var body struct {
+		QueryStatus string `json:"query_status"`
+		urlhausURL
+		URLs []urlhausURL `json:"urls"`
+	}
+	r := c.client.R().SetContext(ctx).SetSuccessResult(&body)
+	var resp *req.Response
+	var err error
+	if t.URL != "" {
+		resp, err = r.SetFormData(map[string]string{"url": t.URL}).Post(urlhausAPI + "/url/")
+	} else {
+		resp, err = r.SetFormData(map[string]string{"host": t.Host}).Post(urlhausAPI + "/host/")
+	}
+	if err != nil && isCancelled(err) {
+		return nil, nil
+	}
+	if err := checkResponse(resp, err); err != nil {
+		return nil, err
+	}
+	if body.QueryStatus != "ok" {
+		return nil, nil
+	}
+	urls := body.URLs
+	if t.URL != "" {
+		urls = []urlhausURL{body.urlhausURL}
+	}
+	var entries []Entry
+	for _, u := range urls {
+		if e, ok := newURLEntry(u.URL, u.Threat, stripTags(u.Tags), u.DateAdded); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// phishtankChecker queries the PhishTank checkurl API. It only answers URLs.
+type phishtankChecker struct {
+	client *req.Client
+}
+
+func (c *phishtankChecker) name() string { return FeedPhishTank }
+
+func (c *phishtankChecker) check(ctx context.Context, t Target) ([]Entry, error) {
+	if t.URL == "" {
+		return nil, nil
+	}
+	var body struct {
+		Results struct {
+			URL        string `json:"url"`
+			InDatabase bool   `json:"in_database"`
+			Verified   bool   `json:"verified"`
+			Valid      bool   `json:"valid"`
+			VerifiedAt string `json:"verified_at"`
+		} `json:"results"`
+	}
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetFormData(map[string]string{"url": t.URL, "format": "json"}).
+		SetSuccessResult(&body).
+		Post(phishtankAPI)
+	if err != nil && isCancelled(err) {
+		return nil, nil
+	}
+	if err := checkResponse(resp, err); err != nil {
+		return nil, err
+	}
+	res := body.Results
+	if !res.InDatabase || !res.Valid {
+		return nil, nil
+	}
+	e, ok := newURLEntry(res.URL, "phishing", nil, res.VerifiedAt)
+	if !ok {
+		return nil, nil
+	}
+	return []Entry{e}, nil
+}
+
+// openphishChecker downloads the OpenPhish community feed on first use.
+type openphishChecker struct {
+	client *req.Client
+
+	once    sync.Once
+	idx     *index
+	loadErr error
+}
+
+func (c *openphishChecker) name() string { return FeedOpenPhish }
+
+func (c *openphishChecker) check(ctx context.Context, t Target) ([]Entry, error) {
+	c.once.Do(func() {
+		feed, _ := LookupFeed(FeedOpenPhish)
+		resp, err := c.client.R().SetContext(ctx).Get(feed.URL)
+		if err != nil && isCancelled(err) {
+			c.loadErr = err
+			return
+		}
+		if err := checkResponse(resp, err); err != nil {
+			c.loadErr = err
+			return
+		}
+		entries, err := parseOpenPhish(bytes.NewReader(resp.Bytes()))
+		if err != nil {
+			c.loadErr = fmt.Errorf("%w: parsing OpenPhish feed: %s", services.ErrRequestFailed, err)
+			return
+		}
+		c.idx = newIndex(FeedOpenPhish, entries)
+	})
+	if c.loadErr != nil {
+		if isCancelled(c.loadErr) {
+			return nil, nil
+		}
+		return nil, c.loadErr
+	}
+	return c.idx.check(ctx, t)
+}
+
+// threatfoxChecker queries the ThreatFox search_ioc API.
+type threatfoxChecker struct {
+	client *req.Client
+}
+
+func (c *threatfoxChecker) name() string { return FeedThreatFox }
+
+func (c *threatfoxChecker) check(ctx context.Context, t Target) ([]Entry, error) {
+	term := t.URL
+	if term == "" {
+		term = t.Host
+	}
+	// data is an array of IOCs when query_status is "ok" and a message
+	// string otherwise.
+	var body struct {
+		QueryStatus string          `json:"query_status"`
+		Data        json.RawMessage `json:"data"`
+	}
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBodyJsonMarshal(map[string]string{"query": "search_ioc", "search_term": term}).
+		SetSuccessResult(&body).
+		Post(threatfoxAPI)
+	if err != nil && isCancelled(err) {
+		return nil, nil
+	}
+	if err := checkResponse(resp, err); err != nil {
+		return nil, err
+	}
+	if body.QueryStatus != "ok" {
+		return nil, nil
+	}
+	var iocs []struct {
+		IOC              string   `json:"ioc"`
+		IOCType          string   `json:"ioc_type"`
+		ThreatType       string   `json:"threat_type"`
+		MalwarePrintable string   `json:"malware_printable"`
+		FirstSeen        string   `json:"first_seen"`
+		Tags             []string `json:"tags"`
+	}
+	if err := json.Unmarshal(body.Data, &iocs); err != nil {
+		return nil, fmt.Errorf("%w: decoding ThreatFox response: %s", services.ErrRequestFailed, err)
+	}
+	var entries []Entry
+	for _, d := range iocs {
+		tags := stripTags(d.Tags)
+		if m := output.StripANSI(d.MalwarePrintable); m != "" && m != "Unknown malware" {
+			tags = append([]string{m}, tags...)
+		}
+		var e Entry
+		var ok bool
+		switch d.IOCType {
+		case "url":
+			e, ok = newURLEntry(d.IOC, d.ThreatType, tags, d.FirstSeen)
+		case "domain":
+			e, ok = newHostEntry(d.IOC, d.IOC, d.ThreatType, tags, d.FirstSeen)
+		case "ip:port":
+			if i := strings.LastIndex(d.IOC, ":"); i > 0 {
+				e, ok = newHostEntry(d.IOC, d.IOC[:i], d.ThreatType, tags, d.FirstSeen)
+			}
+		}
+		if ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// stripTags strips ANSI sequences from API-supplied tags.
+func stripTags(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		if t = output.StripANSI(strings.TrimSpace(t)); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package urlcheck
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Match is one feed entry listing the input.
+type Match struct {
+	Feed       string   `json:"feed"`
+	Indicator  string   `json:"indicator"` // the listed URL, domain, or ip:port
+	ThreatType string   `json:"threat_type,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	FirstSeen  string   `json:"first_seen,omitempty"` // RFC 3339
+}
+
+// Result holds the feed matches for one URL, domain, or IP.
+type Result struct {
+	Input   string   `json:"input"`
+	Matches []Match  `json:"matches,omitempty"`
+	Feeds   []string `json:"feeds,omitempty"` // feeds that were consulted successfully
+}
+
+// IsEmpty reports whether no feed lists the input.
+func (r *Result) IsEmpty() bool {
+	return len(r.Matches) == 0
+}
+
+// rows returns the Feed / Indicator / Threat Type / Tags / First Seen cells.
+func (r *Result) rows() [][]string {
+	rows := make([][]string, 0, len(r.Matches))
+	for _, m := range r.Matches {
+		rows = append(rows, []string{m.Feed, m.Indicator, m.ThreatType, strings.Join(m.Tags, ", "), m.FirstSeen})
+	}
+	return rows
+}
+
+// WriteText renders one "<feed> <threat type> <indicator>" line per match.
+func (r *Result) WriteText(w io.Writer) error {
+	for _, m := range r.Matches {
+		if _, err := fmt.Fprintf(w, "%s %s %s\n", m.Feed, m.ThreatType, m.Indicator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the matches as a table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 30)
+	table.Header([]string{"Feed", "Indicator", "Threat Type", "Tags", "First Seen"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
@@ -0,0 +1,75 @@
+package urlcheck_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/urlcheck"
+)
+
+func TestResult_WriteText(t *testing.T) {
+	result := &urlcheck.Result{
+		Input: "evil.example.com",
+		Matches: []urlcheck.Match{
+			{Feed: "threatfox", Indicator: "evil.example.com", ThreatType: "botnet_cc"},
+			{Feed: "urlhaus", Indicator: "https://evil.example.com/payload.exe", ThreatType: "malware_download"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "threatfox botnet_cc evil.example.com\nurlhaus malware_download https://evil.example.com/payload.exe\n", buf.String())
+}
+
+func TestResult_WriteText_NoThreatType(t *testing.T) {
+	result := &urlcheck.Result{
+		Input:   "https://phish.example.com/login",
+		Matches: []urlcheck.Match{{Feed: "openphish", Indicator: "https://phish.example.com/login"}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "openphish  https://phish.example.com/login\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &urlcheck.Result{
+		Input: "evil.example.com",
+		Matches: []urlcheck.Match{
+			{Feed: "threatfox", Indicator: "evil.example.com", ThreatType: "botnet_cc", Tags: []string{"Agent Tesla"}, FirstSeen: "2026-10-17T12:00:00Z"},
+			{Feed: "urlhaus", Indicator: "https://evil.example.com/payload.exe", ThreatType: "malware_download", Tags: []string{"exe", "AgentTesla"}},
+		},
+		Feeds: []string{"threatfox", "urlhaus"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "THREAT TYPE")
+	assert.Contains(t, out, "exe, AgentTesla")
+	assert.Contains(t, out, "2026-10-17T12:00:00Z")
+}
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&urlcheck.Result{Input: "example.com", Feeds: []string{"urlhaus", "openphish"}}).IsEmpty())
+	assert.False(t, (&urlcheck.Result{Input: "evil.example.com", Matches: []urlcheck.Match{{Feed: "urlhaus", Indicator: "evil.example.com"}}}).IsEmpty())
+}
+
+func TestMultiResult(t *testing.T) {
+	m := &urlcheck.MultiResult{}
+	m.Results = []*urlcheck.Result{
+		{Input: "evil.example.com", Matches: []urlcheck.Match{{Feed: "threatfox", Indicator: "evil.example.com", ThreatType: "botnet_cc"}}},
+		{Input: "example.org"},
+		{Input: "192.0.2.7:443", Matches: []urlcheck.Match{{Feed: "threatfox", Indicator: "192.0.2.7:443", ThreatType: "botnet_cc"}}},
+	}
+
+	var text bytes.Buffer
+	require.NoError(t, m.WriteText(&text))
+	assert.Equal(t, "evil.example.com threatfox botnet_cc evil.example.com\n192.0.2.7:443 threatfox botnet_cc 192.0.2.7:443\n", text.String())
+
+	var table bytes.Buffer
+	require.NoError(t, m.WriteTable(&table))
+	assert.Contains(t, table.String(), "INPUT")
+	assert.Contains(t, table.String(), "192.0.2.7:443")
+	assert.NotContains(t, table.String(), "example.org", "inputs without matches have no rows")
+}
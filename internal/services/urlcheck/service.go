@@ -0,0 +1,159 @@
+package urlcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// DefaultRPS is the target request rate across the live APIs.
+	DefaultRPS float64 = 2.0
+	// DefaultBurst is the burst capacity above DefaultRPS.
+	DefaultBurst = 4
+
+	// Name is the service identifier.
+	Name = "urlcheck"
+	// PAP is the PAP activity level when querying the live APIs (--online).
+	PAP = pap.AMBER
+	// MinPAP is the PAP activity level when matching against downloaded
+	// feeds, which generates no network traffic.
+	MinPAP = pap.RED
+)
+
+// Service checks URLs and domains against reputation feeds.
+type Service struct {
+	client *req.Client
+	logger *slog.Logger
+	dir    string
+
+	once     sync.Once
+	checkers []checker
+	loadErr  error
+}
+
+// NewService creates a new urlcheck service. With a non-nil client the live
+// APIs are queried; otherwise the feeds downloaded into dir are read on first
+// use.
+func NewService(client *req.Client, logger *slog.Logger, dir string) *Service {
+	return &Service{client: client, logger: logger, dir: dir}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns RED for offline lookups and AMBER for live API queries.
+func (s *Service) PAP() pap.Level {
+	if s.client == nil {
+		return MinPAP
+	}
+	return PAP
+}
+
+// AggregateResults combines multiple urlcheck results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run checks the URL, domain, or IP input against every feed concurrently.
+// Feed failures are logged; an error is returned only when every feed failed.
+// Partial results are returned when ctx is cancelled.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	input = output.StripANSI(strings.TrimSpace(input))
+	target, err := ParseTarget(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	type outcome struct {
+		feed    string
+		entries []Entry
+		err     error
+	}
+	outcomes := make([]outcome, len(s.checkers))
+	var wg sync.WaitGroup
+	for i, c := range s.checkers {
+		wg.Go(func() {
+			entries, err := c.check(ctx, target)
+			outcomes[i] = outcome{feed: c.name(), entries: entries, err: err}
+		})
+	}
+	wg.Wait()
+
+	result := &Result{Input: input}
+	var errs []error
+	for _, o := range outcomes {
+		if o.err != nil {
+			s.logger.Debug("urlcheck: feed lookup failed", "feed", o.feed, "input", input, "error", o.err)
+			errs = append(errs, fmt.Errorf("%s: %w", o.feed, o.err))
+			continue
+		}
+		result.Feeds = append(result.Feeds, o.feed)
+		seen := map[string]bool{}
+		for _, e := range o.entries {
+			if seen[e.Indicator] {
+				continue
+			}
+			seen[e.Indicator] = true
+			result.Matches = append(result.Matches, Match{
+				Feed:       o.feed,
+				Indicator:  e.Indicator,
+				ThreatType: e.ThreatType,
+				Tags:       e.Tags,
+				FirstSeen:  e.FirstSeen,
+			})
+		}
+	}
+	if len(errs) == len(s.checkers) && ctx.Err() == nil {
+		return nil, errors.Join(errs...)
+	}
+	slices.SortStableFunc(result.Matches, func(a, b Match) int {
+		return strings.Compare(b.FirstSeen, a.FirstSeen) // newest first; unknown last
+	})
+	return result, nil
+}
+
+// load builds the checkers once: the live APIs in online mode, otherwise one
+// index per feed file present in the data directory.
+func (s *Service) load() error {
+	s.once.Do(func() {
+		if s.client != nil {
+			s.checkers = onlineCheckers(s.client)
+			return
+		}
+		for _, f := range Feeds {
+			path := filepath.Join(s.dir, f.FileName)
+			entries, err := loadFile(f, path)
+			if err != nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					s.logger.Warn("urlcheck: skipping unreadable feed", "path", path, "error", err)
+				}
+				continue
+			}
+			s.checkers = append(s.checkers, newIndex(f.Name, entries))
+		}
+		if len(s.checkers) == 0 {
+			s.loadErr = fmt.Errorf("%w in %s: run \"trident download urlcheck\" or use --online", ErrNoFeeds, s.dir)
+		}
+	})
+	return s.loadErr
+}
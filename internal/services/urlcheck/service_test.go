@@ -0,0 +1,199 @@
+package urlcheck_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/urlcheck"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func runOffline(t *testing.T, input string) *urlcheck.Result {
+	t.Helper()
+	svc := urlcheck.NewService(nil, testutil.NopLogger(), "testdata")
+	raw, err := svc.Run(context.Background(), input)
+	require.NoError(t, err)
+	return raw.(*urlcheck.Result)
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		input, url, host string
+	}{
+		{"example.com", "", "example.com"},
+		{"Example.COM.", "", "example.com"},
+		{"192.0.2.1", "", "192.0.2.1"},
+		{"HTTPS://Example.com:443", "https://example.com/", "example.com"},
+		{"example.com/login#frag", "http://example.com/login", "example.com"},
+		{"http://[2001:db8::1]:8080/x", "http://[2001:db8::1]:8080/x", "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := urlcheck.ParseTarget(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.url, got.URL)
+			assert.Equal(t, tt.host, got.Host)
+		})
+	}
+
+	for _, bad := range []string{"", "not a host", "ftp://example.com/x", "http:///path"} {
+		_, err := urlcheck.ParseTarget(bad)
+		assert.ErrorIs(t, err, services.ErrInvalidInput, bad)
+	}
+}
+
+func TestRun_OfflineURL(t *testing.T) {
+	result := runOffline(t, "https://login.example.net/secure/")
+	require.Len(t, result.Matches, 2)
+	feeds := []string{result.Matches[0].Feed, result.Matches[1].Feed}
+	assert.ElementsMatch(t, []string{"phishtank", "openphish"}, feeds)
+	assert.Equal(t, "phishtank", result.Matches[0].Feed, "dated matches sort first")
+	assert.Equal(t, []string{"urlhaus", "phishtank", "openphish", "threatfox"}, result.Feeds)
+}
+
+func TestRun_OfflineURLMatchesListedHost(t *testing.T) {
+	result := runOffline(t, "https://evil.example.com/other")
+	require.Len(t, result.Matches, 1, "other URLs on the host are not matches, the listed domain is")
+	assert.Equal(t, "threatfox", result.Matches[0].Feed)
+	assert.Equal(t, "botnet_cc", result.Matches[0].ThreatType)
+}
+
+func TestRun_OfflineHost(t *testing.T) {
+	result := runOffline(t, "192.0.2.15")
+	require.Len(t, result.Matches, 2)
+	assert.Equal(t, "threatfox", result.Matches[0].Feed)
+	assert.Equal(t, "192.0.2.15:44915", result.Matches[0].Indicator)
+	assert.Equal(t, "urlhaus", result.Matches[1].Feed)
+	assert.Equal(t, "2026-10-17T10:10:07Z", result.Matches[1].FirstSeen)
+}
+
+func TestRun_OfflineClean(t *testing.T) {
+	assert.True(t, runOffline(t, "example.com").IsEmpty())
+}
+
+func TestRun_OfflineNoFeeds(t *testing.T) {
+	svc := urlcheck.NewService(nil, testutil.NopLogger(), t.TempDir())
+	_, err := svc.Run(context.Background(), "example.com")
+	assert.ErrorIs(t, err, urlcheck.ErrNoFeeds)
+}
+
+func TestRun_OfflinePartialFeeds(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile("testdata/openphish.txt")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openphish.txt"), data, 0o600))
+
+	svc := urlcheck.NewService(nil, testutil.NopLogger(), dir)
+	raw, err := svc.Run(context.Background(), "http://phish.example.io/office365/index.html")
+	require.NoError(t, err)
+	result := raw.(*urlcheck.Result)
+	assert.Equal(t, []string{"openphish"}, result.Feeds)
+	require.Len(t, result.Matches, 1)
+}
+
+func newMockClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.C()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+func TestRun_Online(t *testing.T) {
+	client := newMockClient(t)
+	httpmock.RegisterResponder(http.MethodPost, "https://urlhaus-api.abuse.ch/v1/url/",
+		func(r *http.Request) (*http.Response, error) {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "https://evil.example.com/payload.exe", r.PostForm.Get("url"))
+			return httpmock.NewStringResponse(http.StatusOK, `{
+				"query_status": "ok",
+				"url": "https://evil.example.com/payload.exe",
+				"threat": "malware_download",
+				"tags": ["exe", "AgentTesla"],
+				"date_added": "2026-10-16 08:00:00 UTC"
+			}`), nil
+		})
+	httpmock.RegisterResponder(http.MethodPost, "https://checkurl.phishtank.com/checkurl/",
+		httpmock.NewStringResponder(http.StatusOK, `{"results": {"url": "https://evil.example.com/payload.exe", "in_database": false}}`))
+	httpmock.RegisterResponder(http.MethodGet, "https://openphish.com/feed.txt",
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, "down"))
+	httpmock.RegisterResponder(http.MethodPost, "https://threatfox-api.abuse.ch/api/v1/",
+		httpmock.NewStringResponder(http.StatusOK, `{"query_status": "no_result", "data": "Your search did not yield any results"}`))
+
+	svc := urlcheck.NewService(client, testutil.NopLogger(), "")
+	assert.Equal(t, pap.AMBER, svc.PAP())
+	raw, err := svc.Run(context.Background(), "https://evil.example.com/payload.exe")
+	require.NoError(t, err)
+	result := raw.(*urlcheck.Result)
+
+	require.Len(t, result.Matches, 1)
+	m := result.Matches[0]
+	assert.Equal(t, "urlhaus", m.Feed)
+	assert.Equal(t, "malware_download", m.ThreatType)
+	assert.Equal(t, []string{"exe", "AgentTesla"}, m.Tags)
+	assert.Equal(t, "2026-10-16T08:00:00Z", m.FirstSeen)
+	assert.Equal(t, []string{"urlhaus", "phishtank", "threatfox"}, result.Feeds, "failed feeds are not listed")
+}
+
+func TestRun_OnlineHost(t *testing.T) {
+	client := newMockClient(t)
+	httpmock.RegisterResponder(http.MethodPost, "https://urlhaus-api.abuse.ch/v1/host/",
+		httpmock.NewStringResponder(http.StatusOK, `{
+			"query_status": "ok",
+			"urls": [{"url": "http://192.0.2.15:44915/i", "threat": "malware_download", "tags": ["Mozi"], "date_added": "2026-10-17 10:10:07 UTC"}]
+		}`))
+	httpmock.RegisterResponder(http.MethodGet, "https://openphish.com/feed.txt",
+		httpmock.NewStringResponder(http.StatusOK, "http://phish.example.io/x\n"))
+	httpmock.RegisterResponder(http.MethodPost, "https://threatfox-api.abuse.ch/api/v1/",
+		httpmock.NewStringResponder(http.StatusOK, `{
+			"query_status": "ok",
+			"data": [{"ioc": "192.0.2.15:44915", "ioc_type": "ip:port", "threat_type": "botnet_cc", "malware_printable": "Mozi", "first_seen": "2026-10-17 11:00:00 UTC", "tags": null}]
+		}`))
+
+	svc := urlcheck.NewService(client, testutil.NopLogger(), "")
+	raw, err := svc.Run(context.Background(), "192.0.2.15")
+	require.NoError(t, err)
+	result := raw.(*urlcheck.Result)
+	require.Len(t, result.Matches, 2)
+	assert.Equal(t, "threatfox", result.Matches[0].Feed)
+	assert.Equal(t, []string{"Mozi"}, result.Matches[0].Tags)
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST https://checkurl.phishtank.com/checkurl/"], "PhishTank only checks URLs")
+}
+
+func TestRun_OnlineAllFail(t *testing.T) {
+	client := newMockClient(t)
+	httpmock.RegisterNoResponder(httpmock.NewStringResponder(http.StatusInternalServerError, "boom"))
+
+	svc := urlcheck.NewService(client, testutil.NopLogger(), "")
+	_, err := svc.Run(context.Background(), "https://example.com/")
+	assert.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	client := newMockClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	svc := urlcheck.NewService(client, testutil.NopLogger(), "")
+	_, err := svc.Run(ctx, "https://example.com/")
+	assert.NoError(t, err)
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := urlcheck.NewService(nil, testutil.NopLogger(), "testdata")
+	assert.Equal(t, "urlcheck", svc.Name())
+	assert.Equal(t, pap.RED, svc.PAP())
+	agg := svc.AggregateResults([]services.Result{&urlcheck.Result{Input: "a"}})
+	_, ok := agg.(*urlcheck.MultiResult)
+	assert.True(t, ok)
+}
@@ -0,0 +1,89 @@
+package urlcheck
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/tbckr/trident/internal/services"
+)
+
+// Target is a normalized lookup input.
+type Target struct {
+	// URL is the normalized URL, or "" when the input is a bare host.
+	URL string
+	// Host is the lowercase host name or IP address without port.
+	Host string
+}
+
+// ParseTarget parses a URL ("https://example.com/path", or "example.com/path"
+// without a scheme) or a bare domain or IP address.
+func ParseTarget(input string) (Target, error) {
+	raw := strings.TrimSpace(input)
+	if raw == "" {
+		return Target{}, fmt.Errorf("%w: empty input", services.ErrInvalidInput)
+	}
+	if !strings.Contains(raw, "://") && !strings.Contains(raw, "/") {
+		host := normalizeHost(raw)
+		if !validHost(host) {
+			return Target{}, fmt.Errorf("%w: must be a URL, domain, or IP address: %q", services.ErrInvalidInput, input)
+		}
+		return Target{Host: host}, nil
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, host, ok := normalizeURL(raw)
+	if !ok || !validHost(host) {
+		return Target{}, fmt.Errorf("%w: must be a URL, domain, or IP address: %q", services.ErrInvalidInput, input)
+	}
+	return Target{URL: u, Host: host}, nil
+}
+
+// normalizeURL lowercases the scheme and host, drops default ports and the
+// fragment, and turns an empty path into "/". It returns the normalized URL
+// and its host.
+func normalizeURL(raw string) (string, string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return "", "", false
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", "", false
+	}
+	host := normalizeHost(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	u.Host = host
+	if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	}
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String(), host, true
+}
+
+// normalizeHost lowercases h and strips a trailing dot and IPv6 brackets.
+func normalizeHost(h string) string {
+	h = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(h)), ".")
+	return strings.TrimSuffix(strings.TrimPrefix(h, "["), "]")
+}
+
+func validHost(h string) bool {
+	if _, err := netip.ParseAddr(h); err == nil {
+		return true
+	}
+	return services.IsDomain(h)
+}
@@ -0,0 +1,3 @@
+// Package virustotal provides a keyed service for VirusTotal v3 reports on
+// domains, IP addresses, and file hashes.
+package virustotal
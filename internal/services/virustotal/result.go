@@ -0,0 +1,104 @@
+package virustotal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Result holds the VirusTotal report for a domain, IP address, or file.
+type Result struct {
+	Input        string   `json:"input"`
+	Type         string   `json:"type"` // domain | ip | file
+	Found        bool     `json:"found"`
+	Malicious    int      `json:"malicious"`
+	Suspicious   int      `json:"suspicious"`
+	Harmless     int      `json:"harmless"`
+	Undetected   int      `json:"undetected"`
+	Reputation   int      `json:"reputation"`
+	LastAnalysis string   `json:"last_analysis,omitempty"` // RFC 3339
+	Tags         []string `json:"tags,omitempty"`
+	Categories   []string `json:"categories,omitempty"`
+	// Domain
+	Registrar string `json:"registrar,omitempty"`
+	Created   string `json:"created,omitempty"` // RFC 3339
+	// IP address
+	ASN     string `json:"asn,omitempty"`
+	ASOwner string `json:"as_owner,omitempty"`
+	Country string `json:"country,omitempty"`
+	Network string `json:"network,omitempty"`
+	// File
+	MD5         string `json:"md5,omitempty"`
+	SHA1        string `json:"sha1,omitempty"`
+	SHA256      string `json:"sha256,omitempty"`
+	FileType    string `json:"file_type,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	ThreatLabel string `json:"threat_label,omitempty"`
+}
+
+// IsEmpty reports whether VirusTotal has no report for the input.
+func (r *Result) IsEmpty() bool {
+	return !r.Found
+}
+
+// detections formats the engine verdicts as "malicious/total".
+func (r *Result) detections() string {
+	total := r.Malicious + r.Suspicious + r.Harmless + r.Undetected
+	return fmt.Sprintf("%d/%d", r.Malicious, total)
+}
+
+// rows returns the non-empty fields as Field/Value pairs in display order.
+func (r *Result) rows() [][]string {
+	size := ""
+	if r.Size > 0 {
+		size = strconv.FormatInt(r.Size, 10)
+	}
+	fields := [][]string{
+		{"Detections", r.detections()},
+		{"Suspicious", strconv.Itoa(r.Suspicious)},
+		{"Reputation", strconv.Itoa(r.Reputation)},
+		{"Threat Label", r.ThreatLabel},
+		{"Last Analysis", r.LastAnalysis},
+		{"Registrar", r.Registrar},
+		{"Created", r.Created},
+		{"ASN", r.ASN},
+		{"AS Owner", r.ASOwner},
+		{"Country", r.Country},
+		{"Network", r.Network},
+		{"MD5", r.MD5},
+		{"SHA1", r.SHA1},
+		{"SHA256", r.SHA256},
+		{"File Type", r.FileType},
+		{"File Name", r.FileName},
+		{"Size", size},
+		{"Categories", strings.Join(r.Categories, "\n")},
+		{"Tags", strings.Join(r.Tags, ", ")},
+	}
+	var rows [][]string
+	for _, f := range fields {
+		if f[1] != "" {
+			rows = append(rows, f)
+		}
+	}
+	return rows
+}
+
+// WriteText renders "<input> <malicious>/<total>" on one line.
+func (r *Result) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s %s\n", r.Input, r.detections())
+	return err
+}
+
+// WriteTable renders the non-empty fields as a Field/Value table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 20)
+	table.Header([]string{"Field", "Value"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
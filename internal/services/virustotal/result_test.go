@@ -0,0 +1,87 @@
+package virustotal_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/virustotal"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&virustotal.Result{Input: "example.com", Type: "domain"}).IsEmpty())
+	assert.False(t, (&virustotal.Result{Input: "example.com", Type: "domain", Found: true}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &virustotal.Result{Input: "example.com", Type: "domain", Found: true, Malicious: 2, Suspicious: 1, Harmless: 65, Undetected: 22}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "example.com 2/90\n", buf.String())
+}
+
+func TestResult_WriteText_NoAnalysis(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&virustotal.Result{Input: "example.com", Type: "domain", Found: true}).WriteText(&buf))
+	assert.Equal(t, "example.com 0/0\n", buf.String())
+}
+
+func TestResult_WriteTable_Domain(t *testing.T) {
+	result := &virustotal.Result{
+		Input:      "example.com",
+		Type:       "domain",
+		Found:      true,
+		Malicious:  2,
+		Harmless:   66,
+		Undetected: 22,
+		Registrar:  "Example Registrar",
+		Categories: []string{"parked", "advertisements"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "2/90")
+	assert.Contains(t, out, "Example Registrar")
+	assert.Contains(t, out, "advertisements")
+	assert.NotContains(t, out, "SHA256", "empty fields are omitted")
+}
+
+func TestResult_WriteTable_File(t *testing.T) {
+	result := &virustotal.Result{
+		Input:       "44d88612fea8a8f36de82e1278abb02f",
+		Type:        "file",
+		Found:       true,
+		Malicious:   60,
+		Undetected:  5,
+		SHA256:      "275a021bbfb6489e54d471899f7db9d1663fc695ec2fe2a2c4538aabf651fd0f",
+		FileType:    "Text",
+		Size:        68,
+		ThreatLabel: "virus.eicar/test",
+		Tags:        []string{"text", "attachment"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "60/65")
+	assert.Contains(t, out, "virus.eicar/test")
+	assert.Contains(t, out, "68")
+	assert.Contains(t, out, "text, attachment")
+	assert.NotContains(t, out, "Registrar", "empty fields are omitted")
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	m := &virustotal.MultiResult{}
+	m.Results = []*virustotal.Result{
+		{Input: "example.com", Type: "domain", Found: true, Malicious: 2, Harmless: 66, Registrar: "Example Registrar"},
+		{Input: "192.0.2.1", Type: "ip", Found: true, Harmless: 70, ASN: "AS64500", Country: "DE"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "INPUT")
+	assert.Contains(t, out, "Example Registrar")
+	assert.Contains(t, out, "192.0.2.1")
+	assert.Contains(t, out, "AS64500")
+}
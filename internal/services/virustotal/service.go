@@ -0,0 +1,220 @@
+package virustotal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	baseURL = "https://www.virustotal.com/api/v3"
+
+	// DefaultRPS is the target request rate: the public API allows four
+	// lookups per minute.
+	DefaultRPS float64 = 4.0 / 60
+	// DefaultBurst is the burst capacity above DefaultRPS.
+	DefaultBurst = 1
+
+	// Name is the service identifier.
+	Name = "virustotal"
+	// PAP is the PAP activity level for the VirusTotal service.
+	PAP = pap.AMBER
+)
+
+// object is the "data" member of a VirusTotal v3 object response. Only the
+// attributes shown by trident are decoded.
+type object struct {
+	Data struct {
+		Attributes struct {
+			LastAnalysisStats struct {
+				Malicious  int `json:"malicious"`
+				Suspicious int `json:"suspicious"`
+				Harmless   int `json:"harmless"`
+				Undetected int `json:"undetected"`
+			} `json:"last_analysis_stats"`
+			LastAnalysisDate int64             `json:"last_analysis_date"`
+			Reputation       int               `json:"reputation"`
+			Tags             []string          `json:"tags"`
+			Categories       map[string]string `json:"categories"`
+			// Domain
+			Registrar    string `json:"registrar"`
+			CreationDate int64  `json:"creation_date"`
+			// IP address
+			ASN     int    `json:"asn"`
+			ASOwner string `json:"as_owner"`
+			Country string `json:"country"`
+			Network string `json:"network"`
+			// File
+			MD5                         string `json:"md5"`
+			SHA1                        string `json:"sha1"`
+			SHA256                      string `json:"sha256"`
+			TypeDescription             string `json:"type_description"`
+			MeaningfulName              string `json:"meaningful_name"`
+			Size                        int64  `json:"size"`
+			PopularThreatClassification struct {
+				SuggestedThreatLabel string `json:"suggested_threat_label"`
+			} `json:"popular_threat_classification"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// Service queries the VirusTotal v3 API.
+type Service struct {
+	client *req.Client
+	logger *slog.Logger
+	apiKey string
+}
+
+// NewService creates a new VirusTotal service authenticating with apiKey.
+func NewService(client *req.Client, logger *slog.Logger, apiKey string) *Service {
+	return &Service{client: client, logger: logger, apiKey: apiKey}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP classification for this service.
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple VirusTotal results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run fetches the VirusTotal report for a domain, IP address, or MD5/SHA1/
+// SHA256 file hash. An unknown object yields an empty result.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("%w for %s", services.ErrMissingAPIKey, Name)
+	}
+	input = output.StripANSI(strings.TrimSpace(input))
+	kind, path, err := classify(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj object
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetHeader("x-apikey", s.apiKey).
+		SetSuccessResult(&obj).
+		Get(baseURL + path)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return &Result{Input: input, Type: kind}, nil
+		}
+		return nil, fmt.Errorf("%w: %s", services.ErrRequestFailed, err)
+	}
+	if resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+		return &Result{Input: input, Type: kind}, nil
+	}
+	if resp.Response != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return nil, fmt.Errorf("%w: HTTP %d: VirusTotal rejected the API key", services.ErrRequestFailed, resp.StatusCode)
+	}
+	if resp.Response == nil || !resp.IsSuccessState() {
+		body := resp.String()
+		if len(body) > 200 {
+			body = body[:200] + "..."
+		}
+		return nil, fmt.Errorf("%w: HTTP %d: %q", services.ErrRequestFailed, resp.StatusCode, body)
+	}
+	return newResult(input, kind, &obj), nil
+}
+
+// newResult flattens a VirusTotal object into a Result.
+func newResult(input, kind string, obj *object) *Result {
+	a := obj.Data.Attributes
+	stats := a.LastAnalysisStats
+	r := &Result{
+		Input:        input,
+		Type:         kind,
+		Found:        true,
+		Malicious:    stats.Malicious,
+		Suspicious:   stats.Suspicious,
+		Harmless:     stats.Harmless,
+		Undetected:   stats.Undetected,
+		Reputation:   a.Reputation,
+		LastAnalysis: formatUnix(a.LastAnalysisDate),
+		Registrar:    output.StripANSI(a.Registrar),
+		Created:      formatUnix(a.CreationDate),
+		ASOwner:      output.StripANSI(a.ASOwner),
+		Country:      output.StripANSI(a.Country),
+		Network:      output.StripANSI(a.Network),
+		MD5:          output.StripANSI(a.MD5),
+		SHA1:         output.StripANSI(a.SHA1),
+		SHA256:       output.StripANSI(a.SHA256),
+		FileType:     output.StripANSI(a.TypeDescription),
+		FileName:     output.StripANSI(a.MeaningfulName),
+		Size:         a.Size,
+		ThreatLabel:  output.StripANSI(a.PopularThreatClassification.SuggestedThreatLabel),
+	}
+	if a.ASN != 0 {
+		r.ASN = fmt.Sprintf("AS%d", a.ASN)
+	}
+	for _, tag := range a.Tags {
+		r.Tags = append(r.Tags, output.StripANSI(tag))
+	}
+	seen := map[string]bool{}
+	for _, c := range a.Categories {
+		if c = output.StripANSI(c); c != "" && !seen[c] {
+			seen[c] = true
+			r.Categories = append(r.Categories, c)
+		}
+	}
+	slices.Sort(r.Categories)
+	return r
+}
+
+// classify returns the object kind and API path for input.
+func classify(input string) (string, string, error) {
+	if addr, err := netip.ParseAddr(input); err == nil {
+		return "ip", "/ip_addresses/" + addr.Unmap().String(), nil
+	}
+	if isHash(input) {
+		return "file", "/files/" + strings.ToLower(input), nil
+	}
+	name := strings.ToLower(strings.TrimSuffix(input, "."))
+	if services.IsDomain(name) {
+		return "domain", "/domains/" + name, nil
+	}
+	return "", "", fmt.Errorf("%w: must be a domain, IP address, or MD5/SHA1/SHA256 hash: %q", services.ErrInvalidInput, input)
+}
+
+// isHash reports whether s is a 32-, 40-, or 64-character hex string.
+func isHash(s string) bool {
+	switch len(s) {
+	case 32, 40, 64:
+	default:
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatUnix renders Unix seconds as RFC 3339 UTC, or "" for 0.
+func formatUnix(sec int64) string {
+	if sec <= 0 {
+		return ""
+	}
+	return time.Unix(sec, 0).UTC().Format(time.RFC3339)
+}
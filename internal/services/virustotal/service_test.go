@@ -0,0 +1,135 @@
+package virustotal_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/virustotal"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+// fixtureResponder serves testdata/name and requires the API key header.
+func fixtureResponder(t *testing.T, name string) httpmock.Responder {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+	return func(r *http.Request) (*http.Response, error) {
+		if r.Header.Get("x-apikey") != "test-key" {
+			return httpmock.NewStringResponse(http.StatusUnauthorized, `{"error":{"code":"WrongCredentialsError"}}`), nil
+		}
+		return httpmock.NewBytesResponse(http.StatusOK, data), nil
+	}
+}
+
+func TestRun_Domain(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://www.virustotal.com/api/v3/domains/example.com",
+		fixtureResponder(t, "domain.json"))
+
+	svc := virustotal.NewService(client, testutil.NopLogger(), "test-key")
+	raw, err := svc.Run(context.Background(), "Example.com.")
+	require.NoError(t, err)
+	result := raw.(*virustotal.Result)
+
+	assert.Equal(t, "domain", result.Type)
+	assert.True(t, result.Found)
+	assert.Equal(t, 2, result.Malicious)
+	assert.Equal(t, -5, result.Reputation)
+	assert.Equal(t, "1995-08-14T04:00:00Z", result.Created)
+	assert.Equal(t, []string{"information technology", "parked"}, result.Categories)
+}
+
+func TestRun_IP(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://www.virustotal.com/api/v3/ip_addresses/8.8.8.8",
+		fixtureResponder(t, "ip.json"))
+
+	svc := virustotal.NewService(client, testutil.NopLogger(), "test-key")
+	raw, err := svc.Run(context.Background(), "8.8.8.8")
+	require.NoError(t, err)
+	result := raw.(*virustotal.Result)
+
+	assert.Equal(t, "ip", result.Type)
+	assert.Equal(t, "AS15169", result.ASN)
+	assert.Equal(t, "8.8.8.0/24", result.Network)
+}
+
+func TestRun_File(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://www.virustotal.com/api/v3/files/44d88612fea8a8f36de82e1278abb02f",
+		fixtureResponder(t, "file.json"))
+
+	svc := virustotal.NewService(client, testutil.NopLogger(), "test-key")
+	raw, err := svc.Run(context.Background(), "44D88612FEA8A8F36DE82E1278ABB02F")
+	require.NoError(t, err)
+	result := raw.(*virustotal.Result)
+
+	assert.Equal(t, "file", result.Type)
+	assert.Equal(t, 60, result.Malicious)
+	assert.Equal(t, "virus.eicar/test", result.ThreatLabel)
+	assert.Equal(t, int64(68), result.Size)
+}
+
+func TestRun_NotFound(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://www.virustotal.com/api/v3/domains/unknown.example",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"error":{"code":"NotFoundError"}}`))
+
+	svc := virustotal.NewService(client, testutil.NopLogger(), "test-key")
+	raw, err := svc.Run(context.Background(), "unknown.example")
+	require.NoError(t, err)
+	assert.True(t, raw.IsEmpty())
+}
+
+func TestRun_BadKey(t *testing.T) {
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodGet, "https://www.virustotal.com/api/v3/domains/example.com",
+		fixtureResponder(t, "domain.json"))
+
+	svc := virustotal.NewService(client, testutil.NopLogger(), "wrong-key")
+	_, err := svc.Run(context.Background(), "example.com")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+	assert.Contains(t, err.Error(), "API key")
+}
+
+func TestRun_MissingKey(t *testing.T) {
+	svc := virustotal.NewService(req.NewClient(), testutil.NopLogger(), "")
+	_, err := svc.Run(context.Background(), "example.com")
+	assert.ErrorIs(t, err, services.ErrMissingAPIKey)
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	svc := virustotal.NewService(req.NewClient(), testutil.NopLogger(), "test-key")
+	for _, bad := range []string{"", "not valid!", "abc123"} {
+		_, err := svc.Run(context.Background(), bad)
+		assert.ErrorIs(t, err, services.ErrInvalidInput, "input %q", bad)
+	}
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := virustotal.NewService(req.NewClient(), testutil.NopLogger(), "k")
+	assert.Equal(t, "virustotal", svc.Name())
+	assert.Equal(t, pap.AMBER, svc.PAP())
+
+	agg := svc.AggregateResults([]services.Result{&virustotal.Result{Input: "a"}, &virustotal.Result{Input: "b"}})
+	mr, ok := agg.(*virustotal.MultiResult)
+	require.True(t, ok)
+	assert.Len(t, mr.Results, 2)
+}
@@ -0,0 +1,121 @@
+package whois
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// IANAServer is the root WHOIS server that refers queries to the registry.
+	IANAServer = "whois.iana.org"
+
+	// defaultTimeout bounds a single query when ctx carries no deadline.
+	defaultTimeout = 15 * time.Second
+	// maxResponseSize caps the bytes read from a single WHOIS response.
+	maxResponseSize = 1 << 20
+	// maxHops bounds the referral chain (IANA → registry → registrar → ...).
+	maxHops = 4
+)
+
+// query sends q to server and returns the full response text.
+// server is "host" or "host:port"; port 43 is assumed when omitted.
+func (s *Service) query(ctx context.Context, server, q string) (string, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	conn, err := s.dialer.DialContext(ctx, "tcp", address(server))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Unblock reads and writes when ctx ends; SetDeadline alone would miss
+	// cancellation.
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	data, err := func() ([]byte, error) {
+		if _, err := io.WriteString(conn, q+"\r\n"); err != nil {
+			return nil, err
+		}
+		return io.ReadAll(io.LimitReader(conn, maxResponseSize))
+	}()
+	if err != nil {
+		// Deadline-triggered I/O errors surface as the context error.
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", err
+	}
+	return strings.ToValidUTF8(string(data), "�"), nil
+}
+
+// address appends the default WHOIS port when server has none.
+func address(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(server, "43")
+}
+
+// formatQuery adapts the query string to server-specific syntax. Most servers
+// accept the bare object; a few need flags to return the full record.
+func formatQuery(server string, kind objectKind, key string) string {
+	host := strings.ToLower(server)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	switch {
+	case host == "whois.arin.net" && kind == kindIP:
+		return "n + " + key
+	case host == "whois.arin.net" && kind == kindASN:
+		return "a + " + strings.TrimPrefix(key, "AS")
+	case host == "whois.denic.de" && kind == kindDomain:
+		return "-T dn,ace " + key
+	case host == "whois.verisign-grs.com" && kind == kindDomain:
+		return "domain " + key
+	}
+	return key
+}
+
+// referral extracts the next WHOIS server from a response, or "" when the
+// response names none. Only whois:// (or schemeless) referrals are followed;
+// rwhois and web URLs are ignored.
+func referral(text string) string {
+	for line := range strings.SplitSeq(text, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "refer", "whois", "whois server", "registrar whois server", "referralserver":
+		default:
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if rest, ok := strings.CutPrefix(strings.ToLower(value), "whois://"); ok {
+			value = rest
+		} else if strings.Contains(value, "://") {
+			continue
+		}
+		value = strings.TrimSuffix(strings.ToLower(value), "/")
+		if value != "" && !strings.ContainsAny(value, " /") {
+			return value
+		}
+	}
+	return ""
+}
+
+// sameServer reports whether a and b name the same WHOIS endpoint.
+func sameServer(a, b string) bool {
+	return address(strings.ToLower(a)) == address(strings.ToLower(b))
+}
@@ -0,0 +1,3 @@
+// Package whois queries registration data for domains, IP addresses, and ASNs
+// over the legacy port-43 WHOIS protocol, following referrals from whois.iana.org.
+package whois
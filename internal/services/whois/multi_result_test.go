@@ -0,0 +1,58 @@
+package whois_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/whois"
+)
+
+func TestMultiResult_IsEmpty(t *testing.T) {
+	t.Run("empty when no results", func(t *testing.T) {
+		assert.True(t, (&whois.MultiResult{}).IsEmpty())
+	})
+
+	t.Run("empty when no response could be parsed", func(t *testing.T) {
+		mr := &whois.MultiResult{}
+		mr.Results = []*whois.Result{{Input: "example.com", Raw: "% no match"}, {Input: "example.org"}}
+		assert.True(t, mr.IsEmpty())
+	})
+
+	t.Run("not empty when one input was parsed", func(t *testing.T) {
+		mr := &whois.MultiResult{}
+		mr.Results = []*whois.Result{{Input: "example.com"}, {Input: "example.org", Name: "example.org"}}
+		assert.False(t, mr.IsEmpty())
+	})
+}
+
+func TestMultiResult_WriteTable(t *testing.T) {
+	mr := &whois.MultiResult{}
+	mr.Results = []*whois.Result{
+		{Input: "example.com", ObjectType: "domain", Name: "example.com", Registrar: "Example Registrar"},
+		{Input: "192.0.2.1", ObjectType: "ip network", Name: "test-net-1", Network: "192.0.2.0/24"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "INPUT")
+	assert.Contains(t, out, "example.com")
+	assert.Contains(t, out, "Example Registrar")
+	assert.Contains(t, out, "192.0.2.0/24")
+}
+
+func TestMultiResult_WriteText(t *testing.T) {
+	mr := &whois.MultiResult{}
+	mr.Results = []*whois.Result{
+		{Input: "example.com", Server: "whois.registrar.example", Raw: "Domain Name: example.com\r\n"},
+		{Input: "example.org", Server: "whois.pir.org", Raw: "Domain Name: example.org\n"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, mr.WriteText(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "% example.com (whois.registrar.example)\nDomain Name: example.com\n")
+	assert.Contains(t, out, "% example.org (whois.pir.org)\nDomain Name: example.org\n")
+}
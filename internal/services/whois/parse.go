@@ -0,0 +1,254 @@
+package whois
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// field identifies a normalized Result field that WHOIS keys map onto.
+type field int
+
+const (
+	fieldNone field = iota
+	fieldHandle
+	fieldName
+	fieldRegistrar
+	fieldRegistrantOrg
+	fieldCountry
+	fieldNetwork
+	fieldCreated
+	fieldUpdated
+	fieldExpires
+	fieldStatus
+	fieldNameserver
+	fieldAbuse
+)
+
+// keyFields maps lower-cased WHOIS keys from common formats (ICANN gTLD,
+// RIPE/APNIC/AFRINIC/LACNIC RPSL, ARIN, DENIC, Nominet, JPRS, ...) to fields.
+var keyFields = map[string]field{
+	// Handles and names.
+	"registry domain id": fieldHandle,
+	"nethandle":          fieldHandle,
+	"ashandle":           fieldHandle,
+	"aut-num":            fieldHandle,
+	"domain name":        fieldName,
+	"domain":             fieldName,
+	"netname":            fieldName,
+	"network name":       fieldName,
+	"as-name":            fieldName,
+	"asname":             fieldName,
+
+	// Registrar and registrant.
+	"registrar":               fieldRegistrar,
+	"sponsoring registrar":    fieldRegistrar,
+	"registrar name":          fieldRegistrar,
+	"registrant organization": fieldRegistrantOrg,
+	"registrant organisation": fieldRegistrantOrg,
+	"registrant":              fieldRegistrantOrg,
+	"org-name":                fieldRegistrantOrg,
+	"orgname":                 fieldRegistrantOrg,
+	"organization":            fieldRegistrantOrg,
+	"organisation name":       fieldRegistrantOrg,
+	"owner":                   fieldRegistrantOrg,
+	"registrant country":      fieldCountry,
+	"country":                 fieldCountry,
+
+	// Networks.
+	"cidr":     fieldNetwork,
+	"inetnum":  fieldNetwork,
+	"inet6num": fieldNetwork,
+	"netrange": fieldNetwork,
+
+	// Dates.
+	"creation date":                          fieldCreated,
+	"created":                                fieldCreated,
+	"created on":                             fieldCreated,
+	"created date":                           fieldCreated,
+	"registered on":                          fieldCreated,
+	"registration time":                      fieldCreated,
+	"domain registration date":               fieldCreated,
+	"regdate":                                fieldCreated,
+	"updated date":                           fieldUpdated,
+	"last updated":                           fieldUpdated,
+	"last updated on":                        fieldUpdated,
+	"last modified":                          fieldUpdated,
+	"last-modified":                          fieldUpdated,
+	"last-update":                            fieldUpdated,
+	"changed":                                fieldUpdated,
+	"updated":                                fieldUpdated,
+	"registry expiry date":                   fieldExpires,
+	"registrar registration expiration date": fieldExpires,
+	"expiry date":                            fieldExpires,
+	"expiration date":                        fieldExpires,
+	"expiration time":                        fieldExpires,
+	"expire date":                            fieldExpires,
+	"expires":                                fieldExpires,
+	"expires on":                             fieldExpires,
+	"paid-till":                              fieldExpires,
+	"renewal date":                           fieldExpires,
+
+	// Status, nameservers, abuse.
+	"domain status":                 fieldStatus,
+	"status":                        fieldStatus,
+	"state":                         fieldStatus,
+	"name server":                   fieldNameserver,
+	"name servers":                  fieldNameserver,
+	"nameserver":                    fieldNameserver,
+	"nameservers":                   fieldNameserver,
+	"nserver":                       fieldNameserver,
+	"registrar abuse contact email": fieldAbuse,
+	"abuse-mailbox":                 fieldAbuse,
+	"orgabuseemail":                 fieldAbuse,
+	"abuse email":                   fieldAbuse,
+	"abuse contact":                 fieldAbuse,
+	"registrar abuse contact phone": fieldAbuse,
+}
+
+// bracketLine matches JPRS-style "[Key]   value" lines.
+var bracketLine = regexp.MustCompile(`^\[([^\]]+)\]\s*(.*)$`)
+
+// dateLayouts lists the date formats seen in WHOIS responses, most specific first.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"2006.01.02",
+	"02-Jan-2006",
+	"02.01.2006",
+	"20060102",
+}
+
+// parse extracts normalized fields from one WHOIS response into r. Fields
+// already set on r are kept, so callers control precedence by call order.
+func parse(text string, r *Result) {
+	var (
+		section       field // field of the last "Key:" header with no value
+		sectionIndent int
+		sectionSeen   bool
+	)
+	for line := range strings.SplitSeq(strings.ReplaceAll(text, "\r", ""), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			section, sectionSeen = fieldNone, false
+			continue
+		}
+		if strings.HasPrefix(trimmed, ">>>") {
+			break // ICANN footer; everything after is legal boilerplate
+		}
+		if trimmed[0] == '%' || trimmed[0] == '#' {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		key, value, ok := splitKeyValue(trimmed)
+		f := keyFields[key]
+		if sectionSeen && indent > sectionIndent && (!ok || f == fieldNone) {
+			// Indented continuation of a Nominet-style "Key:" section header.
+			set(r, section, trimmed)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if value == "" {
+			section, sectionIndent, sectionSeen = f, indent, true
+			continue
+		}
+		sectionSeen = false
+		set(r, f, value)
+	}
+}
+
+// splitKeyValue splits "Key: value" and "[Key] value" lines. The key is
+// lower-cased and whitespace-normalized.
+func splitKeyValue(line string) (key, value string, ok bool) {
+	if m := bracketLine.FindStringSubmatch(line); m != nil {
+		key, value = m[1], m[2]
+	} else if key, value, ok = strings.Cut(line, ":"); !ok {
+		return "", "", false
+	}
+	key = strings.ToLower(strings.Join(strings.Fields(key), " "))
+	return key, strings.TrimSpace(value), true
+}
+
+// set stores value into the Result field f. Single-valued fields keep their
+// first value; list fields append unique values.
+func set(r *Result, f field, value string) {
+	value = output.StripANSI(value)
+	if value == "" {
+		return
+	}
+	single := func(dst *string) {
+		if *dst == "" {
+			*dst = value
+		}
+	}
+	switch f {
+	case fieldHandle:
+		single(&r.Handle)
+	case fieldName:
+		value = strings.ToLower(value)
+		single(&r.Name)
+	case fieldRegistrar:
+		single(&r.Registrar)
+	case fieldRegistrantOrg:
+		single(&r.RegistrantOrg)
+	case fieldCountry:
+		value = strings.ToUpper(value)
+		single(&r.Country)
+	case fieldNetwork:
+		single(&r.Network)
+	case fieldCreated:
+		value = normalizeDate(value)
+		single(&r.Created)
+	case fieldUpdated:
+		value = normalizeDate(value)
+		single(&r.Updated)
+	case fieldExpires:
+		value = normalizeDate(value)
+		single(&r.Expires)
+	case fieldStatus:
+		// ICANN status lines carry a trailing EPP reference URL.
+		value = strings.Fields(value)[0]
+		appendUnique(&r.Status, value)
+	case fieldNameserver:
+		// Nominet and DENIC append glue addresses after the host name.
+		value = strings.TrimSuffix(strings.ToLower(strings.Fields(value)[0]), ".")
+		appendUnique(&r.Nameservers, value)
+	case fieldAbuse:
+		appendUnique(&r.AbuseContacts, value)
+	}
+}
+
+func appendUnique(dst *[]string, value string) {
+	if !slices.Contains(*dst, value) {
+		*dst = append(*dst, value)
+	}
+}
+
+// normalizeDate converts recognised date formats to RFC 3339 in UTC and
+// returns unrecognised values unchanged.
+func normalizeDate(value string) string {
+	// RIPE "changed" lines may carry a leading e-mail address.
+	candidate := value
+	if fields := strings.Fields(value); len(fields) == 2 && strings.Contains(fields[0], "@") {
+		candidate = fields[1]
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, candidate); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+package whois
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseFixture(t *testing.T, name string) *Result {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	r := &Result{}
+	parse(string(data), r)
+	return r
+}
+
+func TestParse_JPRSBrackets(t *testing.T) {
+	r := parseFixture(t, "jprs_jp.txt")
+	assert.Equal(t, "example.jp", r.Name)
+	assert.Equal(t, "Example Corp.", r.RegistrantOrg)
+	assert.Equal(t, "2001-02-03T00:00:00Z", r.Created)
+	assert.Equal(t, "2025-02-28T00:00:00Z", r.Expires)
+	assert.Equal(t, "2024/03/01 01:05:02 (JST)", r.Updated, "unknown zone abbreviations are kept verbatim")
+	assert.Equal(t, []string{"Active"}, r.Status)
+	assert.Equal(t, []string{"ns1.example.jp", "ns2.example.jp"}, r.Nameservers)
+}
+
+func TestParse_StopsAtICANNFooter(t *testing.T) {
+	r := parseFixture(t, "verisign_com.txt")
+	assert.Equal(t, "2025-08-13T04:00:00Z", r.Expires, "NOTICE text after >>> must not be parsed")
+	assert.Empty(t, r.AbuseContacts, "empty abuse fields are skipped")
+}
+
+func TestParse_KeepsExistingFields(t *testing.T) {
+	r := &Result{Registrar: "Registrar Inc."}
+	parse("Registrar: Other\nName Server: NS1.EXAMPLE.COM.\nName Server: ns1.example.com\n", r)
+	assert.Equal(t, "Registrar Inc.", r.Registrar)
+	assert.Equal(t, []string{"ns1.example.com"}, r.Nameservers)
+}
+
+func TestNormalizeDate(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"1995-08-14T04:00:00Z", "1995-08-14T04:00:00Z"},
+		{"2024-08-14T07:01:39+0000", "2024-08-14T07:01:39Z"},
+		{"2018-03-12T21:44:25+01:00", "2018-03-12T20:44:25Z"},
+		{"2024-09-01 10:00:00", "2024-09-01T10:00:00Z"},
+		{"26-Jun-2000", "2000-06-26T00:00:00Z"},
+		{"01.02.2003", "2003-02-01T00:00:00Z"},
+		{"hostmaster@example.net 20010101", "2001-01-01T00:00:00Z"},
+		{"before 1995", "before 1995"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, normalizeDate(tt.in), "in=%q", tt.in)
+	}
+}
+
+func TestReferral(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"iana refer", "refer:        whois.verisign-grs.com\n", "whois.verisign-grs.com"},
+		{"registrar", "   Registrar WHOIS Server: WHOIS.Registrar.Example\n", "whois.registrar.example"},
+		{"arin whois url", "ReferralServer:  whois://whois.ripe.net\n", "whois.ripe.net"},
+		{"with port", "ReferralServer: whois://whois.example.net:4343/\n", "whois.example.net:4343"},
+		{"rwhois ignored", "ReferralServer: rwhois://rwhois.example.net:4321\n", ""},
+		{"web url ignored", "Registrar WHOIS Server: https://whois.example.net/\n", ""},
+		{"empty", "Registrar WHOIS Server:\n", ""},
+		{"none", "Domain Name: example.com\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, referral(tt.text))
+		})
+	}
+}
+
+func TestFormatQuery(t *testing.T) {
+	assert.Equal(t, "n + 192.0.2.1", formatQuery("whois.arin.net", kindIP, "192.0.2.1"))
+	assert.Equal(t, "a + 15169", formatQuery("WHOIS.ARIN.NET:43", kindASN, "AS15169"))
+	assert.Equal(t, "-T dn,ace example.de", formatQuery("whois.denic.de", kindDomain, "example.de"))
+	assert.Equal(t, "AS3333", formatQuery("whois.ripe.net", kindASN, "AS3333"))
+}
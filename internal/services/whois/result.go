@@ -0,0 +1,82 @@
+package whois
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Result holds WHOIS registration data for a domain, IP, or ASN, normalized
+// into the same fields as the rdap service. Raw keeps the final response text.
+type Result struct {
+	Input         string   `json:"input"`
+	ObjectType    string   `json:"object_type,omitempty"` // "domain", "ip network", or "autnum"
+	Server        string   `json:"server,omitempty"`      // WHOIS server that answered last
+	Handle        string   `json:"handle,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Registrar     string   `json:"registrar,omitempty"`
+	RegistrantOrg string   `json:"registrant_org,omitempty"`
+	Country       string   `json:"country,omitempty"`
+	Network       string   `json:"network,omitempty"` // CIDR or address range
+	Created       string   `json:"created,omitempty"`
+	Updated       string   `json:"updated,omitempty"`
+	Expires       string   `json:"expires,omitempty"`
+	Status        []string `json:"status,omitempty"`
+	Nameservers   []string `json:"nameservers,omitempty"`
+	AbuseContacts []string `json:"abuse_contacts,omitempty"`
+	Referrals     []string `json:"referrals,omitempty"` // servers queried, in order
+	Raw           string   `json:"raw,omitempty"`
+}
+
+// IsEmpty reports whether no registration fields could be parsed.
+func (r *Result) IsEmpty() bool {
+	return r.Handle == "" && r.Name == "" && r.Registrar == "" &&
+		r.Network == "" && r.Created == "" && len(r.Nameservers) == 0
+}
+
+// rows returns the non-empty fields as Field/Value pairs in display order.
+func (r *Result) rows() [][]string {
+	fields := [][]string{
+		{"Type", r.ObjectType},
+		{"Handle", r.Handle},
+		{"Name", r.Name},
+		{"Registrar", r.Registrar},
+		{"Registrant Org", r.RegistrantOrg},
+		{"Country", r.Country},
+		{"Network", r.Network},
+		{"Created", r.Created},
+		{"Updated", r.Updated},
+		{"Expires", r.Expires},
+		{"Status", strings.Join(r.Status, "\n")},
+		{"Nameservers", strings.Join(r.Nameservers, "\n")},
+		{"Abuse Contacts", strings.Join(r.AbuseContacts, "\n")},
+		{"Referrals", strings.Join(r.Referrals, "\n")},
+	}
+	var rows [][]string
+	for _, f := range fields {
+		if f[1] != "" {
+			rows = append(rows, f)
+		}
+	}
+	return rows
+}
+
+// WriteText writes the raw response of the last WHOIS server, preceded by a
+// "% <server>" comment line so bulk output stays attributable.
+func (r *Result) WriteText(w io.Writer) error {
+	raw := strings.TrimRight(strings.ReplaceAll(r.Raw, "\r", ""), "\n")
+	_, err := fmt.Fprintf(w, "%% %s (%s)\n%s\n\n", r.Input, r.Server, raw)
+	return err
+}
+
+// WriteTable renders the non-empty fields as a Field/Value table.
+func (r *Result) WriteTable(w io.Writer) error {
+	table := output.NewWrappingTable(w, 20, 20)
+	table.Header([]string{"Field", "Value"})
+	if err := table.Bulk(r.rows()); err != nil {
+		return err
+	}
+	return table.Render()
+}
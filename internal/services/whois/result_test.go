@@ -0,0 +1,58 @@
+package whois_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services/whois"
+)
+
+func TestResult_IsEmpty(t *testing.T) {
+	assert.True(t, (&whois.Result{Input: "example.com", Server: "whois.iana.org", Raw: "% no match"}).IsEmpty())
+	assert.False(t, (&whois.Result{Input: "example.com", Registrar: "Example Registrar"}).IsEmpty())
+	assert.False(t, (&whois.Result{Input: "example.com", Nameservers: []string{"a.iana-servers.net"}}).IsEmpty())
+}
+
+func TestResult_WriteText(t *testing.T) {
+	result := &whois.Result{
+		Input:  "example.com",
+		Server: "whois.registrar.example",
+		Raw:    "Domain Name: example.com\r\nRegistrar: Example Registrar\r\n\r\n",
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteText(&buf))
+	assert.Equal(t, "% example.com (whois.registrar.example)\nDomain Name: example.com\nRegistrar: Example Registrar\n\n", buf.String())
+}
+
+func TestResult_WriteText_NoResponse(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&whois.Result{Input: "example.com", Server: "whois.iana.org"}).WriteText(&buf))
+	assert.Equal(t, "% example.com (whois.iana.org)\n\n\n", buf.String())
+}
+
+func TestResult_WriteTable(t *testing.T) {
+	result := &whois.Result{
+		Input:       "example.com",
+		ObjectType:  "domain",
+		Server:      "whois.registrar.example",
+		Name:        "example.com",
+		Registrar:   "Example Registrar",
+		Status:      []string{"clientTransferProhibited", "clientUpdateProhibited"},
+		Nameservers: []string{"a.iana-servers.net", "b.iana-servers.net"},
+		Referrals:   []string{"whois.iana.org", "whois.verisign-grs.com", "whois.registrar.example"},
+		Raw:         "Domain Name: example.com\r\nRegistrar: Example Registrar\r\n",
+	}
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "Registrar")
+	assert.Contains(t, out, "Example Registrar")
+	assert.Contains(t, out, "clientUpdateProhibited")
+	assert.Contains(t, out, "b.iana-servers.net")
+	assert.Contains(t, out, "whois.verisign-grs.com")
+	assert.NotContains(t, out, "Country", "empty fields are omitted")
+	assert.NotContains(t, out, "Domain Name:", "raw text is not part of the table")
+}
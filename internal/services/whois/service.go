@@ -0,0 +1,165 @@
+package whois
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/proxy"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+)
+
+const (
+	// Name is the service identifier.
+	Name = "whois"
+	// PAP is the PAP activity level for the WHOIS service.
+	PAP = pap.AMBER
+)
+
+// objectKind is the kind of registration object being looked up. Values match
+// the ObjectType strings used by the rdap service.
+type objectKind string
+
+const (
+	kindDomain objectKind = "domain"
+	kindIP     objectKind = "ip network"
+	kindASN    objectKind = "autnum"
+)
+
+// Service queries port-43 WHOIS servers for domain, IP, and ASN registration data.
+type Service struct {
+	dialer    proxy.ContextDialer
+	logger    *slog.Logger
+	overrides map[string]string
+}
+
+// NewService creates a new WHOIS service. Connections are made through dialer,
+// so a SOCKS5 dialer tunnels all queries. overrides maps TLDs or domain
+// suffixes (e.g. "de", "co.uk") to the WHOIS server queried directly instead
+// of starting at whois.iana.org.
+func NewService(dialer proxy.ContextDialer, logger *slog.Logger, overrides map[string]string) *Service {
+	normalized := make(map[string]string, len(overrides))
+	for suffix, server := range overrides {
+		normalized[strings.Trim(strings.ToLower(suffix), ".")] = server
+	}
+	return &Service{dialer: dialer, logger: logger, overrides: normalized}
+}
+
+// Name returns the service identifier.
+func (s *Service) Name() string { return Name }
+
+// PAP returns the PAP activity level for the WHOIS service (external server query).
+func (s *Service) PAP() pap.Level { return PAP }
+
+// AggregateResults combines multiple WHOIS results into a MultiResult.
+func (s *Service) AggregateResults(results []services.Result) services.Result {
+	mr := &MultiResult{}
+	for _, r := range results {
+		mr.Results = append(mr.Results, r.(*Result))
+	}
+	return mr
+}
+
+// Run looks up registration data for a domain, IP address, or ASN (e.g. AS15169).
+// The query starts at whois.iana.org (or a configured override) and follows
+// referrals to the registry and registrar. Responses are parsed from the most
+// specific server backwards, so registrar data takes precedence and earlier
+// responses only fill gaps. A failed referral keeps the data gathered so far.
+func (s *Service) Run(ctx context.Context, input string) (services.Result, error) {
+	input = output.StripANSI(strings.TrimSpace(input))
+	kind, key, err := classify(input)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Input: input}
+
+	server := IANAServer
+	if kind == kindDomain {
+		if override := s.override(key); override != "" {
+			server = override
+		}
+	}
+
+	var responses []string
+	for hop := 0; hop < maxHops && server != ""; hop++ {
+		if hop > 0 && s.visited(result.Referrals, server) {
+			break
+		}
+		text, err := s.query(ctx, server, formatQuery(server, kind, key))
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				break
+			}
+			if hop == 0 {
+				return nil, fmt.Errorf("%w: WHOIS query to %q for %q: %v", services.ErrRequestFailed, server, input, err)
+			}
+			s.logger.Debug("whois: referral query failed", "server", server, "input", input, "error", err)
+			break
+		}
+		result.Referrals = append(result.Referrals, server)
+		result.Server = server
+		result.Raw = text
+		// IANA describes the TLD or address block, not the queried object.
+		if !sameServer(server, IANAServer) {
+			responses = append(responses, text)
+		}
+		server = referral(text)
+	}
+
+	for i := len(responses) - 1; i >= 0; i-- {
+		parse(responses[i], result)
+	}
+	if !result.IsEmpty() {
+		result.ObjectType = string(kind)
+	}
+	return result, nil
+}
+
+// override returns the configured server for the longest matching suffix of domain.
+func (s *Service) override(domain string) string {
+	for name := domain; name != ""; {
+		if server, ok := s.overrides[name]; ok {
+			return server
+		}
+		_, rest, ok := strings.Cut(name, ".")
+		if !ok {
+			break
+		}
+		name = rest
+	}
+	return ""
+}
+
+// visited reports whether server already appears in the referral chain.
+func (s *Service) visited(chain []string, server string) bool {
+	for _, prev := range chain {
+		if sameServer(prev, server) {
+			s.logger.Debug("whois: referral loop", "server", server)
+			return true
+		}
+	}
+	return false
+}
+
+// classify maps input to its object kind and the key sent to WHOIS servers.
+func classify(input string) (objectKind, string, error) {
+	if addr, err := netip.ParseAddr(input); err == nil {
+		return kindIP, addr.Unmap().String(), nil
+	}
+	if upper := strings.ToUpper(input); strings.HasPrefix(upper, "AS") {
+		if _, err := strconv.ParseUint(upper[2:], 10, 32); err == nil {
+			return kindASN, upper, nil
+		}
+	}
+	if services.IsDomain(input) {
+		return kindDomain, strings.ToLower(strings.TrimSuffix(input, ".")), nil
+	}
+	return "", "", fmt.Errorf("%w: must be a domain, IP address, or ASN (e.g. AS15169): %q", services.ErrInvalidInput, input)
+}
@@ -0,0 +1,219 @@
+package whois_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/services/whois"
+	"github.com/tbckr/trident/internal/testutil"
+)
+
+// fakeDialer serves canned WHOIS responses over in-memory pipes.
+// responses maps "host:port" → query → fixture file name.
+type fakeDialer struct {
+	t         *testing.T
+	responses map[string]map[string]string
+
+	mu      sync.Mutex
+	queries []string // "host:port query" in dial order
+}
+
+func (d *fakeDialer) DialContext(_ context.Context, _, address string) (net.Conn, error) {
+	byQuery, ok := d.responses[address]
+	if !ok {
+		return nil, fmt.Errorf("dial %s: connection refused", address)
+	}
+	client, server := net.Pipe()
+	go func() {
+		defer func() { _ = server.Close() }()
+		line, err := bufio.NewReader(server).ReadString('\n')
+		if err != nil {
+			return
+		}
+		query := strings.TrimRight(line, "\r\n")
+		d.mu.Lock()
+		d.queries = append(d.queries, address+" "+query)
+		d.mu.Unlock()
+		if name, ok := byQuery[query]; ok {
+			_, _ = server.Write(fixture(d.t, name))
+		}
+	}()
+	return client, nil
+}
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func run(t *testing.T, d *fakeDialer, overrides map[string]string, input string) *whois.Result {
+	t.Helper()
+	svc := whois.NewService(d, testutil.NopLogger(), overrides)
+	raw, err := svc.Run(context.Background(), input)
+	require.NoError(t, err)
+	result, ok := raw.(*whois.Result)
+	require.True(t, ok, "expected *whois.Result")
+	return result
+}
+
+func TestRun_DomainFollowsReferrals(t *testing.T) {
+	d := &fakeDialer{t: t, responses: map[string]map[string]string{
+		"whois.iana.org:43":          {"example.com": "iana_com.txt"},
+		"whois.verisign-grs.com:43":  {"domain example.com": "verisign_com.txt"},
+		"whois.registrar.example:43": {"example.com": "registrar_com.txt"},
+	}}
+
+	result := run(t, d, nil, "Example.com")
+
+	assert.Equal(t, []string{
+		"whois.iana.org:43 example.com",
+		"whois.verisign-grs.com:43 domain example.com",
+		"whois.registrar.example:43 example.com",
+	}, d.queries)
+	assert.Equal(t, []string{"whois.iana.org", "whois.verisign-grs.com", "whois.registrar.example"}, result.Referrals)
+	assert.Equal(t, "whois.registrar.example", result.Server)
+	assert.Equal(t, "domain", result.ObjectType)
+	assert.Equal(t, "example.com", result.Name)
+	assert.Equal(t, "2336799_DOMAIN_COM-VRSN", result.Handle)
+	assert.Equal(t, "Example Registrar, Inc.", result.Registrar, "registrar response takes precedence")
+	assert.Equal(t, "Internet Assigned Numbers Authority", result.RegistrantOrg)
+	assert.Equal(t, "US", result.Country)
+	assert.Equal(t, "1995-08-14T04:00:00Z", result.Created)
+	assert.Equal(t, "2024-08-14T07:01:39Z", result.Updated)
+	assert.Equal(t, "2025-08-13T04:00:00Z", result.Expires)
+	assert.Equal(t, []string{"clientDeleteProhibited", "clientTransferProhibited"}, result.Status)
+	assert.Equal(t, []string{"a.iana-servers.net", "b.iana-servers.net"}, result.Nameservers)
+	assert.Equal(t, []string{"abuse@registrar.example", "+1.3103015800"}, result.AbuseContacts)
+	assert.Contains(t, result.Raw, "Registrar: Example Registrar, Inc.")
+}
+
+func TestRun_RegistrarFailureKeepsRegistryData(t *testing.T) {
+	d := &fakeDialer{t: t, responses: map[string]map[string]string{
+		"whois.iana.org:43":         {"example.com": "iana_com.txt"},
+		"whois.verisign-grs.com:43": {"domain example.com": "verisign_com.txt"},
+	}}
+
+	result := run(t, d, nil, "example.com")
+
+	assert.Equal(t, "whois.verisign-grs.com", result.Server)
+	assert.Equal(t, "RESERVED-Internet Assigned Numbers Authority", result.Registrar)
+	assert.Equal(t, "2025-08-13T04:00:00Z", result.Expires)
+	assert.Empty(t, result.AbuseContacts)
+}
+
+func TestRun_Override(t *testing.T) {
+	d := &fakeDialer{t: t, responses: map[string]map[string]string{
+		"whois.nic.uk:43": {"example.co.uk": "nominet_uk.txt"},
+	}}
+
+	result := run(t, d, map[string]string{"uk": "whois.iana.invalid", ".CO.UK": "whois.nic.uk"}, "example.co.uk")
+
+	assert.Equal(t, []string{"whois.nic.uk:43 example.co.uk"}, d.queries, "longest override suffix wins; IANA is skipped")
+	assert.Equal(t, "example.co.uk", result.Name)
+	assert.Equal(t, "Example Registrar Ltd [Tag = EXAMPLE]", result.Registrar)
+	assert.Equal(t, "2000-06-26T00:00:00Z", result.Created)
+	assert.Equal(t, "2026-06-26T00:00:00Z", result.Expires)
+	assert.Equal(t, "2024-06-01T00:00:00Z", result.Updated)
+	assert.Equal(t, []string{"ns1.example.co.uk", "ns2.example.co.uk"}, result.Nameservers)
+}
+
+func TestRun_OverrideQueryFormat(t *testing.T) {
+	d := &fakeDialer{t: t, responses: map[string]map[string]string{
+		"whois.denic.de:43": {"-T dn,ace example.de": "denic_de.txt"},
+	}}
+
+	result := run(t, d, map[string]string{"de": "whois.denic.de"}, "example.de")
+
+	assert.Equal(t, "example.de", result.Name)
+	assert.Equal(t, []string{"connect"}, result.Status)
+	assert.Equal(t, "2018-03-12T20:44:25Z", result.Updated)
+	assert.Equal(t, []string{"ns1.example.de", "ns2.example.de"}, result.Nameservers)
+}
+
+func TestRun_IP(t *testing.T) {
+	d := &fakeDialer{t: t, responses: map[string]map[string]string{
+		"whois.iana.org:43": {"192.0.2.1": "iana_ip.txt"},
+		"whois.arin.net:43": {"n + 192.0.2.1": "arin_ip.txt"},
+	}}
+
+	result := run(t, d, nil, "192.0.2.1")
+
+	assert.Equal(t, "ip network", result.ObjectType)
+	assert.Equal(t, "NET-192-0-2-0-1", result.Handle)
+	assert.Equal(t, "test-net-1", result.Name)
+	assert.Equal(t, "192.0.2.0 - 192.0.2.255", result.Network)
+	assert.Equal(t, "Internet Assigned Numbers Authority", result.RegistrantOrg)
+	assert.Equal(t, "US", result.Country)
+	assert.Equal(t, "2009-11-25T00:00:00Z", result.Created)
+	assert.Equal(t, []string{"abuse@iana.org"}, result.AbuseContacts)
+}
+
+func TestRun_ReferralLoopStops(t *testing.T) {
+	d := &fakeDialer{t: t, responses: map[string]map[string]string{
+		"whois.iana.org:43":         {"example.com": "iana_com.txt"},
+		"whois.verisign-grs.com:43": {"domain example.com": "iana_com.txt"},
+	}}
+
+	result := run(t, d, nil, "example.com")
+
+	assert.Len(t, d.queries, 2)
+	assert.Equal(t, []string{"whois.iana.org", "whois.verisign-grs.com"}, result.Referrals)
+}
+
+func TestRun_NoReferralIsEmpty(t *testing.T) {
+	d := &fakeDialer{t: t, responses: map[string]map[string]string{
+		"whois.iana.org:43": {},
+	}}
+
+	result := run(t, d, nil, "example.invalid")
+
+	assert.True(t, result.IsEmpty())
+	assert.Equal(t, "whois.iana.org", result.Server)
+}
+
+func TestRun_FirstServerUnreachable(t *testing.T) {
+	svc := whois.NewService(&fakeDialer{t: t}, testutil.NopLogger(), nil)
+	_, err := svc.Run(context.Background(), "example.com")
+	require.ErrorIs(t, err, services.ErrRequestFailed)
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	svc := whois.NewService(&fakeDialer{t: t}, testutil.NopLogger(), nil)
+	for _, input := range []string{"", "not a domain", "AS99999999999", "-flag"} {
+		_, err := svc.Run(context.Background(), input)
+		require.ErrorIs(t, err, services.ErrInvalidInput, "input=%q", input)
+	}
+}
+
+func TestRun_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d := &fakeDialer{t: t, responses: map[string]map[string]string{
+		"whois.iana.org:43": {"example.com": "iana_com.txt"},
+	}}
+	svc := whois.NewService(d, testutil.NopLogger(), nil)
+	raw, err := svc.Run(ctx, "example.com")
+	require.NoError(t, err)
+	result, ok := raw.(*whois.Result)
+	require.True(t, ok)
+	assert.Equal(t, "example.com", result.Input)
+}
+
+func TestService_Metadata(t *testing.T) {
+	svc := whois.NewService(&fakeDialer{t: t}, testutil.NopLogger(), nil)
+	assert.Equal(t, "whois", svc.Name())
+	assert.Equal(t, whois.PAP, svc.PAP())
+}
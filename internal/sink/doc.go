@@ -0,0 +1,4 @@
+// Package sink delivers results, diffs, and watch events to destinations
+// beyond stdout: generic JSON, Slack, Mattermost, and Microsoft Teams
+// webhooks, and rotating files.
+package sink
@@ -0,0 +1,99 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// fileSink appends messages to a file, one JSON object per line or a text
+// block per message, rotating it to <path>.1 … <path>.N once it would grow
+// beyond maxSize.
+type fileSink struct {
+	name     string
+	path     string
+	format   output.Format
+	maxSize  int64
+	maxFiles int
+	defang   DefangPolicy
+
+	mu sync.Mutex
+}
+
+// Name returns the sink name.
+func (s *fileSink) Name() string { return s.name }
+
+// Send appends m to the file, rotating first when needed.
+func (s *fileSink) Send(_ context.Context, m Message) error {
+	entry, err := s.entry(m)
+	if err != nil {
+		return fmt.Errorf("sink %s: %w", s.name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("sink %s: creating directory: %w", s.name, err)
+	}
+	if info, err := os.Stat(s.path); err == nil && info.Size() > 0 && info.Size()+int64(len(entry)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return fmt.Errorf("sink %s: rotating: %w", s.name, err)
+		}
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("sink %s: %w", s.name, err)
+	}
+	if _, err := f.Write(entry); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("sink %s: %w", s.name, err)
+	}
+	return f.Close()
+}
+
+// entry renders m as one NDJSON line or as a "# title" header followed by the
+// text rendering and a blank line.
+func (s *fileSink) entry(m Message) ([]byte, error) {
+	if s.format == output.FormatJSON {
+		payload, err := jsonPayload(m, s.defang)
+		if err != nil {
+			return nil, err
+		}
+		line, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		return append(line, '\n'), nil
+	}
+	body, err := renderText(m)
+	if err != nil {
+		return nil, err
+	}
+	text := "# " + m.Time.Format("2006-01-02T15:04:05Z07:00") + " " + title(m) + "\n" + body + "\n\n"
+	if s.defang != nil && s.defang(output.FormatText) {
+		text = output.DefangText(text)
+	}
+	return []byte(text), nil
+}
+
+// rotate shifts <path>.i to <path>.i+1, dropping the oldest, and moves the
+// active file to <path>.1.
+func (s *fileSink) rotate() error {
+	oldest := fmt.Sprintf("%s.%d", s.path, s.maxFiles)
+	if err := os.Remove(oldest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for i := s.maxFiles - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", s.path, i)
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", s.path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(s.path, s.path+".1")
+}
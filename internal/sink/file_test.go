@@ -0,0 +1,77 @@
+package sink_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/sink"
+)
+
+func TestFileSink_JSON(t *testing.T) {
+	msg := sink.Message{Kind: sink.KindResult, Service: "crtsh", Input: "example.com"}
+	path := filepath.Join(t.TempDir(), "logs", "trident.ndjson")
+	s, err := sink.New(sink.Spec{Type: sink.TypeFile, Path: path}, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Send(context.Background(), msg))
+	require.NoError(t, s.Send(context.Background(), msg))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &m))
+	assert.Equal(t, "crtsh", m["service"])
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestFileSink_TextDefang(t *testing.T) {
+	msg := sink.Message{
+		Time:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Kind:    sink.KindResult,
+		Service: "crtsh",
+		Input:   "example.com",
+		Data:    map[string]any{"subdomains": []string{"vpn.example.com"}},
+	}
+	path := filepath.Join(t.TempDir(), "trident.log")
+	s, err := sink.New(sink.Spec{Type: sink.TypeFile, Path: path, Format: output.FormatText}, nil, defangAll)
+	require.NoError(t, err)
+	require.NoError(t, s.Send(context.Background(), msg))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# 2025-01-01T00:00:00Z trident crtsh: example[.]com\n"))
+	assert.Contains(t, string(data), "vpn[.]example[.]com")
+	assert.NotContains(t, string(data), "vpn.example.com")
+}
+
+func TestFileSink_Rotate(t *testing.T) {
+	msg := sink.Message{Kind: sink.KindResult, Service: "crtsh", Input: "example.com"}
+	path := filepath.Join(t.TempDir(), "trident.ndjson")
+	s, err := sink.New(sink.Spec{Type: sink.TypeFile, Path: path, MaxSize: 1, MaxFiles: 2}, nil, nil)
+	require.NoError(t, err)
+
+	for range 4 {
+		require.NoError(t, s.Send(context.Background(), msg))
+	}
+
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		data, err := os.ReadFile(p)
+		require.NoError(t, err, p)
+		assert.Equal(t, 1, strings.Count(string(data), "\n"), p)
+	}
+	assert.NoFileExists(t, path+".3")
+}
@@ -0,0 +1,89 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/apperr"
+	"github.com/tbckr/trident/internal/output"
+)
+
+// httpSink posts each message as JSON to a webhook URL, shaped for the
+// receiving service by its type.
+type httpSink struct {
+	name   string
+	typ    string
+	url    string
+	client *req.Client
+	defang DefangPolicy
+}
+
+// Name returns the sink name.
+func (s *httpSink) Name() string { return s.name }
+
+// Send posts m and fails on transport errors and non-2xx responses.
+func (s *httpSink) Send(ctx context.Context, m Message) error {
+	payload, err := s.payload(m)
+	if err != nil {
+		return fmt.Errorf("sink %s: %w", s.name, err)
+	}
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBodyJsonMarshal(payload).
+		Post(s.url)
+	if err != nil {
+		return fmt.Errorf("%w: sink %s: %s", apperr.ErrRequestFailed, s.name, err)
+	}
+	if !resp.IsSuccessState() {
+		return fmt.Errorf("%w: sink %s: HTTP %d", apperr.ErrRequestFailed, s.name, resp.StatusCode)
+	}
+	return nil
+}
+
+// payload builds the request body: the message itself for generic webhooks,
+// a markdown text message for Slack and Mattermost, and an Adaptive Card for
+// Teams. Chat messages follow the text-format defang rules.
+func (s *httpSink) payload(m Message) (any, error) {
+	if s.typ == TypeWebhook {
+		return jsonPayload(m, s.defang)
+	}
+	head := title(m)
+	body, err := renderText(m)
+	if err != nil {
+		return nil, err
+	}
+	body = truncate(body, maxTextBody)
+	if s.defang != nil && s.defang(output.FormatText) {
+		head, body = output.DefangText(head), output.DefangText(body)
+	}
+	switch s.typ {
+	case TypeSlack:
+		return map[string]string{"text": "*" + head + "*\n```\n" + body + "\n```"}, nil
+	case TypeMattermost:
+		return map[string]string{"username": "trident", "text": "**" + head + "**\n```\n" + body + "\n```"}, nil
+	default: // TypeTeams
+		return teamsCard(head, body), nil
+	}
+}
+
+// teamsCard wraps a title and monospace body in the Adaptive Card message
+// accepted by Teams incoming webhooks and Workflows.
+func teamsCard(head, body string) map[string]any {
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]any{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body": []map[string]any{
+					{"type": "TextBlock", "text": head, "weight": "Bolder", "wrap": true},
+					{"type": "TextBlock", "text": body, "fontType": "Monospace", "wrap": true},
+				},
+			},
+		}},
+	}
+}
@@ -0,0 +1,140 @@
+package sink_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/apperr"
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/sink"
+)
+
+const hookURL = "https://hooks.example.com/trident"
+
+func newTestClient(t *testing.T) *req.Client {
+	t.Helper()
+	client := req.NewClient()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return client
+}
+
+// capture registers a responder for hookURL and returns the decoded body of
+// the last request.
+func capture(t *testing.T) *map[string]any {
+	t.Helper()
+	var got map[string]any
+	httpmock.RegisterResponder(http.MethodPost, hookURL, func(r *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &got))
+		return httpmock.NewStringResponse(http.StatusOK, "ok"), nil
+	})
+	return &got
+}
+
+func defangAll(output.Format) bool { return true }
+
+func TestHTTPSink_Webhook(t *testing.T) {
+	msg := sink.Message{
+		Time:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Kind:    sink.KindResult,
+		Service: "crtsh",
+		Input:   "example.com",
+		Data:    map[string]any{"subdomains": []string{"vpn.example.com"}},
+	}
+	client := newTestClient(t)
+	got := capture(t)
+
+	s, err := sink.New(sink.Spec{Name: "hook", Type: sink.TypeWebhook, URL: hookURL}, client, nil)
+	require.NoError(t, err)
+	require.NoError(t, s.Send(context.Background(), msg))
+	assert.Equal(t, map[string]any{
+		"time":    "2025-01-01T00:00:00Z",
+		"kind":    "result",
+		"service": "crtsh",
+		"input":   "example.com",
+		"data":    map[string]any{"subdomains": []any{"vpn.example.com"}},
+	}, *got)
+}
+
+func TestHTTPSink_Webhook_Defang(t *testing.T) {
+	msg := sink.Message{
+		Time:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Kind:    sink.KindResult,
+		Service: "crtsh",
+		Input:   "example.com",
+		Data:    map[string]any{"subdomains": []string{"vpn.example.com"}},
+	}
+	client := newTestClient(t)
+	got := capture(t)
+
+	s, err := sink.New(sink.Spec{Name: "hook", Type: sink.TypeWebhook, URL: hookURL}, client, defangAll)
+	require.NoError(t, err)
+	require.NoError(t, s.Send(context.Background(), msg))
+	assert.Equal(t, "2025-01-01T00:00:00Z", (*got)["time"], "timestamps keep their format")
+	assert.Equal(t, "example[.]com", (*got)["input"])
+	assert.Equal(t, map[string]any{"subdomains": []any{"vpn[.]example[.]com"}}, (*got)["data"])
+}
+
+func TestHTTPSink_Chat(t *testing.T) {
+	msg := sink.Message{
+		Kind:    sink.KindResult,
+		Service: "crtsh",
+		Input:   "example.com",
+		Data:    map[string]any{"subdomains": []string{"vpn.example.com"}},
+	}
+	tests := []struct {
+		typ   string
+		check func(t *testing.T, got map[string]any)
+	}{
+		{sink.TypeSlack, func(t *testing.T, got map[string]any) {
+			text, _ := got["text"].(string)
+			assert.Contains(t, text, "*trident crtsh: example[.]com*\n```\n")
+			assert.Contains(t, text, "vpn[.]example[.]com")
+		}},
+		{sink.TypeMattermost, func(t *testing.T, got map[string]any) {
+			assert.Equal(t, "trident", got["username"])
+			text, _ := got["text"].(string)
+			assert.Contains(t, text, "**trident crtsh: example[.]com**")
+		}},
+		{sink.TypeTeams, func(t *testing.T, got map[string]any) {
+			assert.Equal(t, "message", got["type"])
+			raw, err := json.Marshal(got)
+			require.NoError(t, err)
+			assert.Contains(t, string(raw), `"AdaptiveCard"`)
+			assert.Contains(t, string(raw), "trident crtsh: example[.]com")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			client := newTestClient(t)
+			got := capture(t)
+			s, err := sink.New(sink.Spec{Type: tt.typ, URL: hookURL}, client, defangAll)
+			require.NoError(t, err)
+			require.NoError(t, s.Send(context.Background(), msg))
+			tt.check(t, *got)
+		})
+	}
+}
+
+func TestHTTPSink_HTTPError(t *testing.T) {
+	msg := sink.Message{Kind: sink.KindResult, Service: "crtsh", Input: "example.com"}
+	client := newTestClient(t)
+	httpmock.RegisterResponder(http.MethodPost, hookURL, httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+
+	s, err := sink.New(sink.Spec{Type: sink.TypeSlack, URL: hookURL}, client, nil)
+	require.NoError(t, err)
+	err = s.Send(context.Background(), msg)
+	require.ErrorIs(t, err, apperr.ErrRequestFailed)
+	assert.Contains(t, err.Error(), "HTTP 500")
+}
@@ -0,0 +1,224 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/tbckr/trident/internal/apperr"
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Sink types accepted in Spec.Type.
+const (
+	TypeWebhook    = "webhook"
+	TypeSlack      = "slack"
+	TypeMattermost = "mattermost"
+	TypeTeams      = "teams"
+	TypeFile       = "file"
+)
+
+// Types lists the sink types in documentation order.
+var Types = []string{TypeWebhook, TypeSlack, TypeMattermost, TypeTeams, TypeFile}
+
+// Message kinds.
+const (
+	KindResult = "result" // a service result
+	KindDiff   = "diff"   // the changes between two snapshots
+	KindChange = "change" // one watch event
+)
+
+// Message is what a sink delivers: one service result, diff, or watch event.
+type Message struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Service string    `json:"service"`
+	Input   string    `json:"input"`
+	Data    any       `json:"data"`
+}
+
+// Sink delivers messages to one destination.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, m Message) error
+}
+
+// Defaults for file sinks.
+const (
+	DefaultMaxSize  = 10 << 20 // bytes before the file is rotated
+	DefaultMaxFiles = 5        // rotated files kept next to the active one
+)
+
+// Spec describes a sink, as configured in the sinks block or given to --sink.
+type Spec struct {
+	Name     string
+	Type     string
+	URL      string        // HTTP sinks
+	Path     string        // file sinks
+	Format   output.Format // file sinks: json (default) or text
+	MaxSize  int64         // file sinks: rotate beyond this many bytes; 0 = DefaultMaxSize
+	MaxFiles int           // file sinks: rotated files kept; 0 = DefaultMaxFiles
+}
+
+// DefangPolicy reports whether output in the given format is defanged. The
+// CLI passes output.ResolveDefang bound to the PAP level and defang flags.
+type DefangPolicy func(output.Format) bool
+
+// Resolve returns the spec selected by a --sink value: the name of a
+// configured sink, or an ad-hoc "type:target" such as
+// "slack:https://hooks.slack.com/services/..." or "file:/var/log/trident.ndjson".
+func Resolve(value string, configured []Spec) (Spec, error) {
+	for _, s := range configured {
+		if s.Name == value {
+			return s, nil
+		}
+	}
+	typ, target, ok := strings.Cut(value, ":")
+	if ok && slices.Contains(Types, typ) && target != "" {
+		s := Spec{Name: typ, Type: typ}
+		if typ == TypeFile {
+			s.Path = target
+		} else {
+			s.URL = target
+		}
+		return s, nil
+	}
+	names := make([]string, 0, len(configured))
+	for _, s := range configured {
+		names = append(names, s.Name)
+	}
+	avail := "none configured"
+	if len(names) > 0 {
+		avail = "configured: " + strings.Join(names, ", ")
+	}
+	return Spec{}, fmt.Errorf("%w: unknown sink %q (%s; or use <type>:<url|path> with type %s)",
+		apperr.ErrInvalidInput, value, avail, strings.Join(Types, ", "))
+}
+
+// New builds the sink described by spec. HTTP sinks post with client, which
+// carries the configured proxy and User-Agent; defang decides per output
+// format whether delivered content is defanged.
+func New(spec Spec, client *req.Client, defang DefangPolicy) (Sink, error) {
+	name := spec.Name
+	if name == "" {
+		name = spec.Type
+	}
+	switch spec.Type {
+	case TypeWebhook, TypeSlack, TypeMattermost, TypeTeams:
+		u, err := url.Parse(spec.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: sink %q: url must be an http(s) URL", apperr.ErrInvalidInput, name)
+		}
+		return &httpSink{name: name, typ: spec.Type, url: spec.URL, client: client, defang: defang}, nil
+	case TypeFile:
+		if spec.Path == "" {
+			return nil, fmt.Errorf("%w: sink %q: path is required", apperr.ErrInvalidInput, name)
+		}
+		format := spec.Format
+		if format == "" {
+			format = output.FormatJSON
+		}
+		if format != output.FormatJSON && format != output.FormatText {
+			return nil, fmt.Errorf("%w: sink %q: format must be json or text, got %q", apperr.ErrInvalidInput, name, format)
+		}
+		f := &fileSink{name: name, path: spec.Path, format: format, maxSize: spec.MaxSize, maxFiles: spec.MaxFiles, defang: defang}
+		if f.maxSize <= 0 {
+			f.maxSize = DefaultMaxSize
+		}
+		if f.maxFiles <= 0 {
+			f.maxFiles = DefaultMaxFiles
+		}
+		return f, nil
+	default:
+		return nil, fmt.Errorf("%w: sink %q: unknown type %q (want %s)",
+			apperr.ErrInvalidInput, name, spec.Type, strings.Join(Types, ", "))
+	}
+}
+
+// maxTextBody caps the rendered body of chat messages; Slack and Teams reject
+// or truncate much larger payloads.
+const maxTextBody = 3500
+
+// title summarises m in one line, e.g. "trident apex: example.com".
+func title(m Message) string {
+	switch m.Kind {
+	case KindDiff:
+		return "trident diff " + m.Service + ": " + m.Input
+	case KindChange:
+		return "trident watch " + m.Service + ": " + m.Input
+	default:
+		return "trident " + m.Service + ": " + m.Input
+	}
+}
+
+// renderText renders the data of m in text output format, falling back to
+// indented JSON for data without a text form.
+func renderText(m Message) (string, error) {
+	var buf bytes.Buffer
+	if _, ok := m.Data.(output.TextFormattable); ok {
+		if err := output.Write(&buf, output.FormatText, m.Data); err != nil {
+			return "", err
+		}
+	} else if err := output.Write(&buf, output.FormatJSON, m.Data); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// truncate shortens s to at most n runes, marking the cut.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "\n… (truncated)"
+}
+
+// defangJSON returns m as a JSON-compatible tree with every string value
+// defanged except timestamps, whose fractional seconds must keep their dot.
+// Keys and numbers are left alone so the document stays valid.
+func defangJSON(m Message) (any, error) {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var tree any
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, err
+	}
+	return defangValue(tree), nil
+}
+
+func defangValue(v any) any {
+	switch t := v.(type) {
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, t); err == nil {
+			return t
+		}
+		return output.DefangText(t)
+	case map[string]any:
+		for k, child := range t {
+			t[k] = defangValue(child)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = defangValue(child)
+		}
+	}
+	return v
+}
+
+// jsonPayload returns m, defanged when policy asks for it in JSON output.
+func jsonPayload(m Message, defang DefangPolicy) (any, error) {
+	if defang != nil && defang(output.FormatJSON) {
+		return defangJSON(m)
+	}
+	return m, nil
+}
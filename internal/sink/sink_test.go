@@ -0,0 +1,68 @@
+package sink_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/apperr"
+	"github.com/tbckr/trident/internal/output"
+	"github.com/tbckr/trident/internal/sink"
+)
+
+func TestResolve(t *testing.T) {
+	configured := []sink.Spec{{Name: "soc", Type: sink.TypeSlack, URL: "https://hooks.slack.com/services/x"}}
+
+	tests := []struct {
+		name  string
+		value string
+		want  sink.Spec
+	}{
+		{"configured name", "soc", configured[0]},
+		{"ad-hoc url", "teams:https://example.webhook.office.com/x", sink.Spec{Name: "teams", Type: sink.TypeTeams, URL: "https://example.webhook.office.com/x"}},
+		{"ad-hoc file", "file:/var/log/trident.ndjson", sink.Spec{Name: "file", Type: sink.TypeFile, Path: "/var/log/trident.ndjson"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sink.Resolve(tt.value, configured)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolve_Unknown(t *testing.T) {
+	_, err := sink.Resolve("nope", []sink.Spec{{Name: "soc", Type: sink.TypeSlack}})
+	require.ErrorIs(t, err, apperr.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "configured: soc")
+
+	_, err = sink.Resolve("ftp:host", nil)
+	require.ErrorIs(t, err, apperr.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "none configured")
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec sink.Spec
+	}{
+		{"unknown type", sink.Spec{Name: "x", Type: "irc"}},
+		{"missing url", sink.Spec{Name: "x", Type: sink.TypeSlack}},
+		{"non-http url", sink.Spec{Name: "x", Type: sink.TypeWebhook, URL: "ftp://example.com"}},
+		{"missing path", sink.Spec{Name: "x", Type: sink.TypeFile}},
+		{"bad format", sink.Spec{Name: "x", Type: sink.TypeFile, Path: "out.log", Format: output.FormatTable}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sink.New(tt.spec, nil, nil)
+			require.ErrorIs(t, err, apperr.ErrInvalidInput)
+		})
+	}
+}
+
+func TestNew_DefaultName(t *testing.T) {
+	s, err := sink.New(sink.Spec{Type: sink.TypeFile, Path: "out.log"}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sink.TypeFile, s.Name())
+}
@@ -0,0 +1,218 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/tbckr/trident/internal/output"
+)
+
+// Record is one comparable fact of a result, such as the A record of a host
+// or one subdomain. Records sharing a Key form a set of values.
+type Record struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Recorder is implemented by results that know how to split themselves into
+// records for diffing. Results without it are flattened generically.
+type Recorder interface {
+	SnapshotRecords() []Record
+}
+
+// Change kinds reported by Compare.
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change is one difference between two snapshots. Old is empty for added
+// records and New is empty for removed ones.
+type Change struct {
+	Kind string `json:"kind"`
+	Key  string `json:"key"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// Records decodes a saved result into records. When v is non-nil the result
+// is unmarshaled into it and, if v implements Recorder, its records are used;
+// otherwise the JSON is flattened: each scalar becomes a record keyed by its
+// dotted path, and each object inside an array becomes one record holding its
+// compact JSON.
+func Records(raw json.RawMessage, v any) ([]Record, error) {
+	if v != nil {
+		if err := json.Unmarshal(raw, v); err != nil {
+			return nil, fmt.Errorf("decoding result: %w", err)
+		}
+		if r, ok := v.(Recorder); ok {
+			return r.SnapshotRecords(), nil
+		}
+	}
+	var tree any
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("decoding result: %w", err)
+	}
+	var recs []Record
+	flatten("", tree, false, &recs)
+	return recs, nil
+}
+
+// RecordsOf returns the records of an in-memory result: its own when it
+// implements Recorder, otherwise those of its flattened JSON form.
+func RecordsOf(result any) ([]Record, error) {
+	if r, ok := result.(Recorder); ok {
+		return r.SnapshotRecords(), nil
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("encoding result: %w", err)
+	}
+	return Records(raw, nil)
+}
+
+func flatten(key string, v any, inArray bool, recs *[]Record) {
+	switch t := v.(type) {
+	case map[string]any:
+		if inArray {
+			b, _ := json.Marshal(t) // map keys are sorted, so the form is stable
+			*recs = append(*recs, Record{Key: key, Value: string(b)})
+			return
+		}
+		for k, child := range t {
+			flatten(joinKey(key, k), child, false, recs)
+		}
+	case []any:
+		for _, child := range t {
+			flatten(key, child, true, recs)
+		}
+	case nil:
+	case string:
+		*recs = append(*recs, Record{Key: key, Value: t})
+	default:
+		b, _ := json.Marshal(t)
+		*recs = append(*recs, Record{Key: key, Value: string(b)})
+	}
+}
+
+func joinKey(prefix, k string) string {
+	if prefix == "" {
+		return k
+	}
+	return prefix + "." + k
+}
+
+// Compare returns the changes from older to newer. For each key, a single value
+// replaced by a different single value is reported as changed; otherwise the
+// values only in older are removed and those only in newer are added. Changes are
+// sorted by key.
+func Compare(older, newer []Record) []Change {
+	oldVals, newVals := group(older), group(newer)
+	keys := make([]string, 0, len(oldVals)+len(newVals))
+	for k := range oldVals {
+		keys = append(keys, k)
+	}
+	for k := range newVals {
+		if _, ok := oldVals[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []Change
+	for _, k := range keys {
+		o, n := oldVals[k], newVals[k]
+		if len(o) == 1 && len(n) == 1 {
+			if o[0] != n[0] {
+				changes = append(changes, Change{Kind: Changed, Key: k, Old: o[0], New: n[0]})
+			}
+			continue
+		}
+		for _, v := range o {
+			if !slices.Contains(n, v) {
+				changes = append(changes, Change{Kind: Removed, Key: k, Old: v})
+			}
+		}
+		for _, v := range n {
+			if !slices.Contains(o, v) {
+				changes = append(changes, Change{Kind: Added, Key: k, New: v})
+			}
+		}
+	}
+	return changes
+}
+
+// group collects the distinct values of each key, sorted.
+func group(recs []Record) map[string][]string {
+	m := make(map[string][]string)
+	for _, r := range recs {
+		if !slices.Contains(m[r.Key], r.Value) {
+			m[r.Key] = append(m[r.Key], r.Value)
+		}
+	}
+	for _, vals := range m {
+		slices.Sort(vals)
+	}
+	return m
+}
+
+// Diff holds the changes between two snapshots of one service and input.
+type Diff struct {
+	Service string    `json:"service"`
+	Input   string    `json:"input"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Changes []Change  `json:"changes"`
+}
+
+// IsEmpty reports whether the snapshots are identical.
+func (d *Diff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// changeSymbol maps a change kind to its text-output prefix.
+var changeSymbol = map[string]string{Added: "+", Removed: "-", Changed: "~"}
+
+// WriteText renders one change per line: "+ KEY NEW", "- KEY OLD", or
+// "~ KEY OLD -> NEW".
+func (d *Diff) WriteText(w io.Writer) error {
+	for _, c := range d.Changes {
+		var err error
+		switch c.Kind {
+		case Added:
+			_, err = fmt.Fprintf(w, "%s %s %s\n", changeSymbol[c.Kind], c.Key, c.New)
+		case Removed:
+			_, err = fmt.Fprintf(w, "%s %s %s\n", changeSymbol[c.Kind], c.Key, c.Old)
+		default:
+			_, err = fmt.Fprintf(w, "%s %s %s -> %s\n", changeSymbol[c.Kind], c.Key, c.Old, c.New)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable renders the changes as a Change/Record/Old/New table below a
+// line naming the compared snapshots.
+func (d *Diff) WriteTable(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s %s: %s → %s\n", d.Service, d.Input,
+		d.From.Local().Format(time.DateTime), d.To.Local().Format(time.DateTime)); err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		rows = append(rows, []string{c.Kind, c.Key, c.Old, c.New})
+	}
+	table := output.NewWrappingTable(w, 20, 13)
+	table.Header([]string{"Change", "Record", "Old", "New"})
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
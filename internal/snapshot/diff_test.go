@@ -0,0 +1,130 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/snapshot"
+)
+
+func TestCompare(t *testing.T) {
+	older := []snapshot.Record{
+		{Key: "example.com A", Value: "192.0.2.1"},
+		{Key: "example.com MX", Value: "10 mx1.example.com."},
+		{Key: "example.com MX", Value: "20 mx2.example.com."},
+		{Key: "example.com SOA", Value: "ns1. hostmaster. 1"},
+		{Key: "www.example.com A", Value: "192.0.2.2"},
+	}
+	newer := []snapshot.Record{
+		{Key: "example.com A", Value: "192.0.2.9"},
+		{Key: "example.com MX", Value: "20 mx2.example.com."},
+		{Key: "example.com MX", Value: "30 mx3.example.com."},
+		{Key: "example.com SOA", Value: "ns1. hostmaster. 1"},
+		{Key: "vpn.example.com A", Value: "192.0.2.3"},
+	}
+	assert.Equal(t, []snapshot.Change{
+		{Kind: snapshot.Changed, Key: "example.com A", Old: "192.0.2.1", New: "192.0.2.9"},
+		{Kind: snapshot.Removed, Key: "example.com MX", Old: "10 mx1.example.com."},
+		{Kind: snapshot.Added, Key: "example.com MX", New: "30 mx3.example.com."},
+		{Kind: snapshot.Added, Key: "vpn.example.com A", New: "192.0.2.3"},
+		{Kind: snapshot.Removed, Key: "www.example.com A", Old: "192.0.2.2"},
+	}, snapshot.Compare(older, newer))
+}
+
+func TestCompare_Identical(t *testing.T) {
+	recs := []snapshot.Record{{Key: "subdomain", Value: "a"}, {Key: "subdomain", Value: "b"}}
+	reordered := []snapshot.Record{recs[1], recs[0], recs[0]}
+	assert.Empty(t, snapshot.Compare(recs, reordered))
+}
+
+type recorderResult struct {
+	Names []string `json:"names"`
+}
+
+func (r *recorderResult) SnapshotRecords() []snapshot.Record {
+	var recs []snapshot.Record
+	for _, n := range r.Names {
+		recs = append(recs, snapshot.Record{Key: "name", Value: n})
+	}
+	return recs
+}
+
+func TestRecords_Recorder(t *testing.T) {
+	recs, err := snapshot.Records(json.RawMessage(`{"names":["a","b"]}`), &recorderResult{})
+	require.NoError(t, err)
+	assert.Equal(t, []snapshot.Record{{Key: "name", Value: "a"}, {Key: "name", Value: "b"}}, recs)
+}
+
+func TestRecords_Flatten(t *testing.T) {
+	raw := json.RawMessage(`{
+		"input": "example.com",
+		"blocked": true,
+		"asn": {"number": 64500, "name": "EXAMPLE"},
+		"ips": ["192.0.2.1", "192.0.2.2"],
+		"hosts": [{"name": "www", "ip": "192.0.2.1"}],
+		"empty": null
+	}`)
+	recs, err := snapshot.Records(raw, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []snapshot.Record{
+		{Key: "input", Value: "example.com"},
+		{Key: "blocked", Value: "true"},
+		{Key: "asn.number", Value: "64500"},
+		{Key: "asn.name", Value: "EXAMPLE"},
+		{Key: "ips", Value: "192.0.2.1"},
+		{Key: "ips", Value: "192.0.2.2"},
+		{Key: "hosts", Value: `{"ip":"192.0.2.1","name":"www"}`},
+	}, recs)
+}
+
+func TestRecords_Invalid(t *testing.T) {
+	_, err := snapshot.Records(json.RawMessage(`{`), nil)
+	require.Error(t, err)
+	_, err = snapshot.Records(json.RawMessage(`{"names":"x"}`), &recorderResult{})
+	require.Error(t, err)
+}
+
+func TestDiff_Output(t *testing.T) {
+	d := &snapshot.Diff{
+		Service: "crtsh",
+		Input:   "example.com",
+		From:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		Changes: []snapshot.Change{
+			{Kind: snapshot.Added, Key: "subdomain", New: "vpn.example.com"},
+			{Kind: snapshot.Removed, Key: "subdomain", Old: "old.example.com"},
+			{Kind: snapshot.Changed, Key: "example.com A", Old: "192.0.2.1", New: "192.0.2.9"},
+		},
+	}
+	assert.False(t, d.IsEmpty())
+
+	var buf bytes.Buffer
+	require.NoError(t, d.WriteText(&buf))
+	assert.Equal(t, "+ subdomain vpn.example.com\n- subdomain old.example.com\n~ example.com A 192.0.2.1 -> 192.0.2.9\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, d.WriteTable(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "crtsh example.com:")
+	assert.Contains(t, out, "CHANGE")
+	assert.Contains(t, out, "vpn.example.com")
+
+	assert.True(t, (&snapshot.Diff{}).IsEmpty())
+}
+
+func TestRecordsOf(t *testing.T) {
+	recs, err := snapshot.RecordsOf(&recorderResult{Names: []string{"a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []snapshot.Record{{Key: "name", Value: "a"}}, recs)
+
+	recs, err = snapshot.RecordsOf(struct {
+		Blocked bool `json:"blocked"`
+	}{Blocked: true})
+	require.NoError(t, err)
+	assert.Equal(t, []snapshot.Record{{Key: "blocked", Value: "true"}}, recs)
+}
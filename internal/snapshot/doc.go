@@ -0,0 +1,3 @@
+// Package snapshot stores timestamped service results as JSONL files and
+// computes the added, removed, and changed records between two snapshots.
+package snapshot
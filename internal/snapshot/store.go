@@ -0,0 +1,178 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tbckr/trident/internal/appdir"
+	"github.com/tbckr/trident/internal/apperr"
+	"github.com/tbckr/trident/internal/jsonl"
+)
+
+// dirName is the config-dir subdirectory holding the snapshot files.
+const dirName = "snapshots"
+
+// ErrTooFewSnapshots is returned by Select when fewer than two snapshots exist.
+var ErrTooFewSnapshots = errors.New("at least two snapshots are needed to diff")
+
+// Snapshot is one saved service result.
+type Snapshot struct {
+	Time    time.Time       `json:"time"`
+	Service string          `json:"service"`
+	Input   string          `json:"input"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// Store keeps snapshots in <dir>/<service>/<input>.jsonl, one JSON object per
+// line in the order they were saved.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at dir. The directory is created on the
+// first Save.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the directory holding the snapshot files,
+// <config-dir>/snapshots.
+func DefaultDir() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, dirName), nil
+}
+
+// Save appends result, taken at the given time, to the snapshots of service
+// and input.
+func (s *Store) Save(service, input string, result any, at time.Time) error {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("encoding result: %w", err)
+	}
+	path := s.path(service, input)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating snapshot dir: %w", err)
+	}
+	snap := Snapshot{Time: at.UTC(), Service: service, Input: input, Result: raw}
+	if err := jsonl.Append(path, snap); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return nil
+}
+
+// List returns the snapshots of service and input, oldest first. Lines that
+// cannot be decoded are skipped. A missing file yields no snapshots.
+func (s *Store) List(service, input string) ([]Snapshot, error) {
+	snaps, err := jsonl.Read(s.path(service, input), func(snap Snapshot) bool { return !snap.Time.IsZero() })
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot file: %w", err)
+	}
+	sort.SliceStable(snaps, func(i, j int) bool { return snaps[i].Time.Before(snaps[j].Time) })
+	return snaps, nil
+}
+
+// Services returns the names of services with saved snapshots, sorted.
+func (s *Store) Services() ([]string, error) {
+	return s.names(s.dir, true)
+}
+
+// Inputs returns the inputs with saved snapshots for service, sorted, in
+// their file-name form.
+func (s *Store) Inputs(service string) ([]string, error) {
+	return s.names(filepath.Join(s.dir, fileName(service)), false)
+}
+
+func (s *Store) names(dir string, dirs bool) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading snapshot dir: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		switch {
+		case dirs && e.IsDir():
+			names = append(names, e.Name())
+		case !dirs && !e.IsDir() && strings.HasSuffix(e.Name(), ".jsonl"):
+			names = append(names, strings.TrimSuffix(e.Name(), ".jsonl"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *Store) path(service, input string) string {
+	return filepath.Join(s.dir, fileName(service), fileName(input)+".jsonl")
+}
+
+// fileName maps a service name or input to a safe file name: it is lowercased
+// and every character other than a-z, 0-9, '.', '-', and '_' becomes '_'.
+// Leading dots are replaced so an input can never name "." or "..".
+func fileName(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	b := []byte(s)
+	for i, c := range b {
+		ok := c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' && i > 0
+		if !ok {
+			b[i] = '_'
+		}
+	}
+	if len(b) == 0 {
+		return "_"
+	}
+	return string(b)
+}
+
+// Select picks the pair of snapshots to compare from snaps, oldest first. The
+// newer one is always the latest snapshot. With a zero since the older one is
+// the snapshot before it; otherwise it is the latest snapshot taken at or
+// before since, or the earliest snapshot when all are newer.
+func Select(snaps []Snapshot, since time.Time) (from, to Snapshot, err error) {
+	if len(snaps) < 2 {
+		return Snapshot{}, Snapshot{}, ErrTooFewSnapshots
+	}
+	to = snaps[len(snaps)-1]
+	if since.IsZero() {
+		return snaps[len(snaps)-2], to, nil
+	}
+	from = snaps[0]
+	for _, s := range snaps[:len(snaps)-1] {
+		if s.Time.After(since) {
+			break
+		}
+		from = s
+	}
+	return from, to, nil
+}
+
+// ParseSince parses a --since value: a date (2006-01-02), an RFC 3339
+// timestamp, or a duration before now such as "36h" or "7d".
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: --since %q (want YYYY-MM-DD, RFC 3339, or a duration such as 7d or 36h)", apperr.ErrInvalidInput, s)
+}
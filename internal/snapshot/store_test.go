@@ -0,0 +1,143 @@
+package snapshot_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/apperr"
+	"github.com/tbckr/trident/internal/snapshot"
+)
+
+func TestStore_SaveAndList(t *testing.T) {
+	dir := t.TempDir()
+	store := snapshot.NewStore(dir)
+	t1 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(7 * 24 * time.Hour)
+
+	// Saved out of order; List returns them oldest first.
+	require.NoError(t, store.Save("crtsh", "Example.com", map[string]any{"subdomains": []string{"b.example.com"}}, t2))
+	require.NoError(t, store.Save("crtsh", "example.com", map[string]any{"subdomains": []string{"a.example.com"}}, t1))
+
+	snaps, err := store.List("crtsh", "example.com")
+	require.NoError(t, err)
+	require.Len(t, snaps, 2)
+	assert.True(t, snaps[0].Time.Equal(t1))
+	assert.True(t, snaps[1].Time.Equal(t2))
+	assert.Equal(t, "crtsh", snaps[0].Service)
+	assert.JSONEq(t, `{"subdomains":["a.example.com"]}`, string(snaps[0].Result))
+
+	info, err := os.Stat(filepath.Join(dir, "crtsh", "example.com.jsonl"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestStore_List_Missing(t *testing.T) {
+	snaps, err := snapshot.NewStore(t.TempDir()).List("apex", "example.com")
+	require.NoError(t, err)
+	assert.Empty(t, snaps)
+}
+
+func TestStore_List_SkipsCorruptLines(t *testing.T) {
+	dir := t.TempDir()
+	store := snapshot.NewStore(dir)
+	require.NoError(t, store.Save("apex", "example.com", map[string]any{}, time.Now()))
+	f, err := os.OpenFile(filepath.Join(dir, "apex", "example.com.jsonl"), os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString("{\"time\":\"2025-01-\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	snaps, err := store.List("apex", "example.com")
+	require.NoError(t, err)
+	assert.Len(t, snaps, 1)
+}
+
+func TestStore_PathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	store := snapshot.NewStore(dir)
+	require.NoError(t, store.Save("../apex", "../../etc/passwd", map[string]any{}, time.Now()))
+
+	services, err := store.Services()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"_._apex"}, services)
+	inputs, err := store.Inputs("../apex")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"_._.._etc_passwd"}, inputs)
+}
+
+func TestStore_ServicesAndInputs(t *testing.T) {
+	store := snapshot.NewStore(t.TempDir())
+	now := time.Now()
+	require.NoError(t, store.Save("crtsh", "example.org", map[string]any{}, now))
+	require.NoError(t, store.Save("apex", "example.com", map[string]any{}, now))
+	require.NoError(t, store.Save("apex", "2001:db8::1", map[string]any{}, now))
+
+	services, err := store.Services()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apex", "crtsh"}, services)
+
+	inputs, err := store.Inputs("apex")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2001_db8__1", "example.com"}, inputs)
+}
+
+func TestSelect(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	snaps := []snapshot.Snapshot{{Time: day(1)}, {Time: day(8)}, {Time: day(15)}}
+
+	from, to, err := snapshot.Select(snaps, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, day(8), from.Time)
+	assert.Equal(t, day(15), to.Time)
+
+	from, _, err = snapshot.Select(snaps, day(10))
+	require.NoError(t, err)
+	assert.Equal(t, day(8), from.Time)
+
+	from, _, err = snapshot.Select(snaps, day(1))
+	require.NoError(t, err)
+	assert.Equal(t, day(1), from.Time)
+
+	// All snapshots newer than since → the earliest is the baseline.
+	from, _, err = snapshot.Select(snaps, day(0))
+	require.NoError(t, err)
+	assert.Equal(t, day(1), from.Time)
+
+	// since after the latest snapshot still never compares it with itself.
+	from, _, err = snapshot.Select(snaps, day(20))
+	require.NoError(t, err)
+	assert.Equal(t, day(8), from.Time)
+
+	_, _, err = snapshot.Select(snaps[:1], time.Time{})
+	require.ErrorIs(t, err, snapshot.ErrTooFewSnapshots)
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2025-01-31", time.Date(2025, 1, 31, 0, 0, 0, 0, time.Local)},
+		{"2025-01-31T08:00:00Z", time.Date(2025, 1, 31, 8, 0, 0, 0, time.UTC)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"36h", now.Add(-36 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := snapshot.ParseSince(tt.in, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+
+	for _, bad := range []string{"", "yesterday", "-7d", "2025-13-01"} {
+		_, err := snapshot.ParseSince(bad, now)
+		require.ErrorIs(t, err, apperr.ErrInvalidInput, bad)
+	}
+}
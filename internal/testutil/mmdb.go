@@ -0,0 +1,191 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"os"
+	"slices"
+	"testing"
+)
+
+// WriteMMDB writes a minimal MaxMind DB file (24-bit records) to path that maps
+// each CIDR in networks to its data record. ipVersion selects an IPv4-only (4)
+// or IPv6 (6) search tree; IPv4 networks in an IPv6 tree are stored in the
+// ::/96 subtree as MaxMind databases do. Supported record value types: map,
+// []any, string, bool, float64, int, uint16, uint32, and uint64. Networks must
+// not overlap.
+func WriteMMDB(t testing.TB, path string, ipVersion int, dbType string, networks map[string]any) {
+	t.Helper()
+
+	type node struct {
+		child [2]*node
+		data  int // index into records; -1 when not a leaf
+	}
+	newNode := func() *node { return &node{data: -1} }
+	root := newNode()
+	var records [][]byte
+
+	cidrs := make([]string, 0, len(networks))
+	for cidr := range networks {
+		cidrs = append(cidrs, cidr)
+	}
+	slices.Sort(cidrs)
+	for _, cidr := range cidrs {
+		prefix := netip.MustParsePrefix(cidr).Masked()
+		ip := prefix.Addr().AsSlice()
+		bits := prefix.Bits()
+		if prefix.Addr().Is4() && ipVersion == 6 {
+			ip = append(make([]byte, 12), ip...)
+			bits += 96
+		}
+		n := root
+		for i := range bits {
+			bit := ip[i/8] >> (7 - uint(i%8)) & 1
+			if n.child[bit] == nil {
+				n.child[bit] = newNode()
+			}
+			n = n.child[bit]
+		}
+		var buf bytes.Buffer
+		mmdbEncode(t, &buf, networks[cidr])
+		n.data = len(records)
+		records = append(records, buf.Bytes())
+	}
+
+	// Number internal nodes in breadth-first order.
+	var order []*node
+	index := map[*node]int{}
+	queue := []*node{root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		index[n] = len(order)
+		order = append(order, n)
+		for _, c := range n.child {
+			if c != nil && c.data < 0 {
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	var data bytes.Buffer
+	offsets := make([]int, len(records))
+	for i, rec := range records {
+		offsets[i] = data.Len()
+		data.Write(rec)
+	}
+
+	nodeCount := len(order)
+	var tree bytes.Buffer
+	for _, n := range order {
+		for _, c := range n.child {
+			v := nodeCount // empty record → not found
+			switch {
+			case c == nil:
+			case c.data >= 0:
+				v = nodeCount + 16 + offsets[c.data]
+			default:
+				v = index[c]
+			}
+			tree.Write([]byte{byte(v >> 16), byte(v >> 8), byte(v)})
+		}
+	}
+
+	var out bytes.Buffer
+	out.Write(tree.Bytes())
+	out.Write(make([]byte, 16))
+	out.Write(data.Bytes())
+	out.WriteString("\xab\xcd\xefMaxMind.com")
+	mmdbEncode(t, &out, map[string]any{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"database_type":               dbType,
+		"ip_version":                  uint16(ipVersion),
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(24),
+	})
+	if err := os.WriteFile(path, out.Bytes(), 0o600); err != nil {
+		t.Fatalf("writing MMDB: %v", err)
+	}
+}
+
+// mmdbEncode appends the MaxMind DB encoding of v to buf.
+func mmdbEncode(t testing.TB, buf *bytes.Buffer, v any) {
+	t.Helper()
+	uintBytes := func(n uint64) []byte {
+		b := binary.BigEndian.AppendUint64(nil, n)
+		return bytes.TrimLeft(b, "\x00")
+	}
+	switch x := v.(type) {
+	case map[string]any:
+		mmdbControl(buf, 7, len(x))
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			mmdbEncode(t, buf, k)
+			mmdbEncode(t, buf, x[k])
+		}
+	case []any:
+		mmdbControl(buf, 11, len(x))
+		for _, e := range x {
+			mmdbEncode(t, buf, e)
+		}
+	case string:
+		mmdbControl(buf, 2, len(x))
+		buf.WriteString(x)
+	case bool:
+		n := 0
+		if x {
+			n = 1
+		}
+		mmdbControl(buf, 14, n)
+	case float64:
+		mmdbControl(buf, 3, 8)
+		buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(x)))
+	case uint16:
+		b := uintBytes(uint64(x))
+		mmdbControl(buf, 5, len(b))
+		buf.Write(b)
+	case uint32:
+		b := uintBytes(uint64(x))
+		mmdbControl(buf, 6, len(b))
+		buf.Write(b)
+	case int:
+		b := uintBytes(uint64(x))
+		mmdbControl(buf, 6, len(b))
+		buf.Write(b)
+	case uint64:
+		b := uintBytes(x)
+		mmdbControl(buf, 9, len(b))
+		buf.Write(b)
+	default:
+		t.Fatalf("WriteMMDB: unsupported value type %T", v)
+	}
+}
+
+// mmdbControl writes the control byte(s) for a value of type typ and size.
+func mmdbControl(buf *bytes.Buffer, typ, size int) {
+	var ext []byte
+	switch {
+	case size < 29:
+	case size < 285:
+		ext = []byte{byte(size - 29)}
+		size = 29
+	default:
+		n := size - 285
+		ext = []byte{byte(n >> 8), byte(n)}
+		size = 30
+	}
+	if typ < 8 {
+		buf.WriteByte(byte(typ<<5 | size))
+	} else {
+		buf.WriteByte(byte(size))
+		buf.WriteByte(byte(typ - 7))
+	}
+	buf.Write(ext)
+}
@@ -0,0 +1,3 @@
+// Package watch re-runs a service on an interval and reports the records that
+// changed between consecutive runs as events.
+package watch
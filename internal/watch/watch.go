@@ -0,0 +1,113 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/snapshot"
+	"github.com/tbckr/trident/internal/worker"
+)
+
+// MinInterval is the shortest interval the watch command accepts. It keeps a
+// mistyped --every (e.g. "15s" for "15m") from hammering third-party APIs.
+const MinInterval = 10 * time.Second
+
+// Event is one change between two consecutive runs for an input.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Service string    `json:"service"`
+	Input   string    `json:"input"`
+	Run     int       `json:"run"`
+	snapshot.Change
+}
+
+// WriteText renders the event as "TIME INPUT +|-|~ KEY VALUE".
+func (e Event) WriteText(w io.Writer) error {
+	var err error
+	ts := e.Time.Local().Format(time.DateTime)
+	switch e.Kind {
+	case snapshot.Added:
+		_, err = fmt.Fprintf(w, "%s %s + %s %s\n", ts, e.Input, e.Key, e.New)
+	case snapshot.Removed:
+		_, err = fmt.Fprintf(w, "%s %s - %s %s\n", ts, e.Input, e.Key, e.Old)
+	default:
+		_, err = fmt.Fprintf(w, "%s %s ~ %s %s -> %s\n", ts, e.Input, e.Key, e.Old, e.New)
+	}
+	return err
+}
+
+// Options configures a watch loop.
+type Options struct {
+	Every       time.Duration // interval between the starts of consecutive runs
+	MaxRuns     int           // stop after this many runs; 0 = until ctx is done
+	Concurrency int           // worker pool size per run
+
+	// OnResult, when set, is called with every successful result, e.g. to
+	// save it as a snapshot.
+	OnResult func(input string, result services.Result)
+}
+
+// Run looks up inputs with svc every opts.Every and calls emit for each
+// change against the previous successful result of the same input. The first
+// result of an input is the baseline and emits nothing. Failed lookups are
+// logged and keep the previous baseline. Run returns nil when ctx is done or
+// after opts.MaxRuns runs, and the first error returned by emit otherwise.
+func Run(ctx context.Context, svc services.Service, inputs []string, opts Options, emit func(Event) error, logger *slog.Logger) error {
+	if opts.Every <= 0 {
+		return fmt.Errorf("%w: interval must be positive, got %s", services.ErrInvalidInput, opts.Every)
+	}
+	concurrency := max(opts.Concurrency, 1)
+	baseline := make(map[string][]snapshot.Record, len(inputs))
+
+	for run := 1; ; run++ {
+		started := time.Now()
+		results := worker.Run(ctx, svc, inputs, concurrency)
+		if ctx.Err() != nil {
+			// Results of an interrupted run are incomplete; never diff them.
+			return nil
+		}
+		var changes int
+		for _, r := range results {
+			if r.Err != nil {
+				logger.Error("lookup failed", "service", svc.Name(), "input", r.Input, "run", run, "error", r.Err)
+				continue
+			}
+			if opts.OnResult != nil {
+				opts.OnResult(r.Input, r.Output)
+			}
+			recs, err := snapshot.RecordsOf(r.Output)
+			if err != nil {
+				logger.Error("comparing result failed", "service", svc.Name(), "input", r.Input, "error", err)
+				continue
+			}
+			prev, seen := baseline[r.Input]
+			baseline[r.Input] = recs
+			if !seen {
+				continue
+			}
+			for _, c := range snapshot.Compare(prev, recs) {
+				changes++
+				if err := emit(Event{Time: time.Now().UTC(), Service: svc.Name(), Input: r.Input, Run: run, Change: c}); err != nil {
+					return err
+				}
+			}
+		}
+		logger.Debug("watch run finished", "service", svc.Name(), "run", run, "changes", changes,
+			"duration", time.Since(started).Round(time.Millisecond))
+
+		if opts.MaxRuns > 0 && run >= opts.MaxRuns {
+			return nil
+		}
+		timer := time.NewTimer(max(opts.Every-time.Since(started), 0))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package watch_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tbckr/trident/internal/pap"
+	"github.com/tbckr/trident/internal/services"
+	"github.com/tbckr/trident/internal/snapshot"
+	"github.com/tbckr/trident/internal/testutil"
+	"github.com/tbckr/trident/internal/watch"
+)
+
+// listResult is a minimal result whose JSON form is {"names": [...]}.
+type listResult struct {
+	Names []string `json:"names"`
+}
+
+func (r *listResult) IsEmpty() bool { return len(r.Names) == 0 }
+
+// seqService returns the next scripted result for an input on every Run; a
+// nil entry is a failed lookup.
+type seqService struct {
+	mu    sync.Mutex
+	calls map[string]int
+	seq   map[string][]*listResult
+}
+
+func (s *seqService) Name() string   { return "seq" }
+func (s *seqService) PAP() pap.Level { return pap.GREEN }
+func (s *seqService) Run(_ context.Context, input string) (services.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calls == nil {
+		s.calls = map[string]int{}
+	}
+	seq := s.seq[input]
+	r := seq[min(s.calls[input], len(seq)-1)]
+	s.calls[input]++
+	if r == nil {
+		return nil, errors.New("lookup failed")
+	}
+	return r, nil
+}
+func (s *seqService) AggregateResults(results []services.Result) services.Result { return results[0] }
+
+func TestRun_EmitsChangesAfterBaseline(t *testing.T) {
+	svc := &seqService{seq: map[string][]*listResult{
+		"a.example": {
+			{Names: []string{"www"}},
+			{Names: []string{"www", "vpn"}},
+			nil, // failed run keeps the previous baseline
+			{Names: []string{"vpn"}},
+		},
+		"b.example": {{Names: []string{"mail"}}},
+	}}
+
+	var events []watch.Event
+	var saved []string
+	opts := watch.Options{
+		Every:    time.Millisecond,
+		MaxRuns:  4,
+		OnResult: func(input string, _ services.Result) { saved = append(saved, input) },
+	}
+	err := watch.Run(context.Background(), svc, []string{"a.example", "b.example"}, opts,
+		func(e watch.Event) error { events = append(events, e); return nil }, testutil.NopLogger())
+	require.NoError(t, err)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "seq", events[0].Service)
+	assert.Equal(t, "a.example", events[0].Input)
+	assert.Equal(t, 2, events[0].Run)
+	assert.Equal(t, snapshot.Change{Kind: snapshot.Added, Key: "names", New: "vpn"}, events[0].Change)
+	assert.Equal(t, 4, events[1].Run)
+	assert.Equal(t, snapshot.Change{Kind: snapshot.Removed, Key: "names", Old: "www"}, events[1].Change)
+	assert.Len(t, saved, 7, "every successful result is passed to OnResult")
+}
+
+func TestRun_StopsOnCancel(t *testing.T) {
+	svc := &seqService{seq: map[string][]*listResult{"a.example": {{Names: []string{"www"}}}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- watch.Run(ctx, svc, []string{"a.example"}, watch.Options{Every: time.Hour},
+			func(watch.Event) error { return nil }, testutil.NopLogger())
+	}()
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch.Run did not return after cancellation")
+	}
+}
+
+func TestRun_EmitError(t *testing.T) {
+	svc := &seqService{seq: map[string][]*listResult{"a.example": {{Names: []string{"www"}}, {Names: []string{"vpn"}}}}}
+	boom := errors.New("webhook down")
+	err := watch.Run(context.Background(), svc, []string{"a.example"}, watch.Options{Every: time.Millisecond, MaxRuns: 5},
+		func(watch.Event) error { return boom }, testutil.NopLogger())
+	require.ErrorIs(t, err, boom)
+}
+
+func TestRun_InvalidInterval(t *testing.T) {
+	err := watch.Run(context.Background(), &seqService{}, []string{"a.example"}, watch.Options{},
+		func(watch.Event) error { return nil }, testutil.NopLogger())
+	require.ErrorIs(t, err, services.ErrInvalidInput)
+}
+
+func TestEvent_WriteText(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	for _, c := range []snapshot.Change{
+		{Kind: snapshot.Added, Key: "subdomain", New: "vpn.example.com"},
+		{Kind: snapshot.Removed, Key: "subdomain", Old: "old.example.com"},
+		{Kind: snapshot.Changed, Key: "blocked", Old: "false", New: "true"},
+	} {
+		require.NoError(t, watch.Event{Time: ts, Input: "example.com", Change: c}.WriteText(&buf))
+	}
+	stamp := ts.Local().Format(time.DateTime)
+	assert.Equal(t, stamp+" example.com + subdomain vpn.example.com\n"+
+		stamp+" example.com - subdomain old.example.com\n"+
+		stamp+" example.com ~ blocked false -> true\n", buf.String())
+}
@@ -16,9 +16,15 @@ type Result struct {
 
 // Run processes inputs through svc using a bounded goroutine pool of size concurrency.
 // Results are returned in the same order as inputs regardless of completion order.
+// When svc implements services.BatchService, inputs it handles in bulk skip the pool.
 func Run(ctx context.Context, svc services.Service, inputs []string, concurrency int) []Result {
 	results := make([]Result, len(inputs))
 
+	var batched []services.Result
+	if b, ok := svc.(services.BatchService); ok {
+		batched = b.RunBatch(ctx, inputs)
+	}
+
 	type job struct {
 		index int
 		input string
@@ -26,6 +32,10 @@ func Run(ctx context.Context, svc services.Service, inputs []string, concurrency
 
 	jobs := make(chan job, len(inputs))
 	for i, input := range inputs {
+		if i < len(batched) && batched[i] != nil {
+			results[i] = Result{Input: input, Output: batched[i]}
+			continue
+		}
 		jobs <- job{index: i, input: input}
 	}
 	close(jobs)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,6 +55,27 @@ func (p *perInputService) AggregateResults(results []services.Result) services.R
 	return results[0]
 }
 
+// batchService handles inputs prefixed with "batch:" in RunBatch and echoes the rest via Run.
+type batchService struct {
+	echoService
+	runCalls []string
+}
+
+func (b *batchService) Run(ctx context.Context, input string) (services.Result, error) {
+	b.runCalls = append(b.runCalls, input)
+	return b.echoService.Run(ctx, input)
+}
+
+func (b *batchService) RunBatch(_ context.Context, inputs []string) []services.Result {
+	out := make([]services.Result, len(inputs))
+	for i, in := range inputs {
+		if rest, ok := strings.CutPrefix(in, "batch:"); ok {
+			out[i] = stringResult("bulk " + rest)
+		}
+	}
+	return out
+}
+
 func TestRun_OrderPreserved(t *testing.T) {
 	inputs := make([]string, 20)
 	for i := range inputs {
@@ -120,3 +142,16 @@ func TestRun_ConcurrencyOne(t *testing.T) {
 		assert.Equal(t, stringResult(inputs[i]), r.Output)
 	}
 }
+
+func TestRun_BatchService(t *testing.T) {
+	svc := &batchService{}
+	inputs := []string{"batch:a", "b", "batch:c"}
+	results := worker.Run(context.Background(), svc, inputs, 1)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, stringResult("bulk a"), results[0].Output)
+	assert.Equal(t, stringResult("b"), results[1].Output)
+	assert.Equal(t, stringResult("bulk c"), results[2].Output)
+	assert.Equal(t, "batch:c", results[2].Input)
+	assert.Equal(t, []string{"b"}, svc.runCalls, "batched inputs must not go through Run")
+}